@@ -4,5 +4,6 @@ import "context"
 
 type server interface {
 	ListenAndServe() error
+	ListenAndServeTLS(certFile, keyFile string) error
 	Shutdown(ctx context.Context) error
 }
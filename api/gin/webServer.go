@@ -9,6 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-es-indexer-go/api/groups"
+	"github.com/multiversx/mx-chain-es-indexer-go/api/middleware"
 	"github.com/multiversx/mx-chain-es-indexer-go/api/shared"
 	"github.com/multiversx/mx-chain-es-indexer-go/config"
 )
@@ -56,13 +57,31 @@ func (ws *webServer) StartHttpServer() error {
 	gin.DisableConsoleColor()
 	gin.SetMode(gin.ReleaseMode)
 
+	err := checkApiConfig(ws.apiConfig)
+	if err != nil {
+		return err
+	}
+
 	engine = gin.Default()
+	err = engine.SetTrustedProxies(ws.apiConfig.TrustedProxies)
+	if err != nil {
+		return err
+	}
+
 	cfg := cors.DefaultConfig()
 	cfg.AllowAllOrigins = true
 	cfg.AddAllowHeaders("Authorization")
 	engine.Use(cors.New(cfg))
 
-	err := ws.createGroups()
+	if ws.apiConfig.RateLimit.RequestsPerSecond > 0 {
+		rateLimiter, errRateLimiter := middleware.NewRateLimiter(ws.apiConfig.RateLimit)
+		if errRateLimiter != nil {
+			return errRateLimiter
+		}
+		engine.Use(rateLimiter.MiddlewareHandlerFunc())
+	}
+
+	err = ws.createGroups()
 	if err != nil {
 		return err
 	}
@@ -70,8 +89,8 @@ func (ws *webServer) StartHttpServer() error {
 	ws.registerRoutes(engine)
 
 	s := &http.Server{Addr: apiInterface, Handler: engine}
-	log.Debug("creating gin web sever", "interface", apiInterface)
-	ws.httpServer, err = NewHttpServer(s)
+	log.Debug("creating gin web sever", "interface", apiInterface, "tls", ws.apiConfig.TLS.Enabled)
+	ws.httpServer, err = NewHttpServer(s, ws.apiConfig.TLS)
 	if err != nil {
 		return err
 	}
@@ -91,19 +110,82 @@ func (ws *webServer) createGroups() error {
 	}
 	groupsMap["status"] = statusGroup
 
+	healthGroup, err := groups.NewHealthGroup(ws.facade)
+	if err != nil {
+		return err
+	}
+	groupsMap["health"] = healthGroup
+
+	readyGroup, err := groups.NewReadyGroup(ws.facade)
+	if err != nil {
+		return err
+	}
+	groupsMap["ready"] = readyGroup
+
+	adminGroup, err := groups.NewAdminGroup(ws.facade)
+	if err != nil {
+		return err
+	}
+	groupsMap["admin"] = adminGroup
+
+	transactionsGroup, err := groups.NewTransactionsGroup(ws.facade)
+	if err != nil {
+		return err
+	}
+	groupsMap["transactions"] = transactionsGroup
+
+	accountsGroup, err := groups.NewAccountsGroup(ws.facade)
+	if err != nil {
+		return err
+	}
+	groupsMap["accounts"] = accountsGroup
+
+	tokensGroup, err := groups.NewTokensGroup(ws.facade)
+	if err != nil {
+		return err
+	}
+	groupsMap["tokens"] = tokensGroup
+
+	blocksGroup, err := groups.NewBlocksGroup(ws.facade)
+	if err != nil {
+		return err
+	}
+	groupsMap["blocks"] = blocksGroup
+
+	eventsGroup, err := groups.NewEventsGroup(ws.facade)
+	if err != nil {
+		return err
+	}
+	groupsMap["events"] = eventsGroup
+
 	ws.groups = groupsMap
 
 	return nil
 }
 
 func (ws *webServer) registerRoutes(ginRouter *gin.Engine) {
+	authenticator := middleware.NewAuthenticator(ws.apiConfig.Auth)
 	for groupName, groupHandler := range ws.groups {
 		log.Debug("registering gin API group", "group name", groupName)
 		ginGroup := ginRouter.Group(fmt.Sprintf("/%s", groupName))
-		groupHandler.RegisterRoutes(ginGroup, ws.apiConfig)
+		groupHandler.RegisterRoutes(ginGroup, ws.apiConfig, authenticator)
 	}
 }
 
+// checkApiConfig will check the authentication types of the routes, so a typo does not expose a route
+func checkApiConfig(apiConfig config.ApiRoutesConfig) error {
+	for groupName, group := range apiConfig.APIPackages {
+		for _, route := range group.Routes {
+			err := middleware.CheckAuthType(route.Auth)
+			if err != nil {
+				return fmt.Errorf("%w for route %s%s", err, groupName, route.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
 // Close will handle the closing of inner components
 func (ws *webServer) Close() error {
 	var err error
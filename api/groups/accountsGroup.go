@@ -0,0 +1,105 @@
+package groups
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-es-indexer-go/api/shared"
+	"github.com/multiversx/mx-chain-es-indexer-go/core"
+)
+
+const (
+	accountByAddressPath = "/:address"
+	accountHistoryPath   = "/:address/history"
+	accountTokensPath    = "/:address/tokens"
+)
+
+type accountsGroup struct {
+	*baseGroup
+	facade shared.FacadeHandler
+}
+
+// NewAccountsGroup returns a new instance of accounts group
+func NewAccountsGroup(facade shared.FacadeHandler) (*accountsGroup, error) {
+	if check.IfNil(facade) {
+		return nil, fmt.Errorf("%w for accounts group", core.ErrNilFacadeHandler)
+	}
+
+	ag := &accountsGroup{
+		facade:    facade,
+		baseGroup: &baseGroup{},
+	}
+
+	endpoints := []*shared.EndpointHandlerData{
+		{
+			Path:    accountByAddressPath,
+			Handler: ag.getAccount,
+			Method:  http.MethodGet,
+		},
+		{
+			Path:    accountHistoryPath,
+			Handler: ag.getAccountHistory,
+			Method:  http.MethodGet,
+		},
+		{
+			Path:    accountTokensPath,
+			Handler: ag.getAccountTokens,
+			Method:  http.MethodGet,
+		},
+	}
+	ag.endpoints = endpoints
+
+	return ag, nil
+}
+
+// getAccount will return the account with the provided address
+func (ag *accountsGroup) getAccount(c *gin.Context) {
+	account, err := ag.facade.GetAccount(c.Param("address"))
+	if err != nil {
+		returnError(c, err)
+		return
+	}
+
+	returnData(c, "account", account)
+}
+
+// getAccountHistory will return a page with the balance history of the provided address
+func (ag *accountsGroup) getAccountHistory(c *gin.Context) {
+	pagination, err := getPagination(c)
+	if err != nil {
+		returnBadRequest(c, err)
+		return
+	}
+
+	history, err := ag.facade.GetAccountHistory(c.Param("address"), pagination)
+	if err != nil {
+		returnError(c, err)
+		return
+	}
+
+	returnData(c, "history", history)
+}
+
+// getAccountTokens will return a page with the tokens of the provided address
+func (ag *accountsGroup) getAccountTokens(c *gin.Context) {
+	pagination, err := getPagination(c)
+	if err != nil {
+		returnBadRequest(c, err)
+		return
+	}
+
+	tokens, err := ag.facade.GetAccountTokens(c.Param("address"), pagination)
+	if err != nil {
+		returnError(c, err)
+		return
+	}
+
+	returnData(c, "tokens", tokens)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ag *accountsGroup) IsInterfaceNil() bool {
+	return ag == nil
+}
@@ -0,0 +1,151 @@
+package groups
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-es-indexer-go/api/shared"
+	"github.com/multiversx/mx-chain-es-indexer-go/core"
+)
+
+const (
+	statePath        = "/state"
+	pausePath        = "/pause"
+	resumePath       = "/resume"
+	drainPath        = "/drain"
+	indicesPath      = "/indices"
+	enableIndexPath  = "/indices/:index/enable"
+	disableIndexPath = "/indices/:index/disable"
+
+	indexParam = "index"
+)
+
+type adminGroup struct {
+	*baseGroup
+	facade shared.FacadeHandler
+}
+
+// NewAdminGroup returns a new instance of admin group
+func NewAdminGroup(facade shared.FacadeHandler) (*adminGroup, error) {
+	if check.IfNil(facade) {
+		return nil, fmt.Errorf("%w for admin group", core.ErrNilFacadeHandler)
+	}
+
+	ag := &adminGroup{
+		facade:    facade,
+		baseGroup: &baseGroup{},
+	}
+
+	endpoints := []*shared.EndpointHandlerData{
+		{
+			Path:    statePath,
+			Handler: ag.getState,
+			Method:  http.MethodGet,
+		},
+		{
+			Path:    pausePath,
+			Handler: ag.pause,
+			Method:  http.MethodPost,
+		},
+		{
+			Path:    resumePath,
+			Handler: ag.resume,
+			Method:  http.MethodPost,
+		},
+		{
+			Path:    drainPath,
+			Handler: ag.drain,
+			Method:  http.MethodPost,
+		},
+		{
+			Path:    indicesPath,
+			Handler: ag.getEnabledIndices,
+			Method:  http.MethodGet,
+		},
+		{
+			Path:    enableIndexPath,
+			Handler: ag.enableIndex,
+			Method:  http.MethodPost,
+		},
+		{
+			Path:    disableIndexPath,
+			Handler: ag.disableIndex,
+			Method:  http.MethodPost,
+		},
+	}
+	ag.endpoints = endpoints
+
+	return ag, nil
+}
+
+// getState will return the current state of the indexing
+func (ag *adminGroup) getState(c *gin.Context) {
+	returnData(c, "state", ag.facade.GetIndexingState())
+}
+
+// pause will hold back the processing of the new payloads, so the node will not receive acknowledges
+func (ag *adminGroup) pause(c *gin.Context) {
+	err := ag.facade.PauseIndexing()
+	if err != nil {
+		returnError(c, err)
+		return
+	}
+
+	returnData(c, "state", ag.facade.GetIndexingState())
+}
+
+// resume will restart the processing of the payloads
+func (ag *adminGroup) resume(c *gin.Context) {
+	err := ag.facade.ResumeIndexing()
+	if err != nil {
+		returnError(c, err)
+		return
+	}
+
+	returnData(c, "state", ag.facade.GetIndexingState())
+}
+
+// drain will finish the in-flight payloads and then the indexer will exit
+func (ag *adminGroup) drain(c *gin.Context) {
+	ag.facade.DrainIndexing()
+
+	returnData(c, "state", ag.facade.GetIndexingState())
+}
+
+// getEnabledIndices will return the list of the enabled indices
+func (ag *adminGroup) getEnabledIndices(c *gin.Context) {
+	indices, err := ag.facade.GetEnabledIndexes()
+	if err != nil {
+		returnError(c, err)
+		return
+	}
+
+	returnData(c, "indices", indices)
+}
+
+// enableIndex will enable the indexing of the provided index
+func (ag *adminGroup) enableIndex(c *gin.Context) {
+	ag.setIndexEnabled(c, true)
+}
+
+// disableIndex will disable the indexing of the provided index
+func (ag *adminGroup) disableIndex(c *gin.Context) {
+	ag.setIndexEnabled(c, false)
+}
+
+func (ag *adminGroup) setIndexEnabled(c *gin.Context, enabled bool) {
+	err := ag.facade.SetIndexEnabled(c.Param(indexParam), enabled)
+	if err != nil {
+		returnError(c, err)
+		return
+	}
+
+	ag.getEnabledIndices(c)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ag *adminGroup) IsInterfaceNil() bool {
+	return ag == nil
+}
@@ -12,7 +12,9 @@ import (
 var log = logger.GetOrCreate("api/groups")
 
 type endpointProperties struct {
-	isOpen bool
+	isConfigured bool
+	isOpen       bool
+	auth         string
 }
 
 type baseGroup struct {
@@ -25,19 +27,29 @@ func (bg *baseGroup) GetEndpoints() []*shared.EndpointHandlerData {
 }
 
 // RegisterRoutes will register all the providers to the given web server
+// An endpoint missing from the config is not registered. A closed endpoint is served only to the authenticated
+// callers, while an open endpoint requires authentication only if an auth type is configured for it
 func (bg *baseGroup) RegisterRoutes(
 	ws *gin.RouterGroup,
 	apiConfig config.ApiRoutesConfig,
+	authHandler shared.AuthHandler,
 ) {
 	for _, handlerData := range bg.endpoints {
 		properties := getEndpointProperties(ws, handlerData.Path, apiConfig)
 
-		if !properties.isOpen {
-			log.Debug("endpoint is closed", "path", handlerData.Path)
+		if !properties.isConfigured {
+			log.Debug("endpoint is not configured", "path", handlerData.Path)
 			continue
 		}
 
-		ws.Handle(handlerData.Method, handlerData.Path, handlerData.Handler)
+		requiresAuth := !properties.isOpen || properties.auth != ""
+		if !requiresAuth {
+			ws.Handle(handlerData.Method, handlerData.Path, handlerData.Handler)
+			continue
+		}
+
+		log.Debug("endpoint requires authentication", "path", handlerData.Path, "auth", properties.auth)
+		ws.Handle(handlerData.Method, handlerData.Path, authHandler.MiddlewareHandlerFunc(properties.auth), handlerData.Handler)
 	}
 }
 
@@ -51,19 +63,21 @@ func getEndpointProperties(ws *gin.RouterGroup, path string, apiConfig config.Ap
 	group, ok := apiConfig.APIPackages[basePath]
 	if !ok {
 		return endpointProperties{
-			isOpen: false,
+			isConfigured: false,
 		}
 	}
 
 	for _, route := range group.Routes {
 		if route.Name == path {
 			return endpointProperties{
-				isOpen: route.Open,
+				isConfigured: true,
+				isOpen:       route.Open,
+				auth:         route.Auth,
 			}
 		}
 	}
 
 	return endpointProperties{
-		isOpen: false,
+		isConfigured: false,
 	}
 }
@@ -0,0 +1,81 @@
+package groups
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/multiversx/mx-chain-es-indexer-go/api/shared"
+	"github.com/multiversx/mx-chain-es-indexer-go/config"
+	"github.com/stretchr/testify/require"
+)
+
+type authHandlerStub struct {
+	authTypes []string
+}
+
+func (ahs *authHandlerStub) MiddlewareHandlerFunc(authType string) gin.HandlerFunc {
+	ahs.authTypes = append(ahs.authTypes, authType)
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		c.Next()
+	}
+}
+
+func (ahs *authHandlerStub) IsInterfaceNil() bool {
+	return ahs == nil
+}
+
+func TestBaseGroup_RegisterRoutes(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+	okHandler := func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	}
+	bg := &baseGroup{
+		endpoints: []*shared.EndpointHandlerData{
+			{Path: "/open", Handler: okHandler, Method: http.MethodGet},
+			{Path: "/open-with-auth", Handler: okHandler, Method: http.MethodGet},
+			{Path: "/closed", Handler: okHandler, Method: http.MethodGet},
+			{Path: "/missing", Handler: okHandler, Method: http.MethodGet},
+		},
+	}
+	apiConfig := config.ApiRoutesConfig{
+		APIPackages: map[string]config.APIPackageConfig{
+			"group": {
+				Routes: []config.RouteConfig{
+					{Name: "/open", Open: true},
+					{Name: "/open-with-auth", Open: true, Auth: "basic"},
+					{Name: "/closed", Open: false},
+				},
+			},
+		},
+	}
+
+	engine := gin.New()
+	authHandler := &authHandlerStub{}
+	bg.RegisterRoutes(engine.Group("/group"), apiConfig, authHandler)
+	require.Equal(t, []string{"basic", ""}, authHandler.authTypes)
+
+	doRequest := func(path string, withAuth bool) int {
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		if withAuth {
+			req.Header.Set("Authorization", "credentials")
+		}
+		resp := httptest.NewRecorder()
+		engine.ServeHTTP(resp, req)
+		return resp.Code
+	}
+
+	require.Equal(t, http.StatusOK, doRequest("/group/open", false))
+	require.Equal(t, http.StatusUnauthorized, doRequest("/group/open-with-auth", false))
+	require.Equal(t, http.StatusOK, doRequest("/group/open-with-auth", true))
+	require.Equal(t, http.StatusUnauthorized, doRequest("/group/closed", false))
+	require.Equal(t, http.StatusOK, doRequest("/group/closed", true))
+	require.Equal(t, http.StatusNotFound, doRequest("/group/missing", true))
+}
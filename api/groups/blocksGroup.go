@@ -0,0 +1,72 @@
+package groups
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-es-indexer-go/api/shared"
+	"github.com/multiversx/mx-chain-es-indexer-go/core"
+)
+
+const (
+	blockByShardAndNoncePath = "/:shard/:nonce"
+)
+
+type blocksGroup struct {
+	*baseGroup
+	facade shared.FacadeHandler
+}
+
+// NewBlocksGroup returns a new instance of blocks group
+func NewBlocksGroup(facade shared.FacadeHandler) (*blocksGroup, error) {
+	if check.IfNil(facade) {
+		return nil, fmt.Errorf("%w for blocks group", core.ErrNilFacadeHandler)
+	}
+
+	bg := &blocksGroup{
+		facade:    facade,
+		baseGroup: &baseGroup{},
+	}
+
+	endpoints := []*shared.EndpointHandlerData{
+		{
+			Path:    blockByShardAndNoncePath,
+			Handler: bg.getBlock,
+			Method:  http.MethodGet,
+		},
+	}
+	bg.endpoints = endpoints
+
+	return bg, nil
+}
+
+// getBlock will return the block from the provided shard with the provided nonce
+func (bg *blocksGroup) getBlock(c *gin.Context) {
+	shardID, err := strconv.ParseUint(c.Param("shard"), 10, 32)
+	if err != nil {
+		returnBadRequest(c, fmt.Errorf("invalid shard: %w", err))
+		return
+	}
+
+	nonce, err := strconv.ParseUint(c.Param("nonce"), 10, 64)
+	if err != nil {
+		returnBadRequest(c, fmt.Errorf("invalid nonce: %w", err))
+		return
+	}
+
+	block, err := bg.facade.GetBlock(uint32(shardID), nonce)
+	if err != nil {
+		returnError(c, err)
+		return
+	}
+
+	returnData(c, "block", block)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (bg *blocksGroup) IsInterfaceNil() bool {
+	return bg == nil
+}
@@ -0,0 +1,67 @@
+package groups
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/multiversx/mx-chain-es-indexer-go/core"
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
+)
+
+const (
+	codeSuccessful    = "successful"
+	codeBadRequest    = "bad_request"
+	codeNotFound      = "not_found"
+	codeInternalError = "internal_issue"
+
+	codeServiceUnavailable = "service_unavailable"
+	codeConflict           = "conflict"
+
+	sizeQueryParam        = "size"
+	searchAfterQueryParam = "searchAfter"
+)
+
+func getPagination(c *gin.Context) (data.Pagination, error) {
+	pagination := data.Pagination{
+		SearchAfter: c.Query(searchAfterQueryParam),
+	}
+
+	sizeStr := c.Query(sizeQueryParam)
+	if sizeStr == "" {
+		return pagination, nil
+	}
+
+	size, err := strconv.Atoi(sizeStr)
+	if err != nil {
+		return data.Pagination{}, core.ErrInvalidPageSize
+	}
+	pagination.Size = size
+
+	return pagination, nil
+}
+
+func returnData(c *gin.Context, key string, value interface{}) {
+	returnStatus(c, gin.H{key: value}, http.StatusOK, "", codeSuccessful)
+}
+
+func returnBadRequest(c *gin.Context, err error) {
+	returnStatus(c, nil, http.StatusBadRequest, err.Error(), codeBadRequest)
+}
+
+func returnError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, core.ErrNotFound):
+		returnStatus(c, nil, http.StatusNotFound, err.Error(), codeNotFound)
+	case errors.Is(err, core.ErrInvalidPageSize), errors.Is(err, core.ErrInvalidSearchAfter), errors.Is(err, core.ErrUnknownIndex):
+		returnBadRequest(c, err)
+	case errors.Is(err, core.ErrIndexingDrained):
+		returnStatus(c, nil, http.StatusConflict, err.Error(), codeConflict)
+	case errors.Is(err, core.ErrIndicesHandlerNotSet):
+		returnStatus(c, nil, http.StatusServiceUnavailable, err.Error(), codeServiceUnavailable)
+	default:
+		log.Debug("api request failed", "path", c.FullPath(), "error", err)
+		returnStatus(c, nil, http.StatusInternalServerError, err.Error(), codeInternalError)
+	}
+}
@@ -0,0 +1,102 @@
+package groups
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-es-indexer-go/api/shared"
+	"github.com/multiversx/mx-chain-es-indexer-go/core"
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
+)
+
+const (
+	// eventsPath is empty because the events are returned directly on the group's path
+	eventsPath = ""
+
+	addressQueryParam    = "address"
+	identifierQueryParam = "identifier"
+	txHashQueryParam     = "txHash"
+	shardQueryParam      = "shard"
+)
+
+type eventsGroup struct {
+	*baseGroup
+	facade shared.FacadeHandler
+}
+
+// NewEventsGroup returns a new instance of events group
+func NewEventsGroup(facade shared.FacadeHandler) (*eventsGroup, error) {
+	if check.IfNil(facade) {
+		return nil, fmt.Errorf("%w for events group", core.ErrNilFacadeHandler)
+	}
+
+	eg := &eventsGroup{
+		facade:    facade,
+		baseGroup: &baseGroup{},
+	}
+
+	endpoints := []*shared.EndpointHandlerData{
+		{
+			Path:    eventsPath,
+			Handler: eg.getEvents,
+			Method:  http.MethodGet,
+		},
+	}
+	eg.endpoints = endpoints
+
+	return eg, nil
+}
+
+// getEvents will return a page with the events that match the provided query parameters
+func (eg *eventsGroup) getEvents(c *gin.Context) {
+	pagination, err := getPagination(c)
+	if err != nil {
+		returnBadRequest(c, err)
+		return
+	}
+
+	filter, err := getEventsFilter(c)
+	if err != nil {
+		returnBadRequest(c, err)
+		return
+	}
+
+	events, err := eg.facade.GetEvents(filter, pagination)
+	if err != nil {
+		returnError(c, err)
+		return
+	}
+
+	returnData(c, "events", events)
+}
+
+func getEventsFilter(c *gin.Context) (data.EventsFilter, error) {
+	filter := data.EventsFilter{
+		Address:    c.Query(addressQueryParam),
+		Identifier: c.Query(identifierQueryParam),
+		TxHash:     c.Query(txHashQueryParam),
+	}
+
+	shardStr := c.Query(shardQueryParam)
+	if shardStr == "" {
+		return filter, nil
+	}
+
+	shardID, err := strconv.ParseUint(shardStr, 10, 32)
+	if err != nil {
+		return data.EventsFilter{}, fmt.Errorf("invalid shard: %w", err)
+	}
+
+	shardID32 := uint32(shardID)
+	filter.ShardID = &shardID32
+
+	return filter, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (eg *eventsGroup) IsInterfaceNil() bool {
+	return eg == nil
+}
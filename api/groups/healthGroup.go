@@ -0,0 +1,56 @@
+package groups
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-es-indexer-go/api/shared"
+	"github.com/multiversx/mx-chain-es-indexer-go/core"
+)
+
+const healthPath = ""
+
+type healthGroup struct {
+	*baseGroup
+	facade shared.FacadeHandler
+}
+
+// NewHealthGroup returns a new instance of health group, used as a liveness probe
+func NewHealthGroup(facade shared.FacadeHandler) (*healthGroup, error) {
+	if check.IfNil(facade) {
+		return nil, fmt.Errorf("%w for health group", core.ErrNilFacadeHandler)
+	}
+
+	hg := &healthGroup{
+		facade:    facade,
+		baseGroup: &baseGroup{},
+	}
+
+	endpoints := []*shared.EndpointHandlerData{
+		{
+			Path:    healthPath,
+			Handler: hg.getHealth,
+			Method:  http.MethodGet,
+		},
+	}
+	hg.endpoints = endpoints
+
+	return hg, nil
+}
+
+// getHealth will return 200 OK as long as the process is able to serve requests
+func (hg *healthGroup) getHealth(c *gin.Context) {
+	if !hg.facade.IsAlive() {
+		returnStatus(c, gin.H{"alive": false}, http.StatusServiceUnavailable, "", codeServiceUnavailable)
+		return
+	}
+
+	returnStatus(c, gin.H{"alive": true}, http.StatusOK, "", codeSuccessful)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (hg *healthGroup) IsInterfaceNil() bool {
+	return hg == nil
+}
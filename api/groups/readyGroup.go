@@ -0,0 +1,58 @@
+package groups
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-es-indexer-go/api/shared"
+	"github.com/multiversx/mx-chain-es-indexer-go/core"
+)
+
+const readyPath = ""
+
+type readyGroup struct {
+	*baseGroup
+	facade shared.FacadeHandler
+}
+
+// NewReadyGroup returns a new instance of ready group, used as a readiness probe
+func NewReadyGroup(facade shared.FacadeHandler) (*readyGroup, error) {
+	if check.IfNil(facade) {
+		return nil, fmt.Errorf("%w for ready group", core.ErrNilFacadeHandler)
+	}
+
+	rg := &readyGroup{
+		facade:    facade,
+		baseGroup: &baseGroup{},
+	}
+
+	endpoints := []*shared.EndpointHandlerData{
+		{
+			Path:    readyPath,
+			Handler: rg.getReadiness,
+			Method:  http.MethodGet,
+		},
+	}
+	rg.endpoints = endpoints
+
+	return rg, nil
+}
+
+// getReadiness will return 200 OK only if Elasticsearch is reachable, the templates were checked and a payload was
+// received through the WebSocket connection in the last minute, otherwise 503 Service Unavailable
+func (rg *readyGroup) getReadiness(c *gin.Context) {
+	readiness := rg.facade.GetReadiness()
+	if !readiness.Ready {
+		returnStatus(c, gin.H{"readiness": readiness}, http.StatusServiceUnavailable, "", codeServiceUnavailable)
+		return
+	}
+
+	returnStatus(c, gin.H{"readiness": readiness}, http.StatusOK, "", codeSuccessful)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (rg *readyGroup) IsInterfaceNil() bool {
+	return rg == nil
+}
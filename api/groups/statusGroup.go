@@ -11,6 +11,7 @@ import (
 )
 
 const (
+	indexingStatusPath    = ""
 	metricsPath           = "/metrics"
 	prometheusMetricsPath = "/prometheus-metrics"
 )
@@ -32,6 +33,11 @@ func NewStatusGroup(facade shared.FacadeHandler) (*statusGroup, error) {
 	}
 
 	endpoints := []*shared.EndpointHandlerData{
+		{
+			Path:    indexingStatusPath,
+			Handler: sg.getIndexingStatus,
+			Method:  http.MethodGet,
+		},
 		{
 			Path:    metricsPath,
 			Handler: sg.getMetrics,
@@ -48,11 +54,18 @@ func NewStatusGroup(facade shared.FacadeHandler) (*statusGroup, error) {
 	return sg, nil
 }
 
+// getIndexingStatus will expose, for each shard, the last indexed block and the lag versus the wall-clock
+func (sg *statusGroup) getIndexingStatus(c *gin.Context) {
+	indexingStatus := sg.facade.GetIndexingStatus()
+
+	returnStatus(c, gin.H{"shards": indexingStatus}, http.StatusOK, "", codeSuccessful)
+}
+
 // getMetrics will expose endpoints statistics in json format
 func (sg *statusGroup) getMetrics(c *gin.Context) {
 	metricsResults := sg.facade.GetMetrics()
 
-	returnStatus(c, gin.H{"metrics": metricsResults}, http.StatusOK, "", "successful")
+	returnStatus(c, gin.H{"metrics": metricsResults}, http.StatusOK, "", codeSuccessful)
 }
 
 // getPrometheusMetrics will expose proxy metrics in prometheus format
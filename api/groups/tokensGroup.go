@@ -0,0 +1,59 @@
+package groups
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-es-indexer-go/api/shared"
+	"github.com/multiversx/mx-chain-es-indexer-go/core"
+)
+
+const (
+	tokenByIdentifierPath = "/:identifier"
+)
+
+type tokensGroup struct {
+	*baseGroup
+	facade shared.FacadeHandler
+}
+
+// NewTokensGroup returns a new instance of tokens group
+func NewTokensGroup(facade shared.FacadeHandler) (*tokensGroup, error) {
+	if check.IfNil(facade) {
+		return nil, fmt.Errorf("%w for tokens group", core.ErrNilFacadeHandler)
+	}
+
+	tg := &tokensGroup{
+		facade:    facade,
+		baseGroup: &baseGroup{},
+	}
+
+	endpoints := []*shared.EndpointHandlerData{
+		{
+			Path:    tokenByIdentifierPath,
+			Handler: tg.getToken,
+			Method:  http.MethodGet,
+		},
+	}
+	tg.endpoints = endpoints
+
+	return tg, nil
+}
+
+// getToken will return the token with the provided identifier
+func (tg *tokensGroup) getToken(c *gin.Context) {
+	token, err := tg.facade.GetToken(c.Param("identifier"))
+	if err != nil {
+		returnError(c, err)
+		return
+	}
+
+	returnData(c, "token", token)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (tg *tokensGroup) IsInterfaceNil() bool {
+	return tg == nil
+}
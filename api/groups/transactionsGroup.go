@@ -0,0 +1,59 @@
+package groups
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-es-indexer-go/api/shared"
+	"github.com/multiversx/mx-chain-es-indexer-go/core"
+)
+
+const (
+	transactionByHashPath = "/:hash"
+)
+
+type transactionsGroup struct {
+	*baseGroup
+	facade shared.FacadeHandler
+}
+
+// NewTransactionsGroup returns a new instance of transactions group
+func NewTransactionsGroup(facade shared.FacadeHandler) (*transactionsGroup, error) {
+	if check.IfNil(facade) {
+		return nil, fmt.Errorf("%w for transactions group", core.ErrNilFacadeHandler)
+	}
+
+	tg := &transactionsGroup{
+		facade:    facade,
+		baseGroup: &baseGroup{},
+	}
+
+	endpoints := []*shared.EndpointHandlerData{
+		{
+			Path:    transactionByHashPath,
+			Handler: tg.getTransaction,
+			Method:  http.MethodGet,
+		},
+	}
+	tg.endpoints = endpoints
+
+	return tg, nil
+}
+
+// getTransaction will return the transaction with the provided hash
+func (tg *transactionsGroup) getTransaction(c *gin.Context) {
+	tx, err := tg.facade.GetTransaction(c.Param("hash"))
+	if err != nil {
+		returnError(c, err)
+		return
+	}
+
+	returnData(c, "transaction", tx)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (tg *transactionsGroup) IsInterfaceNil() bool {
+	return tg == nil
+}
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/multiversx/mx-chain-es-indexer-go/api/shared"
+	"github.com/multiversx/mx-chain-es-indexer-go/config"
+)
+
+const (
+	// AuthAny accepts both basic auth and bearer-token auth
+	AuthAny = ""
+	// AuthBasic accepts only basic auth
+	AuthBasic = "basic"
+	// AuthBearer accepts only bearer-token auth
+	AuthBearer = "bearer"
+
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+	codeUnauthorized    = "unauthorized"
+)
+
+type authenticator struct {
+	basicAuthUsers map[string]string
+	bearerTokens   []string
+}
+
+// NewAuthenticator will create a new instance of authenticator, a component that checks the credentials of the
+// callers against the ones from the provided config
+func NewAuthenticator(cfg config.ApiAuthConfig) *authenticator {
+	basicAuthUsers := make(map[string]string, len(cfg.BasicAuthUsers))
+	for _, user := range cfg.BasicAuthUsers {
+		basicAuthUsers[user.Username] = user.Password
+	}
+
+	return &authenticator{
+		basicAuthUsers: basicAuthUsers,
+		bearerTokens:   cfg.BearerTokens,
+	}
+}
+
+// CheckAuthType returns an error if the provided authentication type is not supported
+func CheckAuthType(authType string) error {
+	switch authType {
+	case AuthAny, AuthBasic, AuthBearer:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownAuthType, authType)
+	}
+}
+
+// MiddlewareHandlerFunc returns the gin middleware that allows only the callers authenticated with the provided
+// authentication type
+func (a *authenticator) MiddlewareHandlerFunc(authType string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if a.isAuthenticated(c.Request, authType) {
+			c.Next()
+			return
+		}
+
+		if authType != AuthBearer {
+			c.Header("WWW-Authenticate", `Basic realm="elasticindexer"`)
+		}
+		c.AbortWithStatusJSON(
+			http.StatusUnauthorized,
+			shared.GenericAPIResponse{
+				Data:  nil,
+				Error: "missing or invalid credentials",
+				Code:  codeUnauthorized,
+			},
+		)
+	}
+}
+
+func (a *authenticator) isAuthenticated(req *http.Request, authType string) bool {
+	switch authType {
+	case AuthBasic:
+		return a.isBasicAuthValid(req)
+	case AuthBearer:
+		return a.isBearerTokenValid(req)
+	case AuthAny:
+		return a.isBasicAuthValid(req) || a.isBearerTokenValid(req)
+	default:
+		return false
+	}
+}
+
+func (a *authenticator) isBasicAuthValid(req *http.Request) bool {
+	username, password, ok := req.BasicAuth()
+	if !ok {
+		return false
+	}
+
+	expectedPassword, found := a.basicAuthUsers[username]
+	if !found {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(password), []byte(expectedPassword)) == 1
+}
+
+func (a *authenticator) isBearerTokenValid(req *http.Request) bool {
+	header := req.Header.Get(authorizationHeader)
+	if !strings.HasPrefix(header, bearerPrefix) {
+		return false
+	}
+
+	token := []byte(strings.TrimPrefix(header, bearerPrefix))
+	isValid := false
+	for _, bearerToken := range a.bearerTokens {
+		if bearerToken == "" {
+			continue
+		}
+		// all the tokens are compared so the response time does not depend on which token matched
+		if subtle.ConstantTimeCompare(token, []byte(bearerToken)) == 1 {
+			isValid = true
+		}
+	}
+
+	return isValid
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (a *authenticator) IsInterfaceNil() bool {
+	return a == nil
+}
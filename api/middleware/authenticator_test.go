@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/multiversx/mx-chain-es-indexer-go/config"
+	"github.com/stretchr/testify/require"
+)
+
+func createAuthTestEngine(authType string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	auth := NewAuthenticator(config.ApiAuthConfig{
+		BasicAuthUsers: []config.BasicAuthUserConfig{{Username: "user", Password: "pass"}},
+		BearerTokens:   []string{"token"},
+	})
+
+	engine := gin.New()
+	engine.GET("/route", auth.MiddlewareHandlerFunc(authType), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	return engine
+}
+
+func doAuthRequest(engine *gin.Engine, setCredentials func(req *http.Request)) int {
+	req, _ := http.NewRequest(http.MethodGet, "/route", nil)
+	setCredentials(req)
+
+	resp := httptest.NewRecorder()
+	engine.ServeHTTP(resp, req)
+
+	return resp.Code
+}
+
+func TestCheckAuthType(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, CheckAuthType(AuthAny))
+	require.Nil(t, CheckAuthType(AuthBasic))
+	require.Nil(t, CheckAuthType(AuthBearer))
+	require.ErrorIs(t, CheckAuthType("digest"), ErrUnknownAuthType)
+}
+
+func TestAuthenticator_MiddlewareHandlerFunc(t *testing.T) {
+	t.Parallel()
+
+	noCredentials := func(_ *http.Request) {}
+	validBasic := func(req *http.Request) { req.SetBasicAuth("user", "pass") }
+	invalidBasic := func(req *http.Request) { req.SetBasicAuth("user", "wrong") }
+	validBearer := func(req *http.Request) { req.Header.Set(authorizationHeader, "Bearer token") }
+	invalidBearer := func(req *http.Request) { req.Header.Set(authorizationHeader, "Bearer wrong") }
+
+	engine := createAuthTestEngine(AuthBasic)
+	require.Equal(t, http.StatusUnauthorized, doAuthRequest(engine, noCredentials))
+	require.Equal(t, http.StatusUnauthorized, doAuthRequest(engine, invalidBasic))
+	require.Equal(t, http.StatusUnauthorized, doAuthRequest(engine, validBearer))
+	require.Equal(t, http.StatusOK, doAuthRequest(engine, validBasic))
+
+	engine = createAuthTestEngine(AuthBearer)
+	require.Equal(t, http.StatusUnauthorized, doAuthRequest(engine, noCredentials))
+	require.Equal(t, http.StatusUnauthorized, doAuthRequest(engine, invalidBearer))
+	require.Equal(t, http.StatusUnauthorized, doAuthRequest(engine, validBasic))
+	require.Equal(t, http.StatusOK, doAuthRequest(engine, validBearer))
+
+	engine = createAuthTestEngine(AuthAny)
+	require.Equal(t, http.StatusUnauthorized, doAuthRequest(engine, noCredentials))
+	require.Equal(t, http.StatusOK, doAuthRequest(engine, validBasic))
+	require.Equal(t, http.StatusOK, doAuthRequest(engine, validBearer))
+}
+
+func TestAuthenticator_NoCredentialsConfiguredShouldRejectAll(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+	auth := NewAuthenticator(config.ApiAuthConfig{BearerTokens: []string{""}})
+	engine := gin.New()
+	engine.GET("/route", auth.MiddlewareHandlerFunc(AuthAny), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	require.Equal(t, http.StatusUnauthorized, doAuthRequest(engine, func(req *http.Request) {
+		req.Header.Set(authorizationHeader, "Bearer ")
+	}))
+	require.Equal(t, http.StatusUnauthorized, doAuthRequest(engine, func(req *http.Request) {
+		req.SetBasicAuth("", "")
+	}))
+}
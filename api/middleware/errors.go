@@ -0,0 +1,9 @@
+package middleware
+
+import "errors"
+
+// ErrUnknownAuthType signals that an unknown authentication type has been provided
+var ErrUnknownAuthType = errors.New("unknown authentication type")
+
+// ErrInvalidRateLimit signals that an invalid rate limit configuration has been provided
+var ErrInvalidRateLimit = errors.New("invalid rate limit")
@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/multiversx/mx-chain-es-indexer-go/api/shared"
+	"github.com/multiversx/mx-chain-es-indexer-go/config"
+)
+
+const (
+	codeTooManyRequests = "too_many_requests"
+	cleanupInterval     = time.Minute
+)
+
+type tokenBucket struct {
+	tokens     float64
+	lastUpdate time.Time
+}
+
+type rateLimiter struct {
+	mut               sync.Mutex
+	requestsPerSecond float64
+	burst             float64
+	buckets           map[string]*tokenBucket
+	lastCleanup       time.Time
+	getTimeHandler    func() time.Time
+}
+
+// NewRateLimiter will create a new instance of rateLimiter, a component that limits the number of requests per
+// second of each client IP using a token bucket
+func NewRateLimiter(cfg config.ApiRateLimitConfig) (*rateLimiter, error) {
+	if cfg.RequestsPerSecond == 0 || cfg.Burst == 0 {
+		return nil, fmt.Errorf("%w: requests per second %d, burst %d", ErrInvalidRateLimit, cfg.RequestsPerSecond, cfg.Burst)
+	}
+
+	return &rateLimiter{
+		requestsPerSecond: float64(cfg.RequestsPerSecond),
+		burst:             float64(cfg.Burst),
+		buckets:           make(map[string]*tokenBucket),
+		getTimeHandler:    time.Now,
+	}, nil
+}
+
+// MiddlewareHandlerFunc returns the gin middleware that rejects the requests over the limit
+func (rl *rateLimiter) MiddlewareHandlerFunc() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if rl.allow(c.ClientIP()) {
+			c.Next()
+			return
+		}
+
+		c.AbortWithStatusJSON(
+			http.StatusTooManyRequests,
+			shared.GenericAPIResponse{
+				Data:  nil,
+				Error: "too many requests",
+				Code:  codeTooManyRequests,
+			},
+		)
+	}
+}
+
+func (rl *rateLimiter) allow(clientIP string) bool {
+	rl.mut.Lock()
+	defer rl.mut.Unlock()
+
+	now := rl.getTimeHandler()
+	rl.cleanupIfNeeded(now)
+
+	bucket, found := rl.buckets[clientIP]
+	if !found {
+		bucket = &tokenBucket{
+			tokens:     rl.burst,
+			lastUpdate: now,
+		}
+		rl.buckets[clientIP] = bucket
+	}
+
+	elapsed := now.Sub(bucket.lastUpdate).Seconds()
+	bucket.tokens = math.Min(rl.burst, bucket.tokens+elapsed*rl.requestsPerSecond)
+	bucket.lastUpdate = now
+
+	if bucket.tokens < 1 {
+		return false
+	}
+
+	bucket.tokens--
+	return true
+}
+
+// cleanupIfNeeded removes the buckets that were refilled completely, so the map does not grow indefinitely
+func (rl *rateLimiter) cleanupIfNeeded(now time.Time) {
+	if now.Sub(rl.lastCleanup) < cleanupInterval {
+		return
+	}
+	rl.lastCleanup = now
+
+	for clientIP, bucket := range rl.buckets {
+		elapsed := now.Sub(bucket.lastUpdate).Seconds()
+		if bucket.tokens+elapsed*rl.requestsPerSecond >= rl.burst {
+			delete(rl.buckets, clientIP)
+		}
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (rl *rateLimiter) IsInterfaceNil() bool {
+	return rl == nil
+}
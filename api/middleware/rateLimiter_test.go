@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/multiversx/mx-chain-es-indexer-go/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	rl, err := NewRateLimiter(config.ApiRateLimitConfig{RequestsPerSecond: 0, Burst: 1})
+	require.Nil(t, rl)
+	require.ErrorIs(t, err, ErrInvalidRateLimit)
+
+	rl, err = NewRateLimiter(config.ApiRateLimitConfig{RequestsPerSecond: 1, Burst: 0})
+	require.Nil(t, rl)
+	require.ErrorIs(t, err, ErrInvalidRateLimit)
+
+	rl, err = NewRateLimiter(config.ApiRateLimitConfig{RequestsPerSecond: 1, Burst: 1})
+	require.Nil(t, err)
+	require.False(t, rl.IsInterfaceNil())
+}
+
+func TestRateLimiter_AllowShouldLimitEachClientIP(t *testing.T) {
+	t.Parallel()
+
+	currentTime := time.Unix(1000, 0)
+	rl, _ := NewRateLimiter(config.ApiRateLimitConfig{RequestsPerSecond: 2, Burst: 2})
+	rl.getTimeHandler = func() time.Time {
+		return currentTime
+	}
+
+	require.True(t, rl.allow("1.1.1.1"))
+	require.True(t, rl.allow("1.1.1.1"))
+	require.False(t, rl.allow("1.1.1.1"))
+	require.True(t, rl.allow("2.2.2.2"))
+
+	currentTime = currentTime.Add(500 * time.Millisecond)
+	require.True(t, rl.allow("1.1.1.1"))
+	require.False(t, rl.allow("1.1.1.1"))
+
+	currentTime = currentTime.Add(2 * cleanupInterval)
+	require.True(t, rl.allow("3.3.3.3"))
+	require.Len(t, rl.buckets, 1)
+}
+
+func TestRateLimiter_MiddlewareHandlerFunc(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+	rl, _ := NewRateLimiter(config.ApiRateLimitConfig{RequestsPerSecond: 1, Burst: 1})
+	engine := gin.New()
+	engine.Use(rl.MiddlewareHandlerFunc())
+	engine.GET("/route", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	doRequest := func() int {
+		req, _ := http.NewRequest(http.MethodGet, "/route", nil)
+		req.RemoteAddr = "1.1.1.1:1234"
+		resp := httptest.NewRecorder()
+		engine.ServeHTTP(resp, req)
+		return resp.Code
+	}
+
+	require.Equal(t, http.StatusOK, doRequest())
+	require.Equal(t, http.StatusTooManyRequests, doRequest())
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/multiversx/mx-chain-es-indexer-go/config"
 	"github.com/multiversx/mx-chain-es-indexer-go/core/request"
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
 )
 
 // GroupHandler defines the actions needed to be performed by a gin API group
@@ -11,14 +12,37 @@ type GroupHandler interface {
 	RegisterRoutes(
 		ws *gin.RouterGroup,
 		apiConfig config.ApiRoutesConfig,
+		authHandler AuthHandler,
 	)
 	IsInterfaceNil() bool
 }
 
+// AuthHandler defines the behavior of a component that authenticates the callers of the routes
+type AuthHandler interface {
+	MiddlewareHandlerFunc(authType string) gin.HandlerFunc
+	IsInterfaceNil() bool
+}
+
 // FacadeHandler defines all the methods that a facade should implement
 type FacadeHandler interface {
 	GetMetrics() map[string]*request.MetricsResponse
 	GetMetricsForPrometheus() string
+	IsAlive() bool
+	GetReadiness() *request.ReadinessResponse
+	GetIndexingStatus() map[uint32]*request.ShardIndexingStatus
+	PauseIndexing() error
+	ResumeIndexing() error
+	DrainIndexing()
+	GetIndexingState() string
+	GetEnabledIndexes() ([]string, error)
+	SetIndexEnabled(index string, enabled bool) error
+	GetTransaction(hash string) (*data.TransactionResponse, error)
+	GetAccount(address string) (*data.AccountInfo, error)
+	GetAccountHistory(address string, pagination data.Pagination) (*data.AccountHistoryResponse, error)
+	GetAccountTokens(address string, pagination data.Pagination) (*data.AccountTokensResponse, error)
+	GetToken(identifier string) (*data.TokenInfo, error)
+	GetBlock(shardID uint32, nonce uint64) (*data.BlockResponse, error)
+	GetEvents(filter data.EventsFilter, pagination data.Pagination) (*data.EventsResponse, error)
 	IsInterfaceNil() bool
 }
 
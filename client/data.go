@@ -1,14 +1,25 @@
 package client
 
+import "net/http"
+
 const (
 	headerXSRF                       = "kbn-xsrf"
 	headerContentType                = "Content-Type"
 	kibanaPluginPath                 = "_plugin/kibana/api"
 	numOfErrorsToExtractBulkResponse = 5
+	maxBulkItemsRetries              = 3
 )
 
 var headerContentTypeJSON = []string{"application/json"}
 
+// retryableBulkItemStatuses holds the statuses of the bulk items which were not applied and can be sent again
+var retryableBulkItemStatuses = map[int]struct{}{
+	http.StatusTooManyRequests:    {},
+	http.StatusBadGateway:         {},
+	http.StatusServiceUnavailable: {},
+	http.StatusGatewayTimeout:     {},
+}
+
 // BulkRequestResponse defines the structure of a bulk request response
 type BulkRequestResponse struct {
 	Errors bool `json:"errors"`
@@ -0,0 +1,358 @@
+package dualwrite
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-es-indexer-go/core"
+	"github.com/multiversx/mx-chain-es-indexer-go/core/request"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc"
+	logger "github.com/multiversx/mx-chain-logger-go"
+)
+
+const (
+	bulkOperation          = "bulk"
+	removeOperation        = "remove"
+	updateByQueryOperation = "update by query"
+
+	defaultCloseTimeout = 30 * time.Second
+)
+
+var log = logger.GetOrCreate("indexer/client/dualwrite")
+
+// ArgsDualWriteClient holds the arguments needed to create a new dual-write client
+type ArgsDualWriteClient struct {
+	Primary       elasticproc.DatabaseClientHandler
+	Secondary     elasticproc.DatabaseClientHandler
+	StatusMetrics core.StatusMetricsHandler
+	QueueSize     int
+	NumWorkers    int
+	MaxRetries    int
+	RetryInterval time.Duration
+	CloseTimeout  time.Duration
+}
+
+type dualWriteClient struct {
+	primary      elasticproc.DatabaseClientHandler
+	secondary    elasticproc.DatabaseClientHandler
+	queues       [numTargets]*writeQueue
+	order        *secondaryOrder
+	closeTimeout time.Duration
+	closeCtx     context.Context
+	cancel       func()
+	wg           sync.WaitGroup
+	closeOnce    sync.Once
+}
+
+// NewDualWriteClient will create a database client that writes every operation to a primary and a secondary cluster.
+// Each cluster has its own write queue, with a worker for each concurrent request slot, so a slow or unavailable
+// secondary cluster does not block the indexing. The failed idempotent operations are retried, and an operation is
+// executed on the secondary cluster only after the ones that finished on the primary cluster before it was issued.
+// The result of the primary cluster is returned to the caller, while the operations that succeed only on one of the
+// clusters are logged as divergences. All the reads are served by the primary cluster.
+func NewDualWriteClient(args ArgsDualWriteClient) (*dualWriteClient, error) {
+	err := checkArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	closeTimeout := args.CloseTimeout
+	if closeTimeout == 0 {
+		closeTimeout = defaultCloseTimeout
+	}
+	numWorkers := args.NumWorkers
+	if numWorkers < 1 {
+		numWorkers = 1
+	}
+
+	closeCtx, cancel := context.WithCancel(context.Background())
+	dwc := &dualWriteClient{
+		primary:      args.Primary,
+		secondary:    args.Secondary,
+		order:        newSecondaryOrder(),
+		closeTimeout: closeTimeout,
+		closeCtx:     closeCtx,
+		cancel:       cancel,
+	}
+
+	topics := [numTargets]string{request.DualWritePrimaryTopic, request.DualWriteSecondaryTopic}
+	clients := [numTargets]elasticproc.DatabaseClientHandler{args.Primary, args.Secondary}
+	for target := 0; target < numTargets; target++ {
+		dwc.queues[target] = &writeQueue{
+			target:        target,
+			topic:         topics[target],
+			dbClient:      clients[target],
+			operations:    make(chan *writeOperation, args.QueueSize),
+			numWorkers:    numWorkers,
+			maxRetries:    args.MaxRetries,
+			retryInterval: args.RetryInterval,
+			statusMetrics: args.StatusMetrics,
+		}
+		dwc.queues[target].start(closeCtx, &dwc.wg)
+	}
+
+	return dwc, nil
+}
+
+func checkArgs(args ArgsDualWriteClient) error {
+	if check.IfNil(args.Primary) {
+		return ErrNilPrimaryClient
+	}
+	if check.IfNil(args.Secondary) {
+		return ErrNilSecondaryClient
+	}
+	if check.IfNil(args.StatusMetrics) {
+		return core.ErrNilMetricsHandler
+	}
+	if args.QueueSize < 1 {
+		return ErrInvalidQueueSize
+	}
+
+	return nil
+}
+
+// DoBulkRequest will send the bulk request to both clusters and will return the result of the primary cluster
+func (dwc *dualWriteClient) DoBulkRequest(ctx context.Context, buff *bytes.Buffer, index string) error {
+	return dwc.write(ctx, bulkOperation, index, buff, isRetriableBulk, func(ctx context.Context, dbClient elasticproc.DatabaseClientHandler, payload []byte) error {
+		return dbClient.DoBulkRequest(ctx, bytes.NewBuffer(payload), index)
+	})
+}
+
+// DoQueryRemove will send the remove request to both clusters and will return the result of the primary cluster
+func (dwc *dualWriteClient) DoQueryRemove(ctx context.Context, index string, buff *bytes.Buffer) error {
+	return dwc.write(ctx, removeOperation, index, buff, isAlwaysRetriable, func(ctx context.Context, dbClient elasticproc.DatabaseClientHandler, payload []byte) error {
+		return dbClient.DoQueryRemove(ctx, index, bytes.NewBuffer(payload))
+	})
+}
+
+// UpdateByQuery will send the update request to both clusters and will return the result of the primary cluster
+func (dwc *dualWriteClient) UpdateByQuery(ctx context.Context, index string, buff *bytes.Buffer) error {
+	return dwc.write(ctx, updateByQueryOperation, index, buff, isRetriableQuery, func(ctx context.Context, dbClient elasticproc.DatabaseClientHandler, payload []byte) error {
+		return dbClient.UpdateByQuery(ctx, index, bytes.NewBuffer(payload))
+	})
+}
+
+func (dwc *dualWriteClient) write(
+	ctx context.Context,
+	kind string,
+	index string,
+	buff *bytes.Buffer,
+	isRetriable func(payload []byte) bool,
+	handler func(ctx context.Context, dbClient elasticproc.DatabaseClientHandler, payload []byte) error,
+) error {
+	if dwc.closeCtx.Err() != nil {
+		return ErrClientClosed
+	}
+
+	// the payload is copied because the buffer is consumed on every attempt, on every cluster
+	payload := make([]byte, buff.Len())
+	copy(payload, buff.Bytes())
+
+	execute := func(ctx context.Context, dbClient elasticproc.DatabaseClientHandler) error {
+		return handler(ctx, dbClient, payload)
+	}
+
+	retriable := &retriableCheck{payload: payload, isRetriable: isRetriable}
+	tracker := newWriteTracker(kind, index, dwc.order, retriable)
+	enqueuedAt := time.Now()
+
+	// the secondary cluster does not receive the caller's context, so its requests are neither accounted in the
+	// requests metrics of the primary cluster, nor cancelled together with the caller's request
+	secondaryOp := &writeOperation{
+		ctx:          context.Background(),
+		execute:      execute,
+		size:         len(payload),
+		enqueuedAt:   enqueuedAt,
+		tracker:      tracker,
+		dependencies: dwc.order.dependencies(),
+	}
+	dwc.queues[secondaryTarget].addOperation()
+	select {
+	case dwc.queues[secondaryTarget].operations <- secondaryOp:
+	default:
+		log.Warn("dual-write: the secondary write queue is full", "operation", kind, "index", index)
+		dwc.queues[secondaryTarget].finishOperation(secondaryOp, errQueueFull)
+	}
+
+	primaryOp := &writeOperation{
+		ctx:        ctx,
+		execute:    execute,
+		size:       len(payload),
+		enqueuedAt: enqueuedAt,
+		tracker:    tracker,
+	}
+	dwc.queues[primaryTarget].addOperation()
+	select {
+	case dwc.queues[primaryTarget].operations <- primaryOp:
+	case <-ctx.Done():
+		dwc.queues[primaryTarget].finishOperation(primaryOp, ctx.Err())
+		return ctx.Err()
+	case <-dwc.closeCtx.Done():
+		dwc.queues[primaryTarget].finishOperation(primaryOp, ErrClientClosed)
+		return ErrClientClosed
+	}
+
+	select {
+	case err := <-tracker.primaryResult:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-dwc.closeCtx.Done():
+		return dwc.waitPrimaryResultAfterClose(tracker)
+	}
+}
+
+func (dwc *dualWriteClient) waitPrimaryResultAfterClose(tracker *writeTracker) error {
+	// after the write queues are stopped, the operation is either finished or it will never be
+	dwc.wg.Wait()
+
+	select {
+	case err := <-tracker.primaryResult:
+		return err
+	default:
+		return ErrClientClosed
+	}
+}
+
+// DoMultiGet will do a multi get request on the primary cluster
+func (dwc *dualWriteClient) DoMultiGet(ctx context.Context, ids []string, index string, withSource bool, res interface{}) error {
+	return dwc.primary.DoMultiGet(ctx, ids, index, withSource, res)
+}
+
+// DoScrollRequest will do a scroll request on the primary cluster
+func (dwc *dualWriteClient) DoScrollRequest(ctx context.Context, index string, body []byte, withSource bool, handlerFunc func(responseBytes []byte) error) error {
+	return dwc.primary.DoScrollRequest(ctx, index, body, withSource, handlerFunc)
+}
+
+// DoCountRequest will do a count request on the primary cluster
+func (dwc *dualWriteClient) DoCountRequest(ctx context.Context, index string, body []byte) (uint64, error) {
+	return dwc.primary.DoCountRequest(ctx, index, body)
+}
+
+// DoSearchRequest will do a search request on the primary cluster
+func (dwc *dualWriteClient) DoSearchRequest(ctx context.Context, index string, body []byte, res interface{}) error {
+	return dwc.primary.DoSearchRequest(ctx, index, body, res)
+}
+
+// Ping will check if the primary cluster is reachable
+func (dwc *dualWriteClient) Ping(ctx context.Context) error {
+	return dwc.primary.Ping(ctx)
+}
+
+// PutMappings will put the mappings of the provided index on both clusters
+func (dwc *dualWriteClient) PutMappings(indexName string, mappings *bytes.Buffer) error {
+	payload := mappings.Bytes()
+	return dwc.onBothClusters(func(dbClient elasticproc.DatabaseClientHandler) error {
+		return dbClient.PutMappings(indexName, bytes.NewBuffer(payload))
+	})
+}
+
+// GetSettings will fetch the settings of the provided index from the primary cluster
+func (dwc *dualWriteClient) GetSettings(index string, res interface{}) error {
+	return dwc.primary.GetSettings(index, res)
+}
+
+// GetMapping will fetch the mappings of the provided index from the primary cluster
+func (dwc *dualWriteClient) GetMapping(index string, res interface{}) error {
+	return dwc.primary.GetMapping(index, res)
+}
+
+// PutSettings will update the settings of the provided index on both clusters
+func (dwc *dualWriteClient) PutSettings(index string, settings *bytes.Buffer) error {
+	payload := settings.Bytes()
+	return dwc.onBothClusters(func(dbClient elasticproc.DatabaseClientHandler) error {
+		return dbClient.PutSettings(index, bytes.NewBuffer(payload))
+	})
+}
+
+// CheckAndCreateIndex will create the provided index on both clusters, if it does not exist
+func (dwc *dualWriteClient) CheckAndCreateIndex(index string) error {
+	return dwc.onBothClusters(func(dbClient elasticproc.DatabaseClientHandler) error {
+		return dbClient.CheckAndCreateIndex(index)
+	})
+}
+
+// CheckAndCreateAlias will create the provided alias on both clusters, if it does not exist
+func (dwc *dualWriteClient) CheckAndCreateAlias(alias string, index string) error {
+	return dwc.onBothClusters(func(dbClient elasticproc.DatabaseClientHandler) error {
+		return dbClient.CheckAndCreateAlias(alias, index)
+	})
+}
+
+// CheckAndCreateTemplate will create the provided template on both clusters, if it does not exist
+func (dwc *dualWriteClient) CheckAndCreateTemplate(templateName string, template *bytes.Buffer) error {
+	payload := template.Bytes()
+	return dwc.onBothClusters(func(dbClient elasticproc.DatabaseClientHandler) error {
+		return dbClient.CheckAndCreateTemplate(templateName, bytes.NewBuffer(payload))
+	})
+}
+
+// CheckAndCreatePolicy will create the provided policy on both clusters, if it does not exist
+func (dwc *dualWriteClient) CheckAndCreatePolicy(policyName string, policy *bytes.Buffer) error {
+	payload := policy.Bytes()
+	return dwc.onBothClusters(func(dbClient elasticproc.DatabaseClientHandler) error {
+		return dbClient.CheckAndCreatePolicy(policyName, bytes.NewBuffer(payload))
+	})
+}
+
+// onBothClusters is used for the setup operations, which are done synchronously, at start-up
+func (dwc *dualWriteClient) onBothClusters(handler func(dbClient elasticproc.DatabaseClientHandler) error) error {
+	err := handler(dwc.primary)
+	if err != nil {
+		return err
+	}
+
+	return handler(dwc.secondary)
+}
+
+// Close will wait for the pending write operations to be executed, for at most the close timeout, and will stop
+// the write queues. The operations still pending after the timeout are reported as divergences
+func (dwc *dualWriteClient) Close() error {
+	dwc.closeOnce.Do(func() {
+		timer := time.NewTimer(dwc.closeTimeout)
+		defer timer.Stop()
+
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+
+		for !dwc.queuesAreEmpty() {
+			select {
+			case <-timer.C:
+				log.Warn("dual-write: closing with pending write operations",
+					"primary pending operations", dwc.queues[primaryTarget].pendingOperations(),
+					"secondary pending operations", dwc.queues[secondaryTarget].pendingOperations(),
+				)
+				dwc.stop()
+				return
+			case <-ticker.C:
+			}
+		}
+
+		dwc.stop()
+	})
+
+	return nil
+}
+
+func (dwc *dualWriteClient) queuesAreEmpty() bool {
+	for _, queue := range dwc.queues {
+		if queue.pendingOperations() > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (dwc *dualWriteClient) stop() {
+	dwc.cancel()
+	dwc.wg.Wait()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (dwc *dualWriteClient) IsInterfaceNil() bool {
+	return dwc == nil
+}
@@ -0,0 +1,394 @@
+package dualwrite
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-es-indexer-go/core"
+	"github.com/multiversx/mx-chain-es-indexer-go/core/request"
+	"github.com/multiversx/mx-chain-es-indexer-go/metrics"
+	"github.com/multiversx/mx-chain-es-indexer-go/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	indexPayload  = `{"index":{"_id":"1"}}` + "\n" + `{"a":1}` + "\n"
+	scriptPayload = `{"update":{"_id":"1"}}` + "\n" + `{"script":{"source":"ctx._source.count += 1"},"upsert":{}}` + "\n"
+)
+
+func createMockArgs() ArgsDualWriteClient {
+	return ArgsDualWriteClient{
+		Primary:       &mock.DatabaseWriterStub{},
+		Secondary:     &mock.DatabaseWriterStub{},
+		StatusMetrics: metrics.NewStatusMetrics(),
+		QueueSize:     10,
+		MaxRetries:    2,
+		RetryInterval: time.Millisecond,
+		CloseTimeout:  time.Second,
+	}
+}
+
+func TestNewDualWriteClient(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgs()
+	args.Primary = nil
+	dwc, err := NewDualWriteClient(args)
+	require.Nil(t, dwc)
+	require.Equal(t, ErrNilPrimaryClient, err)
+
+	args = createMockArgs()
+	args.Secondary = nil
+	dwc, err = NewDualWriteClient(args)
+	require.Nil(t, dwc)
+	require.Equal(t, ErrNilSecondaryClient, err)
+
+	args = createMockArgs()
+	args.StatusMetrics = nil
+	dwc, err = NewDualWriteClient(args)
+	require.Nil(t, dwc)
+	require.Equal(t, core.ErrNilMetricsHandler, err)
+
+	args = createMockArgs()
+	args.QueueSize = 0
+	dwc, err = NewDualWriteClient(args)
+	require.Nil(t, dwc)
+	require.Equal(t, ErrInvalidQueueSize, err)
+
+	dwc, err = NewDualWriteClient(createMockArgs())
+	require.Nil(t, err)
+	require.False(t, dwc.IsInterfaceNil())
+	require.Nil(t, dwc.Close())
+}
+
+func TestDualWriteClient_DoBulkRequestShouldWriteOnBothClusters(t *testing.T) {
+	t.Parallel()
+
+	payload := []byte(`{"index":{"_id":"1"}}` + "\n" + `{"a":1}` + "\n")
+	receivedPayloads := make(chan string, 2)
+	writeStub := &mock.DatabaseWriterStub{
+		DoBulkRequestCalled: func(buff *bytes.Buffer, index string) error {
+			require.Equal(t, "transactions", index)
+			receivedPayloads <- buff.String()
+			return nil
+		},
+	}
+
+	args := createMockArgs()
+	args.Primary = writeStub
+	args.Secondary = writeStub
+	statusMetrics := metrics.NewStatusMetrics()
+	args.StatusMetrics = statusMetrics
+	dwc, _ := NewDualWriteClient(args)
+
+	err := dwc.DoBulkRequest(context.Background(), bytes.NewBuffer(payload), "transactions")
+	require.Nil(t, err)
+	require.Nil(t, dwc.Close())
+
+	require.Equal(t, string(payload), <-receivedPayloads)
+	require.Equal(t, string(payload), <-receivedPayloads)
+
+	metricsMap := statusMetrics.GetMetrics()
+	require.Equal(t, uint64(1), metricsMap[request.DualWritePrimaryTopic].OperationsCount)
+	require.Equal(t, uint64(len(payload)), metricsMap[request.DualWritePrimaryTopic].TotalData)
+	require.Equal(t, uint64(1), metricsMap[request.DualWriteSecondaryTopic].OperationsCount)
+	require.Equal(t, uint64(0), metricsMap[request.DualWriteSecondaryTopic].TotalErrorsCount)
+}
+
+func TestDualWriteClient_PrimaryErrorShouldBeReturnedAfterRetries(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("primary error")
+	primaryCalls := uint32(0)
+	args := createMockArgs()
+	args.Primary = &mock.DatabaseWriterStub{
+		DoQueryRemoveCalled: func(_ string, _ *bytes.Buffer) error {
+			atomic.AddUint32(&primaryCalls, 1)
+			return expectedErr
+		},
+	}
+	dwc, _ := NewDualWriteClient(args)
+	defer func() {
+		_ = dwc.Close()
+	}()
+
+	err := dwc.DoQueryRemove(context.Background(), "blocks", bytes.NewBufferString("{}"))
+	require.Equal(t, expectedErr, err)
+	require.Equal(t, uint32(args.MaxRetries+1), atomic.LoadUint32(&primaryCalls))
+}
+
+func TestDualWriteClient_SecondaryShouldRetryWithoutBlockingThePrimary(t *testing.T) {
+	t.Parallel()
+
+	secondaryCalls := uint32(0)
+	secondaryDone := make(chan struct{})
+	args := createMockArgs()
+	args.Secondary = &mock.DatabaseWriterStub{
+		DoBulkRequestCalled: func(_ *bytes.Buffer, _ string) error {
+			if atomic.AddUint32(&secondaryCalls, 1) == 1 {
+				return errors.New("secondary unavailable")
+			}
+
+			close(secondaryDone)
+			return nil
+		},
+	}
+	statusMetrics := metrics.NewStatusMetrics()
+	args.StatusMetrics = statusMetrics
+	dwc, _ := NewDualWriteClient(args)
+
+	err := dwc.DoBulkRequest(context.Background(), bytes.NewBufferString(indexPayload), "")
+	require.Nil(t, err)
+
+	select {
+	case <-secondaryDone:
+	case <-time.After(time.Second):
+		require.Fail(t, "the secondary write was not retried")
+	}
+	require.Nil(t, dwc.Close())
+
+	require.Equal(t, uint32(2), atomic.LoadUint32(&secondaryCalls))
+	require.Equal(t, uint64(0), statusMetrics.GetMetrics()[request.DualWriteSecondaryTopic].TotalErrorsCount)
+}
+
+func TestDualWriteClient_FullSecondaryQueueShouldNotBlockThePrimary(t *testing.T) {
+	t.Parallel()
+
+	unblockSecondary := make(chan struct{})
+	args := createMockArgs()
+	args.QueueSize = 1
+	args.MaxRetries = 0
+	args.Secondary = &mock.DatabaseWriterStub{
+		DoBulkRequestCalled: func(_ *bytes.Buffer, _ string) error {
+			<-unblockSecondary
+			return nil
+		},
+	}
+	statusMetrics := metrics.NewStatusMetrics()
+	args.StatusMetrics = statusMetrics
+	dwc, _ := NewDualWriteClient(args)
+
+	for i := 0; i < 5; i++ {
+		err := dwc.DoBulkRequest(context.Background(), bytes.NewBufferString("data"), "")
+		require.Nil(t, err)
+	}
+
+	close(unblockSecondary)
+	require.Nil(t, dwc.Close())
+
+	secondaryMetrics := statusMetrics.GetMetrics()[request.DualWriteSecondaryTopic]
+	require.Equal(t, uint64(5), secondaryMetrics.OperationsCount)
+	require.True(t, secondaryMetrics.TotalErrorsCount > 0)
+}
+
+func TestDualWriteClient_ReadsShouldBeDoneOnlyOnPrimary(t *testing.T) {
+	t.Parallel()
+
+	primaryReads := 0
+	args := createMockArgs()
+	args.Primary = &mock.DatabaseWriterStub{
+		DoMultiGetCalled: func(_ []string, _ string, _ bool, _ interface{}) error {
+			primaryReads++
+			return nil
+		},
+		DoSearchRequestCalled: func(_ string, _ []byte, _ interface{}) error {
+			primaryReads++
+			return nil
+		},
+		PingCalled: func() error {
+			primaryReads++
+			return nil
+		},
+	}
+	args.Secondary = &mock.DatabaseWriterStub{
+		DoMultiGetCalled: func(_ []string, _ string, _ bool, _ interface{}) error {
+			require.Fail(t, "should have not been called")
+			return nil
+		},
+		DoSearchRequestCalled: func(_ string, _ []byte, _ interface{}) error {
+			require.Fail(t, "should have not been called")
+			return nil
+		},
+		PingCalled: func() error {
+			require.Fail(t, "should have not been called")
+			return nil
+		},
+	}
+	dwc, _ := NewDualWriteClient(args)
+	defer func() {
+		_ = dwc.Close()
+	}()
+
+	require.Nil(t, dwc.DoMultiGet(context.Background(), []string{"token"}, "tokens", true, nil))
+	require.Nil(t, dwc.DoSearchRequest(context.Background(), "tokens", []byte("{}"), nil))
+	require.Nil(t, dwc.Ping(context.Background()))
+	require.Equal(t, 3, primaryReads)
+}
+
+func TestDualWriteClient_CheckAndCreateIndexShouldBeDoneOnBothClusters(t *testing.T) {
+	t.Parallel()
+
+	mut := sync.Mutex{}
+	createdIndices := make([]string, 0)
+	writeStub := &mock.DatabaseWriterStub{
+		CheckAndCreateIndexCalled: func(index string) error {
+			mut.Lock()
+			createdIndices = append(createdIndices, index)
+			mut.Unlock()
+			return nil
+		},
+	}
+
+	args := createMockArgs()
+	args.Primary = writeStub
+	args.Secondary = writeStub
+	dwc, _ := NewDualWriteClient(args)
+	defer func() {
+		_ = dwc.Close()
+	}()
+
+	require.Nil(t, dwc.CheckAndCreateIndex("blocks"))
+	require.Equal(t, []string{"blocks", "blocks"}, createdIndices)
+}
+
+func TestDualWriteClient_CloseShouldWaitForThePendingOperations(t *testing.T) {
+	t.Parallel()
+
+	secondaryWrites := uint32(0)
+	args := createMockArgs()
+	args.Secondary = &mock.DatabaseWriterStub{
+		DoBulkRequestCalled: func(_ *bytes.Buffer, _ string) error {
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddUint32(&secondaryWrites, 1)
+			return nil
+		},
+	}
+	dwc, _ := NewDualWriteClient(args)
+
+	for i := 0; i < 3; i++ {
+		_ = dwc.DoBulkRequest(context.Background(), bytes.NewBufferString("data"), "")
+	}
+	require.Nil(t, dwc.Close())
+	require.Equal(t, uint32(3), atomic.LoadUint32(&secondaryWrites))
+
+	err := dwc.DoBulkRequest(context.Background(), bytes.NewBufferString("data"), "")
+	require.Equal(t, ErrClientClosed, err)
+}
+
+func TestDualWriteClient_ScriptedBulkShouldNotBeRetried(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("primary error")
+	primaryCalls := uint32(0)
+	args := createMockArgs()
+	args.Primary = &mock.DatabaseWriterStub{
+		DoBulkRequestCalled: func(_ *bytes.Buffer, _ string) error {
+			atomic.AddUint32(&primaryCalls, 1)
+			return expectedErr
+		},
+	}
+	dwc, _ := NewDualWriteClient(args)
+	defer func() {
+		_ = dwc.Close()
+	}()
+
+	err := dwc.DoBulkRequest(context.Background(), bytes.NewBufferString(scriptPayload), "")
+	require.Equal(t, expectedErr, err)
+	require.Equal(t, uint32(1), atomic.LoadUint32(&primaryCalls))
+
+	err = dwc.DoBulkRequest(context.Background(), bytes.NewBufferString(indexPayload), "")
+	require.Equal(t, expectedErr, err)
+	require.Equal(t, uint32(1+args.MaxRetries+1), atomic.LoadUint32(&primaryCalls))
+}
+
+func TestDualWriteClient_PrimaryRequestsShouldBeConcurrent(t *testing.T) {
+	t.Parallel()
+
+	numWorkers := 3
+	inFlight := make(chan struct{}, numWorkers)
+	unblock := make(chan struct{})
+	args := createMockArgs()
+	args.NumWorkers = numWorkers
+	args.Primary = &mock.DatabaseWriterStub{
+		DoBulkRequestCalled: func(_ *bytes.Buffer, _ string) error {
+			inFlight <- struct{}{}
+			<-unblock
+			return nil
+		},
+	}
+	dwc, _ := NewDualWriteClient(args)
+
+	wg := sync.WaitGroup{}
+	wg.Add(numWorkers)
+	for i := 0; i < numWorkers; i++ {
+		go func() {
+			defer wg.Done()
+			require.Nil(t, dwc.DoBulkRequest(context.Background(), bytes.NewBufferString(indexPayload), ""))
+		}()
+	}
+
+	for i := 0; i < numWorkers; i++ {
+		select {
+		case <-inFlight:
+		case <-time.After(time.Second):
+			require.Fail(t, "the primary requests were not sent concurrently")
+		}
+	}
+	close(unblock)
+	wg.Wait()
+	require.Nil(t, dwc.Close())
+}
+
+func TestDualWriteClient_SecondaryShouldKeepTheOrderOfDependentRequests(t *testing.T) {
+	t.Parallel()
+
+	mut := sync.Mutex{}
+	secondaryPayloads := make([]string, 0)
+	unblockFirst := make(chan struct{})
+	args := createMockArgs()
+	args.NumWorkers = 2
+	args.Secondary = &mock.DatabaseWriterStub{
+		DoBulkRequestCalled: func(buff *bytes.Buffer, _ string) error {
+			if buff.String() == "first" {
+				<-unblockFirst
+			}
+
+			mut.Lock()
+			secondaryPayloads = append(secondaryPayloads, buff.String())
+			mut.Unlock()
+			return nil
+		},
+	}
+	dwc, _ := NewDualWriteClient(args)
+
+	// the second request is issued after the first one finished on the primary cluster, so it might depend on it
+	require.Nil(t, dwc.DoBulkRequest(context.Background(), bytes.NewBufferString("first"), ""))
+	require.Nil(t, dwc.DoBulkRequest(context.Background(), bytes.NewBufferString("second"), ""))
+
+	time.Sleep(20 * time.Millisecond)
+	close(unblockFirst)
+	require.Nil(t, dwc.Close())
+
+	require.Equal(t, []string{"first", "second"}, secondaryPayloads)
+}
+
+func TestIsRetriableBulk(t *testing.T) {
+	t.Parallel()
+
+	deletePayload := `{"delete":{"_id":"1"}}` + "\n" + indexPayload
+	partialUpdatePayload := `{"update":{"_id":"1"}}` + "\n" + `{"doc":{"a":1}}` + "\n"
+
+	require.True(t, isRetriableBulk([]byte(indexPayload)))
+	require.True(t, isRetriableBulk([]byte(deletePayload)))
+	require.True(t, isRetriableBulk([]byte(partialUpdatePayload)))
+	require.False(t, isRetriableBulk([]byte(indexPayload+scriptPayload)))
+	require.False(t, isRetriableBulk([]byte("not a bulk request")))
+
+	require.True(t, isRetriableQuery([]byte(`{"query":{"match_all":{}}}`)))
+	require.False(t, isRetriableQuery([]byte(`{"query":{"match_all":{}},"script":{"source":""}}`)))
+}
@@ -0,0 +1,17 @@
+package dualwrite
+
+import "errors"
+
+// ErrNilPrimaryClient signals that a nil primary database client has been provided
+var ErrNilPrimaryClient = errors.New("nil primary database client")
+
+// ErrNilSecondaryClient signals that a nil secondary database client has been provided
+var ErrNilSecondaryClient = errors.New("nil secondary database client")
+
+// ErrInvalidQueueSize signals that an invalid write queue size has been provided
+var ErrInvalidQueueSize = errors.New("invalid write queue size")
+
+// ErrClientClosed signals that the dual-write client was closed
+var ErrClientClosed = errors.New("dual-write client closed")
+
+var errQueueFull = errors.New("write queue is full, the operation was dropped")
@@ -0,0 +1,70 @@
+package dualwrite
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+const (
+	updateAction = "update"
+	deleteAction = "delete"
+	scriptField  = "script"
+)
+
+// isRetriableBulk returns true if the bulk request has no scripted operations. The index, create, delete and partial
+// document updates give the same result when they are applied again, while a scripted update, as the counters, would
+// be applied twice for the items which succeeded in the failed attempt
+func isRetriableBulk(payload []byte) bool {
+	expectAction := true
+	isUpdate := false
+	for len(payload) > 0 {
+		line := payload
+		newLineIdx := bytes.IndexByte(payload, '\n')
+		if newLineIdx >= 0 {
+			line, payload = payload[:newLineIdx], payload[newLineIdx+1:]
+		} else {
+			payload = nil
+		}
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		fields := make(map[string]json.RawMessage)
+		err := json.Unmarshal(line, &fields)
+		if err != nil {
+			return false
+		}
+
+		if !expectAction {
+			if isUpdate && fields[scriptField] != nil {
+				return false
+			}
+			expectAction = true
+			continue
+		}
+
+		if len(fields) != 1 {
+			return false
+		}
+		_, isUpdate = fields[updateAction]
+		_, isDelete := fields[deleteAction]
+		expectAction = isDelete
+	}
+
+	return true
+}
+
+// isRetriableQuery returns true if the update by query request has no script
+func isRetriableQuery(payload []byte) bool {
+	fields := make(map[string]json.RawMessage)
+	err := json.Unmarshal(payload, &fields)
+	if err != nil {
+		return false
+	}
+
+	return fields[scriptField] == nil
+}
+
+func isAlwaysRetriable(_ []byte) bool {
+	return true
+}
@@ -0,0 +1,274 @@
+package dualwrite
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-es-indexer-go/core"
+	"github.com/multiversx/mx-chain-es-indexer-go/metrics"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc"
+)
+
+const (
+	primaryTarget = iota
+	secondaryTarget
+	numTargets
+)
+
+var targetNames = [numTargets]string{"primary", "secondary"}
+
+type executeHandler func(ctx context.Context, dbClient elasticproc.DatabaseClientHandler) error
+
+// writeOperation is a write request, as seen by one of the targets
+type writeOperation struct {
+	ctx          context.Context
+	execute      executeHandler
+	size         int
+	enqueuedAt   time.Time
+	tracker      *writeTracker
+	dependencies []chan struct{}
+}
+
+// writeTracker collects the results of the same write request on all targets in order to detect divergences
+type writeTracker struct {
+	mut           sync.Mutex
+	kind          string
+	index         string
+	results       [numTargets]error
+	finished      [numTargets]bool
+	remaining     int
+	primaryResult chan error
+	secondaryDone chan struct{}
+	order         *secondaryOrder
+	retriable     *retriableCheck
+}
+
+func newWriteTracker(kind string, index string, order *secondaryOrder, retriable *retriableCheck) *writeTracker {
+	return &writeTracker{
+		kind:          kind,
+		index:         index,
+		remaining:     numTargets,
+		primaryResult: make(chan error, 1),
+		secondaryDone: make(chan struct{}),
+		order:         order,
+		retriable:     retriable,
+	}
+}
+
+func (wt *writeTracker) setResult(target int, err error) {
+	if target == primaryTarget {
+		wt.primaryResult <- err
+	}
+
+	wt.mut.Lock()
+	wt.results[target] = err
+	wt.finished[target] = true
+	wt.remaining--
+	isDone := wt.remaining == 0
+	switch {
+	case target == secondaryTarget:
+		close(wt.secondaryDone)
+		wt.order.remove(wt)
+	case !wt.finished[secondaryTarget]:
+		wt.order.add(wt)
+	}
+	wt.mut.Unlock()
+
+	if isDone {
+		wt.reportDivergence()
+	}
+}
+
+// secondaryOrder keeps the write requests which finished on the primary cluster but are still pending on the
+// secondary one. A request issued after them might depend on them, as the bulk requests on the same documents, so it
+// is executed on the secondary cluster only after them. The requests still pending on the primary cluster are
+// concurrent with the new one, so they can be executed in any order
+type secondaryOrder struct {
+	mut     sync.Mutex
+	pending map[*writeTracker]struct{}
+}
+
+func newSecondaryOrder() *secondaryOrder {
+	return &secondaryOrder{
+		pending: make(map[*writeTracker]struct{}),
+	}
+}
+
+func (so *secondaryOrder) add(wt *writeTracker) {
+	so.mut.Lock()
+	so.pending[wt] = struct{}{}
+	so.mut.Unlock()
+}
+
+func (so *secondaryOrder) remove(wt *writeTracker) {
+	so.mut.Lock()
+	delete(so.pending, wt)
+	so.mut.Unlock()
+}
+
+func (so *secondaryOrder) dependencies() []chan struct{} {
+	so.mut.Lock()
+	defer so.mut.Unlock()
+
+	dependencies := make([]chan struct{}, 0, len(so.pending))
+	for wt := range so.pending {
+		dependencies = append(dependencies, wt.secondaryDone)
+	}
+
+	return dependencies
+}
+
+// retriableCheck tells, once for all the targets, if a failed write request can be sent again. A request that
+// failed might have been partially applied, so only the idempotent ones are retried
+type retriableCheck struct {
+	once        sync.Once
+	payload     []byte
+	isRetriable func(payload []byte) bool
+	result      bool
+}
+
+func (rc *retriableCheck) check() bool {
+	rc.once.Do(func() {
+		rc.result = rc.isRetriable(rc.payload)
+	})
+
+	return rc.result
+}
+
+func (wt *writeTracker) reportDivergence() {
+	primaryErr, secondaryErr := wt.results[primaryTarget], wt.results[secondaryTarget]
+	if (primaryErr == nil) == (secondaryErr == nil) {
+		return
+	}
+
+	log.Error("dual-write divergence",
+		"operation", wt.kind,
+		"index", wt.index,
+		"primary error", errorString(primaryErr),
+		"secondary error", errorString(secondaryErr),
+	)
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return "none"
+	}
+
+	return err.Error()
+}
+
+// writeQueue executes the write operations of one target with a worker for each concurrent request slot, retrying
+// the failed idempotent ones
+type writeQueue struct {
+	target        int
+	topic         string
+	dbClient      elasticproc.DatabaseClientHandler
+	operations    chan *writeOperation
+	numWorkers    int
+	maxRetries    int
+	retryInterval time.Duration
+	statusMetrics core.StatusMetricsHandler
+	numPending    int64
+}
+
+func (wq *writeQueue) start(ctx context.Context, wg *sync.WaitGroup) {
+	wg.Add(wq.numWorkers)
+	for i := 0; i < wq.numWorkers; i++ {
+		go wq.processOperations(ctx, wg)
+	}
+}
+
+func (wq *writeQueue) processOperations(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			wq.dropPendingOperations()
+			return
+		case op := <-wq.operations:
+			wq.executeOperation(ctx, op)
+		}
+	}
+}
+
+func (wq *writeQueue) executeOperation(closeCtx context.Context, op *writeOperation) {
+	for _, dependency := range op.dependencies {
+		select {
+		case <-dependency:
+		case <-closeCtx.Done():
+			wq.finishOperation(op, ErrClientClosed)
+			return
+		}
+	}
+
+	var err error
+	for attempt := 0; attempt <= wq.maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-closeCtx.Done():
+				wq.finishOperation(op, ErrClientClosed)
+				return
+			case <-time.After(wq.retryInterval):
+			}
+		}
+
+		err = op.execute(op.ctx, wq.dbClient)
+		if err == nil {
+			break
+		}
+
+		log.Debug("dual-write: write operation failed",
+			"target", targetNames[wq.target],
+			"operation", op.tracker.kind,
+			"index", op.tracker.index,
+			"attempt", attempt+1,
+			"queue length", len(wq.operations),
+			"error", err.Error(),
+		)
+
+		if !op.tracker.retriable.check() {
+			break
+		}
+	}
+
+	wq.finishOperation(op, err)
+}
+
+// addOperation marks a new operation as pending, until it is finished, either executed or dropped
+func (wq *writeQueue) addOperation() {
+	atomic.AddInt64(&wq.numPending, 1)
+}
+
+func (wq *writeQueue) pendingOperations() int64 {
+	return atomic.LoadInt64(&wq.numPending)
+}
+
+func (wq *writeQueue) finishOperation(op *writeOperation, err error) {
+	defer atomic.AddInt64(&wq.numPending, -1)
+
+	if !check.IfNil(wq.statusMetrics) {
+		wq.statusMetrics.AddIndexingData(metrics.ArgsAddIndexingData{
+			GotError:   err != nil,
+			MessageLen: uint64(op.size),
+			Topic:      wq.topic,
+			Duration:   time.Since(op.enqueuedAt),
+		})
+	}
+
+	op.tracker.setResult(wq.target, err)
+}
+
+func (wq *writeQueue) dropPendingOperations() {
+	for {
+		select {
+		case op := <-wq.operations:
+			wq.finishOperation(op, ErrClientClosed)
+		default:
+			return
+		}
+	}
+}
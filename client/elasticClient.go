@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/elastic/go-elasticsearch/v7"
 	"github.com/elastic/go-elasticsearch/v7/esapi"
@@ -31,17 +32,22 @@ type (
 )
 
 type elasticClient struct {
-	elasticBaseUrl string
-	client         *elasticsearch.Client
+	client *elasticsearch.Client
 
 	// countScroll is used to be incremented after each scroll so the scroll duration is different each time,
 	// bypassing any possible caching based on the same request
 	countScroll int
+
+	// bulkRetryBackOff returns the delay before sending again the items of a bulk rejected by a busy or unavailable shard
+	bulkRetryBackOff func(attempt int) time.Duration
 }
 
-// NewElasticClient will create a new instance of elasticClient
+// NewElasticClient will create a new instance of elasticClient. All the requests, including the ones built
+// manually, go through the connection pool of the Elasticsearch client, so they are spread across the healthy nodes
+// from cfg.Addresses
 func NewElasticClient(cfg elasticsearch.Config) (*elasticClient, error) {
-	if len(cfg.Addresses) == 0 {
+	// when a cloud ID is provided, the address of the cluster is decoded from it by the Elasticsearch client
+	if len(cfg.Addresses) == 0 && cfg.CloudID == "" {
 		return nil, dataindexer.ErrNoElasticUrlProvided
 	}
 
@@ -51,8 +57,8 @@ func NewElasticClient(cfg elasticsearch.Config) (*elasticClient, error) {
 	}
 
 	ec := &elasticClient{
-		client:         es,
-		elasticBaseUrl: cfg.Addresses[0],
+		client:           es,
+		bulkRetryBackOff: bulkRetryBackOff,
 	}
 
 	return ec, nil
@@ -102,6 +108,45 @@ func (ec *elasticClient) PutMappings(indexName string, mappings *bytes.Buffer) e
 	return nil
 }
 
+// GetSettings will fetch the settings of the provided index. The response is keyed by the names of the indices
+// behind the provided index or alias
+func (ec *elasticClient) GetSettings(index string, resBody interface{}) error {
+	res, err := ec.client.Indices.GetSettings(
+		ec.client.Indices.GetSettings.WithIndex(index),
+	)
+	if err != nil {
+		return err
+	}
+
+	return parseResponse(res, resBody, elasticDefaultErrorResponseHandler)
+}
+
+// GetMapping will fetch the mappings of the provided index. The response is keyed by the names of the indices
+// behind the provided index or alias
+func (ec *elasticClient) GetMapping(index string, resBody interface{}) error {
+	res, err := ec.client.Indices.GetMapping(
+		ec.client.Indices.GetMapping.WithIndex(index),
+	)
+	if err != nil {
+		return err
+	}
+
+	return parseResponse(res, resBody, elasticDefaultErrorResponseHandler)
+}
+
+// PutSettings will update the dynamic settings of the provided index
+func (ec *elasticClient) PutSettings(index string, settings *bytes.Buffer) error {
+	res, err := ec.client.Indices.PutSettings(
+		bytes.NewReader(settings.Bytes()),
+		ec.client.Indices.PutSettings.WithIndex(index),
+	)
+	if err != nil {
+		return err
+	}
+
+	return parseResponse(res, nil, elasticDefaultErrorResponseHandler)
+}
+
 // CheckAndCreateAlias creates a new alias if it does not already exist
 func (ec *elasticClient) CheckAndCreateAlias(alias string, indexName string) error {
 	if ec.aliasExists(alias) {
@@ -111,10 +156,32 @@ func (ec *elasticClient) CheckAndCreateAlias(alias string, indexName string) err
 	return ec.createAlias(alias, indexName)
 }
 
-// DoBulkRequest will do a bulk of request to elastic server
+// DoBulkRequest will do a bulk of request to elastic server. A bulk answered with an error status is not sent again,
+// as it may have been partially applied, only the items rejected by a busy or unavailable shard are sent again
 func (ec *elasticClient) DoBulkRequest(ctx context.Context, buff *bytes.Buffer, index string) error {
-	reader := bytes.NewReader(buff.Bytes())
+	body := buff.Bytes()
+	for attempt := 1; ; attempt++ {
+		responseBytes, err := ec.doBulk(ctx, body, index)
+		if err != nil {
+			return err
+		}
+
+		retryBody, err := extractRetryableBulkActions(body, responseBytes)
+		if err != nil || len(retryBody) == 0 || attempt > maxBulkItemsRetries {
+			return extractErrorFromBulkBodyResponseBytes(responseBytes)
+		}
 
+		log.Debug("elasticClient.DoBulkRequest: sending again the rejected items", "attempt", attempt)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(ec.bulkRetryBackOff(attempt)):
+		}
+		body = retryBody
+	}
+}
+
+func (ec *elasticClient) doBulk(ctx context.Context, body []byte, index string) ([]byte, error) {
 	options := make([]func(*esapi.BulkRequest), 0)
 	if index != "" {
 		options = append(options, ec.client.Bulk.WithIndex(index))
@@ -123,16 +190,31 @@ func (ec *elasticClient) DoBulkRequest(ctx context.Context, buff *bytes.Buffer,
 	options = append(options, ec.client.Bulk.WithContext(ctx))
 
 	res, err := ec.client.Bulk(
-		reader,
+		bytes.NewReader(body),
 		options...,
 	)
 	if err != nil {
 		log.Warn("elasticClient.DoBulkRequest",
 			"indexer do bulk request no response", err.Error())
+		return nil, err
+	}
+
+	return readBulkResponse(res)
+}
+
+// Ping will check if the Elasticsearch cluster is reachable
+func (ec *elasticClient) Ping(ctx context.Context) error {
+	res, err := ec.client.Ping(ec.client.Ping.WithContext(ctx))
+	if err != nil {
 		return err
 	}
+	defer closeBody(res)
 
-	return elasticBulkRequestResponseHandler(res)
+	if res.IsError() {
+		return fmt.Errorf("%s", res.String())
+	}
+
+	return nil
 }
 
 // DoMultiGet wil do a multi get request to Elasticsearch server
@@ -226,8 +308,7 @@ func (ec *elasticClient) indexExists(index string) bool {
 // PolicyExists checks if a policy was already created
 func (ec *elasticClient) PolicyExists(policy string) bool {
 	policyRoute := fmt.Sprintf(
-		"%s/%s/ism/policies/%s",
-		ec.elasticBaseUrl,
+		"/%s/ism/policies/%s",
 		kibanaPluginPath,
 		policy,
 	)
@@ -294,8 +375,7 @@ func (ec *elasticClient) createIndex(index string) error {
 // CreatePolicy creates a new policy for elastic indexes. Policies define rollover parameters
 func (ec *elasticClient) createPolicy(policyName string, policy *bytes.Buffer) error {
 	policyRoute := fmt.Sprintf(
-		"%s/_opendistro/_ism/policies/%s",
-		ec.elasticBaseUrl,
+		"/_opendistro/_ism/policies/%s",
 		policyName,
 	)
 
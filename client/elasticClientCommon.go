@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/elastic/go-elasticsearch/v7/esapi"
 	"github.com/multiversx/mx-chain-es-indexer-go/data"
@@ -91,17 +92,86 @@ func elasticDefaultErrorResponseHandler(res *esapi.Response) error {
 		res.StatusCode, responseBody, string(bodyBytes))
 }
 
-func elasticBulkRequestResponseHandler(res *esapi.Response) error {
+func readBulkResponse(res *esapi.Response) ([]byte, error) {
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
 	if res.IsError() {
-		return fmt.Errorf("%s", res.String())
+		return nil, fmt.Errorf("%s", res.String())
 	}
 
 	bodyBytes, err := io.ReadAll(res.Body)
 	if err != nil {
-		return fmt.Errorf("%w cannot read elastic response body bytes", err)
+		return nil, fmt.Errorf("%w cannot read elastic response body bytes", err)
+	}
+
+	return bodyBytes, nil
+}
+
+func bulkRetryBackOff(attempt int) time.Duration {
+	return time.Duration(attempt) * time.Second
+}
+
+// extractRetryableBulkActions returns the actions of the provided bulk body whose items were rejected with a status
+// that allows sending them again, as they were not applied, e.g. 429 for a full write queue or 503 for an unavailable shard
+func extractRetryableBulkActions(requestBody []byte, responseBytes []byte) ([]byte, error) {
+	response := struct {
+		Errors bool              `json:"errors"`
+		Items  []map[string]Item `json:"items"`
+	}{}
+	err := json.Unmarshal(responseBytes, &response)
+	if err != nil || !response.Errors {
+		return nil, err
+	}
+
+	actions, err := splitBulkActions(requestBody)
+	if err != nil {
+		return nil, err
+	}
+	if len(actions) != len(response.Items) {
+		return nil, fmt.Errorf("the bulk response has %d items for %d actions", len(response.Items), len(actions))
+	}
+
+	retryBody := make([]byte, 0)
+	for idx, item := range response.Items {
+		for _, itemResult := range item {
+			_, isRetryable := retryableBulkItemStatuses[itemResult.Status]
+			if isRetryable {
+				retryBody = append(retryBody, actions[idx]...)
+			}
+		}
+	}
+
+	return retryBody, nil
+}
+
+// splitBulkActions splits the provided bulk body in actions, each one made of its metadata line and, except for
+// the delete actions, of its source line
+func splitBulkActions(body []byte) ([][]byte, error) {
+	lines := bytes.SplitAfter(body, []byte("\n"))
+	actions := make([][]byte, 0)
+	for idx := 0; idx < len(lines); idx++ {
+		if len(bytes.TrimSpace(lines[idx])) == 0 {
+			continue
+		}
+
+		meta := make(map[string]json.RawMessage)
+		err := json.Unmarshal(lines[idx], &meta)
+		if err != nil {
+			return nil, fmt.Errorf("%w while parsing the bulk metadata line %d", err, idx)
+		}
+
+		action := lines[idx]
+		_, isDelete := meta["delete"]
+		if !isDelete && idx+1 < len(lines) {
+			idx++
+			action = append(append([]byte{}, action...), lines[idx]...)
+		}
+		actions = append(actions, action)
 	}
 
-	return extractErrorFromBulkBodyResponseBytes(bodyBytes)
+	return actions, nil
 }
 
 func extractErrorFromBulkBodyResponseBytes(bodyBytes []byte) error {
@@ -0,0 +1,30 @@
+package client
+
+import (
+	"bytes"
+	"context"
+)
+
+// DoSearchRequest will perform a search request with the provided body and will load the response in the provided object
+func (ec *elasticClient) DoSearchRequest(ctx context.Context, index string, body []byte, resBody interface{}) error {
+	res, err := ec.client.Search(
+		ec.client.Search.WithIndex(index),
+		ec.client.Search.WithBody(bytes.NewBuffer(body)),
+		ec.client.Search.WithIgnoreUnavailable(true),
+		ec.client.Search.WithContext(ctx),
+	)
+	if err != nil {
+		log.Warn("elasticClient.DoSearchRequest",
+			"cannot do search request", err.Error())
+		return err
+	}
+
+	err = parseResponse(res, resBody, elasticDefaultErrorResponseHandler)
+	if err != nil {
+		log.Warn("elasticClient.DoSearchRequest",
+			"error parsing response", err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v7"
+	"github.com/multiversx/mx-chain-es-indexer-go/client/logging"
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElasticClient_DoSearchRequest(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/accountshistory/_search", r.URL.Path)
+
+		jsonFile, err := os.Open("./testsData/response-search.json")
+		require.Nil(t, err)
+
+		byteValue, _ := ioutil.ReadAll(jsonFile)
+		_, _ = w.Write(byteValue)
+	}))
+	defer ts.Close()
+
+	esClient, _ := NewElasticClient(elasticsearch.Config{
+		Addresses: []string{ts.URL},
+		Logger:    &logging.CustomLogger{},
+	})
+
+	response := &data.ResponseSearch{}
+	err := esClient.DoSearchRequest(context.Background(), "accountshistory", []byte(`{}`), response)
+	require.Nil(t, err)
+	require.Len(t, response.Hits.Hits, 2)
+	require.Equal(t, "erd1-1", response.Hits.Hits[0].ID)
+	require.Equal(t, []interface{}{float64(2000), "erd1-1"}, response.Hits.Hits[0].Sort)
+}
+
+func TestElasticClient_DoSearchRequestErrorResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"type":"parsing_exception"},"status":400}`))
+	}))
+	defer ts.Close()
+
+	esClient, _ := NewElasticClient(elasticsearch.Config{
+		Addresses: []string{ts.URL},
+		Logger:    &logging.CustomLogger{},
+	})
+
+	err := esClient.DoSearchRequest(context.Background(), "events", []byte(`{}`), &data.ResponseSearch{})
+	require.NotNil(t, err)
+}
@@ -1,12 +1,15 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/elastic/go-elasticsearch/v7"
 	"github.com/multiversx/mx-chain-es-indexer-go/client/logging"
@@ -123,3 +126,112 @@ func TestElasticClient_GetWriteIndexOneIndex(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, "delegators-000001", res)
 }
+
+func TestElasticClient_NewClientWithCloudID(t *testing.T) {
+	// base64 of "europe-west1.gcp.cloud.es.io$es-uuid$kibana-uuid"
+	cloudID := "deployment:ZXVyb3BlLXdlc3QxLmdjcC5jbG91ZC5lcy5pbyRlcy11dWlkJGtpYmFuYS11dWlk"
+
+	esClient, err := NewElasticClient(elasticsearch.Config{
+		CloudID: cloudID,
+	})
+	require.Nil(t, err)
+	require.NotNil(t, esClient)
+}
+
+func TestElasticClient_MultipleNodesFailover(t *testing.T) {
+	numRequestsPerNode := make(map[string]int)
+	mut := sync.Mutex{}
+	newNode := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mut.Lock()
+			numRequestsPerNode[name]++
+			mut.Unlock()
+
+			_, _ = w.Write([]byte(`{"errors":false,"items":[]}`))
+		}))
+	}
+
+	firstNode := newNode("first")
+	defer firstNode.Close()
+	secondNode := newNode("second")
+	defer secondNode.Close()
+	downNode := newNode("down")
+	downNode.Close()
+
+	esClient, err := NewElasticClient(elasticsearch.Config{
+		Addresses: []string{firstNode.URL, downNode.URL, secondNode.URL},
+	})
+	require.Nil(t, err)
+
+	for i := 0; i < 10; i++ {
+		err = esClient.DoBulkRequest(context.Background(), bytes.NewBufferString("{}\n"), "")
+		require.Nil(t, err)
+		// the requests built manually go through the connection pool as well
+		_ = esClient.PolicyExists("policy")
+	}
+
+	require.Zero(t, numRequestsPerNode["down"])
+	require.Equal(t, 20, numRequestsPerNode["first"]+numRequestsPerNode["second"])
+	require.Greater(t, numRequestsPerNode["first"], 0)
+	require.Greater(t, numRequestsPerNode["second"], 0)
+}
+
+func TestElasticClient_DoBulkRequestShouldSendAgainOnlyTheRejectedItems(t *testing.T) {
+	t.Parallel()
+
+	indexAction := `{"index":{"_index":"accounts","_id":"a"}}` + "\n" + `{"balance":"1"}` + "\n"
+	updateAction := `{"update":{"_index":"accountsesdt","_id":"b"}}` + "\n" + `{"script":{"source":"ctx._source.n += 1"}}` + "\n"
+	deleteAction := `{"delete":{"_index":"tokens","_id":"c"}}` + "\n"
+
+	receivedBodies := make([]string, 0)
+	mut := sync.Mutex{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mut.Lock()
+		receivedBodies = append(receivedBodies, string(body))
+		numRequests := len(receivedBodies)
+		mut.Unlock()
+
+		if numRequests == 1 {
+			_, _ = w.Write([]byte(`{"errors":true,"items":[{"index":{"_id":"a","status":201}},{"update":{"_id":"b","status":429}},{"delete":{"_id":"c","status":200}}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"errors":false,"items":[{"update":{"_id":"b","status":200}}]}`))
+	}))
+	defer ts.Close()
+
+	esClient, _ := NewElasticClient(elasticsearch.Config{
+		Addresses: []string{ts.URL},
+	})
+	esClient.bulkRetryBackOff = func(_ int) time.Duration {
+		return 0
+	}
+
+	err := esClient.DoBulkRequest(context.Background(), bytes.NewBufferString(indexAction+updateAction+deleteAction), "")
+	require.Nil(t, err)
+	require.Equal(t, []string{indexAction + updateAction + deleteAction, updateAction}, receivedBodies)
+}
+
+func TestElasticClient_DoBulkRequestShouldNotSendAgainABulkAnsweredWithAnError(t *testing.T) {
+	t.Parallel()
+
+	numRequests := 0
+	mut := sync.Mutex{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mut.Lock()
+		numRequests++
+		mut.Unlock()
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	esClient, _ := NewElasticClient(elasticsearch.Config{
+		Addresses:     []string{ts.URL},
+		RetryOnStatus: []int{http.StatusConflict},
+	})
+
+	err := esClient.DoBulkRequest(context.Background(), bytes.NewBufferString(`{"index":{}}`+"\n"+`{}`+"\n"), "")
+	require.NotNil(t, err)
+	require.Equal(t, 1, numRequests)
+}
@@ -0,0 +1,262 @@
+package memory
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/multiversx/mx-chain-es-indexer-go/client/memory/painless"
+)
+
+const (
+	actionIndex  = "index"
+	actionCreate = "create"
+	actionUpdate = "update"
+	actionDelete = "delete"
+
+	opIndex  = "index"
+	opCreate = "create"
+	opDelete = "delete"
+	opNoop   = "noop"
+	opNone   = "none"
+)
+
+type bulkOperation struct {
+	action string
+	index  string
+	id     string
+	body   objectsMap
+}
+
+// parseBulk splits the newline delimited bulk request into operations. Each operation has an action line, followed
+// by a document line for all the actions except delete
+func parseBulk(body []byte, defaultIndex string) ([]*bulkOperation, error) {
+	lines := make([][]byte, 0)
+	for _, line := range bytes.Split(body, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) > 0 {
+			lines = append(lines, line)
+		}
+	}
+
+	operations := make([]*bulkOperation, 0)
+	for idx := 0; idx < len(lines); idx++ {
+		meta, err := decodeObject(lines[idx])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidBulkRequest, err.Error())
+		}
+		if len(meta) != 1 {
+			return nil, fmt.Errorf("%w: invalid action line %s", ErrInvalidBulkRequest, lines[idx])
+		}
+
+		op, err := newBulkOperation(meta, defaultIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		if op.action != actionDelete {
+			idx++
+			if idx == len(lines) {
+				return nil, fmt.Errorf("%w: missing document for the %s action", ErrInvalidBulkRequest, op.action)
+			}
+
+			op.body, err = decodeObject(lines[idx])
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidBulkRequest, err.Error())
+			}
+		}
+
+		operations = append(operations, op)
+	}
+
+	return operations, nil
+}
+
+func newBulkOperation(meta objectsMap, defaultIndex string) (*bulkOperation, error) {
+	for action, value := range meta {
+		switch action {
+		case actionIndex, actionCreate, actionUpdate, actionDelete:
+		default:
+			return nil, fmt.Errorf("%w: unknown action %s", ErrInvalidBulkRequest, action)
+		}
+
+		details, _ := value.(objectsMap)
+		op := &bulkOperation{
+			action: action,
+			index:  defaultIndex,
+		}
+
+		index, ok := details["_index"]
+		if ok {
+			op.index = fmt.Sprint(index)
+		}
+		id, ok := details["_id"]
+		if ok {
+			op.id = fmt.Sprint(id)
+		}
+
+		if op.index == "" {
+			return nil, fmt.Errorf("%w: missing index for the %s action", ErrInvalidBulkRequest, action)
+		}
+		if op.id == "" && action != actionIndex && action != actionCreate {
+			return nil, fmt.Errorf("%w: missing id for the %s action", ErrInvalidBulkRequest, action)
+		}
+
+		return op, nil
+	}
+
+	return nil, ErrInvalidBulkRequest
+}
+
+func (dc *databaseClient) executeOperation(op *bulkOperation) error {
+	indexName := dc.resolveIndex(op.index)
+	documents := dc.getIndex(indexName, true)
+
+	switch op.action {
+	case actionIndex:
+		if op.id == "" {
+			op.id = generateID()
+		}
+		documents[op.id] = op.body
+		return nil
+	case actionCreate:
+		if op.id == "" {
+			op.id = generateID()
+		}
+		_, exists := documents[op.id]
+		if exists {
+			return fmt.Errorf("version conflict, document already exists")
+		}
+		documents[op.id] = op.body
+		return nil
+	case actionDelete:
+		delete(documents, op.id)
+		return nil
+	default:
+		return dc.executeUpdate(indexName, documents, op)
+	}
+}
+
+// executeUpdate applies an update action like Elasticsearch does: a partial document is merged into the existing one,
+// while a script is executed on the existing document. For a missing document, the upsert is stored as it is or, for
+// scripted upserts, the script is executed on it with the create operation
+func (dc *databaseClient) executeUpdate(indexName string, documents map[string]objectsMap, op *bulkOperation) error {
+	existing, exists := documents[op.id]
+
+	partialDoc, hasDoc := op.body["doc"].(objectsMap)
+	if hasDoc {
+		if exists {
+			mergeDocuments(existing, partialDoc)
+			return nil
+		}
+
+		docAsUpsert, _ := op.body["doc_as_upsert"].(bool)
+		if docAsUpsert {
+			documents[op.id] = partialDoc
+			return nil
+		}
+		upsert, hasUpsert := op.body["upsert"].(objectsMap)
+		if hasUpsert {
+			documents[op.id] = upsert
+			return nil
+		}
+
+		return fmt.Errorf("document missing")
+	}
+
+	script, params, err := dc.getScript(op.body["script"])
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		resultOp, source, errExecute := executeScript(script, params, opIndex, indexName, op.id, existing)
+		if errExecute != nil {
+			return errExecute
+		}
+		applyScriptResult(documents, op.id, resultOp, source)
+		return nil
+	}
+
+	upsert, hasUpsert := op.body["upsert"].(objectsMap)
+	if !hasUpsert {
+		return fmt.Errorf("document missing")
+	}
+
+	scriptedUpsert, _ := op.body["scripted_upsert"].(bool)
+	if !scriptedUpsert {
+		documents[op.id] = upsert
+		return nil
+	}
+
+	resultOp, source, err := executeScript(script, params, opCreate, indexName, op.id, upsert)
+	if err != nil {
+		return err
+	}
+	applyScriptResult(documents, op.id, resultOp, source)
+
+	return nil
+}
+
+func executeScript(
+	script *painless.Script,
+	params objectsMap,
+	op string,
+	indexName string,
+	id string,
+	source objectsMap,
+) (string, objectsMap, error) {
+	variables := objectsMap{
+		"ctx": objectsMap{
+			"op":      op,
+			"_index":  indexName,
+			"_id":     id,
+			"_source": source,
+		},
+		"params": params,
+	}
+
+	err := script.Execute(variables)
+	if err != nil {
+		return "", nil, err
+	}
+
+	ctx, _ := variables["ctx"].(objectsMap)
+	resultOp, _ := ctx["op"].(string)
+	resultSource, ok := ctx["_source"].(objectsMap)
+	if !ok && resultOp != opDelete && resultOp != opNoop && resultOp != opNone {
+		return "", nil, fmt.Errorf("%w: the script did not set a document source", ErrInvalidScript)
+	}
+
+	return resultOp, resultSource, nil
+}
+
+func applyScriptResult(documents map[string]objectsMap, id string, op string, source objectsMap) {
+	switch op {
+	case opNoop, opNone:
+	case opDelete:
+		delete(documents, id)
+	default:
+		documents[id] = source
+	}
+}
+
+func mergeDocuments(destination objectsMap, source objectsMap) {
+	for key, value := range source {
+		sourceObject, isSourceObject := value.(objectsMap)
+		destinationObject, isDestinationObject := destination[key].(objectsMap)
+		if isSourceObject && isDestinationObject {
+			mergeDocuments(destinationObject, sourceObject)
+			continue
+		}
+
+		destination[key] = value
+	}
+}
+
+func generateID() string {
+	buff := make([]byte, 10)
+	_, _ = rand.Read(buff)
+
+	return hex.EncodeToString(buff)
+}
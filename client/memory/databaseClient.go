@@ -0,0 +1,488 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/multiversx/mx-chain-es-indexer-go/client/memory/painless"
+)
+
+// scrollPageSize is the number of documents sent to the handler of a scroll request at once, like the real client does
+const scrollPageSize = 9000
+
+type objectsMap = map[string]interface{}
+
+// databaseClient is an in-memory implementation of the database client. It stores the documents of each index and
+// supports the requests sent by the indexer: bulk index, create, update (with documents, upserts and painless scripts)
+// and delete, delete and update by query, multi-get, search, count and scroll. It is meant for tests which need the
+// indexing results without an Elasticsearch cluster
+type databaseClient struct {
+	mut      sync.RWMutex
+	indices  map[string]map[string]objectsMap
+	aliases  map[string]string
+	settings map[string]objectsMap
+	mappings map[string]objectsMap
+
+	mutScripts sync.Mutex
+	scripts    map[string]*painless.Script
+}
+
+// NewDatabaseClient will create a new in-memory database client without any document
+func NewDatabaseClient() *databaseClient {
+	return &databaseClient{
+		indices:  make(map[string]map[string]objectsMap),
+		aliases:  make(map[string]string),
+		settings: make(map[string]objectsMap),
+		mappings: make(map[string]objectsMap),
+		scripts:  make(map[string]*painless.Script),
+	}
+}
+
+// DoBulkRequest will execute the operations of the provided bulk request. The operations without an index use the
+// provided index
+func (dc *databaseClient) DoBulkRequest(_ context.Context, buff *bytes.Buffer, index string) error {
+	operations, err := parseBulk(buff.Bytes(), index)
+	if err != nil {
+		return err
+	}
+
+	dc.mut.Lock()
+	defer dc.mut.Unlock()
+
+	itemsErrors := make([]string, 0)
+	for _, op := range operations {
+		errOperation := dc.executeOperation(op)
+		if errOperation != nil {
+			itemsErrors = append(itemsErrors, fmt.Sprintf("{index: %s, id: %s, action: %s, error: %s}", op.index, op.id, op.action, errOperation))
+		}
+	}
+
+	if len(itemsErrors) > 0 {
+		return fmt.Errorf("%w: %s", ErrBulkItemsFailed, strings.Join(itemsErrors, ", "))
+	}
+
+	return nil
+}
+
+// DoQueryRemove will delete the documents which match the provided query
+func (dc *databaseClient) DoQueryRemove(_ context.Context, index string, body *bytes.Buffer) error {
+	request, err := decodeObject(body.Bytes())
+	if err != nil {
+		return err
+	}
+
+	dc.mut.Lock()
+	defer dc.mut.Unlock()
+
+	documents := dc.getIndex(index, false)
+	ids, err := matchingIDs(documents, request["query"])
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		delete(documents, id)
+	}
+
+	return nil
+}
+
+// DoMultiGet will load the documents with the provided ids in the provided response, in the multi-get response format
+func (dc *databaseClient) DoMultiGet(_ context.Context, ids []string, index string, withSource bool, resBody interface{}) error {
+	dc.mut.RLock()
+	defer dc.mut.RUnlock()
+
+	indexName := dc.resolveIndex(index)
+	documents := dc.indices[indexName]
+	docs := make([]objectsMap, 0, len(ids))
+	for _, id := range ids {
+		doc := objectsMap{
+			"_index": indexName,
+			"_id":    id,
+		}
+
+		source, found := documents[id]
+		doc["found"] = found
+		if found && withSource {
+			doc["_source"] = source
+		}
+		docs = append(docs, doc)
+	}
+
+	return convertResponse(objectsMap{"docs": docs}, resBody)
+}
+
+// DoScrollRequest will call the handler with the pages of the documents which match the provided query, in the
+// search response format. The first page is always sent, even if no document matches
+func (dc *databaseClient) DoScrollRequest(
+	_ context.Context,
+	index string,
+	body []byte,
+	withSource bool,
+	handlerFunc func(responseBytes []byte) error,
+) error {
+	request, err := decodeObject(body)
+	if err != nil {
+		return err
+	}
+
+	dc.mut.RLock()
+	indexName := dc.resolveIndex(index)
+	hits, err := searchHits(indexName, dc.indices[indexName], request)
+	dc.mut.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	for start := 0; start == 0 || start < len(hits); start += scrollPageSize {
+		end := start + scrollPageSize
+		if end > len(hits) {
+			end = len(hits)
+		}
+
+		page := hits[start:end]
+		if !withSource {
+			page = withoutSource(page)
+		}
+
+		responseBytes, errMarshal := json.Marshal(searchResponse(page, len(hits)))
+		if errMarshal != nil {
+			return errMarshal
+		}
+
+		err = handlerFunc(responseBytes)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DoCountRequest will return the number of documents which match the provided query
+func (dc *databaseClient) DoCountRequest(_ context.Context, index string, body []byte) (uint64, error) {
+	request, err := decodeObject(body)
+	if err != nil {
+		return 0, err
+	}
+
+	dc.mut.RLock()
+	defer dc.mut.RUnlock()
+
+	ids, err := matchingIDs(dc.indices[dc.resolveIndex(index)], request["query"])
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(len(ids)), nil
+}
+
+// DoSearchRequest will load the documents which match the provided query in the provided response, in the search
+// response format. The size, from, sort and search_after parameters are applied
+func (dc *databaseClient) DoSearchRequest(_ context.Context, index string, body []byte, resBody interface{}) error {
+	request, err := decodeObject(body)
+	if err != nil {
+		return err
+	}
+
+	dc.mut.RLock()
+	defer dc.mut.RUnlock()
+
+	indexName := dc.resolveIndex(index)
+	hits, err := searchHits(indexName, dc.indices[indexName], request)
+	if err != nil {
+		return err
+	}
+
+	page, err := paginate(hits, request)
+	if err != nil {
+		return err
+	}
+
+	return convertResponse(searchResponse(page, len(hits)), resBody)
+}
+
+// UpdateByQuery will execute the provided script on all the documents which match the provided query
+func (dc *databaseClient) UpdateByQuery(_ context.Context, index string, buff *bytes.Buffer) error {
+	request, err := decodeObject(buff.Bytes())
+	if err != nil {
+		return err
+	}
+
+	script, params, err := dc.getScript(request["script"])
+	if err != nil {
+		return err
+	}
+
+	dc.mut.Lock()
+	defer dc.mut.Unlock()
+
+	indexName := dc.resolveIndex(index)
+	documents := dc.getIndex(indexName, false)
+	ids, err := matchingIDs(documents, request["query"])
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		op, source, errExecute := executeScript(script, params, opIndex, indexName, id, documents[id])
+		if errExecute != nil {
+			return errExecute
+		}
+		applyScriptResult(documents, id, op, source)
+	}
+
+	return nil
+}
+
+// PutMappings will keep the properties of the provided mappings, so they can be read back. The documents are stored as
+// they are received, regardless of the mappings
+func (dc *databaseClient) PutMappings(index string, mappings *bytes.Buffer) error {
+	request, err := decodeObject(mappings.Bytes())
+	if err != nil {
+		return err
+	}
+
+	dc.mut.Lock()
+	defer dc.mut.Unlock()
+
+	indexName := dc.resolveIndex(index)
+	properties, found := dc.mappings[indexName]
+	if !found {
+		properties = objectsMap{}
+		dc.mappings[indexName] = properties
+	}
+
+	newProperties, _ := request["properties"].(objectsMap)
+	for field, mapping := range newProperties {
+		properties[field] = mapping
+	}
+
+	return nil
+}
+
+// GetMapping will load the mappings put for the provided index in the provided response, keyed by the name of the index
+func (dc *databaseClient) GetMapping(index string, resBody interface{}) error {
+	dc.mut.RLock()
+	defer dc.mut.RUnlock()
+
+	indexName := dc.resolveIndex(index)
+	properties := objectsMap{}
+	for field, mapping := range dc.mappings[indexName] {
+		properties[field] = mapping
+	}
+
+	response := objectsMap{
+		indexName: objectsMap{
+			"mappings": objectsMap{
+				"properties": properties,
+			},
+		},
+	}
+
+	return convertResponse(response, resBody)
+}
+
+// GetSettings will load the settings of the provided index in the provided response, keyed by the name of the index
+func (dc *databaseClient) GetSettings(index string, resBody interface{}) error {
+	dc.mut.RLock()
+	defer dc.mut.RUnlock()
+
+	indexName := dc.resolveIndex(index)
+	indexSettings := objectsMap{}
+	for key, value := range dc.settings[indexName] {
+		indexSettings[key] = value
+	}
+
+	response := objectsMap{
+		indexName: objectsMap{
+			"settings": objectsMap{
+				"index": indexSettings,
+			},
+		},
+	}
+
+	return convertResponse(response, resBody)
+}
+
+// PutSettings will update the settings of the provided index. The settings set to null are removed
+func (dc *databaseClient) PutSettings(index string, settings *bytes.Buffer) error {
+	request, err := decodeObject(settings.Bytes())
+	if err != nil {
+		return err
+	}
+
+	newSettings, ok := request["index"].(objectsMap)
+	if !ok {
+		newSettings = request
+	}
+
+	dc.mut.Lock()
+	defer dc.mut.Unlock()
+
+	indexName := dc.resolveIndex(index)
+	indexSettings, found := dc.settings[indexName]
+	if !found {
+		indexSettings = objectsMap{}
+		dc.settings[indexName] = indexSettings
+	}
+
+	for key, value := range newSettings {
+		if value == nil {
+			delete(indexSettings, key)
+			continue
+		}
+		indexSettings[key] = value
+	}
+
+	return nil
+}
+
+// CheckAndCreateIndex will create the provided index if it does not exist
+func (dc *databaseClient) CheckAndCreateIndex(index string) error {
+	dc.mut.Lock()
+	defer dc.mut.Unlock()
+
+	dc.getIndex(index, true)
+
+	return nil
+}
+
+// CheckAndCreateAlias will create the provided alias if it does not exist. The requests for the alias are served by
+// the provided index
+func (dc *databaseClient) CheckAndCreateAlias(alias string, index string) error {
+	dc.mut.Lock()
+	defer dc.mut.Unlock()
+
+	_, found := dc.aliases[alias]
+	if !found {
+		dc.aliases[alias] = index
+	}
+
+	return nil
+}
+
+// CheckAndCreateTemplate does nothing, since the documents are stored as they are received
+func (dc *databaseClient) CheckAndCreateTemplate(_ string, _ *bytes.Buffer) error {
+	return nil
+}
+
+// CheckAndCreatePolicy does nothing, since the documents are never rolled over
+func (dc *databaseClient) CheckAndCreatePolicy(_ string, _ *bytes.Buffer) error {
+	return nil
+}
+
+// Ping always succeeds
+func (dc *databaseClient) Ping(_ context.Context) error {
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (dc *databaseClient) IsInterfaceNil() bool {
+	return dc == nil
+}
+
+func (dc *databaseClient) resolveIndex(index string) string {
+	indexName, isAlias := dc.aliases[index]
+	if isAlias {
+		return indexName
+	}
+
+	return index
+}
+
+// getIndex returns the documents of the provided index or alias. The missing indices are created, like Elasticsearch
+// does when a document is written in an index which does not exist
+func (dc *databaseClient) getIndex(index string, resolved bool) map[string]objectsMap {
+	indexName := index
+	if !resolved {
+		indexName = dc.resolveIndex(index)
+	}
+
+	documents, found := dc.indices[indexName]
+	if !found {
+		documents = make(map[string]objectsMap)
+		dc.indices[indexName] = documents
+	}
+
+	return documents
+}
+
+func (dc *databaseClient) getScript(value interface{}) (*painless.Script, objectsMap, error) {
+	var source string
+	params := objectsMap{}
+	switch typed := value.(type) {
+	case string:
+		source = typed
+	case objectsMap:
+		source, _ = typed["source"].(string)
+		scriptParams, ok := typed["params"].(objectsMap)
+		if ok {
+			params = scriptParams
+		}
+		lang, hasLang := typed["lang"].(string)
+		if hasLang && lang != "painless" {
+			return nil, nil, fmt.Errorf("%w: unsupported language %s", ErrInvalidScript, lang)
+		}
+	}
+	if source == "" {
+		return nil, nil, fmt.Errorf("%w: missing source", ErrInvalidScript)
+	}
+
+	dc.mutScripts.Lock()
+	defer dc.mutScripts.Unlock()
+
+	script, found := dc.scripts[source]
+	if found {
+		return script, params, nil
+	}
+
+	script, err := painless.Compile(source)
+	if err != nil {
+		return nil, nil, err
+	}
+	dc.scripts[source] = script
+
+	return script, params, nil
+}
+
+func decodeObject(body []byte) (objectsMap, error) {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return objectsMap{}, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	object := objectsMap{}
+	err := decoder.Decode(&object)
+	if err != nil {
+		return nil, err
+	}
+
+	return object, nil
+}
+
+func convertResponse(response interface{}, resBody interface{}) error {
+	responseBytes, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(responseBytes, resBody)
+}
+
+func sortedIDs(documents map[string]objectsMap) []string {
+	ids := make([]string, 0, len(documents))
+	for id := range documents {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
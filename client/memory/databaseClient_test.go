@@ -0,0 +1,285 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type multiGetResponse struct {
+	Docs []struct {
+		ID     string          `json:"_id"`
+		Found  bool            `json:"found"`
+		Source json.RawMessage `json:"_source"`
+	} `json:"docs"`
+}
+
+type searchResponseMock struct {
+	Hits struct {
+		Total struct {
+			Value int `json:"value"`
+		} `json:"total"`
+		Hits []struct {
+			ID     string          `json:"_id"`
+			Source json.RawMessage `json:"_source"`
+			Sort   []interface{}   `json:"sort"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+func bulk(lines ...string) *bytes.Buffer {
+	return bytes.NewBufferString(strings.Join(lines, "\n") + "\n")
+}
+
+func getDocument(t *testing.T, dc *databaseClient, index string, id string) string {
+	response := &multiGetResponse{}
+	err := dc.DoMultiGet(context.Background(), []string{id}, index, true, response)
+	require.Nil(t, err)
+	require.Len(t, response.Docs, 1)
+	if !response.Docs[0].Found {
+		return ""
+	}
+
+	return string(response.Docs[0].Source)
+}
+
+func TestDatabaseClient_DoBulkRequest(t *testing.T) {
+	t.Parallel()
+
+	t.Run("index, create and delete", func(t *testing.T) {
+		t.Parallel()
+
+		dc := NewDatabaseClient()
+		err := dc.DoBulkRequest(context.Background(), bulk(
+			`{"index":{"_index":"transactions","_id":"h1"}}`,
+			`{"nonce":1,"value":"10"}`,
+			`{"index":{"_id":"h2"}}`,
+			`{"nonce":2}`,
+			`{"delete":{"_index":"transactions","_id":"h2"}}`,
+			`{"delete":{"_index":"transactions","_id":"missing"}}`,
+		), "transactions")
+		require.Nil(t, err)
+		require.JSONEq(t, `{"nonce":1,"value":"10"}`, getDocument(t, dc, "transactions", "h1"))
+		require.Empty(t, getDocument(t, dc, "transactions", "h2"))
+
+		err = dc.DoBulkRequest(context.Background(), bulk(
+			`{"create":{"_index":"transactions","_id":"h1"}}`,
+			`{"nonce":3}`,
+		), "")
+		require.ErrorIs(t, err, ErrBulkItemsFailed)
+		require.JSONEq(t, `{"nonce":1,"value":"10"}`, getDocument(t, dc, "transactions", "h1"))
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		t.Parallel()
+
+		dc := NewDatabaseClient()
+		err := dc.DoBulkRequest(context.Background(), bulk(`{"index":{"_id":"h1"}}`, `{}`), "")
+		require.ErrorIs(t, err, ErrInvalidBulkRequest)
+
+		err = dc.DoBulkRequest(context.Background(), bulk(`{"index":{"_index":"blocks","_id":"h1"}}`), "")
+		require.ErrorIs(t, err, ErrInvalidBulkRequest)
+
+		err = dc.DoBulkRequest(context.Background(), bulk(`{"upsert":{"_index":"blocks","_id":"h1"}}`, `{}`), "")
+		require.ErrorIs(t, err, ErrInvalidBulkRequest)
+	})
+
+	t.Run("partial documents", func(t *testing.T) {
+		t.Parallel()
+
+		dc := NewDatabaseClient()
+		err := dc.DoBulkRequest(context.Background(), bulk(
+			`{"update":{"_index":"tokens","_id":"t1"}}`,
+			`{"doc":{"data":{"name":"a"}},"doc_as_upsert":true}`,
+			`{"update":{"_index":"tokens","_id":"t1"}}`,
+			`{"doc":{"data":{"creator":"b"},"type":"NFT"}}`,
+		), "")
+		require.Nil(t, err)
+		require.JSONEq(t, `{"data":{"name":"a","creator":"b"},"type":"NFT"}`, getDocument(t, dc, "tokens", "t1"))
+
+		err = dc.DoBulkRequest(context.Background(), bulk(
+			`{"update":{"_index":"tokens","_id":"missing"}}`,
+			`{"doc":{"type":"NFT"}}`,
+		), "")
+		require.ErrorIs(t, err, ErrBulkItemsFailed)
+	})
+
+	t.Run("scripts and upserts", func(t *testing.T) {
+		t.Parallel()
+
+		script := `{"script":{"source":"ctx._source.count += params.count","lang":"painless","params":{"count":2}},"upsert":{"count":2}}`
+		dc := NewDatabaseClient()
+		err := dc.DoBulkRequest(context.Background(), bulk(
+			`{"update":{"_index":"tags","_id":"tag"}}`, script,
+			`{"update":{"_index":"tags","_id":"tag"}}`, script,
+		), "")
+		require.Nil(t, err)
+		require.JSONEq(t, `{"count":4}`, getDocument(t, dc, "tags", "tag"))
+
+		scriptedUpsert := `{"scripted_upsert":true,"script":{"source":"if ('create' == ctx.op) {ctx._source = params.tx} else {ctx._source.fee = params.tx.fee}","params":{"tx":{"fee":"%s","nonce":1}}},"upsert":{}}`
+		err = dc.DoBulkRequest(context.Background(), bulk(
+			`{"update":{"_index":"transactions","_id":"h1"}}`, strings.Replace(scriptedUpsert, "%s", "10", 1),
+			`{"update":{"_index":"transactions","_id":"h1"}}`, strings.Replace(scriptedUpsert, "%s", "20", 1),
+		), "")
+		require.Nil(t, err)
+		require.JSONEq(t, `{"fee":"20","nonce":1}`, getDocument(t, dc, "transactions", "h1"))
+
+		noop := `{"scripted_upsert":true,"script":{"source":"if ('create' == ctx.op) {ctx.op = 'noop'} else {ctx.op = 'delete'}"},"upsert":{}}`
+		err = dc.DoBulkRequest(context.Background(), bulk(
+			`{"update":{"_index":"accounts","_id":"a1"}}`, noop,
+			`{"update":{"_index":"transactions","_id":"h1"}}`, noop,
+		), "")
+		require.Nil(t, err)
+		require.Empty(t, getDocument(t, dc, "accounts", "a1"))
+		require.Empty(t, getDocument(t, dc, "transactions", "h1"))
+
+		err = dc.DoBulkRequest(context.Background(), bulk(
+			`{"update":{"_index":"tags","_id":"tag"}}`,
+			`{"script":{"source":"ctx._source.count.add(1)"},"upsert":{}}`,
+		), "")
+		require.ErrorIs(t, err, ErrBulkItemsFailed)
+		require.JSONEq(t, `{"count":4}`, getDocument(t, dc, "tags", "tag"))
+	})
+}
+
+func TestDatabaseClient_Queries(t *testing.T) {
+	t.Parallel()
+
+	dc := NewDatabaseClient()
+	require.Nil(t, dc.CheckAndCreateIndex("events-000001"))
+	require.Nil(t, dc.CheckAndCreateAlias("events", "events-000001"))
+	err := dc.DoBulkRequest(context.Background(), bulk(
+		`{"index":{"_index":"events","_id":"e1"}}`, `{"identifier":"transfer","shardID":0,"timestamp":10,"topics":["a","b"]}`,
+		`{"index":{"_index":"events","_id":"e2"}}`, `{"identifier":"transfer","shardID":1,"timestamp":20}`,
+		`{"index":{"_index":"events","_id":"e3"}}`, `{"identifier":"burn","shardID":1,"timestamp":30,"data":{"type":"x"}}`,
+	), "")
+	require.Nil(t, err)
+
+	t.Run("count", func(t *testing.T) {
+		t.Parallel()
+
+		queries := map[string]uint64{
+			``:                           3,
+			`{"query":{"match_all":{}}}`: 3,
+			`{"query":{"ids":{"values":["e1","e4"]}}}`:                                                       1,
+			`{"query":{"term":{"identifier":"transfer"}}}`:                                                   2,
+			`{"query":{"match":{"timestamp":{"query":"20","operator":"AND"}}}}`:                              1,
+			`{"query":{"terms":{"topics":["b","c"]}}}`:                                                       1,
+			`{"query":{"exists":{"field":"data.type"}}}`:                                                     1,
+			`{"query":{"range":{"timestamp":{"gt":10,"lte":30}}}}`:                                           2,
+			`{"query":{"bool":{"must":[{"match":{"shardID":1}}],"must_not":[{"exists":{"field":"data"}}]}}}`: 1,
+			`{"query":{"bool":{"should":[{"term":{"_id":"e1"}},{"term":{"_id":"e3"}}]}}}`:                    2,
+		}
+		for query, expected := range queries {
+			count, errCount := dc.DoCountRequest(context.Background(), "events", []byte(query))
+			require.Nil(t, errCount, query)
+			require.Equal(t, expected, count, query)
+		}
+
+		_, errCount := dc.DoCountRequest(context.Background(), "events", []byte(`{"query":{"wildcard":{"identifier":"t*"}}}`))
+		require.ErrorIs(t, errCount, ErrUnsupportedQuery)
+	})
+
+	t.Run("search with sort and search after", func(t *testing.T) {
+		t.Parallel()
+
+		body := `{"size":2,"query":{"match_all":{}},"sort":[{"timestamp":{"order":"desc"}},{"_id":{"order":"asc"}}]}`
+		response := &searchResponseMock{}
+		err := dc.DoSearchRequest(context.Background(), "events", []byte(body), response)
+		require.Nil(t, err)
+		require.Equal(t, 3, response.Hits.Total.Value)
+		require.Len(t, response.Hits.Hits, 2)
+		require.Equal(t, "e3", response.Hits.Hits[0].ID)
+		require.Equal(t, "e2", response.Hits.Hits[1].ID)
+
+		searchAfter, _ := json.Marshal(response.Hits.Hits[1].Sort)
+		body = `{"size":2,"query":{"match_all":{}},"sort":[{"timestamp":{"order":"desc"}},{"_id":{"order":"asc"}}],"search_after":` + string(searchAfter) + `}`
+		response = &searchResponseMock{}
+		err = dc.DoSearchRequest(context.Background(), "events", []byte(body), response)
+		require.Nil(t, err)
+		require.Len(t, response.Hits.Hits, 1)
+		require.Equal(t, "e1", response.Hits.Hits[0].ID)
+	})
+
+	t.Run("scroll without source", func(t *testing.T) {
+		t.Parallel()
+
+		pages := 0
+		handler := func(responseBytes []byte) error {
+			pages++
+			response := &searchResponseMock{}
+			require.Nil(t, json.Unmarshal(responseBytes, response))
+			require.Len(t, response.Hits.Hits, 2)
+			require.Nil(t, response.Hits.Hits[0].Source)
+			return nil
+		}
+		err := dc.DoScrollRequest(context.Background(), "events", []byte(`{"query":{"term":{"shardID":1}}}`), false, handler)
+		require.Nil(t, err)
+		require.Equal(t, 1, pages)
+	})
+}
+
+func TestDatabaseClient_UpdateAndRemoveByQuery(t *testing.T) {
+	t.Parallel()
+
+	dc := NewDatabaseClient()
+	err := dc.DoBulkRequest(context.Background(), bulk(
+		`{"index":{"_index":"delegators","_id":"d1"}}`, `{"timestamp":5,"unDelegateInfo":[{"timestamp":5},{"timestamp":6}]}`,
+		`{"index":{"_index":"delegators","_id":"d2"}}`, `{"timestamp":6,"unDelegateInfo":[{"timestamp":6}]}`,
+	), "")
+	require.Nil(t, err)
+
+	update := `{"query":{"match":{"timestamp":"5"}},"script":{"source":"ctx._source.unDelegateInfo.removeIf(info -> info.timestamp.equals(params.timestamp))","lang":"painless","params":{"timestamp":5}}}`
+	err = dc.UpdateByQuery(context.Background(), "delegators", bytes.NewBufferString(update))
+	require.Nil(t, err)
+	require.JSONEq(t, `{"timestamp":5,"unDelegateInfo":[{"timestamp":6}]}`, getDocument(t, dc, "delegators", "d1"))
+	require.JSONEq(t, `{"timestamp":6,"unDelegateInfo":[{"timestamp":6}]}`, getDocument(t, dc, "delegators", "d2"))
+
+	err = dc.DoQueryRemove(context.Background(), "delegators", bytes.NewBufferString(`{"query":{"term":{"timestamp":6}}}`))
+	require.Nil(t, err)
+	require.NotEmpty(t, getDocument(t, dc, "delegators", "d1"))
+	require.Empty(t, getDocument(t, dc, "delegators", "d2"))
+}
+
+func TestDatabaseClient_Settings(t *testing.T) {
+	t.Parallel()
+
+	dc := NewDatabaseClient()
+	require.Nil(t, dc.CheckAndCreateAlias("blocks", "blocks-000001"))
+
+	err := dc.PutSettings("blocks", bytes.NewBufferString(`{"index":{"refresh_interval":"-1","number_of_replicas":"0"}}`))
+	require.Nil(t, err)
+	err = dc.PutSettings("blocks", bytes.NewBufferString(`{"index":{"number_of_replicas":null}}`))
+	require.Nil(t, err)
+
+	response := make(map[string]interface{})
+	err = dc.GetSettings("blocks", &response)
+	require.Nil(t, err)
+
+	responseBytes, _ := json.Marshal(response)
+	require.JSONEq(t, `{"blocks-000001":{"settings":{"index":{"refresh_interval":"-1"}}}}`, string(responseBytes))
+}
+
+func TestDatabaseClient_Mappings(t *testing.T) {
+	t.Parallel()
+
+	dc := NewDatabaseClient()
+	require.Nil(t, dc.CheckAndCreateAlias("accountsesdt", "accountsesdt-000001"))
+
+	err := dc.PutMappings("accountsesdt", bytes.NewBufferString(`{"properties":{"token":{"type":"text"},"address":{"type":"keyword"}}}`))
+	require.Nil(t, err)
+	err = dc.PutMappings("accountsesdt", bytes.NewBufferString(`{"properties":{"token":{"type":"text","fields":{"keyword":{"type":"keyword"}}}}}`))
+	require.Nil(t, err)
+
+	response := make(map[string]interface{})
+	err = dc.GetMapping("accountsesdt", &response)
+	require.Nil(t, err)
+
+	responseBytes, _ := json.Marshal(response)
+	expected := `{"accountsesdt-000001":{"mappings":{"properties":{"address":{"type":"keyword"},"token":{"type":"text","fields":{"keyword":{"type":"keyword"}}}}}}}`
+	require.JSONEq(t, expected, string(responseBytes))
+}
@@ -0,0 +1,15 @@
+package memory
+
+import "errors"
+
+// ErrInvalidBulkRequest signals that the bulk request cannot be parsed
+var ErrInvalidBulkRequest = errors.New("invalid bulk request")
+
+// ErrBulkItemsFailed signals that some operations from a bulk request failed
+var ErrBulkItemsFailed = errors.New("bulk request items failed")
+
+// ErrUnsupportedQuery signals that the query cannot be evaluated by the in-memory database
+var ErrUnsupportedQuery = errors.New("unsupported query")
+
+// ErrInvalidScript signals that the script of a request is missing or invalid
+var ErrInvalidScript = errors.New("invalid script")
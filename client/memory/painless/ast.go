@@ -0,0 +1,122 @@
+package painless
+
+type statement interface{}
+
+type expression interface{}
+
+type blockStmt struct {
+	statements []statement
+}
+
+type exprStmt struct {
+	expression expression
+}
+
+type declStmt struct {
+	names  []string
+	values []expression
+}
+
+type ifStmt struct {
+	condition expression
+	thenStmt  statement
+	elseStmt  statement
+}
+
+type forStmt struct {
+	init      statement
+	condition expression
+	update    expression
+	body      statement
+}
+
+type forEachStmt struct {
+	name     string
+	iterable expression
+	body     statement
+}
+
+type whileStmt struct {
+	condition expression
+	body      statement
+}
+
+type returnStmt struct {
+	value expression
+}
+
+type breakStmt struct{}
+
+type continueStmt struct{}
+
+type literalExpr struct {
+	value interface{}
+}
+
+type identExpr struct {
+	name string
+}
+
+type fieldExpr struct {
+	target expression
+	name   string
+}
+
+type indexExpr struct {
+	target expression
+	index  expression
+}
+
+type callExpr struct {
+	target expression
+	method string
+	args   []expression
+}
+
+type newExpr struct {
+	typeName string
+	args     []expression
+}
+
+type listExpr struct {
+	items []expression
+}
+
+type mapExpr struct {
+	keys   []expression
+	values []expression
+}
+
+type unaryExpr struct {
+	operator string
+	operand  expression
+}
+
+type binaryExpr struct {
+	operator string
+	left     expression
+	right    expression
+}
+
+type conditionalExpr struct {
+	condition expression
+	thenValue expression
+	elseValue expression
+}
+
+type assignExpr struct {
+	operator string
+	target   expression
+	value    expression
+}
+
+type incrementExpr struct {
+	target expression
+	delta  int64
+	prefix bool
+}
+
+type lambdaExpr struct {
+	params []string
+	body   statement
+}
@@ -0,0 +1,9 @@
+package painless
+
+import "errors"
+
+// ErrSyntax signals that the script cannot be parsed
+var ErrSyntax = errors.New("painless syntax error")
+
+// ErrRuntime signals that the execution of the script failed
+var ErrRuntime = errors.New("painless runtime error")
@@ -0,0 +1,980 @@
+package painless
+
+import (
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// maxLoopIterations protects the indexer from the scripts which never end
+const maxLoopIterations = 1_000_000
+
+type signal int
+
+const (
+	signalNone signal = iota
+	signalBreak
+	signalContinue
+	signalReturn
+)
+
+type scope struct {
+	variables map[string]interface{}
+	parent    *scope
+}
+
+func newScope(parent *scope) *scope {
+	return &scope{
+		variables: make(map[string]interface{}),
+		parent:    parent,
+	}
+}
+
+func (s *scope) lookup(name string) (interface{}, bool) {
+	for current := s; current != nil; current = current.parent {
+		value, found := current.variables[name]
+		if found {
+			return value, true
+		}
+	}
+
+	return nil, false
+}
+
+func (s *scope) assign(name string, value interface{}) bool {
+	for current := s; current != nil; current = current.parent {
+		_, found := current.variables[name]
+		if found {
+			current.variables[name] = value
+			return true
+		}
+	}
+
+	return false
+}
+
+// Script is a compiled painless script
+type Script struct {
+	statements []statement
+}
+
+// Compile parses the provided painless source. Only the subset of the language used by the indexer scripts is supported
+func Compile(source string) (*Script, error) {
+	statements, err := parse(source)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Script{statements: statements}, nil
+}
+
+// Execute runs the script with the provided variables, like ctx and params. The variables hold decoded JSON values
+// and are replaced with their values from the end of the execution
+func (s *Script) Execute(variables map[string]interface{}) error {
+	global := newScope(nil)
+	for name, value := range variables {
+		global.variables[name] = importValue(value)
+	}
+
+	_, _, err := executeStatements(s.statements, newScope(global))
+	if err != nil {
+		return err
+	}
+
+	for name := range variables {
+		variables[name] = exportValue(global.variables[name])
+	}
+
+	return nil
+}
+
+func executeStatements(statements []statement, current *scope) (signal, interface{}, error) {
+	for _, stmt := range statements {
+		sig, value, err := execute(stmt, current)
+		if err != nil || sig != signalNone {
+			return sig, value, err
+		}
+	}
+
+	return signalNone, nil, nil
+}
+
+func execute(stmt statement, current *scope) (signal, interface{}, error) {
+	switch typed := stmt.(type) {
+	case *blockStmt:
+		return executeStatements(typed.statements, newScope(current))
+	case *exprStmt:
+		_, err := evaluate(typed.expression, current)
+		return signalNone, nil, err
+	case *declStmt:
+		for idx, name := range typed.names {
+			value, err := evaluate(typed.values[idx], current)
+			if err != nil {
+				return signalNone, nil, err
+			}
+			current.variables[name] = value
+		}
+		return signalNone, nil, nil
+	case *ifStmt:
+		return executeIf(typed, current)
+	case *forStmt:
+		return executeFor(typed, newScope(current))
+	case *forEachStmt:
+		return executeForEach(typed, current)
+	case *whileStmt:
+		return executeLoop(typed.condition, nil, typed.body, current)
+	case *returnStmt:
+		if typed.value == nil {
+			return signalReturn, nil, nil
+		}
+		value, err := evaluate(typed.value, current)
+		return signalReturn, value, err
+	case *breakStmt:
+		return signalBreak, nil, nil
+	case *continueStmt:
+		return signalContinue, nil, nil
+	default:
+		return signalNone, nil, runtimeError("unsupported statement %T", stmt)
+	}
+}
+
+func executeIf(stmt *ifStmt, current *scope) (signal, interface{}, error) {
+	condition, err := evaluateCondition(stmt.condition, current)
+	if err != nil {
+		return signalNone, nil, err
+	}
+
+	if condition {
+		return execute(stmt.thenStmt, newScope(current))
+	}
+	if stmt.elseStmt != nil {
+		return execute(stmt.elseStmt, newScope(current))
+	}
+
+	return signalNone, nil, nil
+}
+
+func executeFor(stmt *forStmt, current *scope) (signal, interface{}, error) {
+	if stmt.init != nil {
+		_, _, err := execute(stmt.init, current)
+		if err != nil {
+			return signalNone, nil, err
+		}
+	}
+
+	return executeLoop(stmt.condition, stmt.update, stmt.body, current)
+}
+
+func executeLoop(condition expression, update expression, body statement, current *scope) (signal, interface{}, error) {
+	for iteration := 0; ; iteration++ {
+		if iteration == maxLoopIterations {
+			return signalNone, nil, runtimeError("too many loop iterations")
+		}
+
+		if condition != nil {
+			ok, err := evaluateCondition(condition, current)
+			if err != nil {
+				return signalNone, nil, err
+			}
+			if !ok {
+				return signalNone, nil, nil
+			}
+		}
+
+		sig, value, err := execute(body, newScope(current))
+		if err != nil {
+			return signalNone, nil, err
+		}
+		if sig == signalBreak {
+			return signalNone, nil, nil
+		}
+		if sig == signalReturn {
+			return sig, value, nil
+		}
+
+		if update != nil {
+			_, err = evaluate(update, current)
+			if err != nil {
+				return signalNone, nil, err
+			}
+		}
+	}
+}
+
+func executeForEach(stmt *forEachStmt, current *scope) (signal, interface{}, error) {
+	iterable, err := evaluate(stmt.iterable, current)
+	if err != nil {
+		return signalNone, nil, err
+	}
+
+	items, err := iterableItems(iterable)
+	if err != nil {
+		return signalNone, nil, err
+	}
+
+	for _, item := range items {
+		loopScope := newScope(current)
+		loopScope.variables[stmt.name] = item
+
+		sig, value, errExecute := execute(stmt.body, loopScope)
+		if errExecute != nil {
+			return signalNone, nil, errExecute
+		}
+		if sig == signalBreak {
+			return signalNone, nil, nil
+		}
+		if sig == signalReturn {
+			return sig, value, nil
+		}
+	}
+
+	return signalNone, nil, nil
+}
+
+func iterableItems(iterable interface{}) ([]interface{}, error) {
+	switch typed := iterable.(type) {
+	case *list:
+		return append([]interface{}{}, typed.items...), nil
+	case map[string]interface{}:
+		keys := make([]interface{}, 0, len(typed))
+		for key := range typed {
+			keys = append(keys, key)
+		}
+		return keys, nil
+	default:
+		return nil, runtimeError("cannot iterate over %s", typeName(iterable))
+	}
+}
+
+func evaluateCondition(condition expression, current *scope) (bool, error) {
+	value, err := evaluate(condition, current)
+	if err != nil {
+		return false, err
+	}
+
+	return truthy(value)
+}
+
+func evaluate(expr expression, current *scope) (interface{}, error) {
+	switch typed := expr.(type) {
+	case *literalExpr:
+		return typed.value, nil
+	case *identExpr:
+		value, found := current.lookup(typed.name)
+		if !found {
+			return nil, runtimeError("undefined variable %s", typed.name)
+		}
+		return value, nil
+	case *fieldExpr:
+		target, err := evaluate(typed.target, current)
+		if err != nil {
+			return nil, err
+		}
+		return getField(target, typed.name)
+	case *indexExpr:
+		return evaluateIndex(typed, current)
+	case *callExpr:
+		return evaluateCall(typed, current)
+	case *newExpr:
+		return evaluateNew(typed, current)
+	case *listExpr:
+		result := &list{items: make([]interface{}, 0, len(typed.items))}
+		for _, item := range typed.items {
+			value, err := evaluate(item, current)
+			if err != nil {
+				return nil, err
+			}
+			result.items = append(result.items, value)
+		}
+		return result, nil
+	case *mapExpr:
+		return evaluateMap(typed, current)
+	case *unaryExpr:
+		return evaluateUnary(typed, current)
+	case *binaryExpr:
+		return evaluateBinary(typed, current)
+	case *conditionalExpr:
+		condition, err := evaluateCondition(typed.condition, current)
+		if err != nil {
+			return nil, err
+		}
+		if condition {
+			return evaluate(typed.thenValue, current)
+		}
+		return evaluate(typed.elseValue, current)
+	case *assignExpr:
+		return evaluateAssign(typed, current)
+	case *incrementExpr:
+		return evaluateIncrement(typed, current)
+	case *lambdaExpr:
+		return &lambda{params: typed.params, body: typed.body, closure: current}, nil
+	default:
+		return nil, runtimeError("unsupported expression %T", expr)
+	}
+}
+
+func getField(target interface{}, name string) (interface{}, error) {
+	switch typed := target.(type) {
+	case map[string]interface{}:
+		return typed[name], nil
+	case *list:
+		if name == "length" {
+			return int64(len(typed.items)), nil
+		}
+	case string:
+		if name == "length" {
+			return int64(len(typed)), nil
+		}
+	}
+
+	return nil, runtimeError("cannot access field %s of %s", name, typeName(target))
+}
+
+func setField(target interface{}, name string, value interface{}) error {
+	typed, ok := target.(map[string]interface{})
+	if !ok {
+		return runtimeError("cannot set field %s of %s", name, typeName(target))
+	}
+	typed[name] = value
+
+	return nil
+}
+
+func evaluateIndex(expr *indexExpr, current *scope) (interface{}, error) {
+	target, err := evaluate(expr.target, current)
+	if err != nil {
+		return nil, err
+	}
+	index, err := evaluate(expr.index, current)
+	if err != nil {
+		return nil, err
+	}
+
+	return getIndex(target, index)
+}
+
+func getIndex(target interface{}, index interface{}) (interface{}, error) {
+	switch typed := target.(type) {
+	case map[string]interface{}:
+		return typed[toString(index)], nil
+	case *list:
+		position, err := listPosition(typed, index)
+		if err != nil {
+			return nil, err
+		}
+		return typed.items[position], nil
+	default:
+		return nil, runtimeError("cannot index %s", typeName(target))
+	}
+}
+
+func setIndex(target interface{}, index interface{}, value interface{}) error {
+	switch typed := target.(type) {
+	case map[string]interface{}:
+		typed[toString(index)] = value
+		return nil
+	case *list:
+		position, err := listPosition(typed, index)
+		if err != nil {
+			return err
+		}
+		typed.items[position] = value
+		return nil
+	default:
+		return runtimeError("cannot index %s", typeName(target))
+	}
+}
+
+func listPosition(target *list, index interface{}) (int, error) {
+	position, err := toInt(index)
+	if err != nil {
+		return 0, err
+	}
+	if position < 0 || position >= int64(len(target.items)) {
+		return 0, runtimeError("index %d out of bounds for length %d", position, len(target.items))
+	}
+
+	return int(position), nil
+}
+
+func evaluateMap(expr *mapExpr, current *scope) (interface{}, error) {
+	result := make(map[string]interface{}, len(expr.keys))
+	for idx := range expr.keys {
+		key, err := evaluate(expr.keys[idx], current)
+		if err != nil {
+			return nil, err
+		}
+		value, err := evaluate(expr.values[idx], current)
+		if err != nil {
+			return nil, err
+		}
+		result[toString(key)] = value
+	}
+
+	return result, nil
+}
+
+func evaluateNew(expr *newExpr, current *scope) (interface{}, error) {
+	args, err := evaluateArguments(expr.args, current)
+	if err != nil {
+		return nil, err
+	}
+
+	switch expr.typeName {
+	case "HashMap":
+		result := make(map[string]interface{})
+		if len(args) == 1 {
+			source, ok := args[0].(map[string]interface{})
+			if ok {
+				for key, value := range source {
+					result[key] = value
+				}
+			}
+		}
+		return result, nil
+	case "ArrayList":
+		result := &list{items: make([]interface{}, 0)}
+		if len(args) == 1 {
+			source, ok := args[0].(*list)
+			if ok {
+				result.items = append(result.items, source.items...)
+			}
+		}
+		return result, nil
+	case "BigInteger":
+		if len(args) != 1 {
+			return nil, runtimeError("BigInteger expects one argument")
+		}
+		value, errConvert := toBigInt(args[0])
+		if errConvert != nil {
+			return nil, errConvert
+		}
+		return big.NewInt(0).Set(value), nil
+	default:
+		return nil, runtimeError("cannot create %s", expr.typeName)
+	}
+}
+
+func evaluateArguments(args []expression, current *scope) ([]interface{}, error) {
+	values := make([]interface{}, 0, len(args))
+	for _, arg := range args {
+		value, err := evaluate(arg, current)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+func evaluateUnary(expr *unaryExpr, current *scope) (interface{}, error) {
+	operand, err := evaluate(expr.operand, current)
+	if err != nil {
+		return nil, err
+	}
+
+	switch expr.operator {
+	case "!":
+		value, errCondition := truthy(operand)
+		return !value, errCondition
+	case "-":
+		switch typed := operand.(type) {
+		case int64:
+			return -typed, nil
+		case float64:
+			return -typed, nil
+		}
+	case "+":
+		if isNumber(operand) {
+			return operand, nil
+		}
+	}
+
+	return nil, runtimeError("cannot apply %s to %s", expr.operator, typeName(operand))
+}
+
+func evaluateBinary(expr *binaryExpr, current *scope) (interface{}, error) {
+	left, err := evaluate(expr.left, current)
+	if err != nil {
+		return nil, err
+	}
+
+	switch expr.operator {
+	case "&&", "||":
+		leftValue, errCondition := truthy(left)
+		if errCondition != nil {
+			return nil, errCondition
+		}
+		if leftValue == (expr.operator == "||") {
+			return leftValue, nil
+		}
+		return evaluateCondition(expr.right, current)
+	}
+
+	right, err := evaluate(expr.right, current)
+	if err != nil {
+		return nil, err
+	}
+
+	return applyOperator(expr.operator, left, right)
+}
+
+func applyOperator(operator string, left interface{}, right interface{}) (interface{}, error) {
+	switch operator {
+	case "==":
+		return valuesEqual(left, right), nil
+	case "!=":
+		return !valuesEqual(left, right), nil
+	case "<", "<=", ">", ">=":
+		comparison, err := compareValues(left, right)
+		if err != nil {
+			return nil, err
+		}
+		switch operator {
+		case "<":
+			return comparison < 0, nil
+		case "<=":
+			return comparison <= 0, nil
+		case ">":
+			return comparison > 0, nil
+		default:
+			return comparison >= 0, nil
+		}
+	}
+
+	_, isLeftString := left.(string)
+	_, isRightString := right.(string)
+	if operator == "+" && (isLeftString || isRightString) {
+		return toString(left) + toString(right), nil
+	}
+
+	if !isNumber(left) || !isNumber(right) {
+		return nil, runtimeError("cannot apply %s to %s and %s", operator, typeName(left), typeName(right))
+	}
+
+	leftInt, isLeftInt := left.(int64)
+	rightInt, isRightInt := right.(int64)
+	if isLeftInt && isRightInt {
+		switch operator {
+		case "+":
+			return leftInt + rightInt, nil
+		case "-":
+			return leftInt - rightInt, nil
+		case "*":
+			return leftInt * rightInt, nil
+		case "/", "%":
+			if rightInt == 0 {
+				return nil, runtimeError("division by zero")
+			}
+			if operator == "/" {
+				return leftInt / rightInt, nil
+			}
+			return leftInt % rightInt, nil
+		}
+	}
+
+	leftFloat, rightFloat := toFloat(left), toFloat(right)
+	switch operator {
+	case "+":
+		return leftFloat + rightFloat, nil
+	case "-":
+		return leftFloat - rightFloat, nil
+	case "*":
+		return leftFloat * rightFloat, nil
+	case "/":
+		return leftFloat / rightFloat, nil
+	default:
+		return nil, runtimeError("cannot apply %s to %s and %s", operator, typeName(left), typeName(right))
+	}
+}
+
+func evaluateAssign(expr *assignExpr, current *scope) (interface{}, error) {
+	value, err := evaluate(expr.value, current)
+	if err != nil {
+		return nil, err
+	}
+
+	if expr.operator != "" {
+		previous, errPrevious := evaluate(expr.target, current)
+		if errPrevious != nil {
+			return nil, errPrevious
+		}
+		value, err = applyOperator(expr.operator, previous, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return value, assign(expr.target, value, current)
+}
+
+func assign(target expression, value interface{}, current *scope) error {
+	switch typed := target.(type) {
+	case *identExpr:
+		if !current.assign(typed.name, value) {
+			return runtimeError("undefined variable %s", typed.name)
+		}
+		return nil
+	case *fieldExpr:
+		object, err := evaluate(typed.target, current)
+		if err != nil {
+			return err
+		}
+		return setField(object, typed.name, value)
+	case *indexExpr:
+		object, err := evaluate(typed.target, current)
+		if err != nil {
+			return err
+		}
+		index, err := evaluate(typed.index, current)
+		if err != nil {
+			return err
+		}
+		return setIndex(object, index, value)
+	default:
+		return runtimeError("invalid assignment target %T", target)
+	}
+}
+
+func evaluateIncrement(expr *incrementExpr, current *scope) (interface{}, error) {
+	previous, err := evaluate(expr.target, current)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := applyOperator("+", previous, expr.delta)
+	if err != nil {
+		return nil, err
+	}
+
+	err = assign(expr.target, value, current)
+	if err != nil {
+		return nil, err
+	}
+	if expr.prefix {
+		return value, nil
+	}
+
+	return previous, nil
+}
+
+func callLambda(function interface{}, args ...interface{}) (interface{}, error) {
+	typed, ok := function.(*lambda)
+	if !ok {
+		return nil, runtimeError("expected a lambda, found %s", typeName(function))
+	}
+	if len(typed.params) != len(args) {
+		return nil, runtimeError("lambda expects %d arguments, found %d", len(typed.params), len(args))
+	}
+
+	lambdaScope := newScope(typed.closure)
+	for idx, name := range typed.params {
+		lambdaScope.variables[name] = args[idx]
+	}
+
+	_, value, err := execute(typed.body, lambdaScope)
+
+	return value, err
+}
+
+func evaluateCall(expr *callExpr, current *scope) (interface{}, error) {
+	if isMathClass(expr.target, current) {
+		args, err := evaluateArguments(expr.args, current)
+		if err != nil {
+			return nil, err
+		}
+		return callMathMethod(expr.method, args)
+	}
+
+	target, err := evaluate(expr.target, current)
+	if err != nil {
+		return nil, err
+	}
+	args, err := evaluateArguments(expr.args, current)
+	if err != nil {
+		return nil, err
+	}
+
+	switch expr.method {
+	case "equals":
+		if len(args) == 1 {
+			return valuesEqual(target, args[0]), nil
+		}
+	case "toString":
+		if len(args) == 0 && target != nil {
+			return toString(target), nil
+		}
+	}
+
+	switch typed := target.(type) {
+	case map[string]interface{}:
+		return callMapMethod(typed, expr.method, args)
+	case *list:
+		return callListMethod(typed, expr.method, args)
+	case *iterator:
+		return callIteratorMethod(typed, expr.method, args)
+	case string:
+		return callStringMethod(typed, expr.method, args)
+	case *big.Int:
+		return callBigIntMethod(typed, expr.method, args)
+	case nil:
+		return nil, runtimeError("cannot call %s on null", expr.method)
+	}
+
+	return nil, unknownMethod(target, expr.method, args)
+}
+
+func isMathClass(target expression, current *scope) bool {
+	ident, ok := target.(*identExpr)
+	if !ok || ident.name != "Math" {
+		return false
+	}
+
+	_, isVariable := current.lookup(ident.name)
+	return !isVariable
+}
+
+func callMathMethod(method string, args []interface{}) (interface{}, error) {
+	for _, arg := range args {
+		if !isNumber(arg) {
+			return nil, runtimeError("Math.%s expects numbers, found %s", method, typeName(arg))
+		}
+	}
+
+	switch {
+	case (method == "max" || method == "min") && len(args) == 2:
+		comparison, err := compareValues(args[0], args[1])
+		if err != nil {
+			return nil, err
+		}
+		if (comparison >= 0) == (method == "max") {
+			return args[0], nil
+		}
+		return args[1], nil
+	case method == "pow" && len(args) == 2:
+		return math.Pow(toFloat(args[0]), toFloat(args[1])), nil
+	case method == "log" && len(args) == 1:
+		return math.Log(toFloat(args[0])), nil
+	case method == "round" && len(args) == 1:
+		return int64(math.Floor(toFloat(args[0]) + 0.5)), nil
+	}
+
+	return nil, runtimeError("unknown method Math.%s/%d", method, len(args))
+}
+
+func unknownMethod(target interface{}, method string, args []interface{}) error {
+	return runtimeError("unknown method %s/%d of %s", method, len(args), typeName(target))
+}
+
+func callMapMethod(target map[string]interface{}, method string, args []interface{}) (interface{}, error) {
+	switch {
+	case method == "containsKey" && len(args) == 1:
+		_, found := target[toString(args[0])]
+		return found, nil
+	case method == "get" && len(args) == 1:
+		return target[toString(args[0])], nil
+	case method == "getOrDefault" && len(args) == 2:
+		value, found := target[toString(args[0])]
+		if !found {
+			return args[1], nil
+		}
+		return value, nil
+	case method == "put" && len(args) == 2:
+		key := toString(args[0])
+		previous := target[key]
+		target[key] = args[1]
+		return previous, nil
+	case method == "remove" && len(args) == 1:
+		key := toString(args[0])
+		previous := target[key]
+		delete(target, key)
+		return previous, nil
+	case method == "isEmpty" && len(args) == 0:
+		return len(target) == 0, nil
+	case method == "size" && len(args) == 0:
+		return int64(len(target)), nil
+	case method == "keySet" && len(args) == 0:
+		keys := &list{items: make([]interface{}, 0, len(target))}
+		for _, key := range sortedKeys(target) {
+			keys.items = append(keys.items, key)
+		}
+		return keys, nil
+	case method == "values" && len(args) == 0:
+		values := &list{items: make([]interface{}, 0, len(target))}
+		for _, key := range sortedKeys(target) {
+			values.items = append(values.items, target[key])
+		}
+		return values, nil
+	case method == "forEach" && len(args) == 1:
+		for _, key := range sortedKeys(target) {
+			_, err := callLambda(args[0], key, target[key])
+			if err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	}
+
+	return nil, unknownMethod(target, method, args)
+}
+
+func sortedKeys(target map[string]interface{}) []string {
+	keys := make([]string, 0, len(target))
+	for key := range target {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func callListMethod(target *list, method string, args []interface{}) (interface{}, error) {
+	switch {
+	case method == "add" && len(args) == 1:
+		target.items = append(target.items, args[0])
+		return true, nil
+	case method == "get" && len(args) == 1:
+		position, err := listPosition(target, args[0])
+		if err != nil {
+			return nil, err
+		}
+		return target.items[position], nil
+	case method == "set" && len(args) == 2:
+		position, err := listPosition(target, args[0])
+		if err != nil {
+			return nil, err
+		}
+		previous := target.items[position]
+		target.items[position] = args[1]
+		return previous, nil
+	case method == "remove" && len(args) == 1:
+		position, err := listPosition(target, args[0])
+		if err != nil {
+			return nil, err
+		}
+		previous := target.items[position]
+		target.items = append(target.items[:position], target.items[position+1:]...)
+		return previous, nil
+	case method == "size" && len(args) == 0:
+		return int64(len(target.items)), nil
+	case method == "isEmpty" && len(args) == 0:
+		return len(target.items) == 0, nil
+	case method == "contains" && len(args) == 1:
+		return listIndexOf(target, args[0]) >= 0, nil
+	case method == "indexOf" && len(args) == 1:
+		return int64(listIndexOf(target, args[0])), nil
+	case method == "iterator" && len(args) == 0:
+		return &iterator{source: target, current: -1}, nil
+	case method == "removeIf" && len(args) == 1:
+		return listRemoveIf(target, args[0])
+	case method == "forEach" && len(args) == 1:
+		for _, item := range append([]interface{}{}, target.items...) {
+			_, err := callLambda(args[0], item)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	}
+
+	return nil, unknownMethod(target, method, args)
+}
+
+func listIndexOf(target *list, value interface{}) int {
+	for idx, item := range target.items {
+		if valuesEqual(item, value) {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+func listRemoveIf(target *list, predicate interface{}) (interface{}, error) {
+	kept := make([]interface{}, 0, len(target.items))
+	for _, item := range target.items {
+		result, err := callLambda(predicate, item)
+		if err != nil {
+			return nil, err
+		}
+		remove, err := truthy(result)
+		if err != nil {
+			return nil, err
+		}
+		if !remove {
+			kept = append(kept, item)
+		}
+	}
+
+	removed := len(kept) != len(target.items)
+	target.items = kept
+
+	return removed, nil
+}
+
+func callIteratorMethod(target *iterator, method string, args []interface{}) (interface{}, error) {
+	switch {
+	case method == "hasNext" && len(args) == 0:
+		return target.next < len(target.source.items), nil
+	case method == "next" && len(args) == 0:
+		if target.next >= len(target.source.items) {
+			return nil, runtimeError("no such element")
+		}
+		target.current = target.next
+		target.next++
+		return target.source.items[target.current], nil
+	case method == "remove" && len(args) == 0:
+		if target.current < 0 {
+			return nil, runtimeError("illegal iterator state")
+		}
+		items := target.source.items
+		target.source.items = append(items[:target.current], items[target.current+1:]...)
+		target.next = target.current
+		target.current = -1
+		return nil, nil
+	}
+
+	return nil, unknownMethod(target, method, args)
+}
+
+func callStringMethod(target string, method string, args []interface{}) (interface{}, error) {
+	switch {
+	case method == "isEmpty" && len(args) == 0:
+		return len(target) == 0, nil
+	case method == "length" && len(args) == 0:
+		return int64(len(target)), nil
+	case method == "contains" && len(args) == 1:
+		return strings.Contains(target, toString(args[0])), nil
+	case method == "startsWith" && len(args) == 1:
+		return strings.HasPrefix(target, toString(args[0])), nil
+	case method == "endsWith" && len(args) == 1:
+		return strings.HasSuffix(target, toString(args[0])), nil
+	}
+
+	return nil, unknownMethod(target, method, args)
+}
+
+func callBigIntMethod(target *big.Int, method string, args []interface{}) (interface{}, error) {
+	if len(args) != 1 {
+		return nil, unknownMethod(target, method, args)
+	}
+	other, err := toBigInt(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	switch method {
+	case "compareTo":
+		return int64(target.Cmp(other)), nil
+	case "add":
+		return big.NewInt(0).Add(target, other), nil
+	case "subtract":
+		return big.NewInt(0).Sub(target, other), nil
+	case "multiply":
+		return big.NewInt(0).Mul(target, other), nil
+	}
+
+	return nil, unknownMethod(target, method, args)
+}
@@ -0,0 +1,130 @@
+package painless
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenPunct
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value string
+	pos   int
+}
+
+// the punctuation is matched greedily, so the longer operators are listed first
+var punctuation = []string{
+	"->", "==", "!=", "<=", ">=", "&&", "||", "+=", "-=", "*=", "/=", "++", "--",
+	"{", "}", "(", ")", "[", "]", ";", ",", ".", "?", ":", "<", ">", "!", "=", "+", "-", "*", "/", "%",
+}
+
+func tokenize(source string) ([]token, error) {
+	tokens := make([]token, 0)
+	runes := []rune(source)
+	pos := 0
+	for pos < len(runes) {
+		r := runes[pos]
+		switch {
+		case unicode.IsSpace(r):
+			pos++
+		case r == '/' && pos+1 < len(runes) && runes[pos+1] == '/':
+			for pos < len(runes) && runes[pos] != '\n' {
+				pos++
+			}
+		case r == '/' && pos+1 < len(runes) && runes[pos+1] == '*':
+			end := pos + 2
+			for end+1 < len(runes) && !(runes[end] == '*' && runes[end+1] == '/') {
+				end++
+			}
+			if end+1 >= len(runes) {
+				return nil, fmt.Errorf("%w: unterminated comment at %d", ErrSyntax, pos)
+			}
+			pos = end + 2
+		case unicode.IsLetter(r) || r == '_':
+			start := pos
+			for pos < len(runes) && (unicode.IsLetter(runes[pos]) || unicode.IsDigit(runes[pos]) || runes[pos] == '_') {
+				pos++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(runes[start:pos]), pos: start})
+		case unicode.IsDigit(r):
+			start := pos
+			for pos < len(runes) && (unicode.IsDigit(runes[pos]) || runes[pos] == '.') {
+				if runes[pos] == '.' && (pos+1 >= len(runes) || !unicode.IsDigit(runes[pos+1])) {
+					break
+				}
+				pos++
+			}
+			// the type suffixes of the numeric literals are ignored
+			text := string(runes[start:pos])
+			if pos < len(runes) && strings.ContainsRune("lLdDfF", runes[pos]) {
+				pos++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: text, pos: start})
+		case r == '\'' || r == '"':
+			value, next, err := readString(runes, pos)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, text: string(runes[pos:next]), value: value, pos: pos})
+			pos = next
+		default:
+			punct := matchPunctuation(runes[pos:])
+			if punct == "" {
+				return nil, fmt.Errorf("%w: unexpected character %q at %d", ErrSyntax, r, pos)
+			}
+			tokens = append(tokens, token{kind: tokenPunct, text: punct, pos: pos})
+			pos += len(punct)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: pos}), nil
+}
+
+func readString(runes []rune, pos int) (string, int, error) {
+	quote := runes[pos]
+	builder := strings.Builder{}
+	for idx := pos + 1; idx < len(runes); idx++ {
+		switch runes[idx] {
+		case quote:
+			return builder.String(), idx + 1, nil
+		case '\\':
+			idx++
+			if idx >= len(runes) {
+				break
+			}
+			switch runes[idx] {
+			case 'n':
+				builder.WriteRune('\n')
+			case 't':
+				builder.WriteRune('\t')
+			default:
+				builder.WriteRune(runes[idx])
+			}
+		default:
+			builder.WriteRune(runes[idx])
+		}
+	}
+
+	return "", 0, fmt.Errorf("%w: unterminated string at %d", ErrSyntax, pos)
+}
+
+func matchPunctuation(runes []rune) string {
+	for _, punct := range punctuation {
+		if len(runes) >= len(punct) && string(runes[:len(punct)]) == punct {
+			return punct
+		}
+	}
+
+	return ""
+}
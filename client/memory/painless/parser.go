@@ -0,0 +1,794 @@
+package painless
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// typeNames holds the types which can be used to declare variables. The declared type is not enforced, the variables
+// behave as def variables
+var typeNames = map[string]struct{}{
+	"def": {}, "var": {}, "int": {}, "long": {}, "short": {}, "byte": {}, "float": {}, "double": {}, "boolean": {},
+	"char": {}, "String": {}, "Object": {}, "Integer": {}, "Long": {}, "Double": {}, "Boolean": {}, "Map": {},
+	"HashMap": {}, "List": {}, "ArrayList": {}, "Iterator": {}, "BigInteger": {}, "BigDecimal": {},
+}
+
+// defaultValues holds the initial values of the primitive variables declared without a value
+var defaultValues = map[string]interface{}{
+	"int": int64(0), "long": int64(0), "short": int64(0), "byte": int64(0),
+	"float": float64(0), "double": float64(0), "boolean": false,
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func parse(source string) ([]statement, error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	statements := make([]statement, 0)
+	for !p.isEOF() {
+		stmt, errParse := p.parseStatement()
+		if errParse != nil {
+			return nil, errParse
+		}
+		statements = append(statements, stmt)
+	}
+
+	return statements, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) peekAt(offset int) token {
+	if p.pos+offset >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+
+	return p.tokens[p.pos+offset]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+
+	return tok
+}
+
+func (p *parser) isEOF() bool {
+	return p.peek().kind == tokenEOF
+}
+
+func (p *parser) isPunct(text string) bool {
+	tok := p.peek()
+	return tok.kind == tokenPunct && tok.text == text
+}
+
+func (p *parser) isKeyword(text string) bool {
+	tok := p.peek()
+	return tok.kind == tokenIdent && tok.text == text
+}
+
+func (p *parser) acceptPunct(text string) bool {
+	if p.isPunct(text) {
+		p.pos++
+		return true
+	}
+
+	return false
+}
+
+func (p *parser) expectPunct(text string) error {
+	if !p.acceptPunct(text) {
+		return p.errorf("expected %q", text)
+	}
+
+	return nil
+}
+
+func (p *parser) expectIdent() (string, error) {
+	tok := p.peek()
+	if tok.kind != tokenIdent {
+		return "", p.errorf("expected an identifier")
+	}
+	p.pos++
+
+	return tok.text, nil
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	tok := p.peek()
+	found := tok.text
+	if tok.kind == tokenEOF {
+		found = "end of script"
+	}
+
+	return fmt.Errorf("%w: %s at %d, found %q", ErrSyntax, fmt.Sprintf(format, args...), tok.pos, found)
+}
+
+// the semicolons are optional, since the newlines are removed from the scripts before they are sent
+func (p *parser) endStatement() {
+	for p.acceptPunct(";") {
+	}
+}
+
+func (p *parser) parseStatement() (statement, error) {
+	if p.acceptPunct(";") {
+		return &blockStmt{}, nil
+	}
+	if p.isPunct("{") {
+		return p.parseBlock()
+	}
+
+	tok := p.peek()
+	if tok.kind == tokenIdent {
+		switch tok.text {
+		case "if":
+			return p.parseIf()
+		case "for":
+			return p.parseFor()
+		case "while":
+			return p.parseWhile()
+		case "return":
+			return p.parseReturn()
+		case "break":
+			p.next()
+			p.endStatement()
+			return &breakStmt{}, nil
+		case "continue":
+			p.next()
+			p.endStatement()
+			return &continueStmt{}, nil
+		}
+	}
+
+	if p.isDeclaration() {
+		decl, err := p.parseDeclaration()
+		if err != nil {
+			return nil, err
+		}
+		p.endStatement()
+
+		return decl, nil
+	}
+
+	expression, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	p.endStatement()
+
+	return &exprStmt{expression: expression}, nil
+}
+
+func (p *parser) parseBlock() (*blockStmt, error) {
+	err := p.expectPunct("{")
+	if err != nil {
+		return nil, err
+	}
+
+	block := &blockStmt{}
+	for !p.isPunct("}") {
+		if p.isEOF() {
+			return nil, p.errorf("expected %q", "}")
+		}
+
+		stmt, errParse := p.parseStatement()
+		if errParse != nil {
+			return nil, errParse
+		}
+		block.statements = append(block.statements, stmt)
+	}
+	p.next()
+
+	return block, nil
+}
+
+func (p *parser) parseIf() (statement, error) {
+	p.next()
+	condition, err := p.parseParenthesized()
+	if err != nil {
+		return nil, err
+	}
+
+	thenStmt, err := p.parseStatement()
+	if err != nil {
+		return nil, err
+	}
+
+	stmt := &ifStmt{condition: condition, thenStmt: thenStmt}
+	if p.isKeyword("else") {
+		p.next()
+		stmt.elseStmt, err = p.parseStatement()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return stmt, nil
+}
+
+func (p *parser) parseFor() (statement, error) {
+	p.next()
+	err := p.expectPunct("(")
+	if err != nil {
+		return nil, err
+	}
+
+	if p.isForEach() {
+		return p.parseForEach()
+	}
+
+	stmt := &forStmt{}
+	if !p.isPunct(";") {
+		if p.isDeclaration() {
+			stmt.init, err = p.parseDeclaration()
+		} else {
+			var init expression
+			init, err = p.parseExpression()
+			stmt.init = &exprStmt{expression: init}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = p.expectPunct(";")
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.isPunct(";") {
+		stmt.condition, err = p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = p.expectPunct(";")
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.isPunct(")") {
+		stmt.update, err = p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = p.expectPunct(")")
+	if err != nil {
+		return nil, err
+	}
+
+	stmt.body, err = p.parseStatement()
+	if err != nil {
+		return nil, err
+	}
+
+	return stmt, nil
+}
+
+// isForEach checks for the "type name :" sequence of an enhanced for loop
+func (p *parser) isForEach() bool {
+	return p.peek().kind == tokenIdent && p.peekAt(1).kind == tokenIdent &&
+		p.peekAt(2).kind == tokenPunct && p.peekAt(2).text == ":"
+}
+
+func (p *parser) parseForEach() (statement, error) {
+	p.next()
+	name, err := p.expectIdent()
+	if err != nil {
+		return nil, err
+	}
+	err = p.expectPunct(":")
+	if err != nil {
+		return nil, err
+	}
+
+	iterable, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	err = p.expectPunct(")")
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := p.parseStatement()
+	if err != nil {
+		return nil, err
+	}
+
+	return &forEachStmt{name: name, iterable: iterable, body: body}, nil
+}
+
+func (p *parser) parseWhile() (statement, error) {
+	p.next()
+	condition, err := p.parseParenthesized()
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := p.parseStatement()
+	if err != nil {
+		return nil, err
+	}
+
+	return &whileStmt{condition: condition, body: body}, nil
+}
+
+func (p *parser) parseReturn() (statement, error) {
+	p.next()
+
+	stmt := &returnStmt{}
+	if !p.isPunct(";") && !p.isPunct("}") && !p.isEOF() {
+		value, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		stmt.value = value
+	}
+	p.endStatement()
+
+	return stmt, nil
+}
+
+func (p *parser) isDeclaration() bool {
+	tok := p.peek()
+	if tok.kind != tokenIdent {
+		return false
+	}
+	_, isType := typeNames[tok.text]
+
+	return isType && p.peekAt(1).kind == tokenIdent
+}
+
+func (p *parser) parseDeclaration() (statement, error) {
+	typeName := p.next().text
+
+	decl := &declStmt{}
+	for {
+		name, err := p.expectIdent()
+		if err != nil {
+			return nil, err
+		}
+
+		var value expression = &literalExpr{value: defaultValues[typeName]}
+		if p.acceptPunct("=") {
+			value, err = p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+		}
+		decl.names = append(decl.names, name)
+		decl.values = append(decl.values, value)
+
+		if !p.acceptPunct(",") {
+			return decl, nil
+		}
+	}
+}
+
+func (p *parser) parseParenthesized() (expression, error) {
+	err := p.expectPunct("(")
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	return value, p.expectPunct(")")
+}
+
+func (p *parser) parseExpression() (expression, error) {
+	return p.parseAssignment()
+}
+
+func (p *parser) parseAssignment() (expression, error) {
+	if p.isLambda() {
+		return p.parseLambda()
+	}
+
+	target, err := p.parseConditional()
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.peek()
+	if tok.kind != tokenPunct {
+		return target, nil
+	}
+	switch tok.text {
+	case "=", "+=", "-=", "*=", "/=":
+		if !isAssignable(target) {
+			return nil, p.errorf("invalid assignment target")
+		}
+		p.next()
+
+		value, errValue := p.parseAssignment()
+		if errValue != nil {
+			return nil, errValue
+		}
+
+		return &assignExpr{operator: strings.TrimSuffix(tok.text, "="), target: target, value: value}, nil
+	default:
+		return target, nil
+	}
+}
+
+func isAssignable(target expression) bool {
+	switch target.(type) {
+	case *identExpr, *fieldExpr, *indexExpr:
+		return true
+	default:
+		return false
+	}
+}
+
+// isLambda checks for the "name ->" and "(name, ...) ->" sequences
+func (p *parser) isLambda() bool {
+	if p.peek().kind == tokenIdent {
+		next := p.peekAt(1)
+		return next.kind == tokenPunct && next.text == "->"
+	}
+	if !p.isPunct("(") {
+		return false
+	}
+
+	for offset := 1; ; offset += 2 {
+		tok := p.peekAt(offset)
+		if tok.kind == tokenPunct && tok.text == ")" && offset == 1 {
+			arrow := p.peekAt(offset + 1)
+			return arrow.kind == tokenPunct && arrow.text == "->"
+		}
+		if tok.kind != tokenIdent {
+			return false
+		}
+
+		separator := p.peekAt(offset + 1)
+		if separator.kind != tokenPunct {
+			return false
+		}
+		if separator.text == ")" {
+			arrow := p.peekAt(offset + 2)
+			return arrow.kind == tokenPunct && arrow.text == "->"
+		}
+		if separator.text != "," {
+			return false
+		}
+	}
+}
+
+func (p *parser) parseLambda() (expression, error) {
+	lambda := &lambdaExpr{}
+	if p.acceptPunct("(") {
+		for !p.acceptPunct(")") {
+			name, err := p.expectIdent()
+			if err != nil {
+				return nil, err
+			}
+			lambda.params = append(lambda.params, name)
+			p.acceptPunct(",")
+		}
+	} else {
+		lambda.params = append(lambda.params, p.next().text)
+	}
+
+	err := p.expectPunct("->")
+	if err != nil {
+		return nil, err
+	}
+
+	if p.isPunct("{") {
+		lambda.body, err = p.parseBlock()
+		return lambda, err
+	}
+
+	value, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	lambda.body = &returnStmt{value: value}
+
+	return lambda, nil
+}
+
+func (p *parser) parseConditional() (expression, error) {
+	condition, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if !p.acceptPunct("?") {
+		return condition, nil
+	}
+
+	thenValue, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	err = p.expectPunct(":")
+	if err != nil {
+		return nil, err
+	}
+	elseValue, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	return &conditionalExpr{condition: condition, thenValue: thenValue, elseValue: elseValue}, nil
+}
+
+// binaryPrecedence holds the binary operators, from the lowest to the highest precedence
+var binaryPrecedence = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func (p *parser) parseBinary(level int) (expression, error) {
+	if level == len(binaryPrecedence) {
+		return p.parseUnary()
+	}
+
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		operator, found := p.matchOperator(binaryPrecedence[level])
+		if !found {
+			return left, nil
+		}
+		p.next()
+
+		right, errRight := p.parseBinary(level + 1)
+		if errRight != nil {
+			return nil, errRight
+		}
+		left = &binaryExpr{operator: operator, left: left, right: right}
+	}
+}
+
+func (p *parser) matchOperator(operators []string) (string, bool) {
+	tok := p.peek()
+	if tok.kind != tokenPunct {
+		return "", false
+	}
+	for _, operator := range operators {
+		if tok.text == operator {
+			return operator, true
+		}
+	}
+
+	return "", false
+}
+
+func (p *parser) parseUnary() (expression, error) {
+	tok := p.peek()
+	if tok.kind == tokenPunct {
+		switch tok.text {
+		case "!", "-", "+":
+			p.next()
+			operand, err := p.parseUnary()
+			if err != nil {
+				return nil, err
+			}
+			return &unaryExpr{operator: tok.text, operand: operand}, nil
+		case "++", "--":
+			p.next()
+			target, err := p.parseUnary()
+			if err != nil {
+				return nil, err
+			}
+			if !isAssignable(target) {
+				return nil, p.errorf("invalid increment target")
+			}
+			return &incrementExpr{target: target, delta: incrementDelta(tok.text), prefix: true}, nil
+		}
+	}
+
+	return p.parsePostfix()
+}
+
+func incrementDelta(operator string) int64 {
+	if operator == "--" {
+		return -1
+	}
+
+	return 1
+}
+
+func (p *parser) parsePostfix() (expression, error) {
+	value, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		switch {
+		case p.acceptPunct("."):
+			name, errName := p.expectIdent()
+			if errName != nil {
+				return nil, errName
+			}
+			if !p.isPunct("(") {
+				value = &fieldExpr{target: value, name: name}
+				continue
+			}
+
+			args, errArgs := p.parseArguments()
+			if errArgs != nil {
+				return nil, errArgs
+			}
+			value = &callExpr{target: value, method: name, args: args}
+		case p.acceptPunct("["):
+			index, errIndex := p.parseExpression()
+			if errIndex != nil {
+				return nil, errIndex
+			}
+			errIndex = p.expectPunct("]")
+			if errIndex != nil {
+				return nil, errIndex
+			}
+			value = &indexExpr{target: value, index: index}
+		case p.isPunct("++") || p.isPunct("--"):
+			if !isAssignable(value) {
+				return value, nil
+			}
+			value = &incrementExpr{target: value, delta: incrementDelta(p.next().text)}
+		default:
+			return value, nil
+		}
+	}
+}
+
+func (p *parser) parseArguments() ([]expression, error) {
+	err := p.expectPunct("(")
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]expression, 0)
+	for !p.acceptPunct(")") {
+		arg, errArg := p.parseExpression()
+		if errArg != nil {
+			return nil, errArg
+		}
+		args = append(args, arg)
+
+		if !p.isPunct(")") {
+			errArg = p.expectPunct(",")
+			if errArg != nil {
+				return nil, errArg
+			}
+		}
+	}
+
+	return args, nil
+}
+
+func (p *parser) parsePrimary() (expression, error) {
+	tok := p.peek()
+	switch tok.kind {
+	case tokenNumber:
+		p.next()
+		return parseNumber(tok)
+	case tokenString:
+		p.next()
+		return &literalExpr{value: tok.value}, nil
+	case tokenIdent:
+		p.next()
+		switch tok.text {
+		case "true":
+			return &literalExpr{value: true}, nil
+		case "false":
+			return &literalExpr{value: false}, nil
+		case "null":
+			return &literalExpr{value: nil}, nil
+		case "new":
+			return p.parseNew()
+		default:
+			return &identExpr{name: tok.text}, nil
+		}
+	case tokenPunct:
+		switch tok.text {
+		case "(":
+			return p.parseParenthesized()
+		case "[":
+			return p.parseCollectionLiteral()
+		}
+	}
+
+	return nil, p.errorf("unexpected token")
+}
+
+func parseNumber(tok token) (expression, error) {
+	if strings.Contains(tok.text, ".") {
+		value, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid number %s at %d", ErrSyntax, tok.text, tok.pos)
+		}
+		return &literalExpr{value: value}, nil
+	}
+
+	value, err := strconv.ParseInt(tok.text, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid number %s at %d", ErrSyntax, tok.text, tok.pos)
+	}
+
+	return &literalExpr{value: value}, nil
+}
+
+func (p *parser) parseNew() (expression, error) {
+	typeName, err := p.expectIdent()
+	if err != nil {
+		return nil, err
+	}
+
+	args, err := p.parseArguments()
+	if err != nil {
+		return nil, err
+	}
+
+	return &newExpr{typeName: typeName, args: args}, nil
+}
+
+// parseCollectionLiteral parses the list literals, like [a, b], and the map literals, like [:] or ['a': b]
+func (p *parser) parseCollectionLiteral() (expression, error) {
+	p.next()
+	if p.acceptPunct(":") {
+		return &mapExpr{}, p.expectPunct("]")
+	}
+
+	list := &listExpr{}
+	mapLiteral := &mapExpr{}
+	for !p.acceptPunct("]") {
+		item, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.acceptPunct(":") {
+			value, errValue := p.parseExpression()
+			if errValue != nil {
+				return nil, errValue
+			}
+			mapLiteral.keys = append(mapLiteral.keys, item)
+			mapLiteral.values = append(mapLiteral.values, value)
+		} else {
+			list.items = append(list.items, item)
+		}
+
+		if !p.isPunct("]") {
+			err = p.expectPunct(",")
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(mapLiteral.keys) > 0 {
+		if len(list.items) > 0 {
+			return nil, p.errorf("mixed list and map literal")
+		}
+		return mapLiteral, nil
+	}
+
+	return list, nil
+}
@@ -0,0 +1,219 @@
+package painless
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func decode(t *testing.T, value string) map[string]interface{} {
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.UseNumber()
+
+	decoded := make(map[string]interface{})
+	require.Nil(t, decoder.Decode(&decoded))
+
+	return decoded
+}
+
+func run(t *testing.T, source string, ctx string, params string) map[string]interface{} {
+	script, err := Compile(strings.ReplaceAll(source, "\n", ""))
+	require.Nil(t, err)
+
+	variables := map[string]interface{}{
+		"ctx":    decode(t, ctx),
+		"params": decode(t, params),
+	}
+	require.Nil(t, script.Execute(variables))
+
+	encoded, err := json.Marshal(variables["ctx"])
+	require.Nil(t, err)
+
+	return decode(t, string(encoded))
+}
+
+func TestCompile(t *testing.T) {
+	t.Parallel()
+
+	t.Run("syntax errors", func(t *testing.T) {
+		t.Parallel()
+
+		sources := []string{
+			"if (ctx.op == 'create' { }",
+			"ctx._source.a = 'unterminated",
+			"ctx._source.a = ",
+			"1 = 2",
+			"for (int i = 0; i < 2 i++) {}",
+			"ctx._source.a = #",
+		}
+		for _, source := range sources {
+			_, err := Compile(source)
+			require.ErrorIs(t, err, ErrSyntax, source)
+		}
+	})
+
+	t.Run("optional semicolons", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := run(t, "ctx._source.a = 1 ctx._source.b = 2; ctx._source.c = 3", `{"_source":{}}`, `{}`)
+		require.Equal(t, decode(t, `{"_source":{"a":1,"b":2,"c":3}}`), ctx)
+	})
+}
+
+func TestScript_Execute(t *testing.T) {
+	t.Parallel()
+
+	t.Run("create operation with noop", func(t *testing.T) {
+		t.Parallel()
+
+		source := `if ('create' == ctx.op) {ctx.op = 'noop'} else {ctx._source.type = params.type}`
+		ctx := run(t, source, `{"op":"create","_source":{}}`, `{"type":"NFT"}`)
+		require.Equal(t, "noop", ctx["op"])
+
+		ctx = run(t, source, `{"op":"index","_source":{"type":"SFT"}}`, `{"type":"NFT"}`)
+		require.Equal(t, decode(t, `{"op":"index","_source":{"type":"NFT"}}`), ctx)
+	})
+
+	t.Run("numeric comparison and compound assignment", func(t *testing.T) {
+		t.Parallel()
+
+		source := `
+			if (ctx._source.feeNum > params.feeNum) {
+				ctx._source.feeNum -= params.feeNum;
+			}
+			if (ctx._source.gasUsed > params.gasRefunded) {
+				ctx._source.gasUsed -= params.gasRefunded;
+			}
+			ctx._source.count += 1`
+		ctx := run(t, source, `{"_source":{"feeNum":0.5,"gasUsed":100,"count":1}}`, `{"feeNum":0.25,"gasRefunded":40}`)
+		require.Equal(t, decode(t, `{"_source":{"feeNum":0.25,"gasUsed":60,"count":2}}`), ctx)
+	})
+
+	t.Run("big integers", func(t *testing.T) {
+		t.Parallel()
+
+		source := `
+			BigInteger feeFromSource;
+			if ((ctx._source.containsKey('hadRefund')) && (ctx._source.hadRefund)) {
+				feeFromSource = new BigInteger(ctx._source.fee);
+			} else {
+				feeFromSource = new BigInteger(ctx._source.initialPaidFee);
+				ctx._source.hadRefund = true;
+			}
+			BigInteger fee = new BigInteger(params.fee);
+			if (feeFromSource.compareTo(fee) > 0) {
+				ctx._source.fee = feeFromSource.subtract(fee).toString();
+			}`
+		ctx := run(t, source, `{"_source":{"initialPaidFee":"100000000000000000000","fee":"1"}}`, `{"fee":"1"}`)
+		require.Equal(t, decode(t, `{"_source":{"initialPaidFee":"100000000000000000000","fee":"99999999999999999999","hadRefund":true}}`), ctx)
+	})
+
+	t.Run("list loops with break and return", func(t *testing.T) {
+		t.Parallel()
+
+		source := `
+			int i;
+			for ( i = 0; i < params.uris.length; i++) {
+				boolean found = false;
+				int j;
+				for ( j = 0; j < ctx._source.data.uris.length; j++) {
+					if ( params.uris.get(i) == ctx._source.data.uris.get(j) ) {
+						found = true;
+						break
+					}
+				}
+				if ( !found ) {
+					ctx._source.data.uris.add(params.uris.get(i))
+				}
+			}
+			if (ctx._source.data.uris.length > 2) { return }
+			ctx._source.data.small = true`
+		ctx := run(t, source, `{"_source":{"data":{"uris":["a","b"]}}}`, `{"uris":["b","c"]}`)
+		require.Equal(t, decode(t, `{"_source":{"data":{"uris":["a","b","c"]}}}`), ctx)
+	})
+
+	t.Run("maps, lambdas and iterators", func(t *testing.T) {
+		t.Parallel()
+
+		source := `
+			if (!ctx._source.containsKey('roles')) {
+				ctx._source.roles = new HashMap();
+			}
+			if (!ctx._source.roles.containsKey(params.role)) {
+				ctx._source.roles.put(params.role, [params.address]);
+			}
+			ctx._source.roles.get('old').removeIf(p -> p.equals(params.address));
+			if (ctx._source.roles.get('old').length == 0) {
+				ctx._source.roles.remove('old')
+			}
+			params.properties.forEach((key, value) -> ctx._source.properties[key] = value);
+			Iterator itr = ctx._source.unDelegateInfo.iterator();
+			while (itr.hasNext()) {
+				HashMap unDelegate = itr.next();
+				for (int j = 0; j < params.withdrawIds.length; j++) {
+					if (unDelegate.id == params.withdrawIds[j]) {
+						itr.remove();
+					}
+				}
+			}`
+		ctx := run(t, source,
+			`{"_source":{"roles":{"old":["erd1"]},"properties":{},"unDelegateInfo":[{"id":"1"},{"id":"2"},{"id":"3"}]}}`,
+			`{"role":"new","address":"erd1","properties":{"canBurn":true},"withdrawIds":["1","3"]}`,
+		)
+		expected := `{"_source":{"roles":{"new":["erd1"]},"properties":{"canBurn":true},"unDelegateInfo":[{"id":"2"}]}}`
+		require.Equal(t, decode(t, expected), ctx)
+	})
+
+	t.Run("math methods and map views", func(t *testing.T) {
+		t.Parallel()
+
+		source := `
+			double sum = 0;
+			for (def key : params.registers.keySet()) {
+				ctx._source.registers.put(key, Math.max(ctx._source.registers.getOrDefault(key, 0), params.registers.get(key)));
+			}
+			for (def rank : ctx._source.registers.values()) {
+				sum += Math.pow(2, -rank);
+			}
+			ctx._source.sum = sum;
+			ctx._source.log = Math.round(100 * Math.log(Math.min(8, 16)));`
+		ctx := run(t, source, `{"_source":{"registers":{"1":3,"2":1}}}`, `{"registers":{"2":2,"5":1}}`)
+		require.Equal(t, decode(t, `{"_source":{"registers":{"1":3,"2":2,"5":1},"sum":0.875,"log":208}}`), ctx)
+	})
+
+	t.Run("replace source keeps the local variables", func(t *testing.T) {
+		t.Parallel()
+
+		source := `
+			def status = ctx._source.status;
+			ctx._source = params.tx;
+			if (!status.isEmpty()) {
+				ctx._source.status = status;
+			}`
+		ctx := run(t, source, `{"_source":{"status":"fail","fee":"1"}}`, `{"tx":{"status":"success","fee":"2"}}`)
+		require.Equal(t, decode(t, `{"_source":{"status":"fail","fee":"2"}}`), ctx)
+	})
+
+	t.Run("runtime errors", func(t *testing.T) {
+		t.Parallel()
+
+		sources := []string{
+			"ctx._source.missing.isEmpty()",
+			"ctx._source.a = unknown",
+			"ctx._source.a = 'a' - 1",
+			"ctx._source.list.get(5)",
+			"while (true) {}",
+			"ctx._source.a = Math.log('a')",
+			"ctx._source.a = Math.abs(1)",
+		}
+		for _, source := range sources {
+			script, err := Compile(source)
+			require.Nil(t, err, source)
+
+			err = script.Execute(map[string]interface{}{"ctx": decode(t, `{"_source":{"list":[]}}`)})
+			require.ErrorIs(t, err, ErrRuntime, source)
+		}
+	})
+}
@@ -0,0 +1,279 @@
+package painless
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// list holds the script lists, so that they are shared by reference like the Java lists
+type list struct {
+	items []interface{}
+}
+
+type iterator struct {
+	source  *list
+	next    int
+	current int
+}
+
+type lambda struct {
+	params  []string
+	body    statement
+	closure *scope
+}
+
+// importValue converts a decoded JSON value into a script value
+func importValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		converted := make(map[string]interface{}, len(typed))
+		for key, item := range typed {
+			converted[key] = importValue(item)
+		}
+		return converted
+	case []interface{}:
+		converted := &list{items: make([]interface{}, 0, len(typed))}
+		for _, item := range typed {
+			converted.items = append(converted.items, importValue(item))
+		}
+		return converted
+	case json.Number:
+		intValue, err := typed.Int64()
+		if err == nil {
+			return intValue
+		}
+		floatValue, err := typed.Float64()
+		if err == nil {
+			return floatValue
+		}
+		return typed.String()
+	case float64:
+		if typed == math.Trunc(typed) && math.Abs(typed) < 1<<53 {
+			return int64(typed)
+		}
+		return typed
+	case int:
+		return int64(typed)
+	case int64, bool, string, nil:
+		return typed
+	default:
+		return typed
+	}
+}
+
+// exportValue converts a script value into a value which can be encoded as JSON
+func exportValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		converted := make(map[string]interface{}, len(typed))
+		for key, item := range typed {
+			converted[key] = exportValue(item)
+		}
+		return converted
+	case *list:
+		converted := make([]interface{}, 0, len(typed.items))
+		for _, item := range typed.items {
+			converted = append(converted, exportValue(item))
+		}
+		return converted
+	case int64:
+		return json.Number(strconv.FormatInt(typed, 10))
+	case *big.Int:
+		return typed.String()
+	default:
+		return typed
+	}
+}
+
+func typeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "Map"
+	case *list:
+		return "List"
+	case *iterator:
+		return "Iterator"
+	case *lambda:
+		return "lambda"
+	case *big.Int:
+		return "BigInteger"
+	case int64:
+		return "long"
+	case float64:
+		return "double"
+	case string:
+		return "String"
+	case bool:
+		return "boolean"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func isNumber(value interface{}) bool {
+	switch value.(type) {
+	case int64, float64:
+		return true
+	default:
+		return false
+	}
+}
+
+func toFloat(value interface{}) float64 {
+	switch typed := value.(type) {
+	case int64:
+		return float64(typed)
+	case float64:
+		return typed
+	default:
+		return 0
+	}
+}
+
+func toInt(value interface{}) (int64, error) {
+	switch typed := value.(type) {
+	case int64:
+		return typed, nil
+	case float64:
+		return int64(typed), nil
+	default:
+		return 0, runtimeError("cannot use %s as a number", typeName(value))
+	}
+}
+
+// truthy returns the boolean value of a condition. The missing fields evaluate to false
+func truthy(value interface{}) (bool, error) {
+	switch typed := value.(type) {
+	case bool:
+		return typed, nil
+	case nil:
+		return false, nil
+	default:
+		return false, runtimeError("cannot use %s as a boolean", typeName(value))
+	}
+}
+
+func valuesEqual(left interface{}, right interface{}) bool {
+	if isNumber(left) && isNumber(right) {
+		return toFloat(left) == toFloat(right)
+	}
+
+	switch typedLeft := left.(type) {
+	case nil:
+		return right == nil
+	case map[string]interface{}:
+		typedRight, ok := right.(map[string]interface{})
+		if !ok || len(typedLeft) != len(typedRight) {
+			return false
+		}
+		for key, item := range typedLeft {
+			rightItem, found := typedRight[key]
+			if !found || !valuesEqual(item, rightItem) {
+				return false
+			}
+		}
+		return true
+	case *list:
+		typedRight, ok := right.(*list)
+		if !ok || len(typedLeft.items) != len(typedRight.items) {
+			return false
+		}
+		for idx := range typedLeft.items {
+			if !valuesEqual(typedLeft.items[idx], typedRight.items[idx]) {
+				return false
+			}
+		}
+		return true
+	case *big.Int:
+		typedRight, ok := right.(*big.Int)
+		return ok && typedLeft.Cmp(typedRight) == 0
+	case string, bool:
+		return left == right
+	default:
+		return left == right
+	}
+}
+
+func compareValues(left interface{}, right interface{}) (int, error) {
+	if isNumber(left) && isNumber(right) {
+		leftFloat, rightFloat := toFloat(left), toFloat(right)
+		switch {
+		case leftFloat < rightFloat:
+			return -1, nil
+		case leftFloat > rightFloat:
+			return 1, nil
+		default:
+			return 0, nil
+		}
+	}
+
+	leftString, okLeft := left.(string)
+	rightString, okRight := right.(string)
+	if okLeft && okRight {
+		switch {
+		case leftString < rightString:
+			return -1, nil
+		case leftString > rightString:
+			return 1, nil
+		default:
+			return 0, nil
+		}
+	}
+
+	leftBig, okLeft := left.(*big.Int)
+	rightBig, okRight := right.(*big.Int)
+	if okLeft && okRight {
+		return leftBig.Cmp(rightBig), nil
+	}
+
+	return 0, runtimeError("cannot compare %s with %s", typeName(left), typeName(right))
+}
+
+func toString(value interface{}) string {
+	switch typed := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return typed
+	case int64:
+		return strconv.FormatInt(typed, 10)
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(typed)
+	case *big.Int:
+		return typed.String()
+	default:
+		encoded, err := json.Marshal(exportValue(value))
+		if err != nil {
+			return typeName(value)
+		}
+		return string(encoded)
+	}
+}
+
+func toBigInt(value interface{}) (*big.Int, error) {
+	switch typed := value.(type) {
+	case *big.Int:
+		return typed, nil
+	case int64:
+		return big.NewInt(typed), nil
+	case string:
+		bigValue, ok := big.NewInt(0).SetString(typed, 10)
+		if !ok {
+			return nil, runtimeError("invalid BigInteger value %q", typed)
+		}
+		return bigValue, nil
+	default:
+		return nil, runtimeError("cannot use %s as a BigInteger", typeName(value))
+	}
+}
+
+func runtimeError(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrRuntime, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,510 @@
+package memory
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const defaultSearchSize = 10
+
+type hit struct {
+	index  string
+	id     string
+	source objectsMap
+	sort   []interface{}
+}
+
+// matchingIDs returns the sorted ids of the documents which match the provided query. A missing query matches all
+// the documents
+func matchingIDs(documents map[string]objectsMap, query interface{}) ([]string, error) {
+	ids := make([]string, 0)
+	for _, id := range sortedIDs(documents) {
+		matches, err := matchQuery(query, id, documents[id])
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
+
+// searchHits returns all the documents which match the query of the request, sorted by the sort criteria of the
+// request or by id
+func searchHits(indexName string, documents map[string]objectsMap, request objectsMap) ([]*hit, error) {
+	ids, err := matchingIDs(documents, request["query"])
+	if err != nil {
+		return nil, err
+	}
+
+	criteria, err := parseSort(request["sort"])
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]*hit, 0, len(ids))
+	for _, id := range ids {
+		h := &hit{
+			index:  indexName,
+			id:     id,
+			source: documents[id],
+		}
+		for _, criterion := range criteria {
+			h.sort = append(h.sort, sortValue(id, documents[id], criterion.field))
+		}
+		hits = append(hits, h)
+	}
+
+	if len(criteria) > 0 {
+		sortHits(hits, criteria)
+	}
+
+	return hits, nil
+}
+
+// paginate applies the search_after, from and size parameters of the request
+func paginate(hits []*hit, request objectsMap) ([]*hit, error) {
+	searchAfter, hasSearchAfter := request["search_after"].([]interface{})
+	if hasSearchAfter {
+		criteria, err := parseSort(request["sort"])
+		if err != nil {
+			return nil, err
+		}
+
+		start := len(hits)
+		for idx, h := range hits {
+			if compareSortValues(h.sort, searchAfter, criteria) > 0 {
+				start = idx
+				break
+			}
+		}
+		hits = hits[start:]
+	}
+
+	from, err := intParameter(request, "from", 0)
+	if err != nil {
+		return nil, err
+	}
+	size, err := intParameter(request, "size", defaultSearchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if from > len(hits) {
+		from = len(hits)
+	}
+	end := from + size
+	if end > len(hits) {
+		end = len(hits)
+	}
+
+	return hits[from:end], nil
+}
+
+func intParameter(request objectsMap, name string, defaultValue int) (int, error) {
+	value, found := request[name]
+	if !found {
+		return defaultValue, nil
+	}
+
+	number, ok := toNumber(value)
+	if !ok {
+		return 0, fmt.Errorf("%w: invalid %s parameter", ErrUnsupportedQuery, name)
+	}
+
+	return int(number), nil
+}
+
+func withoutSource(hits []*hit) []*hit {
+	result := make([]*hit, 0, len(hits))
+	for _, h := range hits {
+		result = append(result, &hit{index: h.index, id: h.id, sort: h.sort})
+	}
+
+	return result
+}
+
+func searchResponse(hits []*hit, total int) objectsMap {
+	hitsResponse := make([]objectsMap, 0, len(hits))
+	for _, h := range hits {
+		hitResponse := objectsMap{
+			"_index": h.index,
+			"_id":    h.id,
+		}
+		if h.source != nil {
+			hitResponse["_source"] = h.source
+		}
+		if len(h.sort) > 0 {
+			hitResponse["sort"] = h.sort
+		}
+		hitsResponse = append(hitsResponse, hitResponse)
+	}
+
+	return objectsMap{
+		"hits": objectsMap{
+			"total": objectsMap{
+				"value":    total,
+				"relation": "eq",
+			},
+			"hits": hitsResponse,
+		},
+	}
+}
+
+func matchQuery(query interface{}, id string, source objectsMap) (bool, error) {
+	if query == nil {
+		return true, nil
+	}
+
+	queryObject, ok := query.(objectsMap)
+	if !ok || len(queryObject) != 1 {
+		return false, fmt.Errorf("%w: %v", ErrUnsupportedQuery, query)
+	}
+
+	for queryType, body := range queryObject {
+		switch queryType {
+		case "match_all":
+			return true, nil
+		case "ids":
+			return matchIDs(body, id)
+		case "term", "match":
+			return matchTerm(body, id, source)
+		case "terms":
+			return matchTerms(body, id, source)
+		case "exists":
+			return matchExists(body, id, source)
+		case "range":
+			return matchRange(body, id, source)
+		case "bool":
+			return matchBool(body, id, source)
+		}
+	}
+
+	return false, fmt.Errorf("%w: %v", ErrUnsupportedQuery, query)
+}
+
+func matchIDs(body interface{}, id string) (bool, error) {
+	bodyObject, _ := body.(objectsMap)
+	values, ok := bodyObject["values"].([]interface{})
+	if !ok {
+		return false, fmt.Errorf("%w: ids query without values", ErrUnsupportedQuery)
+	}
+
+	for _, value := range values {
+		if fmt.Sprint(value) == id {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// matchTerm handles the term and match queries, which are both evaluated as exact matches, since the indexer
+// queries only keyword and numeric fields
+func matchTerm(body interface{}, id string, source objectsMap) (bool, error) {
+	field, value, err := singleField(body)
+	if err != nil {
+		return false, err
+	}
+
+	valueObject, isObject := value.(objectsMap)
+	if isObject {
+		value, isObject = valueObject["value"]
+		if !isObject {
+			value = valueObject["query"]
+		}
+	}
+
+	for _, fieldValue := range fieldValues(id, source, field) {
+		if valuesEqual(fieldValue, value) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func matchTerms(body interface{}, id string, source objectsMap) (bool, error) {
+	field, value, err := singleField(body)
+	if err != nil {
+		return false, err
+	}
+
+	values, ok := value.([]interface{})
+	if !ok {
+		return false, fmt.Errorf("%w: terms query without values", ErrUnsupportedQuery)
+	}
+
+	for _, fieldValue := range fieldValues(id, source, field) {
+		for _, expected := range values {
+			if valuesEqual(fieldValue, expected) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func matchExists(body interface{}, id string, source objectsMap) (bool, error) {
+	bodyObject, _ := body.(objectsMap)
+	field, ok := bodyObject["field"].(string)
+	if !ok {
+		return false, fmt.Errorf("%w: exists query without field", ErrUnsupportedQuery)
+	}
+
+	return len(fieldValues(id, source, field)) > 0, nil
+}
+
+func matchRange(body interface{}, id string, source objectsMap) (bool, error) {
+	field, value, err := singleField(body)
+	if err != nil {
+		return false, err
+	}
+
+	bounds, ok := value.(objectsMap)
+	if !ok {
+		return false, fmt.Errorf("%w: invalid range query", ErrUnsupportedQuery)
+	}
+
+	for _, fieldValue := range fieldValues(id, source, field) {
+		if inRange(fieldValue, bounds) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func inRange(value interface{}, bounds objectsMap) bool {
+	for operator, bound := range bounds {
+		comparison := compareValues(value, bound)
+		switch operator {
+		case "gt":
+			if comparison <= 0 {
+				return false
+			}
+		case "gte":
+			if comparison < 0 {
+				return false
+			}
+		case "lt":
+			if comparison >= 0 {
+				return false
+			}
+		case "lte":
+			if comparison > 0 {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func matchBool(body interface{}, id string, source objectsMap) (bool, error) {
+	bodyObject, ok := body.(objectsMap)
+	if !ok {
+		return false, fmt.Errorf("%w: invalid bool query", ErrUnsupportedQuery)
+	}
+
+	for _, clause := range []string{"must", "filter"} {
+		for _, query := range clauseQueries(bodyObject[clause]) {
+			matches, err := matchQuery(query, id, source)
+			if err != nil || !matches {
+				return false, err
+			}
+		}
+	}
+
+	for _, query := range clauseQueries(bodyObject["must_not"]) {
+		matches, err := matchQuery(query, id, source)
+		if err != nil || matches {
+			return false, err
+		}
+	}
+
+	shouldQueries := clauseQueries(bodyObject["should"])
+	if len(shouldQueries) == 0 {
+		return true, nil
+	}
+
+	minimumShouldMatch := 1
+	_, hasMust := bodyObject["must"]
+	_, hasFilter := bodyObject["filter"]
+	if hasMust || hasFilter {
+		minimumShouldMatch = 0
+	}
+	minimum, found := toNumber(bodyObject["minimum_should_match"])
+	if found {
+		minimumShouldMatch = int(minimum)
+	}
+
+	matched := 0
+	for _, query := range shouldQueries {
+		matches, err := matchQuery(query, id, source)
+		if err != nil {
+			return false, err
+		}
+		if matches {
+			matched++
+		}
+	}
+
+	return matched >= minimumShouldMatch, nil
+}
+
+func clauseQueries(clause interface{}) []interface{} {
+	switch typed := clause.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		return typed
+	default:
+		return []interface{}{typed}
+	}
+}
+
+func singleField(body interface{}) (string, interface{}, error) {
+	bodyObject, ok := body.(objectsMap)
+	if !ok || len(bodyObject) != 1 {
+		return "", nil, fmt.Errorf("%w: expected a single field in %v", ErrUnsupportedQuery, body)
+	}
+
+	for field, value := range bodyObject {
+		return field, value, nil
+	}
+
+	return "", nil, ErrUnsupportedQuery
+}
+
+// fieldValues returns the values of a dotted field path. The arrays are flattened, like Elasticsearch does
+func fieldValues(id string, source objectsMap, field string) []interface{} {
+	if field == "_id" {
+		return []interface{}{id}
+	}
+
+	values := []interface{}{source}
+	for _, part := range strings.Split(field, ".") {
+		next := make([]interface{}, 0)
+		for _, value := range values {
+			object, ok := value.(objectsMap)
+			if !ok {
+				continue
+			}
+			next = appendFlattened(next, object[part])
+		}
+		values = next
+	}
+
+	return values
+}
+
+func appendFlattened(values []interface{}, value interface{}) []interface{} {
+	switch typed := value.(type) {
+	case nil:
+		return values
+	case []interface{}:
+		for _, item := range typed {
+			values = appendFlattened(values, item)
+		}
+		return values
+	default:
+		return append(values, value)
+	}
+}
+
+func toNumber(value interface{}) (float64, bool) {
+	switch typed := value.(type) {
+	case json.Number:
+		number, err := typed.Float64()
+		return number, err == nil
+	case float64:
+		return typed, true
+	case int:
+		return float64(typed), true
+	case int64:
+		return float64(typed), true
+	case uint64:
+		return float64(typed), true
+	case string:
+		number, err := strconv.ParseFloat(typed, 64)
+		return number, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func isNumeric(value interface{}) bool {
+	switch value.(type) {
+	case json.Number, float64, int, int64, uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+// valuesEqual compares a stored value with a query value. A number matches its string representation, since the
+// queries may send the numbers as strings
+func valuesEqual(stored interface{}, expected interface{}) bool {
+	if isNumeric(stored) || isNumeric(expected) {
+		storedNumber, okStored := exactNumber(stored)
+		expectedNumber, okExpected := exactNumber(expected)
+		return okStored && okExpected && storedNumber.Cmp(expectedNumber) == 0
+	}
+
+	return fmt.Sprint(stored) == fmt.Sprint(expected)
+}
+
+func exactNumber(value interface{}) (*big.Float, bool) {
+	var text string
+	switch typed := value.(type) {
+	case json.Number:
+		text = typed.String()
+	case string:
+		text = typed
+	case float64:
+		return big.NewFloat(typed), true
+	case int:
+		return big.NewFloat(float64(typed)), true
+	case int64:
+		return new(big.Float).SetInt64(typed), true
+	case uint64:
+		return new(big.Float).SetUint64(typed), true
+	default:
+		return nil, false
+	}
+
+	number, ok := new(big.Float).SetPrec(256).SetString(text)
+	return number, ok
+}
+
+// compareValues orders the numbers by value and the other values by their string representation. The missing values
+// are ordered last
+func compareValues(left interface{}, right interface{}) int {
+	if left == nil || right == nil {
+		switch {
+		case left == nil && right == nil:
+			return 0
+		case left == nil:
+			return 1
+		default:
+			return -1
+		}
+	}
+
+	if isNumeric(left) || isNumeric(right) {
+		leftNumber, okLeft := exactNumber(left)
+		rightNumber, okRight := exactNumber(right)
+		if okLeft && okRight {
+			return leftNumber.Cmp(rightNumber)
+		}
+	}
+
+	return strings.Compare(fmt.Sprint(left), fmt.Sprint(right))
+}
@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+)
+
+type sortCriterion struct {
+	field      string
+	descending bool
+}
+
+// parseSort accepts the "field", {"field": "desc"} and {"field": {"order": "desc"}} sort formats
+func parseSort(value interface{}) ([]sortCriterion, error) {
+	criteria := make([]sortCriterion, 0)
+	for _, item := range clauseQueries(value) {
+		switch typed := item.(type) {
+		case string:
+			criteria = append(criteria, sortCriterion{field: typed})
+		case objectsMap:
+			field, options, err := singleField(typed)
+			if err != nil {
+				return nil, err
+			}
+
+			order, ok := options.(string)
+			if !ok {
+				optionsObject, _ := options.(objectsMap)
+				order, _ = optionsObject["order"].(string)
+			}
+			criteria = append(criteria, sortCriterion{field: field, descending: order == "desc"})
+		default:
+			return nil, fmt.Errorf("%w: invalid sort %v", ErrUnsupportedQuery, value)
+		}
+	}
+
+	return criteria, nil
+}
+
+func sortValue(id string, source objectsMap, field string) interface{} {
+	values := fieldValues(id, source, field)
+	if len(values) == 0 {
+		return nil
+	}
+
+	return values[0]
+}
+
+func sortHits(hits []*hit, criteria []sortCriterion) {
+	sort.SliceStable(hits, func(i, j int) bool {
+		return compareSortValues(hits[i].sort, hits[j].sort, criteria) < 0
+	})
+}
+
+func compareSortValues(left []interface{}, right []interface{}, criteria []sortCriterion) int {
+	for idx, criterion := range criteria {
+		if idx >= len(left) || idx >= len(right) {
+			return 0
+		}
+
+		comparison := compareValues(left[idx], right[idx])
+		if comparison == 0 {
+			continue
+		}
+		if criterion.descending && left[idx] != nil && right[idx] != nil {
+			return -comparison
+		}
+
+		return comparison
+	}
+
+	return 0
+}
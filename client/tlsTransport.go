@@ -0,0 +1,94 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+var (
+	errInvalidCACert           = errors.New("no valid certificate found in the CA bundle")
+	errIncompleteClientCert    = errors.New("both the client certificate and the client key have to be provided")
+	errUnexpectedTransportType = errors.New("unexpected type of the default http transport")
+)
+
+// TLSConfig holds the TLS settings of the connection to the Elasticsearch cluster
+type TLSConfig struct {
+	CACertFile         string
+	ClientCertFile     string
+	ClientKeyFile      string
+	InsecureSkipVerify bool
+}
+
+// NewHTTPTransport will create a new http transport that uses the provided TLS settings. The CA bundle is added
+// to the system certificates, while the client certificate and key are used for mutual TLS
+func NewHTTPTransport(cfg TLSConfig) (*http.Transport, error) {
+	defaultTransport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, errUnexpectedTransportType
+	}
+
+	httpTransport := defaultTransport.Clone()
+	tlsConfig, err := createTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	httpTransport.TLSClientConfig = tlsConfig
+
+	return httpTransport, nil
+}
+
+func createTLSConfig(cfg TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// nolint
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+	if cfg.InsecureSkipVerify {
+		log.Warn("the certificate of the Elasticsearch cluster will not be verified, use this only for testing")
+	}
+
+	if cfg.CACertFile != "" {
+		rootCAs, err := loadCACertPool(cfg.CACertFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	hasClientCert := cfg.ClientCertFile != ""
+	hasClientKey := cfg.ClientKeyFile != ""
+	if hasClientCert != hasClientKey {
+		return nil, errIncompleteClientCert
+	}
+	if hasClientCert {
+		clientCert, err := tls.LoadX509KeyPair(cfg.ClientCertFile, cfg.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w while loading the client certificate", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+
+	return tlsConfig, nil
+}
+
+func loadCACertPool(caCertFile string) (*x509.CertPool, error) {
+	caCert, err := os.ReadFile(caCertFile)
+	if err != nil {
+		return nil, fmt.Errorf("%w while reading the CA bundle", err)
+	}
+
+	certPool, err := x509.SystemCertPool()
+	if err != nil || certPool == nil {
+		certPool = x509.NewCertPool()
+	}
+
+	if !certPool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("%w: %s", errInvalidCACert, caCertFile)
+	}
+
+	return certPool, nil
+}
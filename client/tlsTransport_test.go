@@ -0,0 +1,128 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writePEMFile(t *testing.T, dir string, name string, blockType string, bytes []byte) string {
+	filePath := filepath.Join(dir, name)
+	err := os.WriteFile(filePath, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: bytes}), 0600)
+	require.Nil(t, err)
+
+	return filePath
+}
+
+func generateClientCertificate(t *testing.T, dir string) (string, string, *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "indexer"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.Nil(t, err)
+	cert, err := x509.ParseCertificate(certBytes)
+	require.Nil(t, err)
+
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	require.Nil(t, err)
+
+	certFile := writePEMFile(t, dir, "client.crt", "CERTIFICATE", certBytes)
+	keyFile := writePEMFile(t, dir, "client.key", "EC PRIVATE KEY", keyBytes)
+
+	return certFile, keyFile, cert
+}
+
+func doTLSRequest(t *testing.T, cfg TLSConfig, url string) error {
+	httpTransport, err := NewHTTPTransport(cfg)
+	require.Nil(t, err)
+
+	resp, err := (&http.Client{Transport: httpTransport}).Get(url)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+func TestNewHTTPTransport_InvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	_, err := NewHTTPTransport(TLSConfig{CACertFile: filepath.Join(dir, "missing.crt")})
+	require.NotNil(t, err)
+
+	invalidCAFile := filepath.Join(dir, "invalid.crt")
+	require.Nil(t, os.WriteFile(invalidCAFile, []byte("not a certificate"), 0600))
+	_, err = NewHTTPTransport(TLSConfig{CACertFile: invalidCAFile})
+	require.ErrorIs(t, err, errInvalidCACert)
+
+	_, err = NewHTTPTransport(TLSConfig{ClientCertFile: "client.crt"})
+	require.Equal(t, errIncompleteClientCert, err)
+}
+
+func TestNewHTTPTransport_ServerCertificateVerification(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	err := doTLSRequest(t, TLSConfig{}, ts.URL)
+	require.NotNil(t, err)
+
+	err = doTLSRequest(t, TLSConfig{InsecureSkipVerify: true}, ts.URL)
+	require.Nil(t, err)
+
+	caFile := writePEMFile(t, t.TempDir(), "ca.crt", "CERTIFICATE", ts.Certificate().Raw)
+	err = doTLSRequest(t, TLSConfig{CACertFile: caFile}, ts.URL)
+	require.Nil(t, err)
+}
+
+func TestNewHTTPTransport_MutualTLS(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	certFile, keyFile, clientCert := generateClientCertificate(t, dir)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	ts.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	ts.StartTLS()
+	defer ts.Close()
+
+	caFile := writePEMFile(t, dir, "ca.crt", "CERTIFICATE", ts.Certificate().Raw)
+
+	err := doTLSRequest(t, TLSConfig{CACertFile: caFile}, ts.URL)
+	require.NotNil(t, err)
+
+	err = doTLSRequest(t, TLSConfig{CACertFile: caFile, ClientCertFile: certFile, ClientKeyFile: keyFile}, ts.URL)
+	require.Nil(t, err)
+}
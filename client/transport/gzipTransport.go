@@ -0,0 +1,111 @@
+package transport
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	bulkPathSuffix      = "/_bulk"
+	contentEncodingGzip = "gzip"
+)
+
+type compressedSizeKeyType struct{}
+
+// compressedSizeKey is the context key under which the metrics transport expects the size of the compressed body
+var compressedSizeKey = compressedSizeKeyType{}
+
+type gzipTransport struct {
+	transport   http.RoundTripper
+	writersPool sync.Pool
+}
+
+// NewGzipTransport will create a new instance of gzipTransport, a wrapper over the provided round tripper that
+// compresses the bodies of the bulk requests
+func NewGzipTransport(roundTripper http.RoundTripper) (*gzipTransport, error) {
+	if roundTripper == nil {
+		return nil, errNilRoundTripper
+	}
+
+	return &gzipTransport{
+		transport: roundTripper,
+		writersPool: sync.Pool{
+			New: func() interface{} {
+				return gzip.NewWriter(io.Discard)
+			},
+		},
+	}, nil
+}
+
+// RoundTrip implements the http.RoundTripper interface, the body of a bulk request is replaced with its gzip
+// compressed version before being sent by the underlying transport
+func (gt *gzipTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req == nil {
+		return nil, errNilRequest
+	}
+	if !shouldCompress(req) {
+		return gt.transport.RoundTrip(req)
+	}
+
+	compressedBody, err := gt.compress(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// a round tripper should not modify the provided request, so the compressed body is set on a copy
+	compressedReq := req.Clone(req.Context())
+	compressedReq.Body = io.NopCloser(bytes.NewReader(compressedBody))
+	compressedReq.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(compressedBody)), nil
+	}
+	compressedReq.ContentLength = int64(len(compressedBody))
+	compressedReq.Header.Set("Content-Encoding", contentEncodingGzip)
+	compressedReq.Header.Del("Content-Length")
+
+	setCompressedSize(req.Context(), len(compressedBody))
+
+	return gt.transport.RoundTrip(compressedReq)
+}
+
+func (gt *gzipTransport) compress(body io.ReadCloser) ([]byte, error) {
+	defer func() {
+		_ = body.Close()
+	}()
+
+	buff := &bytes.Buffer{}
+	writer := gt.writersPool.Get().(*gzip.Writer)
+	defer gt.writersPool.Put(writer)
+
+	writer.Reset(buff)
+	_, err := io.Copy(writer, body)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buff.Bytes(), nil
+}
+
+func shouldCompress(req *http.Request) bool {
+	if req.Body == nil || req.Body == http.NoBody || req.Header.Get("Content-Encoding") != "" {
+		return false
+	}
+
+	return req.URL != nil && strings.HasSuffix(req.URL.Path, bulkPathSuffix)
+}
+
+func setCompressedSize(ctx context.Context, size int) {
+	compressedSize, ok := ctx.Value(compressedSizeKey).(*int64)
+	if ok {
+		*compressedSize = int64(size)
+	}
+}
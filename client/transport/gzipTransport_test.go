@@ -0,0 +1,109 @@
+package transport
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/multiversx/mx-chain-es-indexer-go/core/request"
+	"github.com/multiversx/mx-chain-es-indexer-go/metrics"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func createBulkBody() []byte {
+	return bytes.Repeat([]byte(`{ "index" : { "_id" : "hash" } }`+"\n"+`{"data":"7472616e73666572"}`+"\n"), 100)
+}
+
+func TestNewGzipTransport(t *testing.T) {
+	t.Parallel()
+
+	transportHandler, err := NewGzipTransport(nil)
+	require.Nil(t, transportHandler)
+	require.Equal(t, errNilRoundTripper, err)
+
+	transportHandler, err = NewGzipTransport(http.DefaultTransport)
+	require.Nil(t, err)
+	require.NotNil(t, transportHandler)
+}
+
+func TestGzipTransport_RoundTripShouldCompressBulkRequests(t *testing.T) {
+	t.Parallel()
+
+	body := createBulkBody()
+	var sentReq *http.Request
+	var sentBody []byte
+	transportHandler, _ := NewGzipTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		sentReq = req
+		sentBody, _ = io.ReadAll(req.Body)
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}))
+
+	req, _ := http.NewRequest(http.MethodPost, "http://localhost:9200/transactions/_bulk", bytes.NewBuffer(body))
+	_, err := transportHandler.RoundTrip(req)
+	require.Nil(t, err)
+
+	require.Equal(t, "gzip", sentReq.Header.Get("Content-Encoding"))
+	require.Equal(t, int64(len(sentBody)), sentReq.ContentLength)
+	require.True(t, len(sentBody) < len(body))
+	require.Empty(t, req.Header.Get("Content-Encoding"))
+
+	reader, err := gzip.NewReader(bytes.NewReader(sentBody))
+	require.Nil(t, err)
+	decompressed, err := io.ReadAll(reader)
+	require.Nil(t, err)
+	require.Equal(t, body, decompressed)
+}
+
+func TestGzipTransport_RoundTripShouldNotCompressOtherRequests(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"query":{"match_all":{}}}`)
+	var sentBody []byte
+	var sentEncoding string
+	transportHandler, _ := NewGzipTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		sentEncoding = req.Header.Get("Content-Encoding")
+		sentBody, _ = io.ReadAll(req.Body)
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}))
+
+	req, _ := http.NewRequest(http.MethodPost, "http://localhost:9200/tokens/_search", bytes.NewBuffer(body))
+	_, err := transportHandler.RoundTrip(req)
+	require.Nil(t, err)
+	require.Empty(t, sentEncoding)
+	require.Equal(t, body, sentBody)
+}
+
+func TestMetricsTransport_RoundTripWithGzipShouldRecordRawAndCompressedSizes(t *testing.T) {
+	t.Parallel()
+
+	body := createBulkBody()
+	var sentBody []byte
+	gzipHandler, _ := NewGzipTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		sentBody, _ = io.ReadAll(req.Body)
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}))
+
+	metricsHandler := metrics.NewStatusMetrics()
+	transportHandler, _ := NewMetricsTransport(metricsHandler, gzipHandler)
+
+	testTopic := "req_bulk_0"
+	contextWithValue := context.WithValue(context.Background(), request.ContextKey, testTopic)
+	req, _ := http.NewRequestWithContext(contextWithValue, http.MethodPost, "http://localhost:9200/_bulk", bytes.NewBuffer(body))
+
+	_, err := transportHandler.RoundTrip(req)
+	require.Nil(t, err)
+
+	metricsMap := metricsHandler.GetMetrics()
+	require.Equal(t, uint64(len(body)), metricsMap[testTopic].TotalData)
+	require.Equal(t, uint64(len(sentBody)), metricsMap[testTopic].TotalCompressedData)
+	require.True(t, metricsMap[testTopic].TotalCompressedData < metricsMap[testTopic].TotalData)
+}
@@ -0,0 +1,113 @@
+package transport
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// idempotentEndpoints holds the last path segments of the requests that only read data, so they can be sent again
+// even if they are sent with the POST method
+var idempotentEndpoints = map[string]struct{}{
+	"_search":  {},
+	"_mget":    {},
+	"_count":   {},
+	"_scroll":  {},
+	"_refresh": {},
+}
+
+var retryableStatuses = map[int]struct{}{
+	http.StatusBadGateway:         {},
+	http.StatusServiceUnavailable: {},
+	http.StatusGatewayTimeout:     {},
+}
+
+// retryableStatusError is returned instead of a 502, 503 or 504 response of an idempotent request. It implements the
+// net.Error interface, so the Elasticsearch client takes the node out of the pool and retries the request on the next node
+type retryableStatusError struct {
+	statusCode int
+}
+
+// Error returns the error message
+func (e *retryableStatusError) Error() string {
+	return fmt.Sprintf("elastic node answered with status code %d", e.statusCode)
+}
+
+// Timeout returns false, the request is retried regardless of the timeout settings of the Elasticsearch client
+func (e *retryableStatusError) Timeout() bool {
+	return false
+}
+
+// Temporary returns true, the node is expected to recover
+func (e *retryableStatusError) Temporary() bool {
+	return true
+}
+
+type idempotentRetryTransport struct {
+	transport http.RoundTripper
+}
+
+// NewIdempotentRetryTransport will create a new instance of idempotentRetryTransport, a wrapper over the provided
+// round tripper that makes the Elasticsearch client retry on the next node only the idempotent requests answered
+// with 502, 503 or 504. The other requests, such as the bulk ones, return the response to the caller
+func NewIdempotentRetryTransport(roundTripper http.RoundTripper) (*idempotentRetryTransport, error) {
+	if roundTripper == nil {
+		return nil, errNilRoundTripper
+	}
+
+	return &idempotentRetryTransport{
+		transport: roundTripper,
+	}, nil
+}
+
+// RoundTrip implements the http.RoundTripper interface, a 502, 503 or 504 response of an idempotent request is
+// replaced with a network error, so the request is retried
+func (irt *idempotentRetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req == nil {
+		return nil, errNilRequest
+	}
+
+	resp, err := irt.transport.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	_, isRetryableStatus := retryableStatuses[resp.StatusCode]
+	if !isRetryableStatus || !isIdempotent(req) {
+		return resp, nil
+	}
+
+	if resp.Body != nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}
+
+	return nil, &retryableStatusError{
+		statusCode: resp.StatusCode,
+	}
+}
+
+func isIdempotent(req *http.Request) bool {
+	if req.URL == nil {
+		return false
+	}
+
+	// a bulk may have been partially applied, e.g. sending its scripted upserts again would count them twice
+	if strings.HasSuffix(req.URL.Path, bulkPathSuffix) {
+		return false
+	}
+
+	lastSegment := req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+	_, isIdempotentEndpoint := idempotentEndpoints[lastSegment]
+	if isIdempotentEndpoint {
+		return true
+	}
+
+	// the settings, mappings, templates, aliases and policies are created or replaced with PUT
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut:
+		return true
+	default:
+		return false
+	}
+}
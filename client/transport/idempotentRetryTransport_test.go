@@ -0,0 +1,79 @@
+package transport
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewIdempotentRetryTransport(t *testing.T) {
+	t.Parallel()
+
+	transportHandler, err := NewIdempotentRetryTransport(nil)
+	require.Nil(t, transportHandler)
+	require.Equal(t, errNilRoundTripper, err)
+
+	transportHandler, err = NewIdempotentRetryTransport(http.DefaultTransport)
+	require.Nil(t, err)
+	require.NotNil(t, transportHandler)
+}
+
+func TestIdempotentRetryTransport_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	statusCode := http.StatusServiceUnavailable
+	transportHandler, _ := NewIdempotentRetryTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: statusCode,
+			Body:       io.NopCloser(bytes.NewBufferString("unavailable")),
+		}, nil
+	}))
+
+	_, err := transportHandler.RoundTrip(nil)
+	require.Equal(t, errNilRequest, err)
+
+	idempotentRequests := []*http.Request{
+		createRequest(t, http.MethodGet, "http://localhost:9200/accounts/_settings"),
+		createRequest(t, http.MethodHead, "http://localhost:9200/_template/accounts"),
+		createRequest(t, http.MethodPut, "http://localhost:9200/_opendistro/_ism/policies/policy"),
+		createRequest(t, http.MethodPost, "http://localhost:9200/accounts/_search"),
+		createRequest(t, http.MethodPost, "http://localhost:9200/_mget"),
+	}
+	for _, req := range idempotentRequests {
+		resp, errRoundTrip := transportHandler.RoundTrip(req)
+		require.Nil(t, resp, req.URL.Path)
+
+		// a network error makes the Elasticsearch client retry the request on the next node
+		netErr, ok := errRoundTrip.(net.Error)
+		require.True(t, ok, req.URL.Path)
+		require.False(t, netErr.Timeout())
+	}
+
+	nonIdempotentRequests := []*http.Request{
+		createRequest(t, http.MethodPost, "http://localhost:9200/_bulk"),
+		createRequest(t, http.MethodPut, "http://localhost:9200/accounts/_bulk"),
+		createRequest(t, http.MethodPost, "http://localhost:9200/accounts/_update_by_query"),
+		createRequest(t, http.MethodPost, "http://localhost:9200/accounts/_delete_by_query"),
+	}
+	for _, req := range nonIdempotentRequests {
+		resp, errRoundTrip := transportHandler.RoundTrip(req)
+		require.Nil(t, errRoundTrip, req.URL.Path)
+		require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	}
+
+	statusCode = http.StatusInternalServerError
+	resp, err := transportHandler.RoundTrip(createRequest(t, http.MethodGet, "http://localhost:9200/accounts/_settings"))
+	require.Nil(t, err)
+	require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+}
+
+func createRequest(t *testing.T, method string, url string) *http.Request {
+	req, err := http.NewRequest(method, url, nil)
+	require.Nil(t, err)
+
+	return req
+}
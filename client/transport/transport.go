@@ -1,6 +1,7 @@
 package transport
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -12,22 +13,28 @@ import (
 	"github.com/multiversx/mx-chain-es-indexer-go/metrics"
 )
 
-var errNilRequest = errors.New("nil request")
+var (
+	errNilRequest      = errors.New("nil request")
+	errNilRoundTripper = errors.New("nil round tripper")
+)
 
 type metricsTransport struct {
 	statusMetrics core.StatusMetricsHandler
 	transport     http.RoundTripper
 }
 
-// NewMetricsTransport will create a new instance of metricsTransport
-func NewMetricsTransport(statusMetrics core.StatusMetricsHandler) (*metricsTransport, error) {
+// NewMetricsTransport will create a new instance of metricsTransport, a wrapper over the provided round tripper
+func NewMetricsTransport(statusMetrics core.StatusMetricsHandler, roundTripper http.RoundTripper) (*metricsTransport, error) {
 	if check.IfNil(statusMetrics) {
 		return nil, core.ErrNilMetricsHandler
 	}
+	if roundTripper == nil {
+		return nil, errNilRoundTripper
+	}
 
 	return &metricsTransport{
 		statusMetrics: statusMetrics,
-		transport:     http.DefaultTransport,
+		transport:     roundTripper,
 	}, nil
 }
 
@@ -41,6 +48,10 @@ func (m *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error)
 	startTime := time.Now()
 	size := req.ContentLength
 
+	// the size of the body, as sent on the wire, is set by the gzip transport if the request is compressed
+	compressedSize := int64(0)
+	req = req.WithContext(context.WithValue(req.Context(), compressedSizeKey, &compressedSize))
+
 	var statusCode int
 	resp, err := m.transport.RoundTrip(req)
 	if err == nil {
@@ -56,11 +67,12 @@ func (m *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error)
 	topic := fmt.Sprintf("%s", valueFromCtx)
 
 	m.statusMetrics.AddIndexingData(metrics.ArgsAddIndexingData{
-		StatusCode: statusCode,
-		GotError:   err != nil,
-		MessageLen: uint64(size),
-		Topic:      topic,
-		Duration:   duration,
+		StatusCode:           statusCode,
+		GotError:             err != nil,
+		MessageLen:           uint64(size),
+		CompressedMessageLen: uint64(compressedSize),
+		Topic:                topic,
+		Duration:             duration,
 	})
 
 	return resp, err
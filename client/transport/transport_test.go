@@ -17,19 +17,23 @@ import (
 func TestNewMetricsTransport(t *testing.T) {
 	t.Parallel()
 
-	transportHandler, err := NewMetricsTransport(nil)
+	transportHandler, err := NewMetricsTransport(nil, http.DefaultTransport)
 	require.Nil(t, transportHandler)
 	require.Equal(t, core.ErrNilMetricsHandler, err)
 
 	metricsHandler := metrics.NewStatusMetrics()
-	transportHandler, err = NewMetricsTransport(metricsHandler)
+	transportHandler, err = NewMetricsTransport(metricsHandler, nil)
+	require.Nil(t, transportHandler)
+	require.Equal(t, errNilRoundTripper, err)
+
+	transportHandler, err = NewMetricsTransport(metricsHandler, http.DefaultTransport)
 	require.Nil(t, err)
 	require.NotNil(t, transportHandler)
 }
 
 func TestMetricsTransport_NilRequest(t *testing.T) {
 	metricsHandler := metrics.NewStatusMetrics()
-	transportHandler, _ := NewMetricsTransport(metricsHandler)
+	transportHandler, _ := NewMetricsTransport(metricsHandler, http.DefaultTransport)
 
 	_, err := transportHandler.RoundTrip(nil)
 	require.Equal(t, errNilRequest, err)
@@ -39,7 +43,7 @@ func TestMetricsTransport_RoundTripNilResponseShouldWork(t *testing.T) {
 	t.Parallel()
 
 	metricsHandler := metrics.NewStatusMetrics()
-	transportHandler, _ := NewMetricsTransport(metricsHandler)
+	transportHandler, _ := NewMetricsTransport(metricsHandler, http.DefaultTransport)
 
 	testErr := errors.New("test")
 	transportHandler.transport = &mock.TransportMock{
@@ -63,7 +67,7 @@ func TestMetricsTransport_RoundTrip(t *testing.T) {
 	t.Parallel()
 
 	metricsHandler := metrics.NewStatusMetrics()
-	transportHandler, _ := NewMetricsTransport(metricsHandler)
+	transportHandler, _ := NewMetricsTransport(metricsHandler, http.DefaultTransport)
 
 	transportHandler.transport = &mock.TransportMock{
 		Response: &http.Response{
@@ -88,7 +92,7 @@ func TestMetricsTransport_RoundTripNoValueInContextShouldNotAddMetrics(t *testin
 	t.Parallel()
 
 	metricsHandler := metrics.NewStatusMetrics()
-	transportHandler, _ := NewMetricsTransport(metricsHandler)
+	transportHandler, _ := NewMetricsTransport(metricsHandler, http.DefaultTransport)
 
 	transportHandler.transport = &mock.TransportMock{
 		Response: &http.Response{
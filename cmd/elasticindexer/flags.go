@@ -47,4 +47,10 @@ var (
 		Name:  "disable-ansi-color",
 		Usage: "Boolean option for disabling ANSI colors in the logging system.",
 	}
+	// printConfig defines a flag that prints the effective configuration, with the secrets masked, and exits
+	printConfig = cli.BoolFlag{
+		Name: "print-config",
+		Usage: "Boolean option for printing the effective configuration, after the environment variables were applied, " +
+			"with the passwords, API keys and tokens masked. The indexer exits after the configuration is validated.",
+	}
 )
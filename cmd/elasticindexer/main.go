@@ -63,6 +63,7 @@ func main() {
 		logLevel,
 		logSaveFile,
 		disableAnsiColor,
+		printConfig,
 	}
 	app.Authors = []cli.Author{
 		{
@@ -92,23 +93,39 @@ func startIndexer(ctx *cli.Context) error {
 		return fmt.Errorf("%w while loading the preferences config file", err)
 	}
 
-	fileLogging, err := initializeLogger(ctx, cfg)
+	apiConfig, err := loadApiConfig(ctx.GlobalString(configurationApiFile.Name))
 	if err != nil {
-		return fmt.Errorf("%w while initializing the logger", err)
+		return fmt.Errorf("%w while loading the api config file", err)
 	}
 
-	statusMetrics := metrics.NewStatusMetrics()
-	wsHost, err := factory.CreateWsIndexer(cfg, clusterCfg, statusMetrics, ctx.App.Version)
+	err = applyEnvOverrides(&cfg, &clusterCfg, &apiConfig)
 	if err != nil {
-		return fmt.Errorf("%w while creating the indexer", err)
+		return err
 	}
 
-	apiConfig, err := loadApiConfig(ctx.GlobalString(configurationApiFile.Name))
+	if ctx.GlobalBool(printConfig.Name) {
+		err = printEffectiveConfig(ctx, cfg, clusterCfg, apiConfig)
+		if err != nil {
+			return err
+		}
+
+		return config.Validate(cfg, clusterCfg, apiConfig)
+	}
+
+	err = config.Validate(cfg, clusterCfg, apiConfig)
 	if err != nil {
-		return fmt.Errorf("%w while loading the api config file", err)
+		return err
+	}
+
+	fileLogging, err := initializeLogger(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("%w while initializing the logger", err)
 	}
 
-	webServer, err := factory.CreateWebServer(apiConfig, statusMetrics)
+	statusMetrics := metrics.NewStatusMetrics()
+	indexingStatus := metrics.NewIndexingStatus()
+	indexingController := wsindexer.NewIndexingController()
+	webServer, err := factory.CreateWebServer(apiConfig, clusterCfg, statusMetrics, indexingStatus, indexingController)
 	if err != nil {
 		return fmt.Errorf("%w while creating the web server", err)
 	}
@@ -118,16 +135,27 @@ func startIndexer(ctx *cli.Context) error {
 		return fmt.Errorf("%w while starting the web server", err)
 	}
 
+	wsHost, err := factory.CreateWsIndexer(cfg, clusterCfg, statusMetrics, indexingStatus, indexingController, ctx.App.Version)
+	if err != nil {
+		return fmt.Errorf("%w while creating the indexer", err)
+	}
+
 	interrupt := make(chan os.Signal, 1)
 	signal.Notify(interrupt, syscall.SIGINT, syscall.SIGTERM)
 
 	retryDuration := time.Duration(clusterCfg.Config.WebSocket.RetryDurationInSec) * time.Second
-	closed := requestSettings(wsHost, retryDuration, interrupt)
+	closed := requestSettings(wsHost, retryDuration, interrupt, indexingController.DrainedChan())
 	if !closed {
-		<-interrupt
+		select {
+		case <-interrupt:
+			log.Info("closing app at user's signal")
+		case <-indexingController.DrainedChan():
+			log.Info("closing app after the indexing was drained")
+		}
 	}
 
-	log.Info("closing app at user's signal")
+	// unblock the payloads held back by a pause, no new payload will be processed from now on
+	indexingController.Drain()
 	err = wsHost.Close()
 	if err != nil {
 		log.Error("cannot close ws indexer", "error", err)
@@ -145,7 +173,7 @@ func startIndexer(ctx *cli.Context) error {
 	return nil
 }
 
-func requestSettings(host wsindexer.WSClient, retryDuration time.Duration, close chan os.Signal) bool {
+func requestSettings(host wsindexer.WSClient, retryDuration time.Duration, close chan os.Signal, drained <-chan struct{}) bool {
 	timer := time.NewTimer(0)
 	defer timer.Stop()
 
@@ -161,6 +189,10 @@ func requestSettings(host wsindexer.WSClient, retryDuration time.Duration, close
 
 			timer.Reset(retryDuration)
 		case <-close:
+			log.Info("closing app at user's signal")
+			return true
+		case <-drained:
+			log.Info("closing app after the indexing was drained")
 			return true
 		}
 	}
@@ -191,6 +223,50 @@ func loadApiConfig(filepath string) (config.ApiRoutesConfig, error) {
 	return cfg, nil
 }
 
+// applyEnvOverrides overrides the loaded configs with the values of the INDEXER_* environment variables
+func applyEnvOverrides(cfg *config.Config, clusterCfg *config.ClusterConfig, apiConfig *config.ApiRoutesConfig) error {
+	err := config.ApplyEnvOverrides(&cfg.Config, config.EnvPrefix)
+	if err != nil {
+		return fmt.Errorf("%w while applying the environment variables on the config", err)
+	}
+
+	err = config.ApplyEnvOverrides(&clusterCfg.Config, config.EnvPrefix)
+	if err != nil {
+		return fmt.Errorf("%w while applying the environment variables on the preferences config", err)
+	}
+
+	err = config.ApplyEnvOverrides(apiConfig, config.ApiEnvPrefix)
+	if err != nil {
+		return fmt.Errorf("%w while applying the environment variables on the api config", err)
+	}
+
+	return nil
+}
+
+// printEffectiveConfig prints the configs used by the indexer, after the environment variables were applied, with
+// the secrets masked
+func printEffectiveConfig(ctx *cli.Context, cfg config.Config, clusterCfg config.ClusterConfig, apiConfig config.ApiRoutesConfig) error {
+	configs := []struct {
+		name string
+		cfg  interface{}
+	}{
+		{name: ctx.GlobalString(configurationFile.Name), cfg: cfg},
+		{name: ctx.GlobalString(configurationPreferencesFile.Name), cfg: clusterCfg},
+		{name: ctx.GlobalString(configurationApiFile.Name), cfg: apiConfig},
+	}
+
+	for _, c := range configs {
+		cfgBytes, err := config.MaskedToml(c.cfg)
+		if err != nil {
+			return fmt.Errorf("%w while printing the %s config file", err, c.name)
+		}
+
+		fmt.Printf("# %s\n%s\n", c.name, cfgBytes)
+	}
+
+	return nil
+}
+
 func initializeLogger(ctx *cli.Context, cfg config.Config) (closing.Closer, error) {
 	logLevelFlagValue := ctx.GlobalString(logLevel.Name)
 	err := logger.SetLogLevel(logLevelFlagValue)
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"github.com/multiversx/mx-chain-es-indexer-go/cmd/loadgenerator/generator"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/urfave/cli"
+)
+
+const defaultMix = generator.Transfers + "=50," +
+	generator.ESDTMultiTransfers + "=15," +
+	generator.SCCalls + "=20," +
+	generator.NFTCreates + "=5," +
+	generator.Logs + "=10"
+
+var (
+	configurationFile = cli.StringFlag{
+		Name:  "config",
+		Usage: "The main configuration file of the indexer",
+		Value: "../elasticindexer/config/config.toml",
+	}
+	// configurationPreferencesFile defines a flag for the path to the preferences toml configuration file
+	configurationPreferencesFile = cli.StringFlag{
+		Name: "config-preferences",
+		Usage: "The preferences configuration file of the indexer. The Elasticsearch cluster and the indexing options " +
+			"are read from this file",
+		Value: "../elasticindexer/config/prefs.toml",
+	}
+	numShards = cli.UintFlag{
+		Name:  "num-shards",
+		Usage: "The number of shards for which blocks are generated",
+		Value: 3,
+	}
+	numRounds = cli.Uint64Flag{
+		Name:  "num-rounds",
+		Usage: "The number of rounds to generate. A block is generated for each shard in every round",
+		Value: 1000,
+	}
+	txsPerBlock = cli.IntFlag{
+		Name:  "txs-per-block",
+		Usage: "The number of transactions in each generated block",
+		Value: 500,
+	}
+	numAccounts = cli.IntFlag{
+		Name:  "num-accounts",
+		Usage: "The number of accounts which send and receive the generated transactions",
+		Value: 10000,
+	}
+	txsMix = cli.StringFlag{
+		Name: "mix",
+		Usage: "The relative weights of the generated kinds of transactions, as comma separated kind=weight pairs. " +
+			"The available kinds are " + generator.Transfers + ", " + generator.ESDTMultiTransfers + ", " +
+			generator.SCCalls + ", " + generator.NFTCreates + " and " + generator.Logs,
+		Value: defaultMix,
+	}
+	seed = cli.Int64Flag{
+		Name:  "seed",
+		Usage: "The seed used to generate the blocks. The same seed generates the same blocks",
+		Value: 1,
+	}
+	fakeDatabase = cli.BoolFlag{
+		Name:  "fake-db",
+		Usage: "Boolean option for sending the data to the in-memory database from client/memory instead of the configured Elasticsearch cluster",
+	}
+	logLevel = cli.StringFlag{
+		Name: "log-level",
+		Usage: "This flag specifies the logger `level(s)`. It can contain multiple comma-separated value. For example" +
+			", if set to *:INFO the logs for all packages will have the INFO level. However, if set to *:INFO,api:DEBUG" +
+			" the logs for all packages will have the INFO level, excepting the api package which will receive a DEBUG" +
+			" log level.",
+		Value: "*:" + logger.LogInfo.String(),
+	}
+)
@@ -0,0 +1,452 @@
+package generator
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/alteredAccount"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-core-go/data/smartContractResult"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+)
+
+const (
+	transferGasLimit      = 50000
+	esdtTransferGasLimit  = 1000000
+	scCallGasLimit        = 10000000
+	scCallGasUsed         = 4000000
+	nftCreateGasLimit     = 3000000
+	numEventsPerLog       = 3
+	numSigners            = 63
+	maxGasPerBlock        = 1500000000
+	signatureLength       = 64
+	eventDataLength       = 32
+	maxTransferredValue   = 1000000000000
+	initialAccountBalance = "1000000000000000000000"
+)
+
+var scCallSuccessData = []byte("@" + hex.EncodeToString([]byte("ok")))
+
+// blockBuilder accumulates the data of a synthetic block of a shard
+type blockBuilder struct {
+	bg             *blocksGenerator
+	state          *shardState
+	shardID        uint32
+	txs            map[string]*outport.TxInfo
+	scrs           map[string]*outport.SCRInfo
+	logs           []*outport.LogData
+	accounts       map[string]*alteredAccount.AlteredAccount
+	txHashes       [][]byte
+	scrHashes      [][]byte
+	executionOrder uint32
+	gasProvided    uint64
+	fees           *big.Int
+}
+
+func newBlockBuilder(bg *blocksGenerator, state *shardState, shardID uint32) *blockBuilder {
+	return &blockBuilder{
+		bg:       bg,
+		state:    state,
+		shardID:  shardID,
+		txs:      make(map[string]*outport.TxInfo),
+		scrs:     make(map[string]*outport.SCRInfo),
+		logs:     make([]*outport.LogData, 0),
+		accounts: make(map[string]*alteredAccount.AlteredAccount),
+		fees:     big.NewInt(0),
+	}
+}
+
+func (bb *blockBuilder) addTransfer() {
+	sender, receiver := bb.pickAccounts()
+	tx := bb.newTransaction(sender, receiver, bb.randomValue(), transferGasLimit, nil)
+	bb.addTx(tx, transferGasLimit)
+
+	bb.alterAccount(sender, true)
+	bb.alterAccount(receiver, false)
+}
+
+func (bb *blockBuilder) addESDTMultiTransfer() {
+	sender, receiver := bb.pickAccounts()
+	tokens := []string{bb.pickToken(), bb.pickToken()}
+
+	txData := fmt.Sprintf("%s@%s@%02x", core.BuiltInFunctionMultiESDTNFTTransfer, hex.EncodeToString(receiver), len(tokens))
+	events := make([]*transaction.Event, 0, len(tokens))
+	for _, token := range tokens {
+		value := bb.randomValue()
+		txData += fmt.Sprintf("@%s@@%s", hex.EncodeToString([]byte(token)), hex.EncodeToString(value.Bytes()))
+		events = append(events, &transaction.Event{
+			Address:    sender,
+			Identifier: []byte(core.BuiltInFunctionMultiESDTNFTTransfer),
+			Topics:     [][]byte{[]byte(token), big.NewInt(0).Bytes(), value.Bytes(), receiver},
+		})
+
+		bb.alterAccountToken(sender, &alteredAccount.AccountTokenData{Identifier: token, Balance: bb.randomValue().String()})
+		bb.alterAccountToken(receiver, &alteredAccount.AccountTokenData{Identifier: token, Balance: bb.randomValue().String()})
+	}
+
+	// the multi transfers are sent by the sender to itself
+	tx := bb.newTransaction(sender, sender, big.NewInt(0), esdtTransferGasLimit, []byte(txData))
+	txHash := bb.addTx(tx, esdtTransferGasLimit)
+	bb.addLog(txHash, sender, events)
+
+	bb.alterAccount(sender, true)
+	bb.alterAccount(receiver, false)
+}
+
+func (bb *blockBuilder) addSCCall() {
+	sender, _ := bb.pickAccounts()
+	contract := bb.pickContract()
+
+	txData := fmt.Sprintf("loadCall@%016x", bb.state.random.Uint64())
+	tx := bb.newTransaction(sender, contract, big.NewInt(0), scCallGasLimit, []byte(txData))
+	txHash := bb.addTx(tx, scCallGasUsed)
+
+	// the smart contract result returns the result of the call and the remaining gas to the sender
+	refund := big.NewInt(0).SetUint64((scCallGasLimit - scCallGasUsed) * gasPrice / 100)
+	scr := &smartContractResult.SmartContractResult{
+		Nonce:          tx.Nonce + 1,
+		Value:          refund,
+		RcvAddr:        sender,
+		SndAddr:        contract,
+		Data:           scCallSuccessData,
+		PrevTxHash:     txHash,
+		OriginalTxHash: txHash,
+		GasPrice:       gasPrice,
+		CallType:       0,
+		OriginalSender: sender,
+	}
+	bb.addSCR(scr)
+	bb.addLog(txHash, contract, []*transaction.Event{
+		{
+			Address:    sender,
+			Identifier: []byte(core.CompletedTxEventIdentifier),
+			Topics:     [][]byte{txHash},
+		},
+	})
+
+	bb.alterAccount(sender, true)
+	bb.alterAccount(contract, false)
+}
+
+func (bb *blockBuilder) addNFTCreate() {
+	creator, _ := bb.pickAccounts()
+
+	// the nonces are interleaved between shards, so that the NFTs created in different shards have different identifiers
+	bb.state.nftNonce++
+	nonce := (bb.state.nftNonce-1)*uint64(bb.bg.numShards) + uint64(bb.shardID) + 1
+	name := []byte(fmt.Sprintf("Load NFT #%d", nonce))
+	hash := randomBytes(bb.state.random, addressLength)
+	attributes := []byte(fmt.Sprintf("tags:load,generated;metadata:%x", hash[:8]))
+	uri := []byte(fmt.Sprintf("https://nft.load/%d.json", nonce))
+	royalties := uint32(500)
+
+	esdtToken := &esdt.ESDigitalToken{
+		Value: big.NewInt(1),
+		TokenMetaData: &esdt.MetaData{
+			Nonce:      nonce,
+			Name:       name,
+			Creator:    creator,
+			Royalties:  royalties,
+			Hash:       hash,
+			URIs:       [][]byte{uri},
+			Attributes: attributes,
+		},
+	}
+	esdtTokenBytes, err := bb.bg.tokenMarshaller.Marshal(esdtToken)
+	if err != nil {
+		log.Warn("blockBuilder.addNFTCreate: cannot marshal the token data", "error", err)
+		return
+	}
+
+	txData := fmt.Sprintf("%s@%s@01@%s@%04x@%s@%s@%s",
+		core.BuiltInFunctionESDTNFTCreate,
+		hex.EncodeToString([]byte(bb.bg.nftCollection)),
+		hex.EncodeToString(name),
+		royalties,
+		hex.EncodeToString(hash),
+		hex.EncodeToString(attributes),
+		hex.EncodeToString(uri),
+	)
+	tx := bb.newTransaction(creator, creator, big.NewInt(0), nftCreateGasLimit, []byte(txData))
+	txHash := bb.addTx(tx, nftCreateGasLimit)
+	bb.addLog(txHash, creator, []*transaction.Event{
+		{
+			Address:    creator,
+			Identifier: []byte(core.BuiltInFunctionESDTNFTCreate),
+			Topics:     [][]byte{[]byte(bb.bg.nftCollection), big.NewInt(0).SetUint64(nonce).Bytes(), big.NewInt(1).Bytes(), esdtTokenBytes},
+		},
+	})
+
+	bb.alterAccountToken(creator, &alteredAccount.AccountTokenData{
+		Nonce:      nonce,
+		Identifier: bb.bg.nftCollection,
+		Balance:    "1",
+		MetaData: &alteredAccount.TokenMetaData{
+			Nonce:      nonce,
+			Name:       string(name),
+			Creator:    bb.bg.encodeAddress(creator),
+			Royalties:  royalties,
+			Hash:       hash,
+			URIs:       [][]byte{uri},
+			Attributes: attributes,
+		},
+		AdditionalData: &alteredAccount.AdditionalAccountTokenData{
+			IsNFTCreate: true,
+		},
+		Type: core.NonFungibleESDT,
+	})
+	bb.alterAccount(creator, true)
+}
+
+func (bb *blockBuilder) addTxWithLogs() {
+	sender, _ := bb.pickAccounts()
+	contract := bb.pickContract()
+
+	tx := bb.newTransaction(sender, contract, big.NewInt(0), scCallGasLimit, []byte("emitEvents"))
+	txHash := bb.addTx(tx, scCallGasUsed)
+
+	events := make([]*transaction.Event, 0, numEventsPerLog+1)
+	for idx := 0; idx < numEventsPerLog; idx++ {
+		events = append(events, &transaction.Event{
+			Address:    contract,
+			Identifier: []byte(fmt.Sprintf("loadEvent%d", idx)),
+			Topics:     [][]byte{[]byte("load"), sender, big.NewInt(int64(idx)).Bytes()},
+			Data:       randomBytes(bb.state.random, eventDataLength),
+		})
+	}
+	events = append(events, &transaction.Event{
+		Address:    sender,
+		Identifier: []byte(core.WriteLogIdentifier),
+		Topics:     [][]byte{sender},
+		Data:       scCallSuccessData,
+	})
+	bb.addLog(txHash, contract, events)
+
+	bb.alterAccount(sender, true)
+	bb.alterAccount(contract, false)
+}
+
+func (bb *blockBuilder) newTransaction(sender []byte, receiver []byte, value *big.Int, gasLimit uint64, data []byte) *transaction.Transaction {
+	bb.state.txNonce++
+
+	return &transaction.Transaction{
+		Nonce:     bb.state.txNonce,
+		Value:     value,
+		RcvAddr:   receiver,
+		SndAddr:   sender,
+		GasPrice:  gasPrice,
+		GasLimit:  gasLimit,
+		Data:      data,
+		ChainID:   []byte("loadgen"),
+		Version:   1,
+		Signature: randomBytes(bb.state.random, signatureLength),
+	}
+}
+
+func (bb *blockBuilder) addTx(tx *transaction.Transaction, gasUsed uint64) []byte {
+	txHash := newHash(bb.shardID, bb.state)
+	fee := big.NewInt(0).SetUint64(gasUsed * gasPrice)
+	initialPaidFee := big.NewInt(0).SetUint64(tx.GasLimit * gasPrice)
+
+	bb.executionOrder++
+	bb.txs[hex.EncodeToString(txHash)] = &outport.TxInfo{
+		Transaction: tx,
+		FeeInfo: &outport.FeeInfo{
+			GasUsed:        gasUsed,
+			Fee:            fee,
+			InitialPaidFee: initialPaidFee,
+		},
+		ExecutionOrder: bb.executionOrder,
+	}
+	bb.txHashes = append(bb.txHashes, txHash)
+	bb.gasProvided += tx.GasLimit
+	bb.fees.Add(bb.fees, fee)
+
+	return txHash
+}
+
+func (bb *blockBuilder) addSCR(scr *smartContractResult.SmartContractResult) {
+	scrHash := newHash(bb.shardID, bb.state)
+
+	bb.executionOrder++
+	bb.scrs[hex.EncodeToString(scrHash)] = &outport.SCRInfo{
+		SmartContractResult: scr,
+		FeeInfo: &outport.FeeInfo{
+			Fee:            big.NewInt(0),
+			InitialPaidFee: big.NewInt(0),
+		},
+		ExecutionOrder: bb.executionOrder,
+	}
+	bb.scrHashes = append(bb.scrHashes, scrHash)
+}
+
+func (bb *blockBuilder) addLog(txHash []byte, address []byte, events []*transaction.Event) {
+	bb.logs = append(bb.logs, &outport.LogData{
+		TxHash: hex.EncodeToString(txHash),
+		Log: &transaction.Log{
+			Address: address,
+			Events:  events,
+		},
+	})
+}
+
+func (bb *blockBuilder) alterAccount(address []byte, isSender bool) *alteredAccount.AlteredAccount {
+	encodedAddress := bb.bg.encodeAddress(address)
+	account, found := bb.accounts[encodedAddress]
+	if !found {
+		account = &alteredAccount.AlteredAccount{
+			Address: encodedAddress,
+			Balance: initialAccountBalance,
+			AdditionalData: &alteredAccount.AdditionalAccountData{
+				BalanceChanged: true,
+			},
+		}
+		bb.accounts[encodedAddress] = account
+	}
+
+	if isSender {
+		account.Nonce = bb.state.txNonce
+		account.AdditionalData.IsSender = true
+	}
+
+	return account
+}
+
+func (bb *blockBuilder) alterAccountToken(address []byte, tokenData *alteredAccount.AccountTokenData) {
+	account := bb.alterAccount(address, false)
+	account.Tokens = append(account.Tokens, tokenData)
+}
+
+func (bb *blockBuilder) pickAccounts() ([]byte, []byte) {
+	numAccounts := len(bb.bg.accounts)
+	senderIndex := bb.state.random.Intn(numAccounts)
+	// the receiver is different from the sender
+	receiverIndex := (senderIndex + 1 + bb.state.random.Intn(numAccounts-1)) % numAccounts
+
+	return bb.bg.accounts[senderIndex], bb.bg.accounts[receiverIndex]
+}
+
+func (bb *blockBuilder) pickContract() []byte {
+	return bb.bg.contracts[bb.state.random.Intn(len(bb.bg.contracts))]
+}
+
+func (bb *blockBuilder) pickToken() string {
+	return bb.bg.tokens[bb.state.random.Intn(len(bb.bg.tokens))]
+}
+
+func (bb *blockBuilder) randomValue() *big.Int {
+	return big.NewInt(bb.state.random.Int63n(maxTransferredValue) + 1)
+}
+
+func (bb *blockBuilder) build() (*Block, error) {
+	miniBlocks := make([]*block.MiniBlock, 0, 2)
+	miniBlockHeaders := make([]block.MiniBlockHeader, 0, 2)
+	if len(bb.txHashes) > 0 {
+		miniBlocks = append(miniBlocks, bb.newMiniBlock(bb.txHashes, block.TxBlock))
+	}
+	if len(bb.scrHashes) > 0 {
+		miniBlocks = append(miniBlocks, bb.newMiniBlock(bb.scrHashes, block.SmartContractResultBlock))
+	}
+	for _, mb := range miniBlocks {
+		miniBlockHeaders = append(miniBlockHeaders, block.MiniBlockHeader{
+			Hash:            newHash(bb.shardID, bb.state),
+			SenderShardID:   mb.SenderShardID,
+			ReceiverShardID: mb.ReceiverShardID,
+			TxCount:         uint32(len(mb.TxHashes)),
+			Type:            mb.Type,
+		})
+	}
+
+	round := bb.state.nonce
+	epoch := uint32(round / roundsPerEpoch)
+	timestamp := uint64(genesisTimestamp + round*roundDuration)
+	header := &block.Header{
+		Nonce:            bb.state.nonce,
+		PrevHash:         bb.state.prevHash,
+		PubKeysBitmap:    []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f},
+		ShardID:          bb.shardID,
+		TimeStamp:        timestamp,
+		Round:            round,
+		Epoch:            epoch,
+		RandSeed:         randomBytes(bb.state.random, signatureLength),
+		RootHash:         newHash(bb.shardID, bb.state),
+		MiniBlockHeaders: miniBlockHeaders,
+		TxCount:          uint32(len(bb.txHashes) + len(bb.scrHashes)),
+		AccumulatedFees:  bb.fees,
+		DeveloperFees:    big.NewInt(0).Div(bb.fees, big.NewInt(10)),
+		ChainID:          []byte("loadgen"),
+		SoftwareVersion:  []byte("loadgen"),
+	}
+	headerBytes, err := bb.bg.marshaller.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	headerHash := newHash(bb.shardID, bb.state)
+	bb.state.prevHash = headerHash
+
+	signers := make([]uint64, 0, numSigners)
+	for idx := uint64(0); idx < numSigners; idx++ {
+		signers = append(signers, idx)
+	}
+
+	outportBlock := &outport.OutportBlock{
+		ShardID: bb.shardID,
+		BlockData: &outport.BlockData{
+			ShardID:     bb.shardID,
+			HeaderBytes: headerBytes,
+			HeaderType:  string(core.ShardHeaderV1),
+			HeaderHash:  headerHash,
+			Body: &block.Body{
+				MiniBlocks: miniBlocks,
+			},
+		},
+		TransactionPool: &outport.TransactionPool{
+			Transactions:         bb.txs,
+			SmartContractResults: bb.scrs,
+			Logs:                 bb.logs,
+		},
+		HeaderGasConsumption: &outport.HeaderGasConsumption{
+			GasProvided:    bb.gasProvided,
+			MaxGasPerBlock: maxGasPerBlock,
+		},
+		AlteredAccounts:        bb.accounts,
+		NumberOfShards:         bb.bg.numShards,
+		SignersIndexes:         signers,
+		HighestFinalBlockNonce: bb.state.nonce - 1,
+	}
+
+	return &Block{
+		OutportBlock: outportBlock,
+		RoundsInfo: &outport.RoundsInfo{
+			ShardID: bb.shardID,
+			RoundsInfo: []*outport.RoundInfo{
+				{
+					Round:            round,
+					SignersIndexes:   signers,
+					BlockWasProposed: true,
+					ShardId:          bb.shardID,
+					Epoch:            epoch,
+					Timestamp:        timestamp,
+				},
+			},
+		},
+		FinalizedBlock: &outport.FinalizedBlock{
+			ShardID:    bb.shardID,
+			HeaderHash: headerHash,
+		},
+		NumTxs: len(bb.txHashes),
+	}, nil
+}
+
+func (bb *blockBuilder) newMiniBlock(hashes [][]byte, mbType block.Type) *block.MiniBlock {
+	return &block.MiniBlock{
+		TxHashes:        hashes,
+		ReceiverShardID: bb.shardID,
+		SenderShardID:   bb.shardID,
+		Type:            mbType,
+	}
+}
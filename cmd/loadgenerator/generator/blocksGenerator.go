@@ -0,0 +1,222 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	logger "github.com/multiversx/mx-chain-logger-go"
+)
+
+const (
+	gasPrice          = 1000000000
+	genesisTimestamp  = 1700000000
+	roundDuration     = 6
+	roundsPerEpoch    = 14400
+	numContracts      = 16
+	numFungibleTokens = 16
+	addressLength     = 32
+	scAddressPrefix   = 10
+)
+
+var log = logger.GetOrCreate("loadgenerator/generator")
+
+// ArgsBlocksGenerator holds the arguments needed to create a blocks generator
+type ArgsBlocksGenerator struct {
+	Marshaller      marshal.Marshalizer
+	TokenMarshaller marshal.Marshalizer
+	PubkeyConverter core.PubkeyConverter
+	NumShards       uint32
+	TxsPerBlock     int
+	NumAccounts     int
+	Mix             Mix
+	Seed            int64
+}
+
+// Block holds the payloads sent by a node for a block
+type Block struct {
+	OutportBlock   *outport.OutportBlock
+	RoundsInfo     *outport.RoundsInfo
+	FinalizedBlock *outport.FinalizedBlock
+	NumTxs         int
+}
+
+type shardState struct {
+	random   *rand.Rand
+	nonce    uint64
+	txNonce  uint64
+	nftNonce uint64
+	counter  uint64
+	prevHash []byte
+}
+
+type blocksGenerator struct {
+	marshaller        marshal.Marshalizer
+	tokenMarshaller   marshal.Marshalizer
+	pubkeyConverter   core.PubkeyConverter
+	numShards         uint32
+	txsPerBlock       int
+	seed              int64
+	kinds             []string
+	cumulativeWeights []uint64
+	totalWeight       uint64
+	accounts          [][]byte
+	contracts         [][]byte
+	tokens            []string
+	nftCollection     string
+
+	mutShards sync.Mutex
+	shards    map[uint32]*shardState
+}
+
+// NewBlocksGenerator will create a generator of synthetic blocks. The blocks of a shard are generated deterministically
+// from the provided seed
+func NewBlocksGenerator(args ArgsBlocksGenerator) (*blocksGenerator, error) {
+	err := checkArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	bg := &blocksGenerator{
+		marshaller:      args.Marshaller,
+		tokenMarshaller: args.TokenMarshaller,
+		pubkeyConverter: args.PubkeyConverter,
+		numShards:       args.NumShards,
+		txsPerBlock:     args.TxsPerBlock,
+		seed:            args.Seed,
+		shards:          make(map[uint32]*shardState),
+		nftCollection:   "LOADNFT-a1b2c3",
+	}
+
+	for _, kind := range args.Mix.sortedKinds() {
+		bg.totalWeight += uint64(args.Mix[kind])
+		bg.kinds = append(bg.kinds, kind)
+		bg.cumulativeWeights = append(bg.cumulativeWeights, bg.totalWeight)
+	}
+
+	random := rand.New(rand.NewSource(args.Seed))
+	for idx := 0; idx < args.NumAccounts; idx++ {
+		bg.accounts = append(bg.accounts, randomBytes(random, addressLength))
+	}
+	for idx := 0; idx < numContracts; idx++ {
+		contract := randomBytes(random, addressLength)
+		// the smart contracts addresses start with zero bytes, followed by the VM type
+		copy(contract, make([]byte, scAddressPrefix))
+		contract[scAddressPrefix] = 5
+		bg.contracts = append(bg.contracts, contract)
+	}
+	for idx := 0; idx < numFungibleTokens; idx++ {
+		bg.tokens = append(bg.tokens, fmt.Sprintf("LOAD%d-%06x", idx, random.Intn(1<<24)))
+	}
+
+	return bg, nil
+}
+
+func checkArgs(args ArgsBlocksGenerator) error {
+	if check.IfNil(args.Marshaller) {
+		return ErrNilMarshaller
+	}
+	if check.IfNil(args.TokenMarshaller) {
+		return ErrNilTokenMarshaller
+	}
+	if check.IfNil(args.PubkeyConverter) {
+		return ErrNilPubkeyConverter
+	}
+	if args.NumShards == 0 {
+		return ErrInvalidNumOfShards
+	}
+	if args.NumAccounts < 2 {
+		return ErrInvalidNumOfAccounts
+	}
+	if args.Mix.totalWeight() == 0 {
+		return ErrInvalidMix
+	}
+
+	return nil
+}
+
+// GenerateBlock will generate the next block of the provided shard. The blocks of the same shard should not be
+// generated concurrently
+func (bg *blocksGenerator) GenerateBlock(shardID uint32) (*Block, error) {
+	state := bg.getShardState(shardID)
+	state.nonce++
+
+	builder := newBlockBuilder(bg, state, shardID)
+	for idx := 0; idx < bg.txsPerBlock; idx++ {
+		switch bg.pickKind(state.random) {
+		case Transfers:
+			builder.addTransfer()
+		case ESDTMultiTransfers:
+			builder.addESDTMultiTransfer()
+		case SCCalls:
+			builder.addSCCall()
+		case NFTCreates:
+			builder.addNFTCreate()
+		case Logs:
+			builder.addTxWithLogs()
+		}
+	}
+
+	return builder.build()
+}
+
+func (bg *blocksGenerator) getShardState(shardID uint32) *shardState {
+	bg.mutShards.Lock()
+	defer bg.mutShards.Unlock()
+
+	state, found := bg.shards[shardID]
+	if !found {
+		state = &shardState{
+			random: rand.New(rand.NewSource(bg.seed + int64(shardID) + 1)),
+		}
+		bg.shards[shardID] = state
+	}
+
+	return state
+}
+
+func (bg *blocksGenerator) pickKind(random *rand.Rand) string {
+	value := uint64(random.Int63n(int64(bg.totalWeight)))
+	for idx, cumulativeWeight := range bg.cumulativeWeights {
+		if value < cumulativeWeight {
+			return bg.kinds[idx]
+		}
+	}
+
+	return bg.kinds[len(bg.kinds)-1]
+}
+
+func (bg *blocksGenerator) encodeAddress(address []byte) string {
+	return bg.pubkeyConverter.SilentEncode(address, log)
+}
+
+// newHash returns a unique hash for the provided shard state
+func newHash(shardID uint32, state *shardState) []byte {
+	state.counter++
+
+	input := make([]byte, 20)
+	binary.BigEndian.PutUint32(input, shardID)
+	binary.BigEndian.PutUint64(input[4:], state.nonce)
+	binary.BigEndian.PutUint64(input[12:], state.counter)
+	hash := sha256.Sum256(input)
+
+	return hash[:]
+}
+
+func randomBytes(random *rand.Rand, length int) []byte {
+	buff := make([]byte, length)
+	_, _ = random.Read(buff)
+
+	return buff
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (bg *blocksGenerator) IsInterfaceNil() bool {
+	return bg == nil
+}
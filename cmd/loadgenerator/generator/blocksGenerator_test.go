@@ -0,0 +1,189 @@
+package generator
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/stretchr/testify/require"
+)
+
+func createMockArgsBlocksGenerator() ArgsBlocksGenerator {
+	converter, _ := pubkeyConverter.NewBech32PubkeyConverter(32, "erd")
+
+	return ArgsBlocksGenerator{
+		Marshaller:      &marshal.JsonMarshalizer{},
+		TokenMarshaller: &marshal.GogoProtoMarshalizer{},
+		PubkeyConverter: converter,
+		NumShards:       3,
+		TxsPerBlock:     50,
+		NumAccounts:     100,
+		Mix: Mix{
+			Transfers:          1,
+			ESDTMultiTransfers: 1,
+			SCCalls:            1,
+			NFTCreates:         1,
+			Logs:               1,
+		},
+		Seed: 7,
+	}
+}
+
+func TestNewBlocksGenerator(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil marshaller should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsBlocksGenerator()
+		args.Marshaller = nil
+		bg, err := NewBlocksGenerator(args)
+		require.Equal(t, ErrNilMarshaller, err)
+		require.Nil(t, bg)
+	})
+	t.Run("nil token marshaller should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsBlocksGenerator()
+		args.TokenMarshaller = nil
+		bg, err := NewBlocksGenerator(args)
+		require.Equal(t, ErrNilTokenMarshaller, err)
+		require.Nil(t, bg)
+	})
+	t.Run("nil pubkey converter should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsBlocksGenerator()
+		args.PubkeyConverter = nil
+		bg, err := NewBlocksGenerator(args)
+		require.Equal(t, ErrNilPubkeyConverter, err)
+		require.Nil(t, bg)
+	})
+	t.Run("zero shards should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsBlocksGenerator()
+		args.NumShards = 0
+		bg, err := NewBlocksGenerator(args)
+		require.Equal(t, ErrInvalidNumOfShards, err)
+		require.Nil(t, bg)
+	})
+	t.Run("one account should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsBlocksGenerator()
+		args.NumAccounts = 1
+		bg, err := NewBlocksGenerator(args)
+		require.Equal(t, ErrInvalidNumOfAccounts, err)
+		require.Nil(t, bg)
+	})
+	t.Run("empty mix should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsBlocksGenerator()
+		args.Mix = Mix{}
+		bg, err := NewBlocksGenerator(args)
+		require.Equal(t, ErrInvalidMix, err)
+		require.Nil(t, bg)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		bg, err := NewBlocksGenerator(createMockArgsBlocksGenerator())
+		require.Nil(t, err)
+		require.False(t, bg.IsInterfaceNil())
+	})
+}
+
+func TestBlocksGenerator_GenerateBlock(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsBlocksGenerator()
+	bg, _ := NewBlocksGenerator(args)
+
+	firstBlock, err := bg.GenerateBlock(1)
+	require.Nil(t, err)
+	secondBlock, err := bg.GenerateBlock(1)
+	require.Nil(t, err)
+
+	require.Equal(t, args.TxsPerBlock, firstBlock.NumTxs)
+	require.Equal(t, uint32(1), firstBlock.OutportBlock.ShardID)
+	require.Equal(t, firstBlock.OutportBlock.BlockData.HeaderHash, firstBlock.FinalizedBlock.HeaderHash)
+	require.NotEqual(t, firstBlock.OutportBlock.BlockData.HeaderHash, secondBlock.OutportBlock.BlockData.HeaderHash)
+
+	header := &block.Header{}
+	err = args.Marshaller.Unmarshal(header, secondBlock.OutportBlock.BlockData.HeaderBytes)
+	require.Nil(t, err)
+	require.Equal(t, uint64(2), header.Nonce)
+	require.Equal(t, uint32(1), header.ShardID)
+	require.Equal(t, firstBlock.OutportBlock.BlockData.HeaderHash, header.PrevHash)
+
+	// all the transactions and the smart contract results should be referenced by the miniblocks
+	pool := secondBlock.OutportBlock.TransactionPool
+	miniBlocks := secondBlock.OutportBlock.BlockData.Body.MiniBlocks
+	numReferenced := 0
+	for _, mb := range miniBlocks {
+		for _, hash := range mb.TxHashes {
+			switch mb.Type {
+			case block.TxBlock:
+				require.NotNil(t, pool.Transactions[hex.EncodeToString(hash)])
+			case block.SmartContractResultBlock:
+				require.NotNil(t, pool.SmartContractResults[hex.EncodeToString(hash)])
+			}
+			numReferenced++
+		}
+	}
+	require.Equal(t, len(pool.Transactions)+len(pool.SmartContractResults), numReferenced)
+	require.Len(t, pool.Transactions, args.TxsPerBlock)
+	require.NotEmpty(t, pool.SmartContractResults)
+	require.NotEmpty(t, pool.Logs)
+	require.NotEmpty(t, secondBlock.OutportBlock.AlteredAccounts)
+}
+
+func TestBlocksGenerator_GenerateBlockIsDeterministic(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsBlocksGenerator()
+	bg1, _ := NewBlocksGenerator(args)
+	bg2, _ := NewBlocksGenerator(args)
+
+	block1, err := bg1.GenerateBlock(0)
+	require.Nil(t, err)
+	block2, err := bg2.GenerateBlock(0)
+	require.Nil(t, err)
+
+	require.Equal(t, block1, block2)
+}
+
+func TestBlocksGenerator_GenerateBlockNFTCreates(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsBlocksGenerator()
+	args.Mix = Mix{NFTCreates: 1}
+	bg, _ := NewBlocksGenerator(args)
+
+	nonces := make(map[uint64]struct{})
+	for shardID := uint32(0); shardID < args.NumShards; shardID++ {
+		generatedBlock, err := bg.GenerateBlock(shardID)
+		require.Nil(t, err)
+
+		for _, logData := range generatedBlock.OutportBlock.TransactionPool.Logs {
+			event := logData.Log.Events[0]
+			require.Equal(t, core.BuiltInFunctionESDTNFTCreate, string(event.Identifier))
+
+			esdtToken := &esdt.ESDigitalToken{}
+			err = args.TokenMarshaller.Unmarshal(esdtToken, event.Topics[3])
+			require.Nil(t, err)
+
+			// the NFTs created in different shards should not have the same nonce
+			_, found := nonces[esdtToken.TokenMetaData.Nonce]
+			require.False(t, found)
+			nonces[esdtToken.TokenMetaData.Nonce] = struct{}{}
+		}
+	}
+	require.Len(t, nonces, int(args.NumShards)*args.TxsPerBlock)
+}
@@ -0,0 +1,24 @@
+package generator
+
+import "errors"
+
+// ErrNilMarshaller signals that a nil marshaller has been provided
+var ErrNilMarshaller = errors.New("nil marshaller")
+
+// ErrNilTokenMarshaller signals that a nil marshaller for the tokens data has been provided
+var ErrNilTokenMarshaller = errors.New("nil token marshaller")
+
+// ErrNilPubkeyConverter signals that a nil public key converter has been provided
+var ErrNilPubkeyConverter = errors.New("nil pubkey converter")
+
+// ErrInvalidNumOfShards signals that an invalid number of shards has been provided
+var ErrInvalidNumOfShards = errors.New("invalid number of shards")
+
+// ErrInvalidNumOfAccounts signals that an invalid number of accounts has been provided
+var ErrInvalidNumOfAccounts = errors.New("invalid number of accounts")
+
+// ErrInvalidMix signals that the provided transactions mix cannot be parsed
+var ErrInvalidMix = errors.New("invalid transactions mix")
+
+// ErrUnknownTxKind signals that the provided transactions mix contains an unknown kind of transaction
+var ErrUnknownTxKind = errors.New("unknown kind of transaction")
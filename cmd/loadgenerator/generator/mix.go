@@ -0,0 +1,96 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Transfers are plain EGLD transfers between two accounts
+	Transfers = "transfers"
+	// ESDTMultiTransfers are transfers of two fungible tokens with a MultiESDTNFTTransfer call
+	ESDTMultiTransfers = "esdt-multi-transfers"
+	// SCCalls are smart contract calls which generate smart contract results
+	SCCalls = "sc-calls"
+	// NFTCreates are NFT creations which update the tokens and the accounts with tokens
+	NFTCreates = "nft-creates"
+	// Logs are smart contract calls which only emit custom events
+	Logs = "logs"
+)
+
+var txKinds = map[string]struct{}{
+	Transfers:          {},
+	ESDTMultiTransfers: {},
+	SCCalls:            {},
+	NFTCreates:         {},
+	Logs:               {},
+}
+
+// Mix holds the relative weights of the generated kinds of transactions
+type Mix map[string]uint32
+
+// ParseMix will parse a mix provided as comma separated kind=weight pairs, e.g. "transfers=70,sc-calls=30"
+func ParseMix(mix string) (Mix, error) {
+	parsedMix := make(Mix)
+	for _, pair := range strings.Split(mix, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		kindAndWeight := strings.Split(pair, "=")
+		if len(kindAndWeight) != 2 {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidMix, pair)
+		}
+
+		kind := strings.TrimSpace(kindAndWeight[0])
+		_, known := txKinds[kind]
+		if !known {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownTxKind, kind)
+		}
+
+		weight, err := strconv.ParseUint(strings.TrimSpace(kindAndWeight[1]), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidMix, err.Error())
+		}
+
+		parsedMix[kind] = uint32(weight)
+	}
+
+	if parsedMix.totalWeight() == 0 {
+		return nil, fmt.Errorf("%w: the sum of the weights should be positive", ErrInvalidMix)
+	}
+
+	return parsedMix, nil
+}
+
+// String returns the mix in the format accepted by ParseMix
+func (m Mix) String() string {
+	pairs := make([]string, 0, len(m))
+	for _, kind := range m.sortedKinds() {
+		pairs = append(pairs, fmt.Sprintf("%s=%d", kind, m[kind]))
+	}
+
+	return strings.Join(pairs, ",")
+}
+
+func (m Mix) totalWeight() uint64 {
+	total := uint64(0)
+	for _, weight := range m {
+		total += uint64(weight)
+	}
+
+	return total
+}
+
+func (m Mix) sortedKinds() []string {
+	kinds := make([]string, 0, len(m))
+	for kind := range m {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	return kinds
+}
@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMix(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid mix should work", func(t *testing.T) {
+		t.Parallel()
+
+		mix, err := ParseMix(" transfers=70, sc-calls=30,logs=0,")
+		require.Nil(t, err)
+		require.Equal(t, Mix{Transfers: 70, SCCalls: 30, Logs: 0}, mix)
+		require.Equal(t, "logs=0,sc-calls=30,transfers=70", mix.String())
+	})
+	t.Run("unknown kind should error", func(t *testing.T) {
+		t.Parallel()
+
+		mix, err := ParseMix("transfers=70,swaps=30")
+		require.True(t, errors.Is(err, ErrUnknownTxKind))
+		require.Nil(t, mix)
+	})
+	t.Run("invalid weight should error", func(t *testing.T) {
+		t.Parallel()
+
+		mix, err := ParseMix("transfers=-1")
+		require.True(t, errors.Is(err, ErrInvalidMix))
+		require.Nil(t, mix)
+	})
+	t.Run("missing weight should error", func(t *testing.T) {
+		t.Parallel()
+
+		mix, err := ParseMix("transfers")
+		require.True(t, errors.Is(err, ErrInvalidMix))
+		require.Nil(t, mix)
+	})
+	t.Run("zero total weight should error", func(t *testing.T) {
+		t.Parallel()
+
+		mix, err := ParseMix("transfers=0,logs=0")
+		require.True(t, errors.Is(err, ErrInvalidMix))
+		require.Nil(t, mix)
+	})
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	factoryMarshaller "github.com/multiversx/mx-chain-core-go/marshal/factory"
+	"github.com/multiversx/mx-chain-es-indexer-go/client/memory"
+	"github.com/multiversx/mx-chain-es-indexer-go/cmd/loadgenerator/generator"
+	"github.com/multiversx/mx-chain-es-indexer-go/cmd/loadgenerator/runner"
+	"github.com/multiversx/mx-chain-es-indexer-go/config"
+	"github.com/multiversx/mx-chain-es-indexer-go/factory"
+	"github.com/multiversx/mx-chain-es-indexer-go/metrics"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/wsindexer"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/urfave/cli"
+)
+
+var (
+	log          = logger.GetOrCreate("loadgenerator")
+	helpTemplate = `NAME:
+   {{.Name}} - {{.Usage}}
+USAGE:
+   {{.HelpName}} {{if .VisibleFlags}}[global options]{{end}}
+   {{if len .Authors}}
+AUTHOR:
+   {{range .Authors}}{{ . }}{{end}}
+   {{end}}{{if .Commands}}
+GLOBAL OPTIONS:
+   {{range .VisibleFlags}}{{.}}
+   {{end}}
+VERSION:
+   {{.Version}}
+   {{end}}
+`
+)
+
+const version = "loadgenerator"
+
+func main() {
+	app := cli.NewApp()
+	cli.AppHelpTemplate = helpTemplate
+	app.Name = "Load generator"
+	app.Usage = "This tool will send synthetic blocks to the indexer and report the indexing throughput and latencies"
+	app.Flags = []cli.Flag{
+		configurationFile,
+		configurationPreferencesFile,
+		numShards,
+		numRounds,
+		txsPerBlock,
+		numAccounts,
+		txsMix,
+		seed,
+		fakeDatabase,
+		logLevel,
+	}
+	app.Authors = []cli.Author{
+		{
+			Name:  "The MultiversX Team",
+			Email: "contact@multiversx.com",
+		},
+	}
+
+	app.Version = version
+	app.Action = generateLoad
+
+	err := app.Run(os.Args)
+	if err != nil {
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+}
+
+func generateLoad(ctx *cli.Context) error {
+	err := logger.SetLogLevel(ctx.GlobalString(logLevel.Name))
+	if err != nil {
+		return err
+	}
+
+	cfg := config.Config{}
+	err = core.LoadTomlFile(&cfg, ctx.GlobalString(configurationFile.Name))
+	if err != nil {
+		return fmt.Errorf("%w while loading the config file", err)
+	}
+
+	clusterCfg := config.ClusterConfig{}
+	err = core.LoadTomlFile(&clusterCfg, ctx.GlobalString(configurationPreferencesFile.Name))
+	if err != nil {
+		return fmt.Errorf("%w while loading the preferences config file", err)
+	}
+
+	wsMarshaller, err := factoryMarshaller.NewMarshalizer(clusterCfg.Config.WebSocket.DataMarshallerType)
+	if err != nil {
+		return err
+	}
+
+	blocksGenerator, err := createBlocksGenerator(ctx, cfg, wsMarshaller)
+	if err != nil {
+		return fmt.Errorf("%w while creating the blocks generator", err)
+	}
+
+	// a nil client means that the configured cluster is used
+	var dbClient elasticproc.DatabaseClientHandler
+	if ctx.GlobalBool(fakeDatabase.Name) {
+		dbClient = memory.NewDatabaseClient()
+	}
+
+	payloadHandler, err := factory.CreatePayloadProcessor(
+		cfg,
+		clusterCfg,
+		metrics.NewStatusMetrics(),
+		metrics.NewIndexingStatus(),
+		wsindexer.NewIndexingController(),
+		version,
+		dbClient,
+	)
+	if err != nil {
+		return fmt.Errorf("%w while creating the indexer", err)
+	}
+
+	loadRunner, err := runner.NewLoadRunner(runner.ArgsLoadRunner{
+		Marshaller:     wsMarshaller,
+		Generator:      blocksGenerator,
+		PayloadHandler: payloadHandler,
+		NumShards:      uint32(ctx.GlobalUint(numShards.Name)),
+		NumRounds:      ctx.GlobalUint64(numRounds.Name),
+	})
+	if err != nil {
+		return err
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go stopAtUserSignal(cancel)
+
+	report, err := loadRunner.Run(runCtx)
+	if err != nil {
+		return err
+	}
+
+	err = report.Print(os.Stdout)
+	if err != nil {
+		return err
+	}
+	if dbClient != nil {
+		return printNumDocuments(runCtx, dbClient, cfg.Config.AvailableIndices)
+	}
+
+	return nil
+}
+
+func createBlocksGenerator(ctx *cli.Context, cfg config.Config, wsMarshaller marshal.Marshalizer) (runner.BlocksGenerator, error) {
+	mix, err := generator.ParseMix(ctx.GlobalString(txsMix.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	tokenMarshaller, err := factoryMarshaller.NewMarshalizer(cfg.Config.Marshaller.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	addressPubkeyConverter, err := pubkeyConverter.NewBech32PubkeyConverter(cfg.Config.AddressConverter.Length, cfg.Config.AddressConverter.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("generating blocks", "mix", mix.String())
+
+	return generator.NewBlocksGenerator(generator.ArgsBlocksGenerator{
+		Marshaller:      wsMarshaller,
+		TokenMarshaller: tokenMarshaller,
+		PubkeyConverter: addressPubkeyConverter,
+		NumShards:       uint32(ctx.GlobalUint(numShards.Name)),
+		TxsPerBlock:     ctx.GlobalInt(txsPerBlock.Name),
+		NumAccounts:     ctx.GlobalInt(numAccounts.Name),
+		Mix:             mix,
+		Seed:            ctx.GlobalInt64(seed.Name),
+	})
+}
+
+func stopAtUserSignal(cancel context.CancelFunc) {
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, syscall.SIGINT, syscall.SIGTERM)
+
+	<-interrupt
+	log.Info("stopping the load at user's signal")
+	cancel()
+}
+
+func printNumDocuments(ctx context.Context, dbClient elasticproc.DatabaseClientHandler, indices []string) error {
+	fmt.Println()
+	fmt.Println("documents saved in the in-memory database:")
+	for _, index := range indices {
+		numDocuments, err := dbClient.DoCountRequest(ctx, index, nil)
+		if err != nil {
+			return err
+		}
+		if numDocuments == 0 {
+			continue
+		}
+
+		fmt.Printf("  %-25s %10d\n", index, numDocuments)
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package runner
+
+import "errors"
+
+// ErrNilMarshaller signals that a nil marshaller has been provided
+var ErrNilMarshaller = errors.New("nil marshaller")
+
+// ErrNilBlocksGenerator signals that a nil blocks generator has been provided
+var ErrNilBlocksGenerator = errors.New("nil blocks generator")
+
+// ErrNilPayloadHandler signals that a nil payload handler has been provided
+var ErrNilPayloadHandler = errors.New("nil payload handler")
+
+// ErrInvalidNumOfShards signals that an invalid number of shards has been provided
+var ErrInvalidNumOfShards = errors.New("invalid number of shards")
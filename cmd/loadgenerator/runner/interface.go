@@ -0,0 +1,9 @@
+package runner
+
+import "github.com/multiversx/mx-chain-es-indexer-go/cmd/loadgenerator/generator"
+
+// BlocksGenerator defines what a synthetic blocks generator should be able to do
+type BlocksGenerator interface {
+	GenerateBlock(shardID uint32) (*generator.Block, error)
+	IsInterfaceNil() bool
+}
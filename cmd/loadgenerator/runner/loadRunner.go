@@ -0,0 +1,139 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/multiversx/mx-chain-communication-go/websocket"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	logger "github.com/multiversx/mx-chain-logger-go"
+)
+
+const (
+	payloadVersion = 1
+	progressRounds = 100
+)
+
+var log = logger.GetOrCreate("loadgenerator/runner")
+
+// ArgsLoadRunner holds the arguments needed to create a load runner
+type ArgsLoadRunner struct {
+	Marshaller     marshal.Marshalizer
+	Generator      BlocksGenerator
+	PayloadHandler websocket.PayloadHandler
+	NumShards      uint32
+	NumRounds      uint64
+}
+
+type loadRunner struct {
+	marshaller     marshal.Marshalizer
+	generator      BlocksGenerator
+	payloadHandler websocket.PayloadHandler
+	numShards      uint32
+	numRounds      uint64
+}
+
+// NewLoadRunner will create a runner which sends the generated blocks to the payload handler, the same way a node
+// sends them through the web socket
+func NewLoadRunner(args ArgsLoadRunner) (*loadRunner, error) {
+	if check.IfNil(args.Marshaller) {
+		return nil, ErrNilMarshaller
+	}
+	if check.IfNil(args.Generator) {
+		return nil, ErrNilBlocksGenerator
+	}
+	if check.IfNil(args.PayloadHandler) {
+		return nil, ErrNilPayloadHandler
+	}
+	if args.NumShards == 0 {
+		return nil, ErrInvalidNumOfShards
+	}
+
+	return &loadRunner{
+		marshaller:     args.Marshaller,
+		generator:      args.Generator,
+		payloadHandler: args.PayloadHandler,
+		numShards:      args.NumShards,
+		numRounds:      args.NumRounds,
+	}, nil
+}
+
+// Run will send the blocks of all the shards, round by round, until the number of rounds is reached or the context is
+// done. The time spent generating the blocks is not included in the report. The payload handler is closed at the end,
+// so that the pending writes are accounted
+func (lr *loadRunner) Run(ctx context.Context) (*Report, error) {
+	collector := newStatsCollector()
+
+	var round uint64
+	for round = 0; round < lr.numRounds; round++ {
+		if ctx.Err() != nil {
+			log.Info("the load was stopped before the last round", "round", round)
+			break
+		}
+
+		for shardID := uint32(0); shardID < lr.numShards; shardID++ {
+			err := lr.sendBlock(shardID, collector)
+			if err != nil {
+				return nil, fmt.Errorf("%w for shard %d, round %d", err, shardID, round+1)
+			}
+		}
+
+		if (round+1)%progressRounds == 0 {
+			log.Info("load progress", "rounds", round+1, "blocks", collector.numBlocks, "txs", collector.numTxs)
+		}
+	}
+
+	start := time.Now()
+	err := lr.payloadHandler.Close()
+	collector.addClose(time.Since(start))
+
+	return collector.report(round), err
+}
+
+func (lr *loadRunner) sendBlock(shardID uint32, collector *statsCollector) error {
+	generatedBlock, err := lr.generator.GenerateBlock(shardID)
+	if err != nil {
+		return err
+	}
+
+	err = lr.sendPayload(generatedBlock.OutportBlock, outport.TopicSaveBlock, collector)
+	if err != nil {
+		return err
+	}
+	err = lr.sendPayload(generatedBlock.RoundsInfo, outport.TopicSaveRoundsInfo, collector)
+	if err != nil {
+		return err
+	}
+	err = lr.sendPayload(generatedBlock.FinalizedBlock, outport.TopicFinalizedBlock, collector)
+	if err != nil {
+		return err
+	}
+
+	collector.addBlock(generatedBlock.NumTxs)
+
+	return nil
+}
+
+func (lr *loadRunner) sendPayload(payloadData interface{}, topic string, collector *statsCollector) error {
+	payload, err := lr.marshaller.Marshal(payloadData)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	err = lr.payloadHandler.ProcessPayload(payload, topic, payloadVersion)
+	if err != nil {
+		return fmt.Errorf("%w while processing the payload of topic %s", err, topic)
+	}
+	collector.addPayload(topic, len(payload), time.Since(start))
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (lr *loadRunner) IsInterfaceNil() bool {
+	return lr == nil
+}
@@ -0,0 +1,212 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/multiversx/mx-chain-es-indexer-go/cmd/loadgenerator/generator"
+	"github.com/stretchr/testify/require"
+)
+
+type blocksGeneratorStub struct {
+	GenerateBlockCalled func(shardID uint32) (*generator.Block, error)
+}
+
+func (stub *blocksGeneratorStub) GenerateBlock(shardID uint32) (*generator.Block, error) {
+	return stub.GenerateBlockCalled(shardID)
+}
+
+func (stub *blocksGeneratorStub) IsInterfaceNil() bool {
+	return stub == nil
+}
+
+type payloadHandlerStub struct {
+	ProcessPayloadCalled func(payload []byte, topic string, version uint32) error
+	CloseCalled          func() error
+}
+
+func (stub *payloadHandlerStub) ProcessPayload(payload []byte, topic string, version uint32) error {
+	return stub.ProcessPayloadCalled(payload, topic, version)
+}
+
+func (stub *payloadHandlerStub) Close() error {
+	if stub.CloseCalled != nil {
+		return stub.CloseCalled()
+	}
+
+	return nil
+}
+
+func (stub *payloadHandlerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
+
+func createGeneratedBlock(shardID uint32) *generator.Block {
+	return &generator.Block{
+		OutportBlock:   &outport.OutportBlock{ShardID: shardID},
+		RoundsInfo:     &outport.RoundsInfo{ShardID: shardID},
+		FinalizedBlock: &outport.FinalizedBlock{ShardID: shardID},
+		NumTxs:         10,
+	}
+}
+
+func createMockArgsLoadRunner() ArgsLoadRunner {
+	return ArgsLoadRunner{
+		Marshaller: &marshal.JsonMarshalizer{},
+		Generator: &blocksGeneratorStub{
+			GenerateBlockCalled: func(shardID uint32) (*generator.Block, error) {
+				return createGeneratedBlock(shardID), nil
+			},
+		},
+		PayloadHandler: &payloadHandlerStub{
+			ProcessPayloadCalled: func(payload []byte, topic string, version uint32) error {
+				return nil
+			},
+		},
+		NumShards: 2,
+		NumRounds: 5,
+	}
+}
+
+func TestNewLoadRunner(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil marshaller should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsLoadRunner()
+		args.Marshaller = nil
+		lr, err := NewLoadRunner(args)
+		require.Equal(t, ErrNilMarshaller, err)
+		require.Nil(t, lr)
+	})
+	t.Run("nil generator should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsLoadRunner()
+		args.Generator = nil
+		lr, err := NewLoadRunner(args)
+		require.Equal(t, ErrNilBlocksGenerator, err)
+		require.Nil(t, lr)
+	})
+	t.Run("nil payload handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsLoadRunner()
+		args.PayloadHandler = nil
+		lr, err := NewLoadRunner(args)
+		require.Equal(t, ErrNilPayloadHandler, err)
+		require.Nil(t, lr)
+	})
+	t.Run("zero shards should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsLoadRunner()
+		args.NumShards = 0
+		lr, err := NewLoadRunner(args)
+		require.Equal(t, ErrInvalidNumOfShards, err)
+		require.Nil(t, lr)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		lr, err := NewLoadRunner(createMockArgsLoadRunner())
+		require.Nil(t, err)
+		require.False(t, lr.IsInterfaceNil())
+	})
+}
+
+func TestLoadRunner_RunShouldSendAllTopicsAndReport(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsLoadRunner()
+	topics := make([]string, 0)
+	closed := false
+	args.PayloadHandler = &payloadHandlerStub{
+		ProcessPayloadCalled: func(payload []byte, topic string, version uint32) error {
+			require.Equal(t, uint32(payloadVersion), version)
+			topics = append(topics, topic)
+			return nil
+		},
+		CloseCalled: func() error {
+			closed = true
+			return nil
+		},
+	}
+	lr, _ := NewLoadRunner(args)
+
+	report, err := lr.Run(context.Background())
+	require.Nil(t, err)
+	require.True(t, closed)
+	require.Len(t, topics, 3*10)
+	require.Equal(t, []string{outport.TopicSaveBlock, outport.TopicSaveRoundsInfo, outport.TopicFinalizedBlock}, topics[:3])
+
+	require.Equal(t, uint64(5), report.NumRounds)
+	require.Equal(t, 10, report.NumBlocks)
+	require.Equal(t, 100, report.NumTxs)
+	require.Len(t, report.Topics, 3)
+	require.Equal(t, outport.TopicSaveBlock, report.Topics[0].Topic)
+	require.Equal(t, 10, report.Topics[0].NumPayloads)
+	require.NotZero(t, report.Topics[0].TotalBytes)
+
+	buff := &bytes.Buffer{}
+	err = report.Print(buff)
+	require.Nil(t, err)
+	require.Contains(t, buff.String(), outport.TopicFinalizedBlock)
+}
+
+func TestLoadRunner_RunShouldStopOnProcessingError(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	args := createMockArgsLoadRunner()
+	args.PayloadHandler = &payloadHandlerStub{
+		ProcessPayloadCalled: func(payload []byte, topic string, version uint32) error {
+			return expectedErr
+		},
+	}
+	lr, _ := NewLoadRunner(args)
+
+	report, err := lr.Run(context.Background())
+	require.True(t, errors.Is(err, expectedErr))
+	require.Nil(t, report)
+}
+
+func TestLoadRunner_RunShouldStopWhenContextIsDone(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	args := createMockArgsLoadRunner()
+	args.NumRounds = 100
+	args.Generator = &blocksGeneratorStub{
+		GenerateBlockCalled: func(shardID uint32) (*generator.Block, error) {
+			cancel()
+			return createGeneratedBlock(shardID), nil
+		},
+	}
+	lr, _ := NewLoadRunner(args)
+
+	report, err := lr.Run(ctx)
+	require.Nil(t, err)
+	require.Equal(t, uint64(1), report.NumRounds)
+	require.Equal(t, 2, report.NumBlocks)
+}
+
+func TestPercentile(t *testing.T) {
+	t.Parallel()
+
+	durations := make([]time.Duration, 0, 100)
+	for idx := 1; idx <= 100; idx++ {
+		durations = append(durations, time.Duration(idx)*time.Millisecond)
+	}
+
+	require.Equal(t, time.Duration(0), percentile(nil, 50))
+	require.Equal(t, 50*time.Millisecond, percentile(durations, 50))
+	require.Equal(t, 99*time.Millisecond, percentile(durations, 99))
+	require.Equal(t, time.Millisecond, percentile(durations[:1], 99))
+}
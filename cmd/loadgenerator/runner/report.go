@@ -0,0 +1,164 @@
+package runner
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+// TopicReport holds the sizes and the processing latencies of the payloads of a topic
+type TopicReport struct {
+	Topic       string
+	NumPayloads int
+	TotalBytes  uint64
+	P50         time.Duration
+	P90         time.Duration
+	P99         time.Duration
+	Max         time.Duration
+}
+
+// Report holds the results of a load run
+type Report struct {
+	NumRounds     uint64
+	NumBlocks     int
+	NumTxs        int
+	Duration      time.Duration
+	CloseDuration time.Duration
+	Topics        []TopicReport
+}
+
+// BlocksPerSecond returns the number of blocks indexed per second
+func (r *Report) BlocksPerSecond() float64 {
+	return perSecond(r.NumBlocks, r.Duration)
+}
+
+// TxsPerSecond returns the number of transactions indexed per second
+func (r *Report) TxsPerSecond() float64 {
+	return perSecond(r.NumTxs, r.Duration)
+}
+
+// Print will write the report in a human-readable format
+func (r *Report) Print(writer io.Writer) error {
+	tw := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintf(tw, "rounds\t%d\n", r.NumRounds)
+	_, _ = fmt.Fprintf(tw, "blocks\t%d\n", r.NumBlocks)
+	_, _ = fmt.Fprintf(tw, "transactions\t%d\n", r.NumTxs)
+	_, _ = fmt.Fprintf(tw, "duration\t%s (close %s)\n", r.Duration, r.CloseDuration)
+	_, _ = fmt.Fprintf(tw, "throughput\t%.2f blocks/s, %.2f txs/s\n", r.BlocksPerSecond(), r.TxsPerSecond())
+	_, _ = fmt.Fprintln(tw)
+
+	_, _ = fmt.Fprintln(tw, "topic\tpayloads\ttotal bytes\tbytes/payload\tp50\tp90\tp99\tmax")
+	for _, topic := range r.Topics {
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%s\t%s\t%s\t%s\n",
+			topic.Topic,
+			topic.NumPayloads,
+			topic.TotalBytes,
+			topic.TotalBytes/uint64(topic.NumPayloads),
+			topic.P50,
+			topic.P90,
+			topic.P99,
+			topic.Max,
+		)
+	}
+
+	return tw.Flush()
+}
+
+func perSecond(count int, duration time.Duration) float64 {
+	if duration <= 0 {
+		return 0
+	}
+
+	return float64(count) / duration.Seconds()
+}
+
+type topicStats struct {
+	totalBytes uint64
+	durations  []time.Duration
+}
+
+// statsCollector is used by the runner from a single go routine
+type statsCollector struct {
+	numBlocks     int
+	numTxs        int
+	duration      time.Duration
+	closeDuration time.Duration
+	topicsOrder   []string
+	topics        map[string]*topicStats
+}
+
+func newStatsCollector() *statsCollector {
+	return &statsCollector{
+		topics: make(map[string]*topicStats),
+	}
+}
+
+func (sc *statsCollector) addPayload(topic string, numBytes int, duration time.Duration) {
+	stats, found := sc.topics[topic]
+	if !found {
+		stats = &topicStats{}
+		sc.topics[topic] = stats
+		sc.topicsOrder = append(sc.topicsOrder, topic)
+	}
+
+	stats.totalBytes += uint64(numBytes)
+	stats.durations = append(stats.durations, duration)
+	sc.duration += duration
+}
+
+func (sc *statsCollector) addBlock(numTxs int) {
+	sc.numBlocks++
+	sc.numTxs += numTxs
+}
+
+func (sc *statsCollector) addClose(duration time.Duration) {
+	sc.closeDuration = duration
+	sc.duration += duration
+}
+
+func (sc *statsCollector) report(numRounds uint64) *Report {
+	report := &Report{
+		NumRounds:     numRounds,
+		NumBlocks:     sc.numBlocks,
+		NumTxs:        sc.numTxs,
+		Duration:      sc.duration,
+		CloseDuration: sc.closeDuration,
+		Topics:        make([]TopicReport, 0, len(sc.topicsOrder)),
+	}
+
+	for _, topic := range sc.topicsOrder {
+		stats := sc.topics[topic]
+		sort.Slice(stats.durations, func(i, j int) bool {
+			return stats.durations[i] < stats.durations[j]
+		})
+
+		report.Topics = append(report.Topics, TopicReport{
+			Topic:       topic,
+			NumPayloads: len(stats.durations),
+			TotalBytes:  stats.totalBytes,
+			P50:         percentile(stats.durations, 50),
+			P90:         percentile(stats.durations, 90),
+			P99:         percentile(stats.durations, 99),
+			Max:         stats.durations[len(stats.durations)-1],
+		})
+	}
+
+	return report
+}
+
+// percentile returns the nearest-rank percentile of the provided sorted durations
+func percentile(sortedDurations []time.Duration, percent int) time.Duration {
+	if len(sortedDurations) == 0 {
+		return 0
+	}
+
+	rank := (percent*len(sortedDurations) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sortedDurations[rank-1]
+}
@@ -22,6 +22,9 @@ type Config struct {
 		Economics struct {
 			Denomination int `toml:"denomination"`
 		} `toml:"economics"`
+		TokensCache struct {
+			Capacity int `toml:"capacity"`
+		} `toml:"tokens-cache"`
 		Logs struct {
 			LogFileLifeSpanInMB  int    `toml:"log-file-life-span-in-mb"`
 			LogFileLifeSpanInSec int    `toml:"log-file-life-span-in-sec"`
@@ -44,22 +47,82 @@ type ClusterConfig struct {
 			WithAcknowledge    bool   `toml:"with-acknowledge"`
 			AckTimeoutInSec    uint32 `toml:"acknowledge-timeout-in-seconds"`
 		} `toml:"web-socket"`
-		ElasticCluster struct {
-			UseKibana                 bool   `toml:"use-kibana"`
-			URL                       string `toml:"url"`
-			UserName                  string `toml:"username"`
-			Password                  string `toml:"password"`
-			BulkRequestMaxSizeInBytes int    `toml:"bulk-request-max-size-in-bytes"`
-		} `toml:"elastic-cluster"`
+		ElasticCluster ElasticClusterConfig `toml:"elastic-cluster"`
+		DualWrite      struct {
+			Enabled           bool                 `toml:"enabled"`
+			QueueSize         int                  `toml:"queue-size"`
+			MaxRetries        int                  `toml:"max-retries"`
+			RetryIntervalInMs uint32               `toml:"retry-interval-in-milliseconds"`
+			SecondaryCluster  ElasticClusterConfig `toml:"secondary-cluster"`
+		} `toml:"dual-write"`
+		ImportDB struct {
+			BatchBlocks          bool   `toml:"batch-blocks"`
+			FlushSizeInBytes     int    `toml:"flush-size-in-bytes"`
+			FlushIntervalInMs    uint32 `toml:"flush-interval-in-milliseconds"`
+			ApplyIndexSettings   bool   `toml:"apply-index-settings"`
+			RefreshInterval      string `toml:"refresh-interval"`
+			NumberOfReplicas     int    `toml:"number-of-replicas"`
+			OriginalSettingsFile string `toml:"original-settings-file"`
+		} `toml:"import-db"`
 	} `toml:"config"`
 }
 
+// ElasticClusterConfig will hold the config for the connection to an Elasticsearch cluster
+type ElasticClusterConfig struct {
+	UseKibana                  bool     `toml:"use-kibana"`
+	URL                        string   `toml:"url"`
+	URLs                       []string `toml:"urls"`
+	DiscoverNodesIntervalInSec uint32   `toml:"discover-nodes-interval-in-seconds"`
+	UserName                   string   `toml:"username"`
+	Password                   string   `toml:"password" secret:"true"`
+	APIKey                     string   `toml:"api-key" secret:"true"`
+	CloudID                    string   `toml:"cloud-id"`
+	BulkRequestMaxSizeInBytes  int      `toml:"bulk-request-max-size-in-bytes"`
+	NumConcurrentBulkRequests  int      `toml:"num-concurrent-bulk-requests"`
+	CompressBulkRequests       bool     `toml:"compress-bulk-requests"`
+	TLS                        struct {
+		CACertFile         string `toml:"ca-cert-file"`
+		ClientCertFile     string `toml:"client-cert-file"`
+		ClientKeyFile      string `toml:"client-key-file"`
+		InsecureSkipVerify bool   `toml:"insecure-skip-verify"`
+	} `toml:"tls"`
+}
+
 // ApiRoutesConfig holds the configuration related to Rest API routes
 type ApiRoutesConfig struct {
 	RestApiInterface string                      `toml:"rest-api-interface"`
+	TrustedProxies   []string                    `toml:"trusted-proxies"`
+	TLS              ApiTLSConfig                `toml:"tls"`
+	Auth             ApiAuthConfig               `toml:"auth"`
+	RateLimit        ApiRateLimitConfig          `toml:"rate-limit"`
 	APIPackages      map[string]APIPackageConfig `toml:"api-packages"`
 }
 
+// ApiTLSConfig holds the configuration for serving the Rest API over TLS
+type ApiTLSConfig struct {
+	Enabled  bool   `toml:"enabled"`
+	CertFile string `toml:"cert-file"`
+	KeyFile  string `toml:"key-file"`
+}
+
+// ApiAuthConfig holds the credentials accepted by the Rest API routes that require authentication
+type ApiAuthConfig struct {
+	BasicAuthUsers []BasicAuthUserConfig `toml:"basic-auth-users"`
+	BearerTokens   []string              `toml:"bearer-tokens" secret:"true"`
+}
+
+// BasicAuthUserConfig holds the credentials of a basic auth user
+type BasicAuthUserConfig struct {
+	Username string `toml:"username"`
+	Password string `toml:"password" secret:"true"`
+}
+
+// ApiRateLimitConfig holds the configuration for the per client IP rate limiter
+type ApiRateLimitConfig struct {
+	RequestsPerSecond uint32 `toml:"requests-per-second"`
+	Burst             uint32 `toml:"burst"`
+}
+
 // APIPackageConfig holds the configuration for the routes of each package
 type APIPackageConfig struct {
 	Routes []RouteConfig `toml:"routes"`
@@ -69,4 +132,5 @@ type APIPackageConfig struct {
 type RouteConfig struct {
 	Name string `toml:"name"`
 	Open bool   `toml:"open"`
+	Auth string `toml:"auth"`
 }
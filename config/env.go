@@ -0,0 +1,177 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+)
+
+const (
+	// EnvPrefix is the prefix of the environment variables that override the main and the preferences configs
+	EnvPrefix = "INDEXER"
+	// ApiEnvPrefix is the prefix of the environment variables that override the api config
+	ApiEnvPrefix = "INDEXER_API"
+
+	envFileSuffix  = "_FILE"
+	envListSep     = ","
+	tomlValueKey   = "value"
+	tomlTag        = "toml"
+	envNameSep     = "_"
+	tomlNameSep    = "-"
+	inlineArrayTag = "["
+)
+
+type envLookupFunc func(key string) (string, bool)
+
+// ApplyEnvOverrides overrides the fields of the provided config with the values of the environment variables. The
+// name of the variable is built from the prefix and the toml keys of the field, upper cased and joined by
+// underscores, e.g. INDEXER_ELASTIC_CLUSTER_URL for the url of the elastic-cluster section. A variable with the
+// _FILE suffix holds the path of a file whose content is used as value, which is useful for passwords and tokens.
+// The lists can be set as comma separated values, while the lists of tables and the maps use the TOML inline syntax
+func ApplyEnvOverrides(cfg interface{}, prefix string) error {
+	return applyEnvOverrides(cfg, prefix, os.LookupEnv)
+}
+
+func applyEnvOverrides(cfg interface{}, prefix string, lookup envLookupFunc) error {
+	value := reflect.ValueOf(cfg)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: expected a pointer to a struct, got %T", ErrInvalidEnvOverride, cfg)
+	}
+
+	return overrideStruct(value.Elem(), prefix, lookup)
+}
+
+func overrideStruct(value reflect.Value, prefix string, lookup envLookupFunc) error {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		key := field.Tag.Get(tomlTag)
+		if key == "" || key == "-" {
+			continue
+		}
+
+		name := prefix + envNameSep + envName(key)
+		if field.Type.Kind() == reflect.Struct {
+			err := overrideStruct(value.Field(i), name, lookup)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		envValue, found, err := lookupEnvValue(name, lookup)
+		if err != nil {
+			return err
+		}
+		if !found {
+			continue
+		}
+
+		err = setFieldValue(value.Field(i), envValue)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %s", ErrInvalidEnvOverride, name, err.Error())
+		}
+	}
+
+	return nil
+}
+
+func envName(tomlKey string) string {
+	return strings.ToUpper(strings.ReplaceAll(tomlKey, tomlNameSep, envNameSep))
+}
+
+// lookupEnvValue returns the value of the environment variable or, if it is not set, the content of the file set in
+// the variable with the _FILE suffix
+func lookupEnvValue(name string, lookup envLookupFunc) (string, bool, error) {
+	envValue, found := lookup(name)
+	if found {
+		return envValue, true, nil
+	}
+
+	filePath, found := lookup(name + envFileSuffix)
+	if !found {
+		return "", false, nil
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", false, fmt.Errorf("%w: %s: %s", ErrInvalidEnvOverride, name+envFileSuffix, err.Error())
+	}
+
+	return strings.TrimSpace(string(content)), true, nil
+}
+
+func setFieldValue(field reflect.Value, envValue string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(envValue)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(envValue)
+		if err != nil {
+			return err
+		}
+		field.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(envValue, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(envValue, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(parsed)
+	case reflect.Slice:
+		isInlineArray := strings.HasPrefix(strings.TrimSpace(envValue), inlineArrayTag)
+		if field.Type().Elem().Kind() == reflect.String && !isInlineArray {
+			field.Set(reflect.ValueOf(splitList(envValue)))
+			return nil
+		}
+		return setInlineTomlValue(field, envValue)
+	case reflect.Map:
+		return setInlineTomlValue(field, envValue)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+
+	return nil
+}
+
+func splitList(envValue string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(envValue, envListSep) {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// setInlineTomlValue decodes the environment variable as a TOML inline value, like
+// [{ username = "admin", password = "secret" }] for a list of tables
+func setInlineTomlValue(field reflect.Value, envValue string) error {
+	wrapperType := reflect.StructOf([]reflect.StructField{
+		{
+			Name: "Value",
+			Type: field.Type(),
+			Tag:  reflect.StructTag(fmt.Sprintf(`%s:"%s"`, tomlTag, tomlValueKey)),
+		},
+	})
+	wrapper := reflect.New(wrapperType)
+
+	err := toml.Unmarshal([]byte(tomlValueKey+" = "+envValue), wrapper.Interface())
+	if err != nil {
+		return err
+	}
+
+	field.Set(wrapper.Elem().Field(0))
+
+	return nil
+}
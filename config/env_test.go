@@ -0,0 +1,118 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createLookup(env map[string]string) envLookupFunc {
+	return func(key string) (string, bool) {
+		value, found := env[key]
+		return value, found
+	}
+}
+
+func TestApplyEnvOverrides_InvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	err := applyEnvOverrides(ClusterConfig{}, EnvPrefix, createLookup(nil))
+	require.True(t, errors.Is(err, ErrInvalidEnvOverride))
+}
+
+func TestApplyEnvOverrides_ClusterConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg := ClusterConfig{}
+	cfg.Config.ElasticCluster.URL = "http://localhost:9200"
+	cfg.Config.ElasticCluster.UserName = "user"
+	cfg.Config.WebSocket.Mode = "server"
+
+	env := map[string]string{
+		"INDEXER_ELASTIC_CLUSTER_URL":                            "http://elastic:9200",
+		"INDEXER_ELASTIC_CLUSTER_URLS":                           "http://node1:9200, http://node2:9200",
+		"INDEXER_ELASTIC_CLUSTER_BULK_REQUEST_MAX_SIZE_IN_BYTES": "1024",
+		"INDEXER_ELASTIC_CLUSTER_TLS_INSECURE_SKIP_VERIFY":       "true",
+		"INDEXER_WEB_SOCKET_ACKNOWLEDGE_TIMEOUT_IN_SECONDS":      "10",
+		"INDEXER_DISABLED_INDICES":                               `["rating", "rounds"]`,
+		"INDEXER_DUAL_WRITE_SECONDARY_CLUSTER_API_KEY":           "key",
+	}
+	err := applyEnvOverrides(&cfg.Config, EnvPrefix, createLookup(env))
+	require.Nil(t, err)
+
+	require.Equal(t, "http://elastic:9200", cfg.Config.ElasticCluster.URL)
+	require.Equal(t, []string{"http://node1:9200", "http://node2:9200"}, cfg.Config.ElasticCluster.URLs)
+	require.Equal(t, 1024, cfg.Config.ElasticCluster.BulkRequestMaxSizeInBytes)
+	require.True(t, cfg.Config.ElasticCluster.TLS.InsecureSkipVerify)
+	require.Equal(t, uint32(10), cfg.Config.WebSocket.AckTimeoutInSec)
+	require.Equal(t, []string{"rating", "rounds"}, cfg.Config.DisabledIndices)
+	require.Equal(t, "key", cfg.Config.DualWrite.SecondaryCluster.APIKey)
+
+	// the fields without environment variables are not changed
+	require.Equal(t, "user", cfg.Config.ElasticCluster.UserName)
+	require.Equal(t, "server", cfg.Config.WebSocket.Mode)
+}
+
+func TestApplyEnvOverrides_ApiConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg := ApiRoutesConfig{}
+	env := map[string]string{
+		"INDEXER_API_REST_API_INTERFACE":    ":9090",
+		"INDEXER_API_AUTH_BASIC_AUTH_USERS": `[{ username = "admin", password = "secret" }]`,
+		"INDEXER_API_API_PACKAGES":          `{ status = { routes = [{ name = "/metrics", open = true }] } }`,
+	}
+	err := applyEnvOverrides(&cfg, ApiEnvPrefix, createLookup(env))
+	require.Nil(t, err)
+
+	require.Equal(t, ":9090", cfg.RestApiInterface)
+	require.Equal(t, []BasicAuthUserConfig{{Username: "admin", Password: "secret"}}, cfg.Auth.BasicAuthUsers)
+	require.Equal(t, map[string]APIPackageConfig{
+		"status": {Routes: []RouteConfig{{Name: "/metrics", Open: true}}},
+	}, cfg.APIPackages)
+}
+
+func TestApplyEnvOverrides_SecretsFile(t *testing.T) {
+	t.Parallel()
+
+	secretFile := filepath.Join(t.TempDir(), "password")
+	err := os.WriteFile(secretFile, []byte("secret\n"), 0600)
+	require.Nil(t, err)
+
+	cfg := ClusterConfig{}
+	env := map[string]string{
+		"INDEXER_ELASTIC_CLUSTER_PASSWORD_FILE": secretFile,
+		"INDEXER_ELASTIC_CLUSTER_API_KEY":       "key",
+		"INDEXER_ELASTIC_CLUSTER_API_KEY_FILE":  secretFile,
+	}
+	err = applyEnvOverrides(&cfg.Config, EnvPrefix, createLookup(env))
+	require.Nil(t, err)
+	require.Equal(t, "secret", cfg.Config.ElasticCluster.Password)
+	// the value of the variable has priority over the file
+	require.Equal(t, "key", cfg.Config.ElasticCluster.APIKey)
+
+	env = map[string]string{
+		"INDEXER_ELASTIC_CLUSTER_PASSWORD_FILE": filepath.Join(t.TempDir(), "missing"),
+	}
+	err = applyEnvOverrides(&cfg.Config, EnvPrefix, createLookup(env))
+	require.True(t, errors.Is(err, ErrInvalidEnvOverride))
+}
+
+func TestApplyEnvOverrides_InvalidValues(t *testing.T) {
+	t.Parallel()
+
+	for name, value := range map[string]string{
+		"INDEXER_ELASTIC_CLUSTER_COMPRESS_BULK_REQUESTS":       "maybe",
+		"INDEXER_ELASTIC_CLUSTER_NUM_CONCURRENT_BULK_REQUESTS": "many",
+		"INDEXER_WEB_SOCKET_RETRY_DURATION_IN_SECONDS":         "-1",
+		"INDEXER_DISABLED_INDICES":                             "[rating",
+	} {
+		cfg := ClusterConfig{}
+		err := applyEnvOverrides(&cfg.Config, EnvPrefix, createLookup(map[string]string{name: value}))
+		require.True(t, errors.Is(err, ErrInvalidEnvOverride), name)
+		require.Contains(t, err.Error(), name)
+	}
+}
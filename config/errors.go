@@ -0,0 +1,9 @@
+package config
+
+import "errors"
+
+// ErrInvalidEnvOverride signals that an environment variable cannot be applied on the config
+var ErrInvalidEnvOverride = errors.New("invalid environment variable override")
+
+// ErrInvalidConfig signals that the config holds an invalid value
+var ErrInvalidConfig = errors.New("invalid config")
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/pelletier/go-toml"
+)
+
+const (
+	secretTag   = "secret"
+	maskedValue = "******"
+)
+
+// MaskedToml encodes the provided config as TOML, with the values of the secret fields, like passwords, API keys and
+// tokens, replaced by a mask. The empty secrets are left empty, so it can be seen that they are not set
+func MaskedToml(cfg interface{}) ([]byte, error) {
+	value := reflect.ValueOf(cfg)
+	if value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: expected a struct, got %T", ErrInvalidConfig, cfg)
+	}
+
+	// the config is copied through its TOML encoding, so the slices and maps of the provided config are not masked
+	cfgBytes, err := toml.Marshal(value.Interface())
+	if err != nil {
+		return nil, err
+	}
+	cfgCopy := reflect.New(value.Type())
+	err = toml.Unmarshal(cfgBytes, cfgCopy.Interface())
+	if err != nil {
+		return nil, err
+	}
+
+	maskSecrets(cfgCopy.Elem(), false)
+
+	return toml.Marshal(cfgCopy.Elem().Interface())
+}
+
+func maskSecrets(value reflect.Value, isSecret bool) {
+	switch value.Kind() {
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			maskSecrets(value.Field(i), isSecret || field.Tag.Get(secretTag) == "true")
+		}
+	case reflect.Slice:
+		for i := 0; i < value.Len(); i++ {
+			maskSecrets(value.Index(i), isSecret)
+		}
+	case reflect.Map:
+		for _, key := range value.MapKeys() {
+			item := reflect.New(value.Type().Elem()).Elem()
+			item.Set(value.MapIndex(key))
+			maskSecrets(item, isSecret)
+			value.SetMapIndex(key, item)
+		}
+	case reflect.String:
+		if isSecret && value.String() != "" {
+			value.SetString(maskedValue)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaskedToml_InvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := MaskedToml("config")
+	require.True(t, errors.Is(err, ErrInvalidConfig))
+}
+
+func TestMaskedToml_ClusterConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg := ClusterConfig{}
+	cfg.Config.ElasticCluster.UserName = "user"
+	cfg.Config.ElasticCluster.Password = "secret"
+	cfg.Config.DualWrite.SecondaryCluster.APIKey = "key"
+
+	cfgBytes, err := MaskedToml(cfg)
+	require.Nil(t, err)
+
+	cfgString := string(cfgBytes)
+	require.Contains(t, cfgString, `username = "user"`)
+	require.Contains(t, cfgString, `password = "******"`)
+	require.Contains(t, cfgString, `api-key = "******"`)
+	require.NotContains(t, cfgString, "secret")
+	require.NotContains(t, cfgString, `"key"`)
+	// the empty secrets are not masked
+	require.Contains(t, cfgString, `password = ""`)
+}
+
+func TestMaskedToml_ApiConfigIsNotChanged(t *testing.T) {
+	t.Parallel()
+
+	cfg := ApiRoutesConfig{
+		Auth: ApiAuthConfig{
+			BasicAuthUsers: []BasicAuthUserConfig{{Username: "admin", Password: "secret"}},
+			BearerTokens:   []string{"token"},
+		},
+	}
+
+	cfgBytes, err := MaskedToml(&cfg)
+	require.Nil(t, err)
+
+	cfgString := string(cfgBytes)
+	require.Contains(t, cfgString, `username = "admin"`)
+	require.NotContains(t, cfgString, "secret")
+	require.NotContains(t, cfgString, `"token"`)
+	require.Equal(t, "secret", cfg.Auth.BasicAuthUsers[0].Password)
+	require.Equal(t, []string{"token"}, cfg.Auth.BearerTokens)
+}
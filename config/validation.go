@@ -0,0 +1,147 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/multiversx/mx-chain-communication-go/websocket/data"
+	marshalFactory "github.com/multiversx/mx-chain-core-go/marshal/factory"
+)
+
+var (
+	supportedWebSocketModes = []string{data.ModeClient, data.ModeServer}
+	supportedMarshallers    = []string{marshalFactory.JsonMarshalizer, marshalFactory.GogoProtobuf, marshalFactory.TxJsonMarshalizer}
+	supportedHashers        = []string{"blake2b", "sha256", "keccak"}
+	supportedRouteAuthTypes = []string{"", "basic", "bearer"}
+)
+
+// Validate checks the loaded configs and returns all the problems found at once, joined in a single error
+func Validate(cfg Config, clusterCfg ClusterConfig, apiCfg ApiRoutesConfig) error {
+	problems := make([]error, 0)
+	addProblem := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Errorf("%w: "+format, append([]interface{}{ErrInvalidConfig}, args...)...))
+	}
+
+	validateMainConfig(cfg, addProblem)
+	validateClusterConfig(cfg, clusterCfg, addProblem)
+	validateApiConfig(apiCfg, addProblem)
+
+	return errors.Join(problems...)
+}
+
+func validateMainConfig(cfg Config, addProblem func(format string, args ...interface{})) {
+	if len(cfg.Config.AvailableIndices) == 0 {
+		addProblem("available-indices is empty")
+	}
+	if cfg.Config.AddressConverter.Length <= 0 {
+		addProblem("address-converter length should be greater than 0, got %d", cfg.Config.AddressConverter.Length)
+	}
+	if cfg.Config.ValidatorKeysConverter.Length <= 0 {
+		addProblem("validator-keys-converter length should be greater than 0, got %d", cfg.Config.ValidatorKeysConverter.Length)
+	}
+	if !contains(supportedHashers, cfg.Config.Hasher.Type) {
+		addProblem("unsupported hasher type %q, expected one of %q", cfg.Config.Hasher.Type, supportedHashers)
+	}
+	if !contains(supportedMarshallers, cfg.Config.Marshaller.Type) {
+		addProblem("unsupported marshaller type %q, expected one of %q", cfg.Config.Marshaller.Type, supportedMarshallers)
+	}
+}
+
+func validateClusterConfig(cfg Config, clusterCfg ClusterConfig, addProblem func(format string, args ...interface{})) {
+	for _, index := range clusterCfg.Config.DisabledIndices {
+		if !contains(cfg.Config.AvailableIndices, index) {
+			addProblem("disabled-indices contains the unknown index %q", index)
+		}
+	}
+
+	webSocket := clusterCfg.Config.WebSocket
+	if !contains(supportedWebSocketModes, webSocket.Mode) {
+		addProblem("invalid web-socket mode %q, expected one of %q", webSocket.Mode, supportedWebSocketModes)
+	}
+	if !contains(supportedMarshallers, webSocket.DataMarshallerType) {
+		addProblem("unsupported web-socket data-marshaller-type %q, expected one of %q", webSocket.DataMarshallerType, supportedMarshallers)
+	}
+	if webSocket.URL == "" {
+		addProblem("web-socket url is empty")
+	}
+
+	validateElasticCluster("elastic-cluster", clusterCfg.Config.ElasticCluster, addProblem)
+	if clusterCfg.Config.ElasticCluster.BulkRequestMaxSizeInBytes <= 0 {
+		addProblem("elastic-cluster bulk-request-max-size-in-bytes should be greater than 0, got %d", clusterCfg.Config.ElasticCluster.BulkRequestMaxSizeInBytes)
+	}
+	if clusterCfg.Config.ElasticCluster.NumConcurrentBulkRequests < 0 {
+		addProblem("elastic-cluster num-concurrent-bulk-requests should not be negative, got %d", clusterCfg.Config.ElasticCluster.NumConcurrentBulkRequests)
+	}
+
+	dualWrite := clusterCfg.Config.DualWrite
+	if dualWrite.Enabled {
+		validateElasticCluster("dual-write secondary-cluster", dualWrite.SecondaryCluster, addProblem)
+		if dualWrite.QueueSize <= 0 {
+			addProblem("dual-write queue-size should be greater than 0, got %d", dualWrite.QueueSize)
+		}
+		if dualWrite.MaxRetries < 0 {
+			addProblem("dual-write max-retries should not be negative, got %d", dualWrite.MaxRetries)
+		}
+	}
+
+	importDB := clusterCfg.Config.ImportDB
+	if importDB.BatchBlocks && importDB.FlushSizeInBytes <= 0 {
+		addProblem("import-db flush-size-in-bytes should be greater than 0, got %d", importDB.FlushSizeInBytes)
+	}
+	if importDB.NumberOfReplicas < 0 {
+		addProblem("import-db number-of-replicas should not be negative, got %d", importDB.NumberOfReplicas)
+	}
+}
+
+func validateElasticCluster(section string, clusterCfg ElasticClusterConfig, addProblem func(format string, args ...interface{})) {
+	if clusterCfg.URL == "" && len(clusterCfg.URLs) == 0 && clusterCfg.CloudID == "" {
+		addProblem("%s should have an url, urls or a cloud-id", section)
+	}
+	if clusterCfg.Password != "" && clusterCfg.UserName == "" {
+		addProblem("%s has a password but no username", section)
+	}
+
+	tls := clusterCfg.TLS
+	if (tls.ClientCertFile == "") != (tls.ClientKeyFile == "") {
+		addProblem("%s tls should have both the client-cert-file and the client-key-file, or none of them", section)
+	}
+}
+
+func validateApiConfig(apiCfg ApiRoutesConfig, addProblem func(format string, args ...interface{})) {
+	if apiCfg.TLS.Enabled && (apiCfg.TLS.CertFile == "" || apiCfg.TLS.KeyFile == "") {
+		addProblem("api tls is enabled but the cert-file or the key-file is missing")
+	}
+	if apiCfg.RateLimit.RequestsPerSecond > 0 && apiCfg.RateLimit.Burst == 0 {
+		addProblem("api rate-limit burst should be greater than 0 when requests-per-second is set")
+	}
+	for _, user := range apiCfg.Auth.BasicAuthUsers {
+		if user.Username == "" {
+			addProblem("api auth has a basic auth user without username")
+		}
+	}
+
+	packageNames := make([]string, 0, len(apiCfg.APIPackages))
+	for packageName := range apiCfg.APIPackages {
+		packageNames = append(packageNames, packageName)
+	}
+	sort.Strings(packageNames)
+
+	for _, packageName := range packageNames {
+		for _, route := range apiCfg.APIPackages[packageName].Routes {
+			if !contains(supportedRouteAuthTypes, route.Auth) {
+				addProblem("api route %q of the package %q has the unknown auth type %q", route.Name, packageName, route.Auth)
+			}
+		}
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pelletier/go-toml"
+	"github.com/stretchr/testify/require"
+)
+
+func loadConfigs(t *testing.T) (Config, ClusterConfig, ApiRoutesConfig) {
+	cfg := Config{}
+	err := loadTomlFile(&cfg, "../cmd/elasticindexer/config/config.toml")
+	require.Nil(t, err)
+
+	clusterCfg := ClusterConfig{}
+	err = loadTomlFile(&clusterCfg, "../cmd/elasticindexer/config/prefs.toml")
+	require.Nil(t, err)
+
+	apiCfg := ApiRoutesConfig{}
+	err = loadTomlFile(&apiCfg, "../cmd/elasticindexer/config/api.toml")
+	require.Nil(t, err)
+
+	return cfg, clusterCfg, apiCfg
+}
+
+func loadTomlFile(dest interface{}, path string) error {
+	tree, err := toml.LoadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return tree.Unmarshal(dest)
+}
+
+func TestValidate_DefaultConfigs(t *testing.T) {
+	t.Parallel()
+
+	cfg, clusterCfg, apiCfg := loadConfigs(t)
+	require.Nil(t, Validate(cfg, clusterCfg, apiCfg))
+}
+
+func TestValidate_ReportsAllProblems(t *testing.T) {
+	t.Parallel()
+
+	cfg, clusterCfg, apiCfg := loadConfigs(t)
+	cfg.Config.Hasher.Type = "md5"
+	clusterCfg.Config.DisabledIndices = []string{"rating", "unknown"}
+	clusterCfg.Config.WebSocket.Mode = "peer"
+	clusterCfg.Config.WebSocket.DataMarshallerType = "xml"
+	clusterCfg.Config.ElasticCluster.BulkRequestMaxSizeInBytes = 0
+	clusterCfg.Config.DualWrite.Enabled = true
+	clusterCfg.Config.DualWrite.QueueSize = -1
+	apiCfg.TLS.Enabled = true
+	apiCfg.APIPackages["status"].Routes[0].Auth = "digest"
+
+	err := Validate(cfg, clusterCfg, apiCfg)
+	require.True(t, errors.Is(err, ErrInvalidConfig))
+
+	problems := strings.Split(err.Error(), "\n")
+	require.Equal(t, []string{
+		`invalid config: unsupported hasher type "md5", expected one of ["blake2b" "sha256" "keccak"]`,
+		`invalid config: disabled-indices contains the unknown index "unknown"`,
+		`invalid config: invalid web-socket mode "peer", expected one of ["client" "server"]`,
+		`invalid config: unsupported web-socket data-marshaller-type "xml", expected one of ["json" "gogo protobuf" "tx-json"]`,
+		`invalid config: elastic-cluster bulk-request-max-size-in-bytes should be greater than 0, got 0`,
+		`invalid config: dual-write queue-size should be greater than 0, got -1`,
+		`invalid config: api tls is enabled but the cert-file or the key-file is missing`,
+		`invalid config: api route "" of the package "status" has the unknown auth type "digest"`,
+	}, problems)
+}
+
+func TestValidate_ElasticCluster(t *testing.T) {
+	t.Parallel()
+
+	cfg, clusterCfg, apiCfg := loadConfigs(t)
+	clusterCfg.Config.ElasticCluster.URL = ""
+	clusterCfg.Config.ElasticCluster.Password = "secret"
+	clusterCfg.Config.ElasticCluster.TLS.ClientCertFile = "client.crt"
+
+	err := Validate(cfg, clusterCfg, apiCfg)
+	require.Equal(t, strings.Join([]string{
+		"invalid config: elastic-cluster should have an url, urls or a cloud-id",
+		"invalid config: elastic-cluster has a password but no username",
+		"invalid config: elastic-cluster tls should have both the client-cert-file and the client-key-file, or none of them",
+	}, "\n"), err.Error())
+
+	clusterCfg.Config.ElasticCluster.CloudID = "deployment:id"
+	clusterCfg.Config.ElasticCluster.UserName = "user"
+	clusterCfg.Config.ElasticCluster.TLS.ClientKeyFile = "client.key"
+	require.Nil(t, Validate(cfg, clusterCfg, apiCfg))
+}
@@ -5,5 +5,35 @@ import "errors"
 // ErrNilMetricsHandler signals that a nil metrics handler has been provided
 var ErrNilMetricsHandler = errors.New("nil metrics handler")
 
+// ErrNilIndexingStatusHandler signals that a nil indexing status handler has been provided
+var ErrNilIndexingStatusHandler = errors.New("nil indexing status handler")
+
+// ErrNilIndexingController signals that a nil indexing controller has been provided
+var ErrNilIndexingController = errors.New("nil indexing controller")
+
+// ErrNilIndicesHandler signals that a nil indices handler has been provided
+var ErrNilIndicesHandler = errors.New("nil indices handler")
+
+// ErrIndicesHandlerNotSet signals that the indices handler was not set yet, the indexer is still starting
+var ErrIndicesHandlerNotSet = errors.New("indices handler not set")
+
+// ErrIndexingDrained signals that the indexing was drained and no more payloads will be processed
+var ErrIndexingDrained = errors.New("indexing is drained")
+
+// ErrUnknownIndex signals that an unknown index has been provided
+var ErrUnknownIndex = errors.New("unknown index")
+
 // ErrNilFacadeHandler signal that a nil facade handler has been provided
 var ErrNilFacadeHandler = errors.New("nil facade handler")
+
+// ErrNilDataProvider signals that a nil data provider has been provided
+var ErrNilDataProvider = errors.New("nil data provider")
+
+// ErrNotFound signals that the requested data was not found
+var ErrNotFound = errors.New("not found")
+
+// ErrInvalidPageSize signals that an invalid page size has been provided
+var ErrInvalidPageSize = errors.New("invalid page size")
+
+// ErrInvalidSearchAfter signals that an invalid search after cursor has been provided
+var ErrInvalidSearchAfter = errors.New("invalid search after cursor")
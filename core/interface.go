@@ -13,6 +13,39 @@ type StatusMetricsHandler interface {
 	IsInterfaceNil() bool
 }
 
+// IndexingStatusHandler defines the behavior of a component that keeps track of the indexer's state
+type IndexingStatusHandler interface {
+	SetTemplatesChecked()
+	SetPayloadReceived()
+	SetLastIndexedBlock(shardID uint32, nonce uint64, round uint64, timestamp uint64)
+	IsTemplatesChecked() bool
+	IsWebSocketConnected() bool
+	GetIndexingStatus() map[uint32]*request.ShardIndexingStatus
+	IsInterfaceNil() bool
+}
+
+// IndicesHandler defines the behavior of a component that can enable or disable indices at runtime
+type IndicesHandler interface {
+	SetIndexEnabled(index string, enabled bool) error
+	GetEnabledIndexes() []string
+	IsInterfaceNil() bool
+}
+
+// IndexingControllerHandler defines the behavior of a component that is able to pause, resume or drain the indexing
+type IndexingControllerHandler interface {
+	StartProcessing() error
+	DoneProcessing()
+	Pause() error
+	Resume() error
+	Drain()
+	DrainedChan() <-chan struct{}
+	GetState() string
+	SetIndicesHandler(handler IndicesHandler) error
+	SetIndexEnabled(index string, enabled bool) error
+	GetEnabledIndexes() ([]string, error)
+	IsInterfaceNil() bool
+}
+
 // WebServerHandler defines the behavior of a component that handles the web server
 type WebServerHandler interface {
 	StartHttpServer() error
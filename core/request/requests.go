@@ -25,15 +25,23 @@ const (
 	UpdateTopic string = "req_update"
 	// ScrollTopic is the identifier for the scroll requests metrics
 	ScrollTopic string = "req_scroll"
+	// DualWritePrimaryTopic is the identifier for the write operations sent to the primary cluster in dual-write mode
+	DualWritePrimaryTopic string = "dual_write_primary"
+	// DualWriteSecondaryTopic is the identifier for the write operations sent to the secondary cluster in dual-write mode
+	DualWriteSecondaryTopic string = "dual_write_secondary"
+	// EventProcessingTopic is the identifier for the events which could not be processed
+	EventProcessingTopic string = "event_processing"
 )
 
 // MetricsResponse defines the response for status metrics endpoint
 type MetricsResponse struct {
-	TotalData         uint64         `json:"total_data"`
-	OperationsCount   uint64         `json:"operations_count"`
-	TotalErrorsCount  uint64         `json:"total_errors_count"`
-	ErrorsCount       map[int]uint64 `json:"errors_count,omitempty"`
-	TotalIndexingTime time.Duration  `json:"total_time"`
+	TotalData uint64 `json:"total_data"`
+	// TotalCompressedData holds the size of the compressed request bodies, it is set only for the compressed requests
+	TotalCompressedData uint64         `json:"total_compressed_data,omitempty"`
+	OperationsCount     uint64         `json:"operations_count"`
+	TotalErrorsCount    uint64         `json:"total_errors_count"`
+	ErrorsCount         map[int]uint64 `json:"errors_count,omitempty"`
+	TotalIndexingTime   time.Duration  `json:"total_time"`
 }
 
 // ExtendTopicWithShardID will concatenate topic with shardID
@@ -57,3 +65,20 @@ func SplitTopicAndShardID(topicWithShardID string) (string, string) {
 
 	return strings.Join(split[:shardIDIndex], separator), shardIDStr
 }
+
+// ShardIndexingStatus defines the response for the indexing status of a shard
+type ShardIndexingStatus struct {
+	Nonce     uint64 `json:"nonce"`
+	Round     uint64 `json:"round"`
+	Timestamp uint64 `json:"timestamp"`
+	LagInSec  int64  `json:"lagInSec"`
+}
+
+// ReadinessResponse defines the response for the readiness endpoint
+type ReadinessResponse struct {
+	Ready                  bool   `json:"ready"`
+	ElasticsearchReachable bool   `json:"elasticsearchReachable"`
+	TemplatesChecked       bool   `json:"templatesChecked"`
+	WebSocketConnected     bool   `json:"webSocketConnected"`
+	IndexingState          string `json:"indexingState"`
+}
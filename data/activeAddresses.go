@@ -0,0 +1,22 @@
+package data
+
+import "time"
+
+// ActiveAddresses holds the addresses which were active in a block, which are added in the active addresses of a
+// period (a day or an epoch), either for the whole chain or for a smart contract. For the whole chain, the active
+// addresses are the senders of the transactions and the smart contracts which were called, while for a smart contract
+// they are its callers. The registers hold the hyperloglog sketch of the addresses, used once the period has too many
+// addresses to be kept in an exact set. The shard, the nonce and the hash of the block identify the block, so it is
+// added only once, and it can be removed from the exact set when it is reverted
+type ActiveAddresses struct {
+	Period     string           `json:"period"`
+	Timestamp  time.Duration    `json:"timestamp"`
+	Epoch      uint32           `json:"epoch"`
+	Contract   string           `json:"contract,omitempty"`
+	ShardID    uint32           `json:"shardID"`
+	Nonce      uint64           `json:"nonce"`
+	HeaderHash string           `json:"headerHash"`
+	Revert     bool             `json:"revert"`
+	Addresses  []string         `json:"addresses"`
+	Registers  map[uint32]uint8 `json:"registers"`
+}
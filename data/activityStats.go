@@ -0,0 +1,21 @@
+package data
+
+import "time"
+
+// ActivityStats holds the activity of a block, which is added in the hourly and the daily activity rollups. The
+// transactions are counted in the shard they were sent from, and the volumes hold only the transfers which did not
+// fail. The activity of a reverted block is subtracted with negative values, while its senders are not removed from
+// the unique senders sketch. The timestamp of the block is replaced by the start of the rollup period when the activity
+// is serialized
+type ActivityStats struct {
+	Timestamp        time.Duration     `json:"timestamp"`
+	ShardID          uint32            `json:"shardID"`
+	Nonce            uint64            `json:"nonce"`
+	Revert           bool              `json:"revert"`
+	NumTransactions  int64             `json:"numTransactions"`
+	EGLDVolume       string            `json:"egldVolume"`
+	TokensVolume     map[string]string `json:"tokensVolume"`
+	Fees             string            `json:"fees"`
+	GasUsed          int64             `json:"gasUsed"`
+	SendersRegisters map[uint32]uint8  `json:"sendersRegisters"`
+}
@@ -0,0 +1,76 @@
+package data
+
+import "encoding/json"
+
+// Pagination holds the parameters used for a paginated request
+type Pagination struct {
+	Size        int
+	SearchAfter string
+}
+
+// EventsFilter holds the parameters used for filtering the events
+type EventsFilter struct {
+	Address    string
+	Identifier string
+	TxHash     string
+	ShardID    *uint32
+}
+
+// ResponseSearch defines the generic structure for an Elasticsearch search request
+type ResponseSearch struct {
+	Hits struct {
+		Hits []*ResponseSearchHit `json:"hits"`
+	} `json:"hits"`
+}
+
+// ResponseSearchHit defines the structure of a hit from an Elasticsearch search response
+type ResponseSearchHit struct {
+	ID     string          `json:"_id"`
+	Source json.RawMessage `json:"_source"`
+	Sort   []interface{}   `json:"sort"`
+}
+
+// ResponseMultiGet defines the generic structure for an Elasticsearch multi get response
+type ResponseMultiGet struct {
+	Docs []struct {
+		Found  bool            `json:"found"`
+		ID     string          `json:"_id"`
+		Source json.RawMessage `json:"_source"`
+	} `json:"docs"`
+}
+
+// TransactionResponse is the DTO returned by the API for a transaction
+type TransactionResponse struct {
+	Hash string `json:"hash"`
+	Transaction
+}
+
+// BlockResponse is the DTO returned by the API for a block
+type BlockResponse struct {
+	Hash string `json:"hash"`
+	Block
+}
+
+// AccountHistoryResponse is the DTO returned by the API for a page of an account's balance history
+type AccountHistoryResponse struct {
+	History     []*AccountBalanceHistory `json:"history"`
+	SearchAfter string                   `json:"searchAfter,omitempty"`
+}
+
+// AccountTokensResponse is the DTO returned by the API for a page of an account's tokens
+type AccountTokensResponse struct {
+	Tokens      []*AccountInfo `json:"tokens"`
+	SearchAfter string         `json:"searchAfter,omitempty"`
+}
+
+// EventResponse is the DTO returned by the API for an event
+type EventResponse struct {
+	ID string `json:"id"`
+	LogEvent
+}
+
+// EventsResponse is the DTO returned by the API for a page of events
+type EventsResponse struct {
+	Events      []*EventResponse `json:"events"`
+	SearchAfter string           `json:"searchAfter,omitempty"`
+}
@@ -1,6 +1,9 @@
 package data
 
-import "bytes"
+import (
+	"bytes"
+	"encoding/json"
+)
 
 // DefaultMaxBulkSize is the constant for the maximum size of one bulk request that is sent to the Elasticsearch database
 const DefaultMaxBulkSize = 4194304 // 4MB
@@ -10,6 +13,7 @@ type BufferSlice struct {
 	buffSlice         []*bytes.Buffer
 	bulkSizeThreshold int
 	idx               int
+	encoder           *json.Encoder
 }
 
 // NewBufferSlice will create a new buffer
@@ -18,23 +22,22 @@ func NewBufferSlice(bulkSizeThreshold int) *BufferSlice {
 		bulkSizeThreshold = DefaultMaxBulkSize
 	}
 
-	return &BufferSlice{
+	bs := &BufferSlice{
 		buffSlice:         make([]*bytes.Buffer, 0),
 		bulkSizeThreshold: bulkSizeThreshold,
 		idx:               0,
 	}
+	bs.encoder = json.NewEncoder(&currentBufferWriter{bufferSlice: bs})
+
+	return bs
 }
 
 // PutData will put meta bytes and serializeData in buffer
 func (bs *BufferSlice) PutData(meta []byte, serializedData []byte) error {
-	if len(bs.buffSlice) == 0 {
-		bs.buffSlice = append(bs.buffSlice, &bytes.Buffer{})
-	}
-
-	currentBuff := bs.buffSlice[bs.idx]
+	currentBuff := bs.currentBuffer()
 
 	if bs.aNewElementIsNeeded(meta, serializedData) {
-		currentBuff = &bytes.Buffer{}
+		currentBuff = getBuffer()
 		bs.buffSlice = append(bs.buffSlice, currentBuff)
 		bs.idx++
 	}
@@ -56,6 +59,81 @@ func (bs *BufferSlice) PutData(meta []byte, serializedData []byte) error {
 	return nil
 }
 
+// PutDocument will write the action line and the JSON encoding of the document directly into the current buffer
+func (bs *BufferSlice) PutDocument(meta *MetaTemplate, id string, document interface{}) error {
+	return bs.PutEnclosedDocument(meta, id, nil, document)
+}
+
+// PutEnclosedDocument will write the action line and the JSON encoding of the document, enclosed by the constant parts
+// of the provided template, directly into the current buffer. The output is the same as the one of the PutData method
+// called with the json.Marshal output, without the intermediary allocations
+func (bs *BufferSlice) PutEnclosedDocument(meta *MetaTemplate, id string, docTemplate *DocumentTemplate, document interface{}) error {
+	currentBuff := bs.currentBuffer()
+	startLen := currentBuff.Len()
+
+	err := bs.writeDocument(currentBuff, meta, id, docTemplate, document)
+	if err != nil {
+		currentBuff.Truncate(startLen)
+		return err
+	}
+
+	// the trailing new line is not taken into account, as in the aNewElementIsNeeded method
+	sizeExceeded := currentBuff.Len()-1 > bs.bulkSizeThreshold
+	if !sizeExceeded || startLen == 0 {
+		return nil
+	}
+
+	newBuff := getBuffer()
+	_, _ = newBuff.Write(currentBuff.Bytes()[startLen:])
+	currentBuff.Truncate(startLen)
+	bs.buffSlice = append(bs.buffSlice, newBuff)
+	bs.idx++
+
+	return nil
+}
+
+func (bs *BufferSlice) writeDocument(buff *bytes.Buffer, meta *MetaTemplate, id string, docTemplate *DocumentTemplate, document interface{}) error {
+	_, _ = buff.Write(meta.prefix)
+	err := bs.encodeWithoutNewLine(buff, id)
+	if err != nil {
+		return err
+	}
+	_, _ = buff.Write(meta.suffix)
+
+	if docTemplate != nil {
+		_, _ = buff.Write(docTemplate.prefix)
+	}
+	err = bs.encodeWithoutNewLine(buff, document)
+	if err != nil {
+		return err
+	}
+	if docTemplate != nil {
+		_, _ = buff.Write(docTemplate.suffix)
+	}
+
+	return buff.WriteByte('\n')
+}
+
+// encodeWithoutNewLine writes the JSON encoding of the value at the end of the current buffer. The encoder escapes the
+// output in the same way as json.Marshal, but it terminates each value with a new line, which is removed
+func (bs *BufferSlice) encodeWithoutNewLine(buff *bytes.Buffer, value interface{}) error {
+	err := bs.encoder.Encode(value)
+	if err != nil {
+		return err
+	}
+
+	buff.Truncate(buff.Len() - 1)
+	return nil
+}
+
+func (bs *BufferSlice) currentBuffer() *bytes.Buffer {
+	if len(bs.buffSlice) == 0 {
+		bs.buffSlice = append(bs.buffSlice, getBuffer())
+	}
+
+	return bs.buffSlice[bs.idx]
+}
+
 // Buffers will return the slice of buffers
 func (bs *BufferSlice) Buffers() []*bytes.Buffer {
 	return bs.buffSlice
@@ -68,3 +146,12 @@ func (bs *BufferSlice) aNewElementIsNeeded(meta []byte, serializedData []byte) b
 
 	return buffLenWithCurrentAcc > bs.bulkSizeThreshold && currentBuff.Len() != 0
 }
+
+type currentBufferWriter struct {
+	bufferSlice *BufferSlice
+}
+
+// Write will append the provided bytes to the current buffer of the buffer slice
+func (cbw *currentBufferWriter) Write(p []byte) (int, error) {
+	return cbw.bufferSlice.currentBuffer().Write(p)
+}
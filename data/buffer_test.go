@@ -1,7 +1,11 @@
 package data
 
 import (
+	"bytes"
 	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -49,6 +53,73 @@ func TestBufferSlice_PutDataShouldWorkNilSerializedDataSize1(t *testing.T) {
 	require.Equal(t, "my dataserialized\n", returnedBuffSlice[0].String())
 }
 
+func TestBufferSlice_PutDocumentShouldWriteTheSameBytesAsPutData(t *testing.T) {
+	doc := &Transaction{Hash: "txHash", Sender: "<sender>", Data: []byte("a&b"), Value: "10"}
+	id := `weird"id<>`
+	format := `{ "index" : { "_index":"%s", "_id" : "%s" } }`
+
+	serializedDoc, _ := json.Marshal(doc)
+	escapedID, _ := json.Marshal(id)
+	expectedSlice := NewBufferSlice(0)
+	err := expectedSlice.PutData([]byte(fmt.Sprintf(`{ "index" : { "_index":"%s", "_id" : %s } }%s`, "transactions", escapedID, "\n")), serializedDoc)
+	require.Nil(t, err)
+
+	buffSlice := NewBufferSlice(0)
+	err = buffSlice.PutDocument(NewMetaTemplate(format, "transactions"), id, doc)
+	require.Nil(t, err)
+	require.Equal(t, expectedSlice.Buffers()[0].String(), buffSlice.Buffers()[0].String())
+}
+
+func TestBufferSlice_PutEnclosedDocument(t *testing.T) {
+	buffSlice := NewBufferSlice(0)
+	meta := NewMetaTemplate(`{"update":{"_index":"%s","_id":"%s"}}`, "accounts")
+	docTemplate := NewDocumentTemplate(`{"script":{"source":"return"},"upsert":`, `}`)
+
+	err := buffSlice.PutEnclosedDocument(meta, "addr", docTemplate, &AccountInfo{Address: "addr", Balance: "1"})
+	require.Nil(t, err)
+
+	expected := `{"update":{"_index":"accounts","_id":"addr"}}
+{"script":{"source":"return"},"upsert":{"address":"addr","balance":"1","balanceNum":0,"shardID":0}}
+`
+	require.Equal(t, expected, buffSlice.Buffers()[0].String())
+}
+
+func TestBufferSlice_PutDocumentShouldStartANewBufferWhenTheThresholdIsExceeded(t *testing.T) {
+	meta := NewMetaTemplate(`{"index":{"_index":"%s","_id":"%s"}}`, "index")
+	buffSlice := NewBufferSlice(60)
+
+	require.Nil(t, buffSlice.PutDocument(meta, "id1", "document-1"))
+	require.Nil(t, buffSlice.PutDocument(meta, "id2", "document-2"))
+
+	buffers := buffSlice.Buffers()
+	require.Len(t, buffers, 2)
+	require.Equal(t, "{\"index\":{\"_index\":\"index\",\"_id\":\"id1\"}}\n\"document-1\"\n", buffers[0].String())
+	require.Equal(t, "{\"index\":{\"_index\":\"index\",\"_id\":\"id2\"}}\n\"document-2\"\n", buffers[1].String())
+}
+
+func TestBufferSlice_PutDocumentEncodingErrorShouldNotWritePartialData(t *testing.T) {
+	meta := NewMetaTemplate(`{"index":{"_index":"%s","_id":"%s"}}`, "index")
+	buffSlice := NewBufferSlice(0)
+
+	require.Nil(t, buffSlice.PutDocument(meta, "id1", "document-1"))
+	err := buffSlice.PutDocument(meta, "id2", math.Inf(1))
+	require.NotNil(t, err)
+	require.Equal(t, "{\"index\":{\"_index\":\"index\",\"_id\":\"id1\"}}\n\"document-1\"\n", buffSlice.Buffers()[0].String())
+}
+
+func TestReleaseBuffers(t *testing.T) {
+	buffSlice := NewBufferSlice(0)
+	require.Nil(t, buffSlice.PutData([]byte("meta"), []byte("data")))
+
+	largeBuffer := bytes.NewBuffer(make([]byte, 0, maxPooledBufferCapacity+1))
+	ReleaseBuffers(append(buffSlice.Buffers(), nil, largeBuffer))
+
+	// the released buffers are reset, so the next requests do not contain old data
+	newSlice := NewBufferSlice(0)
+	require.Nil(t, newSlice.PutData([]byte("meta2"), nil))
+	require.Equal(t, "meta2", newSlice.Buffers()[0].String())
+}
+
 func generateRandomBytes(n int) []byte {
 	b := make([]byte, n)
 	_, _ = rand.Read(b)
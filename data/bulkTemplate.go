@@ -0,0 +1,82 @@
+package data
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// maxPooledBufferCapacity is the capacity above which a released buffer is left to the garbage collector, so the pool
+// does not keep alive the memory of an unusually large bulk request
+const maxPooledBufferCapacity = 4 * DefaultMaxBulkSize
+
+// idPlaceholder marks the position of the document id in the formatted action line
+const idPlaceholder = "\x00"
+
+var buffersPool = sync.Pool{
+	New: func() interface{} {
+		return &bytes.Buffer{}
+	},
+}
+
+// MetaTemplate holds the precomputed parts of the action line of a bulk request. Only the document id is written for
+// every document
+type MetaTemplate struct {
+	prefix []byte
+	suffix []byte
+}
+
+// NewMetaTemplate will create the action line template for the provided index. The format receives the index and the
+// document id, the id verb being quoted, e.g. `{ "index" : { "_index":"%s", "_id" : "%s" } }`
+func NewMetaTemplate(format string, index string) *MetaTemplate {
+	quotedPlaceholder := `"` + idPlaceholder + `"`
+	formatted := fmt.Sprintf(format, index, idPlaceholder)
+	parts := strings.SplitN(formatted, quotedPlaceholder, 2)
+	if len(parts) != 2 {
+		return &MetaTemplate{
+			prefix: []byte(formatted),
+			suffix: []byte("\n"),
+		}
+	}
+
+	return &MetaTemplate{
+		prefix: []byte(parts[0]),
+		suffix: []byte(parts[1] + "\n"),
+	}
+}
+
+// DocumentTemplate holds the constant parts which enclose a serialized document, such as the painless script of an
+// upsert request
+type DocumentTemplate struct {
+	prefix []byte
+	suffix []byte
+}
+
+// NewDocumentTemplate will create a document template from the parts written before and after the document
+func NewDocumentTemplate(prefix string, suffix string) *DocumentTemplate {
+	return &DocumentTemplate{
+		prefix: []byte(prefix),
+		suffix: []byte(suffix),
+	}
+}
+
+// ReleaseBuffers will return the provided buffers to the pool. It should be called only after the buffers were sent
+// and no other component holds a reference to them
+func ReleaseBuffers(buffers []*bytes.Buffer) {
+	for _, buff := range buffers {
+		if buff == nil || buff.Cap() > maxPooledBufferCapacity {
+			continue
+		}
+
+		buff.Reset()
+		buffersPool.Put(buff)
+	}
+}
+
+func getBuffer() *bytes.Buffer {
+	buff := buffersPool.Get().(*bytes.Buffer)
+	buff.Reset()
+
+	return buff
+}
@@ -0,0 +1,27 @@
+package data
+
+import "time"
+
+// ContractStats holds the interactions of a block with a smart contract, which are added in the document of the smart
+// contract. The interactions are counted in the shard of the smart contract, the calls are the transactions and the
+// smart contract results with a function, and the received values hold only the transfers which did not fail. The
+// deploy and the owner change hold the deploy, upgrade and change owner events of the smart contract from the block.
+// The counters of a reverted block are subtracted with negative values
+type ContractStats struct {
+	Address          string            `json:"address"`
+	HeaderHash       string            `json:"headerHash"`
+	Nonce            uint64            `json:"nonce"`
+	Timestamp        time.Duration     `json:"timestamp"`
+	Revert           bool              `json:"revert"`
+	NumCalls         int64             `json:"numCalls"`
+	NumFailedCalls   int64             `json:"numFailedCalls"`
+	Calls            map[string]int64  `json:"calls"`
+	FailedCalls      map[string]int64  `json:"failedCalls"`
+	GasUsed          int64             `json:"gasUsed"`
+	EGLDReceived     string            `json:"egldReceived"`
+	ESDTReceived     map[string]string `json:"esdtReceived"`
+	Callers          []string          `json:"callers"`
+	CallersRegisters map[uint32]uint8  `json:"callersRegisters"`
+	Deploy           *ScDeployInfo     `json:"deploy,omitempty"`
+	OwnerChange      *OwnerData        `json:"ownerChange,omitempty"`
+}
@@ -22,6 +22,15 @@ type ValidatorRatingInfo struct {
 	Rating    float32 `json:"rating"`
 }
 
+// ValidatorRatingHistory is a structure containing the rating of a validator in an epoch and the timestamp of the epoch
+// start block
+type ValidatorRatingHistory struct {
+	PublicKey string        `json:"publicKey"`
+	Epoch     uint32        `json:"epoch"`
+	Rating    float32       `json:"rating"`
+	Timestamp time.Duration `json:"timestamp,omitempty"`
+}
+
 // RoundInfo is a structure containing block signers and shard id
 type RoundInfo struct {
 	Round            uint64        `json:"round"`
@@ -0,0 +1,29 @@
+package data
+
+import "time"
+
+// EpochSummary holds the activity of a shard in an epoch, accumulated from the blocks of the shard. The transactions and
+// the smart contract results are counted in the shard they were sent from, so the cross-shard ones are counted once.
+// The protocol fees are the accumulated fees which are not sent to the developers, i.e. the burnt fees and the fees
+// distributed as rewards
+type EpochSummary struct {
+	Epoch                uint32        `json:"epoch"`
+	ShardID              uint32        `json:"shardID"`
+	FirstNonce           uint64        `json:"firstNonce"`
+	LastNonce            uint64        `json:"lastNonce"`
+	StartTimestamp       time.Duration `json:"startTimestamp"`
+	LastTimestamp        time.Duration `json:"lastTimestamp"`
+	NumBlocks            uint64        `json:"numBlocks"`
+	NumTransactions      uint64        `json:"numTransactions"`
+	NumScResults         uint64        `json:"numScResults"`
+	NumEvents            uint64        `json:"numEvents"`
+	GasUsed              uint64        `json:"gasUsed"`
+	AccumulatedFees      string        `json:"accumulatedFees"`
+	DeveloperFees        string        `json:"developerFees"`
+	ProtocolFees         string        `json:"protocolFees"`
+	ActiveAccounts       uint64        `json:"activeAccounts"`
+	NewAccounts          uint64        `json:"newAccounts"`
+	NewTokens            uint64        `json:"newTokens"`
+	NFTMints             uint64        `json:"nftMints"`
+	ActiveAccountsSketch string        `json:"activeAccountsSketch"`
+}
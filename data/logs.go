@@ -40,4 +40,5 @@ type PreparedLogsResults struct {
 	TokenRolesAndProperties *tokeninfo.TokenRolesAndProperties
 	DBLogs                  []*Logs
 	DBEvents                []*LogEvent
+	ProcessingErrors        []*ProcessingError
 }
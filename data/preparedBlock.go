@@ -0,0 +1,20 @@
+package data
+
+import "bytes"
+
+// PreparedBlock holds the serialized data of a block, which is ready to be sent to the database
+type PreparedBlock struct {
+	ShardID    uint32
+	Nonce      uint64
+	Round      uint64
+	Timestamp  uint64
+	HeaderHash []byte
+	Buffers    []*bytes.Buffer
+	TokensInfo []*TokenInfo
+}
+
+// MustBeCommittedBeforeNextBlock returns true if the block changes the type or the owner of some tokens. This data is
+// read from the database while the next block is prepared, so the next block can be prepared only after the commit
+func (pb *PreparedBlock) MustBeCommittedBeforeNextBlock() bool {
+	return len(pb.TokensInfo) > 0
+}
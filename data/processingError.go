@@ -0,0 +1,16 @@
+package data
+
+import "time"
+
+// ProcessingError holds the details of an event which could not be processed
+type ProcessingError struct {
+	UUID       string        `json:"uuid"`
+	ID         string        `json:"-"`
+	TxHash     string        `json:"txHash"`
+	Order      int           `json:"order"`
+	Identifier string        `json:"identifier"`
+	Address    string        `json:"address"`
+	Error      string        `json:"error"`
+	ShardID    uint32        `json:"shardID"`
+	Timestamp  time.Duration `json:"timestamp,omitempty"`
+}
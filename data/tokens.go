@@ -60,6 +60,7 @@ type TokenInfo struct {
 	Data              *TokenMetaData   `json:"data,omitempty"`
 	OwnersHistory     []*OwnerData     `json:"ownersHistory,omitempty"`
 	TransferOwnership bool             `json:"-"`
+	NewToken          bool             `json:"-"`
 	ChangeToDynamic   bool             `json:"-"`
 	Properties        *TokenProperties `json:"properties,omitempty"`
 }
@@ -0,0 +1,47 @@
+package data
+
+// ValidatorStats holds the activity of a validator in an epoch, computed from the rounds of its shard
+type ValidatorStats struct {
+	PublicKey         string  `json:"publicKey"`
+	ShardID           uint32  `json:"shardID"`
+	Epoch             uint32  `json:"epoch"`
+	BlocksProposed    uint64  `json:"blocksProposed"`
+	BlocksMissed      uint64  `json:"blocksMissed"`
+	RoundsSigned      uint64  `json:"roundsSigned"`
+	RoundsEligible    uint64  `json:"roundsEligible"`
+	LeaderSuccessRate float64 `json:"leaderSuccessRate"`
+	RoundsSignedRate  float64 `json:"roundsSignedRate"`
+	LastRound         uint64  `json:"lastRound"`
+	Timestamp         uint64  `json:"timestamp"`
+}
+
+// ValidatorStatsRating holds the rating of a validator in an epoch, which is saved in the same document as its
+// ValidatorStats. The previous epoch rating and the change are missing when the previous epoch was not indexed
+type ValidatorStatsRating struct {
+	PublicKey           string   `json:"publicKey"`
+	Epoch               uint32   `json:"epoch"`
+	Rating              float32  `json:"rating"`
+	PreviousEpochRating *float32 `json:"previousEpochRating,omitempty"`
+	RatingChange        *float32 `json:"ratingChange,omitempty"`
+}
+
+// ResponseValidatorsPublicKeys is the structure for the validators public keys response
+type ResponseValidatorsPublicKeys struct {
+	Docs []struct {
+		Found  bool                 `json:"found"`
+		ID     string               `json:"_id"`
+		Source ValidatorsPublicKeys `json:"_source"`
+	} `json:"docs"`
+}
+
+// ResponseValidatorsStatsRating is the structure for the validator stats response, holding only the rating. The rating
+// is missing for the documents created only from rounds
+type ResponseValidatorsStatsRating struct {
+	Docs []struct {
+		Found  bool   `json:"found"`
+		ID     string `json:"_id"`
+		Source struct {
+			Rating *float32 `json:"rating"`
+		} `json:"_source"`
+	} `json:"docs"`
+}
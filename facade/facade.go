@@ -4,34 +4,151 @@ import (
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-es-indexer-go/core"
 	"github.com/multiversx/mx-chain-es-indexer-go/core/request"
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/wsindexer"
 )
 
-type metricsFacade struct {
-	statusMetrics core.StatusMetricsHandler
+// ArgsIndexerFacade holds all the components needed to create a new instance of indexerFacade
+type ArgsIndexerFacade struct {
+	StatusMetrics      core.StatusMetricsHandler
+	IndexingStatus     core.IndexingStatusHandler
+	IndexingController core.IndexingControllerHandler
+	DataProvider       DataProviderHandler
 }
 
-// NewMetricsFacade will create a new instance of metricsFacade
-func NewMetricsFacade(statusMetrics core.StatusMetricsHandler) (*metricsFacade, error) {
-	if check.IfNil(statusMetrics) {
+type indexerFacade struct {
+	statusMetrics      core.StatusMetricsHandler
+	indexingStatus     core.IndexingStatusHandler
+	indexingController core.IndexingControllerHandler
+	dataProvider       DataProviderHandler
+}
+
+// NewIndexerFacade will create a new instance of indexerFacade
+func NewIndexerFacade(args ArgsIndexerFacade) (*indexerFacade, error) {
+	if check.IfNil(args.StatusMetrics) {
 		return nil, core.ErrNilMetricsHandler
 	}
+	if check.IfNil(args.IndexingStatus) {
+		return nil, core.ErrNilIndexingStatusHandler
+	}
+	if check.IfNil(args.IndexingController) {
+		return nil, core.ErrNilIndexingController
+	}
+	if check.IfNil(args.DataProvider) {
+		return nil, core.ErrNilDataProvider
+	}
 
-	return &metricsFacade{
-		statusMetrics: statusMetrics,
+	return &indexerFacade{
+		statusMetrics:      args.StatusMetrics,
+		indexingStatus:     args.IndexingStatus,
+		indexingController: args.IndexingController,
+		dataProvider:       args.DataProvider,
 	}, nil
 }
 
 // GetMetrics will return metrics in json format
-func (mf *metricsFacade) GetMetrics() map[string]*request.MetricsResponse {
-	return mf.statusMetrics.GetMetrics()
+func (f *indexerFacade) GetMetrics() map[string]*request.MetricsResponse {
+	return f.statusMetrics.GetMetrics()
 }
 
 // GetMetricsForPrometheus will return metrics in prometheus format
-func (mf *metricsFacade) GetMetricsForPrometheus() string {
-	return mf.statusMetrics.GetMetricsForPrometheus()
+func (f *indexerFacade) GetMetricsForPrometheus() string {
+	return f.statusMetrics.GetMetricsForPrometheus()
+}
+
+// IsAlive returns true if the process is able to serve requests
+func (f *indexerFacade) IsAlive() bool {
+	return true
+}
+
+// GetReadiness will check if the indexer is ready to index data: Elasticsearch is reachable, the templates were
+// checked and the WebSocket connection is established. The WebSocket connection is required only while the indexing is
+// running, since no payloads are received while the indexing is paused or drained by an admin
+func (f *indexerFacade) GetReadiness() *request.ReadinessResponse {
+	readiness := &request.ReadinessResponse{
+		ElasticsearchReachable: f.dataProvider.IsElasticsearchReachable(),
+		TemplatesChecked:       f.indexingStatus.IsTemplatesChecked(),
+		WebSocketConnected:     f.indexingStatus.IsWebSocketConnected(),
+		IndexingState:          f.indexingController.GetState(),
+	}
+	isWebSocketRequired := readiness.IndexingState == wsindexer.StateRunning
+	isWebSocketReady := readiness.WebSocketConnected || !isWebSocketRequired
+	readiness.Ready = readiness.ElasticsearchReachable && readiness.TemplatesChecked && isWebSocketReady
+
+	return readiness
+}
+
+// GetIndexingStatus returns, for each shard, the last indexed block and the lag versus the wall-clock
+func (f *indexerFacade) GetIndexingStatus() map[uint32]*request.ShardIndexingStatus {
+	return f.indexingStatus.GetIndexingStatus()
+}
+
+// PauseIndexing will hold back the processing of the new payloads
+func (f *indexerFacade) PauseIndexing() error {
+	return f.indexingController.Pause()
+}
+
+// ResumeIndexing will restart the processing of the payloads
+func (f *indexerFacade) ResumeIndexing() error {
+	return f.indexingController.Resume()
+}
+
+// DrainIndexing will finish the in-flight payloads and then the indexer will exit
+func (f *indexerFacade) DrainIndexing() {
+	f.indexingController.Drain()
+}
+
+// GetIndexingState returns the current state of the indexing
+func (f *indexerFacade) GetIndexingState() string {
+	return f.indexingController.GetState()
+}
+
+// GetEnabledIndexes returns the list of the enabled indices
+func (f *indexerFacade) GetEnabledIndexes() ([]string, error) {
+	return f.indexingController.GetEnabledIndexes()
+}
+
+// SetIndexEnabled will enable or disable the indexing of the provided index at runtime
+func (f *indexerFacade) SetIndexEnabled(index string, enabled bool) error {
+	return f.indexingController.SetIndexEnabled(index, enabled)
+}
+
+// GetTransaction will return the transaction with the provided hash
+func (f *indexerFacade) GetTransaction(hash string) (*data.TransactionResponse, error) {
+	return f.dataProvider.GetTransaction(hash)
+}
+
+// GetAccount will return the account with the provided address
+func (f *indexerFacade) GetAccount(address string) (*data.AccountInfo, error) {
+	return f.dataProvider.GetAccount(address)
+}
+
+// GetAccountHistory will return a page with the balance history of the provided address
+func (f *indexerFacade) GetAccountHistory(address string, pagination data.Pagination) (*data.AccountHistoryResponse, error) {
+	return f.dataProvider.GetAccountHistory(address, pagination)
+}
+
+// GetAccountTokens will return a page with the tokens of the provided address
+func (f *indexerFacade) GetAccountTokens(address string, pagination data.Pagination) (*data.AccountTokensResponse, error) {
+	return f.dataProvider.GetAccountTokens(address, pagination)
+}
+
+// GetToken will return the token with the provided identifier
+func (f *indexerFacade) GetToken(identifier string) (*data.TokenInfo, error) {
+	return f.dataProvider.GetToken(identifier)
+}
+
+// GetBlock will return the block from the provided shard with the provided nonce
+func (f *indexerFacade) GetBlock(shardID uint32, nonce uint64) (*data.BlockResponse, error) {
+	return f.dataProvider.GetBlock(shardID, nonce)
+}
+
+// GetEvents will return a page with the events that match the provided filter
+func (f *indexerFacade) GetEvents(filter data.EventsFilter, pagination data.Pagination) (*data.EventsResponse, error) {
+	return f.dataProvider.GetEvents(filter, pagination)
 }
 
 // IsInterfaceNil returns true if there is no value under the interface
-func (mf *metricsFacade) IsInterfaceNil() bool {
-	return mf == nil
+func (f *indexerFacade) IsInterfaceNil() bool {
+	return f == nil
 }
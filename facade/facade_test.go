@@ -0,0 +1,45 @@
+package facade
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-es-indexer-go/metrics"
+	"github.com/multiversx/mx-chain-es-indexer-go/mock"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/dataprovider"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/wsindexer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexerFacade_GetReadinessShouldNotRequireTheWebSocketWhileNotRunning(t *testing.T) {
+	t.Parallel()
+
+	indexingStatus := metrics.NewIndexingStatus()
+	indexingStatus.SetTemplatesChecked()
+	indexingController := wsindexer.NewIndexingController()
+	dataProvider, _ := dataprovider.NewDataProvider(dataprovider.ArgsDataProvider{DBClient: &mock.DatabaseWriterStub{}})
+	facade, _ := NewIndexerFacade(ArgsIndexerFacade{
+		StatusMetrics:      metrics.NewStatusMetrics(),
+		IndexingStatus:     indexingStatus,
+		IndexingController: indexingController,
+		DataProvider:       dataProvider,
+	})
+
+	readiness := facade.GetReadiness()
+	require.False(t, readiness.WebSocketConnected)
+	require.False(t, readiness.Ready)
+	require.Equal(t, wsindexer.StateRunning, readiness.IndexingState)
+
+	// no payloads are received while the indexing is paused
+	require.Nil(t, facade.PauseIndexing())
+	readiness = facade.GetReadiness()
+	require.False(t, readiness.WebSocketConnected)
+	require.True(t, readiness.Ready)
+	require.Equal(t, wsindexer.StatePaused, readiness.IndexingState)
+
+	require.Nil(t, facade.ResumeIndexing())
+	indexingStatus.SetPayloadReceived()
+	require.True(t, facade.GetReadiness().Ready)
+
+	facade.DrainIndexing()
+	require.True(t, facade.GetReadiness().Ready)
+}
@@ -0,0 +1,16 @@
+package facade
+
+import "github.com/multiversx/mx-chain-es-indexer-go/data"
+
+// DataProviderHandler defines what a component that is able to read the indexed data should do
+type DataProviderHandler interface {
+	GetTransaction(hash string) (*data.TransactionResponse, error)
+	GetAccount(address string) (*data.AccountInfo, error)
+	GetAccountHistory(address string, pagination data.Pagination) (*data.AccountHistoryResponse, error)
+	GetAccountTokens(address string, pagination data.Pagination) (*data.AccountTokensResponse, error)
+	GetToken(identifier string) (*data.TokenInfo, error)
+	GetBlock(shardID uint32, nonce uint64) (*data.BlockResponse, error)
+	GetEvents(filter data.EventsFilter, pagination data.Pagination) (*data.EventsResponse, error)
+	IsElasticsearchReachable() bool
+	IsInterfaceNil() bool
+}
@@ -0,0 +1,59 @@
+package factory
+
+import (
+	"time"
+
+	"github.com/multiversx/mx-chain-es-indexer-go/client"
+	"github.com/multiversx/mx-chain-es-indexer-go/config"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/factory"
+)
+
+// createElasticTLSConfig will create the TLS settings of the Elasticsearch connection from the cluster config
+func createElasticTLSConfig(elasticCfg config.ElasticClusterConfig) client.TLSConfig {
+	tlsCfg := elasticCfg.TLS
+
+	return client.TLSConfig{
+		CACertFile:         tlsCfg.CACertFile,
+		ClientCertFile:     tlsCfg.ClientCertFile,
+		ClientKeyFile:      tlsCfg.ClientKeyFile,
+		InsecureSkipVerify: tlsCfg.InsecureSkipVerify,
+	}
+}
+
+// getElasticAddresses returns the addresses of the Elasticsearch nodes from the urls list, including the url field,
+// kept for backwards compatibility, without duplicates
+func getElasticAddresses(elasticCfg config.ElasticClusterConfig) []string {
+	candidates := append([]string{elasticCfg.URL}, elasticCfg.URLs...)
+
+	addresses := make([]string, 0, len(candidates))
+	seen := make(map[string]struct{})
+	for _, address := range candidates {
+		_, found := seen[address]
+		if address == "" || found {
+			continue
+		}
+
+		seen[address] = struct{}{}
+		addresses = append(addresses, address)
+	}
+
+	return addresses
+}
+
+func getDiscoverNodesInterval(elasticCfg config.ElasticClusterConfig) time.Duration {
+	return time.Duration(elasticCfg.DiscoverNodesIntervalInSec) * time.Second
+}
+
+// createElasticClientArgs will create the arguments of an Elasticsearch client from the cluster config
+func createElasticClientArgs(elasticCfg config.ElasticClusterConfig) factory.ArgsElasticClientFactory {
+	return factory.ArgsElasticClientFactory{
+		Urls:                  getElasticAddresses(elasticCfg),
+		DiscoverNodesInterval: getDiscoverNodesInterval(elasticCfg),
+		UserName:              elasticCfg.UserName,
+		Password:              elasticCfg.Password,
+		APIKey:                elasticCfg.APIKey,
+		CloudID:               elasticCfg.CloudID,
+		TLS:                   createElasticTLSConfig(elasticCfg),
+		CompressBulkRequests:  elasticCfg.CompressBulkRequests,
+	}
+}
@@ -0,0 +1,24 @@
+package factory
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-es-indexer-go/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetElasticAddresses(t *testing.T) {
+	t.Parallel()
+
+	elasticCfg := config.ElasticClusterConfig{}
+	require.Empty(t, getElasticAddresses(elasticCfg))
+
+	elasticCfg.URL = "http://node-1:9200"
+	require.Equal(t, []string{"http://node-1:9200"}, getElasticAddresses(elasticCfg))
+
+	elasticCfg.URLs = []string{"http://node-2:9200", "", "http://node-1:9200", "http://node-3:9200"}
+	require.Equal(t, []string{"http://node-1:9200", "http://node-2:9200", "http://node-3:9200"}, getElasticAddresses(elasticCfg))
+
+	elasticCfg.URL = ""
+	require.Equal(t, []string{"http://node-2:9200", "http://node-1:9200", "http://node-3:9200"}, getElasticAddresses(elasticCfg))
+}
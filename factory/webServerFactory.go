@@ -5,17 +5,44 @@ import (
 	"github.com/multiversx/mx-chain-es-indexer-go/config"
 	"github.com/multiversx/mx-chain-es-indexer-go/core"
 	"github.com/multiversx/mx-chain-es-indexer-go/facade"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/dataprovider"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/factory"
 )
 
 // CreateWebServer will create a new instance of core.WebServerHandler
-func CreateWebServer(apiConfig config.ApiRoutesConfig, statusMetricsHandler core.StatusMetricsHandler) (core.WebServerHandler, error) {
-	metricsFacade, err := facade.NewMetricsFacade(statusMetricsHandler)
+func CreateWebServer(
+	apiConfig config.ApiRoutesConfig,
+	clusterCfg config.ClusterConfig,
+	statusMetricsHandler core.StatusMetricsHandler,
+	indexingStatus core.IndexingStatusHandler,
+	indexingController core.IndexingControllerHandler,
+) (core.WebServerHandler, error) {
+	// the client used by the API is created without the status metrics, so the read requests will not be
+	// accounted as indexing requests
+	dbClient, err := factory.CreateElasticClient(createElasticClientArgs(clusterCfg.Config.ElasticCluster))
+	if err != nil {
+		return nil, err
+	}
+
+	dataProvider, err := dataprovider.NewDataProvider(dataprovider.ArgsDataProvider{
+		DBClient: dbClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	indexerFacade, err := facade.NewIndexerFacade(facade.ArgsIndexerFacade{
+		StatusMetrics:      statusMetricsHandler,
+		IndexingStatus:     indexingStatus,
+		IndexingController: indexingController,
+		DataProvider:       dataProvider,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	args := gin.ArgsWebServer{
-		Facade:    metricsFacade,
+		Facade:    indexerFacade,
 		ApiConfig: apiConfig,
 	}
 	return gin.NewWebServer(args)
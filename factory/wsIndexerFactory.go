@@ -1,6 +1,9 @@
 package factory
 
 import (
+	"time"
+
+	"github.com/multiversx/mx-chain-communication-go/websocket"
 	"github.com/multiversx/mx-chain-communication-go/websocket/data"
 	factoryHost "github.com/multiversx/mx-chain-communication-go/websocket/factory"
 	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
@@ -9,6 +12,8 @@ import (
 	factoryMarshaller "github.com/multiversx/mx-chain-core-go/marshal/factory"
 	"github.com/multiversx/mx-chain-es-indexer-go/config"
 	"github.com/multiversx/mx-chain-es-indexer-go/core"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/dataindexer"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/factory"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/wsindexer"
 	logger "github.com/multiversx/mx-chain-logger-go"
@@ -17,38 +22,85 @@ import (
 var log = logger.GetOrCreate("elasticindexer")
 
 // CreateWsIndexer will create a new instance of wsindexer.WSClient
-func CreateWsIndexer(cfg config.Config, clusterCfg config.ClusterConfig, statusMetrics core.StatusMetricsHandler, version string) (wsindexer.WSClient, error) {
+func CreateWsIndexer(
+	cfg config.Config,
+	clusterCfg config.ClusterConfig,
+	statusMetrics core.StatusMetricsHandler,
+	indexingStatus core.IndexingStatusHandler,
+	indexingController core.IndexingControllerHandler,
+	version string,
+) (wsindexer.WSClient, error) {
 	wsMarshaller, err := factoryMarshaller.NewMarshalizer(clusterCfg.Config.WebSocket.DataMarshallerType)
 	if err != nil {
 		return nil, err
 	}
 
-	dataIndexer, err := createDataIndexer(cfg, clusterCfg, wsMarshaller, statusMetrics, version)
+	indexer, err := createPayloadProcessor(cfg, clusterCfg, wsMarshaller, statusMetrics, indexingStatus, indexingController, version, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	args := wsindexer.ArgsIndexer{
-		Marshaller:    wsMarshaller,
-		DataIndexer:   dataIndexer,
-		StatusMetrics: statusMetrics,
+	host, err := createWsHost(clusterCfg, wsMarshaller)
+	if err != nil {
+		return nil, err
 	}
-	indexer, err := wsindexer.NewIndexer(args)
+
+	err = host.SetPayloadHandler(indexer)
 	if err != nil {
 		return nil, err
 	}
 
-	host, err := createWsHost(clusterCfg, wsMarshaller)
+	return host, nil
+}
+
+// CreatePayloadProcessor will create the component which indexes the payloads sent by the node, without the web socket
+// host. If the provided database client is nil, a client for the configured cluster is created
+func CreatePayloadProcessor(
+	cfg config.Config,
+	clusterCfg config.ClusterConfig,
+	statusMetrics core.StatusMetricsHandler,
+	indexingStatus core.IndexingStatusHandler,
+	indexingController core.IndexingControllerHandler,
+	version string,
+	dbClient elasticproc.DatabaseClientHandler,
+) (websocket.PayloadHandler, error) {
+	wsMarshaller, err := factoryMarshaller.NewMarshalizer(clusterCfg.Config.WebSocket.DataMarshallerType)
 	if err != nil {
 		return nil, err
 	}
 
-	err = host.SetPayloadHandler(indexer)
+	return createPayloadProcessor(cfg, clusterCfg, wsMarshaller, statusMetrics, indexingStatus, indexingController, version, dbClient)
+}
+
+func createPayloadProcessor(
+	cfg config.Config,
+	clusterCfg config.ClusterConfig,
+	wsMarshaller marshal.Marshalizer,
+	statusMetrics core.StatusMetricsHandler,
+	indexingStatus core.IndexingStatusHandler,
+	indexingController core.IndexingControllerHandler,
+	version string,
+	dbClient elasticproc.DatabaseClientHandler,
+) (websocket.PayloadHandler, error) {
+	dataIndexer, err := createDataIndexer(cfg, clusterCfg, wsMarshaller, statusMetrics, indexingStatus, version, dbClient)
 	if err != nil {
 		return nil, err
 	}
 
-	return host, nil
+	err = indexingController.SetIndicesHandler(dataIndexer)
+	if err != nil {
+		return nil, err
+	}
+
+	args := wsindexer.ArgsIndexer{
+		Marshaller:     wsMarshaller,
+		DataIndexer:    dataIndexer,
+		StatusMetrics:  statusMetrics,
+		IndexingStatus: indexingStatus,
+		Controller:     indexingController,
+	}
+
+	return wsindexer.NewIndexer(args)
 }
 
 func createDataIndexer(
@@ -56,7 +108,9 @@ func createDataIndexer(
 	clusterCfg config.ClusterConfig,
 	wsMarshaller marshal.Marshalizer,
 	statusMetrics core.StatusMetricsHandler,
+	indexingStatus core.IndexingStatusHandler,
 	version string,
+	dbClient elasticproc.DatabaseClientHandler,
 ) (wsindexer.DataIndexer, error) {
 	marshaller, err := factoryMarshaller.NewMarshalizer(cfg.Config.Marshaller.Type)
 	if err != nil {
@@ -76,23 +130,63 @@ func createDataIndexer(
 	}
 
 	return factory.NewIndexer(factory.ArgsIndexerFactory{
-		UseKibana:                clusterCfg.Config.ElasticCluster.UseKibana,
-		Denomination:             cfg.Config.Economics.Denomination,
-		BulkRequestMaxSize:       clusterCfg.Config.ElasticCluster.BulkRequestMaxSizeInBytes,
-		Url:                      clusterCfg.Config.ElasticCluster.URL,
-		UserName:                 clusterCfg.Config.ElasticCluster.UserName,
-		Password:                 clusterCfg.Config.ElasticCluster.Password,
-		EnabledIndexes:           prepareIndices(cfg.Config.AvailableIndices, clusterCfg.Config.DisabledIndices),
-		Marshalizer:              marshaller,
-		Hasher:                   hasher,
-		AddressPubkeyConverter:   addressPubkeyConverter,
-		ValidatorPubkeyConverter: validatorPubkeyConverter,
-		HeaderMarshaller:         wsMarshaller,
-		StatusMetrics:            statusMetrics,
-		Version:                  version,
+		UseKibana:                 clusterCfg.Config.ElasticCluster.UseKibana,
+		Denomination:              cfg.Config.Economics.Denomination,
+		BulkRequestMaxSize:        clusterCfg.Config.ElasticCluster.BulkRequestMaxSizeInBytes,
+		NumConcurrentBulkRequests: clusterCfg.Config.ElasticCluster.NumConcurrentBulkRequests,
+		TokensCacheCapacity:       cfg.Config.TokensCache.Capacity,
+		Urls:                      getElasticAddresses(clusterCfg.Config.ElasticCluster),
+		DiscoverNodesInterval:     getDiscoverNodesInterval(clusterCfg.Config.ElasticCluster),
+		UserName:                  clusterCfg.Config.ElasticCluster.UserName,
+		Password:                  clusterCfg.Config.ElasticCluster.Password,
+		APIKey:                    clusterCfg.Config.ElasticCluster.APIKey,
+		CloudID:                   clusterCfg.Config.ElasticCluster.CloudID,
+		TLS:                       createElasticTLSConfig(clusterCfg.Config.ElasticCluster),
+		CompressBulkRequests:      clusterCfg.Config.ElasticCluster.CompressBulkRequests,
+		EnabledIndexes:            prepareIndices(cfg.Config.AvailableIndices, clusterCfg.Config.DisabledIndices),
+		Marshalizer:               marshaller,
+		Hasher:                    hasher,
+		AddressPubkeyConverter:    addressPubkeyConverter,
+		ValidatorPubkeyConverter:  validatorPubkeyConverter,
+		HeaderMarshaller:          wsMarshaller,
+		StatusMetrics:             statusMetrics,
+		IndexingStatus:            indexingStatus,
+		Version:                   version,
+		DBClient:                  dbClient,
+		DualWrite:                 createDualWriteArgs(clusterCfg),
+		ImportDBBatch:             createImportDBBatchConfig(clusterCfg),
+		ImportDBIndexSettings: elasticproc.ImportDBIndexSettings{
+			Enabled:              clusterCfg.Config.ImportDB.ApplyIndexSettings,
+			RefreshInterval:      clusterCfg.Config.ImportDB.RefreshInterval,
+			NumberOfReplicas:     clusterCfg.Config.ImportDB.NumberOfReplicas,
+			OriginalSettingsFile: clusterCfg.Config.ImportDB.OriginalSettingsFile,
+		},
 	})
 }
 
+func createImportDBBatchConfig(clusterCfg config.ClusterConfig) dataindexer.ImportDBBatchConfig {
+	importDBCfg := clusterCfg.Config.ImportDB
+
+	return dataindexer.ImportDBBatchConfig{
+		Enabled:            importDBCfg.BatchBlocks,
+		BulkRequestMaxSize: clusterCfg.Config.ElasticCluster.BulkRequestMaxSizeInBytes,
+		FlushSize:          importDBCfg.FlushSizeInBytes,
+		FlushInterval:      time.Duration(importDBCfg.FlushIntervalInMs) * time.Millisecond,
+	}
+}
+
+func createDualWriteArgs(clusterCfg config.ClusterConfig) factory.ArgsDualWrite {
+	dualWriteCfg := clusterCfg.Config.DualWrite
+
+	return factory.ArgsDualWrite{
+		Enabled:       dualWriteCfg.Enabled,
+		Secondary:     createElasticClientArgs(dualWriteCfg.SecondaryCluster),
+		QueueSize:     dualWriteCfg.QueueSize,
+		MaxRetries:    dualWriteCfg.MaxRetries,
+		RetryInterval: time.Duration(dualWriteCfg.RetryIntervalInMs) * time.Millisecond,
+	}
+}
+
 func prepareIndices(availableIndices, disabledIndices []string) []string {
 	indices := make([]string, 0)
 
@@ -2,6 +2,9 @@ package factory
 
 import (
 	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-es-indexer-go/config"
 
 	"github.com/stretchr/testify/require"
 )
@@ -21,3 +24,19 @@ func TestPrepareIndices(t *testing.T) {
 	res = prepareIndices(available, disabled)
 	require.Equal(t, []string{"index1", "index2"}, res)
 }
+
+func TestCreateImportDBBatchConfig(t *testing.T) {
+	t.Parallel()
+
+	clusterCfg := config.ClusterConfig{}
+	clusterCfg.Config.ImportDB.BatchBlocks = true
+	clusterCfg.Config.ImportDB.FlushSizeInBytes = 100
+	clusterCfg.Config.ImportDB.FlushIntervalInMs = 2000
+	clusterCfg.Config.ElasticCluster.BulkRequestMaxSizeInBytes = 50
+	clusterCfg.Config.WebSocket.WithAcknowledge = true
+	batchConfig := createImportDBBatchConfig(clusterCfg)
+	require.True(t, batchConfig.Enabled)
+	require.Equal(t, 100, batchConfig.FlushSize)
+	require.Equal(t, 50, batchConfig.BulkRequestMaxSize)
+	require.Equal(t, 2*time.Second, batchConfig.FlushInterval)
+}
@@ -0,0 +1,18 @@
+//go:build integrationtests
+
+package integrationtests
+
+import (
+	"github.com/elastic/go-elasticsearch/v7"
+	"github.com/multiversx/mx-chain-es-indexer-go/client"
+	"github.com/multiversx/mx-chain-es-indexer-go/client/logging"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc"
+)
+
+// nolint
+func createESClient(url string) (elasticproc.DatabaseClientHandler, error) {
+	return client.NewElasticClient(elasticsearch.Config{
+		Addresses: []string{url},
+		Logger:    &logging.CustomLogger{},
+	})
+}
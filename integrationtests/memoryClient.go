@@ -0,0 +1,17 @@
+//go:build !integrationtests
+
+package integrationtests
+
+import (
+	"github.com/multiversx/mx-chain-es-indexer-go/client/memory"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc"
+)
+
+// memoryClient is shared by all the tests, so the documents indexed by a test are visible to the next ones, like on
+// a real cluster
+var memoryClient = memory.NewDatabaseClient()
+
+// nolint
+func createESClient(_ string) (elasticproc.DatabaseClientHandler, error) {
+	return memoryClient, nil
+}
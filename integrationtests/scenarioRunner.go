@@ -0,0 +1,388 @@
+package integrationtests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/multiversx/mx-chain-es-indexer-go/client/memory"
+	"github.com/multiversx/mx-chain-es-indexer-go/mock"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/dataindexer"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc"
+)
+
+const (
+	scenarioFileName = "scenario.json"
+	expectedFileName = "expected.json"
+)
+
+// ignoredDocumentFields holds the fields which are generated randomly for each indexed document. The encoded sketches
+// are not readable in the golden files, so only the counts estimated with them are compared
+var ignoredDocumentFields = []string{"uuid", "activeAccountsSketch"}
+
+// ignoredIndexFields holds the fields which differ between runs only in the documents of an index. The search order of
+// the transactions follows the iteration order of a map, and the operations index holds copies of the transactions
+var ignoredIndexFields = map[string][]string{
+	dataindexer.TransactionsIndex: {"searchOrder"},
+	dataindexer.OperationsIndex:   {"searchOrder"},
+}
+
+// scenario describes the payloads sent by a node to the indexer. The steps are executed in order
+type scenario struct {
+	Description string         `json:"description"`
+	Steps       []scenarioStep `json:"steps"`
+}
+
+// scenarioStep holds an outport payload, encoded with the JSON marshaller, and the topic it was sent on. For the
+// SaveBlock and RevertIndexedBlock topics, the header can be written as a JSON object, instead of the header bytes
+// from the payload, and the header hash can be omitted
+type scenarioStep struct {
+	Topic      string          `json:"topic"`
+	HeaderType string          `json:"headerType,omitempty"`
+	Header     json.RawMessage `json:"header,omitempty"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// indexedDocuments holds the documents of each index, by document id
+type indexedDocuments map[string]map[string]interface{}
+
+// scenarioRunner executes the scenarios through a data indexer backed by an in-memory database, so each scenario
+// starts from an empty database
+type scenarioRunner struct {
+	marshaller     marshal.Marshalizer
+	blockContainer dataindexer.BlockContainerHandler
+	dbClient       elasticproc.DatabaseClientHandler
+	indexer        dataIndexerHandler
+}
+
+type dataIndexerHandler interface {
+	SaveBlock(outportBlock *outport.OutportBlock) error
+	RevertIndexedBlock(blockData *outport.BlockData) error
+	SaveRoundsInfo(rounds *outport.RoundsInfo) error
+	SaveValidatorsRating(ratingData *outport.ValidatorsRating) error
+	SaveValidatorsPubKeys(validatorsPubKeys *outport.ValidatorsPubKeys) error
+	SaveAccounts(accounts *outport.Accounts) error
+	FinalizedBlock(finalizedBlock *outport.FinalizedBlock) error
+}
+
+func newScenarioRunner() (*scenarioRunner, error) {
+	dbClient := memory.NewDatabaseClient()
+	elasticProcessor, err := CreateElasticProcessor(dbClient)
+	if err != nil {
+		return nil, err
+	}
+
+	blockContainer, err := createBlockContainer()
+	if err != nil {
+		return nil, err
+	}
+
+	marshaller := &marshal.JsonMarshalizer{}
+	indexer, err := dataindexer.NewDataIndexer(dataindexer.ArgDataIndexer{
+		HeaderMarshaller: marshaller,
+		ElasticProcessor: elasticProcessor,
+		BlockContainer:   blockContainer,
+		IndexingStatus:   &mock.IndexingStatusStub{},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &scenarioRunner{
+		marshaller:     marshaller,
+		blockContainer: blockContainer,
+		dbClient:       dbClient,
+		indexer:        indexer,
+	}, nil
+}
+
+func createBlockContainer() (dataindexer.BlockContainerHandler, error) {
+	container := block.NewEmptyBlockCreatorsContainer()
+	err := container.Add(core.ShardHeaderV1, block.NewEmptyHeaderCreator())
+	if err != nil {
+		return nil, err
+	}
+	err = container.Add(core.ShardHeaderV2, block.NewEmptyHeaderV2Creator())
+	if err != nil {
+		return nil, err
+	}
+	err = container.Add(core.MetaHeader, block.NewEmptyMetaBlockCreator())
+	if err != nil {
+		return nil, err
+	}
+
+	return container, nil
+}
+
+// runScenario executes the scenario from the provided directory and returns the documents from the database
+func runScenario(directory string) (indexedDocuments, error) {
+	scenarioBytes, err := os.ReadFile(filepath.Join(directory, scenarioFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	sc := &scenario{}
+	err = json.Unmarshal(scenarioBytes, sc)
+	if err != nil {
+		return nil, fmt.Errorf("%w while decoding %s", err, scenarioFileName)
+	}
+
+	runner, err := newScenarioRunner()
+	if err != nil {
+		return nil, err
+	}
+
+	for idx, step := range sc.Steps {
+		err = runner.executeStep(step)
+		if err != nil {
+			return nil, fmt.Errorf("%w in step %d, topic %s", err, idx, step.Topic)
+		}
+	}
+
+	return runner.getDocuments()
+}
+
+func (sr *scenarioRunner) executeStep(step scenarioStep) error {
+	switch step.Topic {
+	case outport.TopicSaveBlock:
+		outportBlock := &outport.OutportBlock{}
+		err := sr.marshaller.Unmarshal(outportBlock, step.Payload)
+		if err != nil {
+			return err
+		}
+		if outportBlock.BlockData == nil {
+			outportBlock.BlockData = &outport.BlockData{}
+		}
+		err = sr.setHeader(outportBlock.BlockData, step)
+		if err != nil {
+			return err
+		}
+
+		return sr.indexer.SaveBlock(outportBlock)
+	case outport.TopicRevertIndexedBlock:
+		blockData := &outport.BlockData{}
+		err := sr.marshaller.Unmarshal(blockData, step.Payload)
+		if err != nil {
+			return err
+		}
+		err = sr.setHeader(blockData, step)
+		if err != nil {
+			return err
+		}
+
+		return sr.indexer.RevertIndexedBlock(blockData)
+	case outport.TopicFinalizedBlock:
+		finalizedBlock := &outport.FinalizedBlock{}
+		err := sr.marshaller.Unmarshal(finalizedBlock, step.Payload)
+		if err != nil {
+			return err
+		}
+
+		return sr.indexer.FinalizedBlock(finalizedBlock)
+	case outport.TopicSaveRoundsInfo:
+		roundsInfo := &outport.RoundsInfo{}
+		err := sr.marshaller.Unmarshal(roundsInfo, step.Payload)
+		if err != nil {
+			return err
+		}
+
+		return sr.indexer.SaveRoundsInfo(roundsInfo)
+	case outport.TopicSaveValidatorsRating:
+		ratingData := &outport.ValidatorsRating{}
+		err := sr.marshaller.Unmarshal(ratingData, step.Payload)
+		if err != nil {
+			return err
+		}
+
+		return sr.indexer.SaveValidatorsRating(ratingData)
+	case outport.TopicSaveValidatorsPubKeys:
+		validatorsPubKeys := &outport.ValidatorsPubKeys{}
+		err := sr.marshaller.Unmarshal(validatorsPubKeys, step.Payload)
+		if err != nil {
+			return err
+		}
+
+		return sr.indexer.SaveValidatorsPubKeys(validatorsPubKeys)
+	case outport.TopicSaveAccounts:
+		accounts := &outport.Accounts{}
+		err := sr.marshaller.Unmarshal(accounts, step.Payload)
+		if err != nil {
+			return err
+		}
+
+		return sr.indexer.SaveAccounts(accounts)
+	default:
+		return fmt.Errorf("unknown topic %s", step.Topic)
+	}
+}
+
+// setHeader puts the header of the step in the block data. When the payload has no header hash, it is computed from
+// the header, like the node does
+func (sr *scenarioRunner) setHeader(blockData *outport.BlockData, step scenarioStep) error {
+	if len(step.Header) > 0 {
+		blockData.HeaderBytes = step.Header
+	}
+	if step.HeaderType != "" {
+		blockData.HeaderType = step.HeaderType
+	}
+	if len(blockData.HeaderHash) > 0 {
+		return nil
+	}
+
+	creator, err := sr.blockContainer.Get(core.HeaderType(blockData.HeaderType))
+	if err != nil {
+		return err
+	}
+	header, err := block.GetHeaderFromBytes(sr.marshaller, creator, blockData.HeaderBytes)
+	if err != nil {
+		return err
+	}
+
+	blockData.HeaderHash, err = core.CalculateHash(&mock.MarshalizerMock{}, &mock.HasherMock{}, header)
+
+	return err
+}
+
+func (sr *scenarioRunner) getDocuments() (indexedDocuments, error) {
+	documents := make(indexedDocuments)
+	for _, index := range enabledIndexes {
+		handler := func(responseBytes []byte) error {
+			response := &scrollResponse{}
+			err := json.Unmarshal(responseBytes, response)
+			if err != nil {
+				return err
+			}
+
+			for _, hit := range response.Hits.Hits {
+				if documents[index] == nil {
+					documents[index] = make(map[string]interface{})
+				}
+				documents[index][hit.ID] = withoutIgnoredFields(index, hit.Source)
+			}
+
+			return nil
+		}
+
+		err := sr.dbClient.DoScrollRequest(context.Background(), index, []byte(`{"query":{"match_all":{}}}`), true, handler)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return documents, nil
+}
+
+type scrollResponse struct {
+	Hits struct {
+		Hits []struct {
+			ID     string                 `json:"_id"`
+			Source map[string]interface{} `json:"_source"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+func withoutIgnoredFields(index string, source map[string]interface{}) map[string]interface{} {
+	for _, field := range ignoredDocumentFields {
+		delete(source, field)
+	}
+	for _, field := range ignoredIndexFields[index] {
+		delete(source, field)
+	}
+
+	return source
+}
+
+// readExpectedDocuments loads the golden file of the scenario from the provided directory
+func readExpectedDocuments(directory string) (indexedDocuments, error) {
+	expectedBytes, err := os.ReadFile(filepath.Join(directory, expectedFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	expected := make(indexedDocuments)
+	err = json.Unmarshal(expectedBytes, &expected)
+	if err != nil {
+		return nil, fmt.Errorf("%w while decoding %s", err, expectedFileName)
+	}
+
+	return expected, nil
+}
+
+// writeExpectedDocuments replaces the golden file of the scenario from the provided directory
+func writeExpectedDocuments(directory string, documents indexedDocuments) error {
+	documentsBytes, err := json.MarshalIndent(documents, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(directory, expectedFileName), append(documentsBytes, '\n'), 0644)
+}
+
+// diffDocuments returns the differences between the expected and the indexed documents, sorted by index and id
+func diffDocuments(expected indexedDocuments, actual indexedDocuments) []string {
+	diffs := make([]string, 0)
+	for _, index := range unionKeys(expected, actual) {
+		expectedDocs, actualDocs := expected[index], actual[index]
+		ids := make(map[string]struct{})
+		for id := range expectedDocs {
+			ids[id] = struct{}{}
+		}
+		for id := range actualDocs {
+			ids[id] = struct{}{}
+		}
+
+		for _, id := range sortedKeys(ids) {
+			expectedDoc, isExpected := expectedDocs[id]
+			actualDoc, isIndexed := actualDocs[id]
+			switch {
+			case !isIndexed:
+				diffs = append(diffs, fmt.Sprintf("%s/%s: missing document, expected %s", index, id, compactJSON(expectedDoc)))
+			case !isExpected:
+				diffs = append(diffs, fmt.Sprintf("%s/%s: unexpected document %s", index, id, compactJSON(actualDoc)))
+			case !reflect.DeepEqual(expectedDoc, actualDoc):
+				diffs = append(diffs, fmt.Sprintf("%s/%s: expected %s, got %s", index, id, compactJSON(expectedDoc), compactJSON(actualDoc)))
+			}
+		}
+	}
+
+	return diffs
+}
+
+func unionKeys(expected indexedDocuments, actual indexedDocuments) []string {
+	keys := make(map[string]struct{})
+	for key := range expected {
+		keys[key] = struct{}{}
+	}
+	for key := range actual {
+		keys[key] = struct{}{}
+	}
+
+	return sortedKeys(keys)
+}
+
+func sortedKeys(keys map[string]struct{}) []string {
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	return sorted
+}
+
+func compactJSON(document interface{}) string {
+	documentBytes, err := json.Marshal(document)
+	if err != nil {
+		return fmt.Sprintf("%v", document)
+	}
+
+	return string(documentBytes)
+}
@@ -0,0 +1,47 @@
+package integrationtests
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const scenariosDirectory = "./testdata/scenarios"
+
+var updateGoldenFiles = flag.Bool("update", false, "regenerate the expected documents of the scenarios")
+
+// TestScenarios executes each scenario from testdata/scenarios and compares the indexed documents with the golden
+// file of the scenario. Run with -update to regenerate the golden files
+func TestScenarios(t *testing.T) {
+	entries, err := os.ReadDir(scenariosDirectory)
+	require.Nil(t, err)
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		directory := filepath.Join(scenariosDirectory, entry.Name())
+		t.Run(entry.Name(), func(t *testing.T) {
+			t.Parallel()
+
+			documents, errRun := runScenario(directory)
+			require.Nil(t, errRun)
+
+			if *updateGoldenFiles {
+				require.Nil(t, writeExpectedDocuments(directory, documents))
+				return
+			}
+
+			expected, errRead := readExpectedDocuments(directory)
+			require.Nil(t, errRead, "run the test with -update to create the golden file")
+
+			diffs := diffDocuments(expected, documents)
+			require.Empty(t, diffs, strings.Join(diffs, "\n"))
+		})
+	}
+}
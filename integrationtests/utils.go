@@ -10,10 +10,7 @@ import (
 	"os"
 	"path"
 
-	"github.com/elastic/go-elasticsearch/v7"
 	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
-	"github.com/multiversx/mx-chain-es-indexer-go/client"
-	"github.com/multiversx/mx-chain-es-indexer-go/client/logging"
 	"github.com/multiversx/mx-chain-es-indexer-go/mock"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/dataindexer"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc"
@@ -24,6 +21,11 @@ import (
 var (
 	log                = logger.GetOrCreate("integration-tests")
 	pubKeyConverter, _ = pubkeyConverter.NewBech32PubkeyConverter(32, addressPrefix)
+	enabledIndexes     = []string{dataindexer.TransactionsIndex, dataindexer.LogsIndex, dataindexer.AccountsESDTIndex, dataindexer.ScResultsIndex,
+		dataindexer.ReceiptsIndex, dataindexer.BlockIndex, dataindexer.AccountsIndex, dataindexer.TokensIndex, dataindexer.TagsIndex, dataindexer.EventsIndex,
+		dataindexer.OperationsIndex, dataindexer.DelegatorsIndex, dataindexer.ESDTsIndex, dataindexer.SCDeploysIndex, dataindexer.MiniblocksIndex, dataindexer.ValuesIndex,
+		dataindexer.ValidatorsIndex, dataindexer.RatingIndex, dataindexer.ValidatorStatsIndex, dataindexer.RatingHistoryIndex, dataindexer.EpochSummaryIndex,
+		dataindexer.StatsHourlyIndex, dataindexer.StatsDailyIndex, dataindexer.ActiveAddressesIndex, dataindexer.ContractsIndex}
 )
 
 // nolint
@@ -31,14 +33,6 @@ func setLogLevelDebug() {
 	_ = logger.SetLogLevel("process:DEBUG")
 }
 
-// nolint
-func createESClient(url string) (elasticproc.DatabaseClientHandler, error) {
-	return client.NewElasticClient(elasticsearch.Config{
-		Addresses: []string{url},
-		Logger:    &logging.CustomLogger{},
-	})
-}
-
 // nolint
 func decodeAddress(address string) []byte {
 	decoded, err := pubKeyConverter.Decode(address)
@@ -57,10 +51,8 @@ func CreateElasticProcessor(
 		AddressPubkeyConverter:   pubKeyConverter,
 		ValidatorPubkeyConverter: mock.NewPubkeyConverterMock(32),
 		DBClient:                 esClient,
-		EnabledIndexes: []string{dataindexer.TransactionsIndex, dataindexer.LogsIndex, dataindexer.AccountsESDTIndex, dataindexer.ScResultsIndex,
-			dataindexer.ReceiptsIndex, dataindexer.BlockIndex, dataindexer.AccountsIndex, dataindexer.TokensIndex, dataindexer.TagsIndex, dataindexer.EventsIndex,
-			dataindexer.OperationsIndex, dataindexer.DelegatorsIndex, dataindexer.ESDTsIndex, dataindexer.SCDeploysIndex, dataindexer.MiniblocksIndex, dataindexer.ValuesIndex},
-		Denomination: 18,
+		EnabledIndexes:           enabledIndexes,
+		Denomination:             18,
 	}
 
 	return factory.CreateElasticProcessor(args)
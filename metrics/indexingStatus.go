@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/multiversx/mx-chain-es-indexer-go/core/request"
+)
+
+// webSocketInactivityTimeout is the duration after which the WebSocket link is considered down if no payload was received.
+// The node sends at least the rounds info in every round, so a healthy link is never idle for this long
+const webSocketInactivityTimeout = time.Minute
+
+type lastIndexedBlock struct {
+	nonce     uint64
+	round     uint64
+	timestamp uint64
+}
+
+type indexingStatus struct {
+	mut               sync.RWMutex
+	templatesChecked  bool
+	lastPayloadTime   time.Time
+	lastIndexedBlocks map[uint32]*lastIndexedBlock
+	getTimeHandler    func() time.Time
+}
+
+// NewIndexingStatus will return an instance of the indexingStatus, a component that keeps track of the indexer's
+// state: the templates check, the WebSocket connection and the last indexed block for each shard
+func NewIndexingStatus() *indexingStatus {
+	return &indexingStatus{
+		lastIndexedBlocks: make(map[uint32]*lastIndexedBlock),
+		getTimeHandler:    time.Now,
+	}
+}
+
+// SetTemplatesChecked will mark that the templates, the indices and the aliases were checked and created
+func (is *indexingStatus) SetTemplatesChecked() {
+	is.mut.Lock()
+	is.templatesChecked = true
+	is.mut.Unlock()
+}
+
+// SetPayloadReceived will save the moment when the last payload was received through the WebSocket connection
+func (is *indexingStatus) SetPayloadReceived() {
+	is.mut.Lock()
+	is.lastPayloadTime = is.getTimeHandler()
+	is.mut.Unlock()
+}
+
+// SetLastIndexedBlock will save the details of the last indexed block for the provided shard
+func (is *indexingStatus) SetLastIndexedBlock(shardID uint32, nonce uint64, round uint64, timestamp uint64) {
+	is.mut.Lock()
+	defer is.mut.Unlock()
+
+	is.lastIndexedBlocks[shardID] = &lastIndexedBlock{
+		nonce:     nonce,
+		round:     round,
+		timestamp: timestamp,
+	}
+}
+
+// IsTemplatesChecked returns true if the templates were checked
+func (is *indexingStatus) IsTemplatesChecked() bool {
+	is.mut.RLock()
+	defer is.mut.RUnlock()
+
+	return is.templatesChecked
+}
+
+// IsWebSocketConnected returns true if a payload was received through the WebSocket connection in the last minute
+func (is *indexingStatus) IsWebSocketConnected() bool {
+	is.mut.RLock()
+	defer is.mut.RUnlock()
+
+	if is.lastPayloadTime.IsZero() {
+		return false
+	}
+
+	return is.getTimeHandler().Sub(is.lastPayloadTime) <= webSocketInactivityTimeout
+}
+
+// GetIndexingStatus returns, for each shard, the last indexed block and the lag versus the wall-clock
+func (is *indexingStatus) GetIndexingStatus() map[uint32]*request.ShardIndexingStatus {
+	is.mut.RLock()
+	defer is.mut.RUnlock()
+
+	now := is.getTimeHandler().Unix()
+	statuses := make(map[uint32]*request.ShardIndexingStatus, len(is.lastIndexedBlocks))
+	for shardID, block := range is.lastIndexedBlocks {
+		statuses[shardID] = &request.ShardIndexingStatus{
+			Nonce:     block.nonce,
+			Round:     block.round,
+			Timestamp: block.timestamp,
+			LagInSec:  now - int64(block.timestamp),
+		}
+	}
+
+	return statuses
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (is *indexingStatus) IsInterfaceNil() bool {
+	return is == nil
+}
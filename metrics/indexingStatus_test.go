@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-es-indexer-go/core/request"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexingStatus_Flags(t *testing.T) {
+	t.Parallel()
+
+	is := NewIndexingStatus()
+	require.False(t, is.IsInterfaceNil())
+	require.False(t, is.IsTemplatesChecked())
+	require.False(t, is.IsWebSocketConnected())
+
+	is.SetTemplatesChecked()
+	is.SetPayloadReceived()
+	require.True(t, is.IsTemplatesChecked())
+	require.True(t, is.IsWebSocketConnected())
+}
+
+func TestIndexingStatus_IsWebSocketConnectedAfterInactivity(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1000, 0)
+	is := NewIndexingStatus()
+	is.getTimeHandler = func() time.Time {
+		return now
+	}
+
+	is.SetPayloadReceived()
+	now = now.Add(webSocketInactivityTimeout)
+	require.True(t, is.IsWebSocketConnected())
+
+	now = now.Add(time.Second)
+	require.False(t, is.IsWebSocketConnected())
+
+	is.SetPayloadReceived()
+	require.True(t, is.IsWebSocketConnected())
+}
+
+func TestIndexingStatus_GetIndexingStatus(t *testing.T) {
+	t.Parallel()
+
+	is := NewIndexingStatus()
+	is.getTimeHandler = func() time.Time {
+		return time.Unix(1000, 0)
+	}
+
+	require.Empty(t, is.GetIndexingStatus())
+
+	is.SetLastIndexedBlock(0, 10, 11, 994)
+	is.SetLastIndexedBlock(1, 20, 21, 990)
+	is.SetLastIndexedBlock(0, 12, 13, 1000)
+
+	require.Equal(t, map[uint32]*request.ShardIndexingStatus{
+		0: {Nonce: 12, Round: 13, Timestamp: 1000, LagInSec: 0},
+		1: {Nonce: 20, Round: 21, Timestamp: 990, LagInSec: 10},
+	}, is.GetIndexingStatus())
+}
+
+func TestIndexingStatus_ConcurrentOperations(t *testing.T) {
+	t.Parallel()
+
+	is := NewIndexingStatus()
+
+	numOperations := 100
+	wg := sync.WaitGroup{}
+	wg.Add(numOperations)
+	for i := 0; i < numOperations; i++ {
+		go func(idx int) {
+			switch idx % 4 {
+			case 0:
+				is.SetLastIndexedBlock(uint32(idx%3), uint64(idx), uint64(idx), uint64(idx))
+			case 1:
+				_ = is.GetIndexingStatus()
+			case 2:
+				is.SetPayloadReceived()
+			case 3:
+				_ = is.IsTemplatesChecked()
+			}
+			wg.Done()
+		}(i)
+	}
+	wg.Wait()
+}
@@ -11,11 +11,12 @@ import (
 )
 
 const (
-	operationCount = "operations_count"
-	errorsCount    = "errors_count"
-	totalTime      = "total_time"
-	totalData      = "total_data"
-	requestsErrors = "requests_errors"
+	operationCount      = "operations_count"
+	errorsCount         = "errors_count"
+	totalTime           = "total_time"
+	totalData           = "total_data"
+	totalCompressedData = "total_compressed_data"
+	requestsErrors      = "requests_errors"
 )
 
 type statusMetrics struct {
@@ -46,6 +47,7 @@ func (sm *statusMetrics) AddIndexingData(args ArgsAddIndexingData) {
 	sm.metrics[topic].OperationsCount++
 	sm.metrics[topic].TotalIndexingTime += args.Duration
 	sm.metrics[topic].TotalData += args.MessageLen
+	sm.metrics[topic].TotalCompressedData += args.CompressedMessageLen
 
 	isErrorCode := args.StatusCode >= http.StatusBadRequest
 	if args.GotError || isErrorCode {
@@ -75,6 +77,9 @@ func (sm *statusMetrics) GetMetricsForPrometheus() string {
 	for topicWithShardID, metricsData := range metrics {
 		topic, shardIDStr := request.SplitTopicAndShardID(topicWithShardID)
 		stringBuilder.WriteString(counterMetric(topic, totalData, shardIDStr, metricsData.TotalData))
+		if metricsData.TotalCompressedData > 0 {
+			stringBuilder.WriteString(counterMetric(topic, totalCompressedData, shardIDStr, metricsData.TotalCompressedData))
+		}
 		stringBuilder.WriteString(counterMetric(topic, errorsCount, shardIDStr, metricsData.TotalErrorsCount))
 		stringBuilder.WriteString(counterMetric(topic, operationCount, shardIDStr, metricsData.OperationsCount))
 		stringBuilder.WriteString(counterMetric(topic, totalTime, shardIDStr, uint64(metricsData.TotalIndexingTime.Milliseconds())))
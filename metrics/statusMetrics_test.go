@@ -60,6 +60,26 @@ test1{operation="requests_errors",shardID="0",errorCode="400"} 1
 `, prometheusMetrics)
 }
 
+func TestStatusMetrics_AddIndexingDataWithCompressedData(t *testing.T) {
+	t.Parallel()
+
+	statusMetricsHandler := NewStatusMetrics()
+
+	topic := "req_bulk_1"
+	statusMetricsHandler.AddIndexingData(ArgsAddIndexingData{
+		MessageLen:           1000,
+		CompressedMessageLen: 150,
+		Topic:                topic,
+	})
+
+	metrics := statusMetricsHandler.GetMetrics()
+	require.Equal(t, uint64(1000), metrics[topic].TotalData)
+	require.Equal(t, uint64(150), metrics[topic].TotalCompressedData)
+
+	prometheusMetrics := statusMetricsHandler.GetMetricsForPrometheus()
+	require.Contains(t, prometheusMetrics, `req_bulk{operation="total_compressed_data",shardID="1"} 150`)
+}
+
 func TestCamelCaseToSnakeCase(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,112 @@
+package mock
+
+import (
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
+)
+
+// DataIndexerStub -
+type DataIndexerStub struct {
+	SaveBlockCalled          func(outportBlock *outport.OutportBlock) error
+	PrepareBlockCalled       func(outportBlock *outport.OutportBlock) (*data.PreparedBlock, error)
+	CommitBlockCalled        func(preparedBlock *data.PreparedBlock) error
+	RevertIndexedBlockCalled func(blockData *outport.BlockData) error
+	SaveRoundsInfoCalled     func(roundsInfos *outport.RoundsInfo) error
+	SaveAccountsCalled       func(accountsData *outport.Accounts) error
+	FinalizedBlockCalled     func(finalizedBlock *outport.FinalizedBlock) error
+	CloseCalled              func() error
+}
+
+// SaveBlock -
+func (dis *DataIndexerStub) SaveBlock(outportBlock *outport.OutportBlock) error {
+	if dis.SaveBlockCalled != nil {
+		return dis.SaveBlockCalled(outportBlock)
+	}
+	return nil
+}
+
+// PrepareBlock -
+func (dis *DataIndexerStub) PrepareBlock(outportBlock *outport.OutportBlock) (*data.PreparedBlock, error) {
+	if dis.PrepareBlockCalled != nil {
+		return dis.PrepareBlockCalled(outportBlock)
+	}
+	return &data.PreparedBlock{}, nil
+}
+
+// CommitBlock -
+func (dis *DataIndexerStub) CommitBlock(preparedBlock *data.PreparedBlock) error {
+	if dis.CommitBlockCalled != nil {
+		return dis.CommitBlockCalled(preparedBlock)
+	}
+	return nil
+}
+
+// RevertIndexedBlock -
+func (dis *DataIndexerStub) RevertIndexedBlock(blockData *outport.BlockData) error {
+	if dis.RevertIndexedBlockCalled != nil {
+		return dis.RevertIndexedBlockCalled(blockData)
+	}
+	return nil
+}
+
+// SaveRoundsInfo -
+func (dis *DataIndexerStub) SaveRoundsInfo(roundsInfos *outport.RoundsInfo) error {
+	if dis.SaveRoundsInfoCalled != nil {
+		return dis.SaveRoundsInfoCalled(roundsInfos)
+	}
+	return nil
+}
+
+// SaveValidatorsPubKeys -
+func (dis *DataIndexerStub) SaveValidatorsPubKeys(_ *outport.ValidatorsPubKeys) error {
+	return nil
+}
+
+// SaveValidatorsRating -
+func (dis *DataIndexerStub) SaveValidatorsRating(_ *outport.ValidatorsRating) error {
+	return nil
+}
+
+// SaveAccounts -
+func (dis *DataIndexerStub) SaveAccounts(accountsData *outport.Accounts) error {
+	if dis.SaveAccountsCalled != nil {
+		return dis.SaveAccountsCalled(accountsData)
+	}
+	return nil
+}
+
+// FinalizedBlock -
+func (dis *DataIndexerStub) FinalizedBlock(finalizedBlock *outport.FinalizedBlock) error {
+	if dis.FinalizedBlockCalled != nil {
+		return dis.FinalizedBlockCalled(finalizedBlock)
+	}
+	return nil
+}
+
+// SetCurrentSettings -
+func (dis *DataIndexerStub) SetCurrentSettings(_ outport.OutportConfig) error {
+	return nil
+}
+
+// SetIndexEnabled -
+func (dis *DataIndexerStub) SetIndexEnabled(_ string, _ bool) error {
+	return nil
+}
+
+// GetEnabledIndexes -
+func (dis *DataIndexerStub) GetEnabledIndexes() []string {
+	return nil
+}
+
+// Close -
+func (dis *DataIndexerStub) Close() error {
+	if dis.CloseCalled != nil {
+		return dis.CloseCalled()
+	}
+	return nil
+}
+
+// IsInterfaceNil -
+func (dis *DataIndexerStub) IsInterfaceNil() bool {
+	return dis == nil
+}
@@ -12,6 +12,19 @@ type DatabaseWriterStub struct {
 	DoMultiGetCalled          func(ids []string, index string, withSource bool, response interface{}) error
 	CheckAndCreateIndexCalled func(index string) error
 	DoScrollRequestCalled     func(index string, body []byte, withSource bool, handlerFunc func(responseBytes []byte) error) error
+	DoSearchRequestCalled     func(index string, body []byte, response interface{}) error
+	PingCalled                func() error
+	GetSettingsCalled         func(index string, response interface{}) error
+	GetMappingCalled          func(index string, response interface{}) error
+	PutSettingsCalled         func(index string, settings *bytes.Buffer) error
+}
+
+// Ping -
+func (dwm *DatabaseWriterStub) Ping(_ context.Context) error {
+	if dwm.PingCalled != nil {
+		return dwm.PingCalled()
+	}
+	return nil
 }
 
 // PutMappings -
@@ -19,6 +32,30 @@ func (dwm *DatabaseWriterStub) PutMappings(_ string, _ *bytes.Buffer) error {
 	return nil
 }
 
+// GetMapping -
+func (dwm *DatabaseWriterStub) GetMapping(index string, response interface{}) error {
+	if dwm.GetMappingCalled != nil {
+		return dwm.GetMappingCalled(index, response)
+	}
+	return nil
+}
+
+// GetSettings -
+func (dwm *DatabaseWriterStub) GetSettings(index string, response interface{}) error {
+	if dwm.GetSettingsCalled != nil {
+		return dwm.GetSettingsCalled(index, response)
+	}
+	return nil
+}
+
+// PutSettings -
+func (dwm *DatabaseWriterStub) PutSettings(index string, settings *bytes.Buffer) error {
+	if dwm.PutSettingsCalled != nil {
+		return dwm.PutSettingsCalled(index, settings)
+	}
+	return nil
+}
+
 // UpdateByQuery -
 func (dwm *DatabaseWriterStub) UpdateByQuery(_ context.Context, _ string, _ *bytes.Buffer) error {
 	return nil
@@ -29,6 +66,14 @@ func (dwm *DatabaseWriterStub) DoCountRequest(_ context.Context, _ string, _ []b
 	return 0, nil
 }
 
+// DoSearchRequest -
+func (dwm *DatabaseWriterStub) DoSearchRequest(_ context.Context, index string, body []byte, response interface{}) error {
+	if dwm.DoSearchRequestCalled != nil {
+		return dwm.DoSearchRequestCalled(index, body, response)
+	}
+	return nil
+}
+
 // DoScrollRequest -
 func (dwm *DatabaseWriterStub) DoScrollRequest(_ context.Context, index string, body []byte, withSource bool, handlerFunc func(responseBytes []byte) error) error {
 	if dwm.DoScrollRequestCalled != nil {
@@ -4,10 +4,13 @@ import (
 	coreData "github.com/multiversx/mx-chain-core-go/data"
 	"github.com/multiversx/mx-chain-core-go/data/block"
 	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
 )
 
 // ElasticProcessorStub -
 type ElasticProcessorStub struct {
+	PrepareBlockCalled               func(outportBlockWithHeader *outport.OutportBlockWithHeader) (*data.PreparedBlock, error)
+	CommitBlockCalled                func(preparedBlock *data.PreparedBlock) error
 	SaveHeaderCalled                 func(outportBlockWithHeader *outport.OutportBlockWithHeader) error
 	RemoveHeaderCalled               func(header coreData.HeaderHandler) error
 	RemoveMiniblocksCalled           func(header coreData.HeaderHandler, body *block.Body) error
@@ -19,6 +22,9 @@ type ElasticProcessorStub struct {
 	SaveShardValidatorsPubKeysCalled func(validators *outport.ValidatorsPubKeys) error
 	SaveAccountsCalled               func(accountsData *outport.Accounts) error
 	RemoveAccountsESDTCalled         func(headerTimestamp uint64) error
+	SetIndexEnabledCalled            func(index string, enabled bool) error
+	GetEnabledIndexesCalled          func() []string
+	CloseCalled                      func() error
 }
 
 // RemoveAccountsESDT -
@@ -30,6 +36,22 @@ func (eim *ElasticProcessorStub) RemoveAccountsESDT(headerTimestamp uint64, _ ui
 	return nil
 }
 
+// PrepareBlock -
+func (eim *ElasticProcessorStub) PrepareBlock(obh *outport.OutportBlockWithHeader) (*data.PreparedBlock, error) {
+	if eim.PrepareBlockCalled != nil {
+		return eim.PrepareBlockCalled(obh)
+	}
+	return &data.PreparedBlock{}, nil
+}
+
+// CommitBlock -
+func (eim *ElasticProcessorStub) CommitBlock(preparedBlock *data.PreparedBlock) error {
+	if eim.CommitBlockCalled != nil {
+		return eim.CommitBlockCalled(preparedBlock)
+	}
+	return nil
+}
+
 // SaveHeader -
 func (eim *ElasticProcessorStub) SaveHeader(obh *outport.OutportBlockWithHeader) error {
 	if eim.SaveHeaderCalled != nil {
@@ -116,6 +138,30 @@ func (eim *ElasticProcessorStub) SetOutportConfig(_ outport.OutportConfig) error
 	return nil
 }
 
+// SetIndexEnabled -
+func (eim *ElasticProcessorStub) SetIndexEnabled(index string, enabled bool) error {
+	if eim.SetIndexEnabledCalled != nil {
+		return eim.SetIndexEnabledCalled(index, enabled)
+	}
+	return nil
+}
+
+// GetEnabledIndexes -
+func (eim *ElasticProcessorStub) GetEnabledIndexes() []string {
+	if eim.GetEnabledIndexesCalled != nil {
+		return eim.GetEnabledIndexesCalled()
+	}
+	return nil
+}
+
+// Close -
+func (eim *ElasticProcessorStub) Close() error {
+	if eim.CloseCalled != nil {
+		return eim.CloseCalled()
+	}
+	return nil
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (eim *ElasticProcessorStub) IsInterfaceNil() bool {
 	return eim == nil
@@ -0,0 +1,47 @@
+package mock
+
+import "github.com/multiversx/mx-chain-es-indexer-go/core/request"
+
+// IndexingStatusStub -
+type IndexingStatusStub struct {
+	SetLastIndexedBlockCalled func(shardID uint32, nonce uint64, round uint64, timestamp uint64)
+	SetPayloadReceivedCalled  func()
+}
+
+// SetTemplatesChecked -
+func (iss *IndexingStatusStub) SetTemplatesChecked() {
+}
+
+// SetPayloadReceived -
+func (iss *IndexingStatusStub) SetPayloadReceived() {
+	if iss.SetPayloadReceivedCalled != nil {
+		iss.SetPayloadReceivedCalled()
+	}
+}
+
+// SetLastIndexedBlock -
+func (iss *IndexingStatusStub) SetLastIndexedBlock(shardID uint32, nonce uint64, round uint64, timestamp uint64) {
+	if iss.SetLastIndexedBlockCalled != nil {
+		iss.SetLastIndexedBlockCalled(shardID, nonce, round, timestamp)
+	}
+}
+
+// IsTemplatesChecked -
+func (iss *IndexingStatusStub) IsTemplatesChecked() bool {
+	return false
+}
+
+// IsWebSocketConnected -
+func (iss *IndexingStatusStub) IsWebSocketConnected() bool {
+	return false
+}
+
+// GetIndexingStatus -
+func (iss *IndexingStatusStub) GetIndexingStatus() map[uint32]*request.ShardIndexingStatus {
+	return nil
+}
+
+// IsInterfaceNil -
+func (iss *IndexingStatusStub) IsInterfaceNil() bool {
+	return iss == nil
+}
@@ -49,6 +49,22 @@ const (
 	ValuesIndex = "values"
 	// EventsIndex is the Elasticsearch index for log events
 	EventsIndex = "events"
+	// ProcessingErrorsIndex is the Elasticsearch index for the events which could not be processed
+	ProcessingErrorsIndex = "processingerrors"
+	// ValidatorStatsIndex is the Elasticsearch index for the activity and the rating of the validators in each epoch
+	ValidatorStatsIndex = "validatorstats"
+	// RatingHistoryIndex is the Elasticsearch index for all the ratings received for the validators
+	RatingHistoryIndex = "ratinghistory"
+	// EpochSummaryIndex is the Elasticsearch index for the activity and the fees of each shard in each epoch
+	EpochSummaryIndex = "epochsummary"
+	// StatsHourlyIndex is the Elasticsearch index for the activity of the chain in each hour
+	StatsHourlyIndex = "stats-hourly"
+	// StatsDailyIndex is the Elasticsearch index for the activity of the chain in each day
+	StatsDailyIndex = "stats-daily"
+	// ActiveAddressesIndex is the Elasticsearch index for the active addresses of each day and epoch
+	ActiveAddressesIndex = "activeaddresses"
+	// ContractsIndex is the Elasticsearch index for the interactions and the deploy information of the smart contracts
+	ContractsIndex = "contracts"
 
 	// TransactionsPolicy is the Elasticsearch policy for the transactions
 	TransactionsPolicy = "transactions_policy"
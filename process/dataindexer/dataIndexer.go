@@ -3,6 +3,7 @@ package dataindexer
 import (
 	"encoding/hex"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/multiversx/mx-chain-core-go/core"
@@ -11,6 +12,8 @@ import (
 	"github.com/multiversx/mx-chain-core-go/data/block"
 	"github.com/multiversx/mx-chain-core-go/data/outport"
 	"github.com/multiversx/mx-chain-core-go/marshal"
+	indexerCore "github.com/multiversx/mx-chain-es-indexer-go/core"
+	indexerData "github.com/multiversx/mx-chain-es-indexer-go/data"
 	logger "github.com/multiversx/mx-chain-logger-go"
 )
 
@@ -21,12 +24,18 @@ type ArgDataIndexer struct {
 	HeaderMarshaller marshal.Marshalizer
 	ElasticProcessor ElasticProcessor
 	BlockContainer   BlockContainerHandler
+	IndexingStatus   indexerCore.IndexingStatusHandler
+	ImportDBBatch    ImportDBBatchConfig
 }
 
 type dataIndexer struct {
 	elasticProcessor ElasticProcessor
 	headerMarshaller marshal.Marshalizer
 	blockContainer   BlockContainerHandler
+	indexingStatus   indexerCore.IndexingStatusHandler
+	importDBBatch    *importDBBatch
+	mutImportDB      sync.RWMutex
+	importDB         bool
 }
 
 // NewDataIndexer will create a new data indexer
@@ -40,6 +49,10 @@ func NewDataIndexer(arguments ArgDataIndexer) (*dataIndexer, error) {
 		elasticProcessor: arguments.ElasticProcessor,
 		headerMarshaller: arguments.HeaderMarshaller,
 		blockContainer:   arguments.BlockContainer,
+		indexingStatus:   arguments.IndexingStatus,
+	}
+	if arguments.ImportDBBatch.Enabled {
+		dataIndexerObj.importDBBatch = newImportDBBatch(arguments.ImportDBBatch, arguments.ElasticProcessor, arguments.IndexingStatus)
 	}
 
 	return dataIndexerObj, nil
@@ -55,8 +68,11 @@ func checkIndexerArgs(arguments ArgDataIndexer) error {
 	if check.IfNilReflect(arguments.BlockContainer) {
 		return ErrNilBlockContainerHandler
 	}
+	if check.IfNil(arguments.IndexingStatus) {
+		return indexerCore.ErrNilIndexingStatusHandler
+	}
 
-	return nil
+	return checkImportDBBatchConfig(arguments.ImportDBBatch)
 }
 
 func (di *dataIndexer) getHeaderFromBytes(headerType core.HeaderType, headerBytes []byte) (header data.HeaderHandler, err error) {
@@ -70,19 +86,32 @@ func (di *dataIndexer) getHeaderFromBytes(headerType core.HeaderType, headerByte
 
 // SaveBlock saves the block info in the queue to be sent to elastic
 func (di *dataIndexer) SaveBlock(outportBlock *outport.OutportBlock) error {
-	header, err := di.getHeaderFromBytes(core.HeaderType(outportBlock.BlockData.HeaderType), outportBlock.BlockData.HeaderBytes)
+	preparedBlock, err := di.PrepareBlock(outportBlock)
 	if err != nil {
 		return err
 	}
 
+	return di.CommitBlock(preparedBlock)
+}
+
+// PrepareBlock will decode and serialize the provided block, without sending it to elastic
+func (di *dataIndexer) PrepareBlock(outportBlock *outport.OutportBlock) (*indexerData.PreparedBlock, error) {
+	if outportBlock.BlockData == nil {
+		return nil, ErrNilBlockData
+	}
+
+	header, err := di.getHeaderFromBytes(core.HeaderType(outportBlock.BlockData.HeaderType), outportBlock.BlockData.HeaderBytes)
+	if err != nil {
+		return nil, err
+	}
+
 	headerHash := outportBlock.BlockData.HeaderHash
-	shardID := header.GetShardID()
 	headerNonce := header.GetNonce()
 	startTime := time.Now()
 	defer func() {
-		log.Debug("di.SaveBlockData",
+		log.Debug("di.PrepareBlock",
 			"duration", time.Since(startTime),
-			"shardID", shardID,
+			"shardID", header.GetShardID(),
 			"nonce", headerNonce,
 			"hash", headerHash,
 		)
@@ -92,51 +121,90 @@ func (di *dataIndexer) SaveBlock(outportBlock *outport.OutportBlock) error {
 	if outportBlock.TransactionPool == nil {
 		outportBlock.TransactionPool = &outport.TransactionPool{}
 	}
+	removeMalformedEntries(outportBlock)
 
-	return di.saveBlockData(outportBlock, header)
-}
-
-func (di *dataIndexer) saveBlockData(outportBlock *outport.OutportBlock, header data.HeaderHandler) error {
 	outportBlockWithHeader := &outport.OutportBlockWithHeader{
 		OutportBlock: outportBlock,
 		Header:       header,
 	}
-
-	headerHash := outportBlock.BlockData.HeaderHash
-	headerNonce := header.GetNonce()
-	err := di.elasticProcessor.SaveHeader(outportBlockWithHeader)
+	preparedBlock, err := di.elasticProcessor.PrepareBlock(outportBlockWithHeader)
 	if err != nil {
-		return fmt.Errorf("%w when saving header block, hash %s, nonce %d",
+		return nil, fmt.Errorf("%w, block hash %s, nonce %d",
 			err, hex.EncodeToString(headerHash), headerNonce)
 	}
 
-	if len(outportBlock.BlockData.Body.MiniBlocks) == 0 {
+	return preparedBlock, nil
+}
+
+// CommitBlock will send to elastic a block prepared with PrepareBlock. In import-db mode, the block can be added in a
+// batch with other blocks, which is sent later
+func (di *dataIndexer) CommitBlock(preparedBlock *indexerData.PreparedBlock) error {
+	if di.shouldBatchBlocks() {
+		err := di.importDBBatch.add(preparedBlock)
+		if err != nil {
+			return fmt.Errorf("%w when flushing the import-db batch, hash %s, nonce %d",
+				err, hex.EncodeToString(preparedBlock.HeaderHash), preparedBlock.Nonce)
+		}
+
 		return nil
 	}
 
-	miniBlocks := append(outportBlock.BlockData.Body.MiniBlocks, outportBlock.BlockData.IntraShardMiniBlocks...)
-	err = di.elasticProcessor.SaveMiniblocks(header, miniBlocks)
-	if err != nil {
-		return fmt.Errorf("%w when saving miniblocks, block hash %s, nonce %d",
-			err, hex.EncodeToString(headerHash), headerNonce)
-	}
+	startTime := time.Now()
+	defer func() {
+		log.Debug("di.CommitBlock",
+			"duration", time.Since(startTime),
+			"shardID", preparedBlock.ShardID,
+			"nonce", preparedBlock.Nonce,
+			"hash", preparedBlock.HeaderHash,
+		)
+	}()
 
-	err = di.elasticProcessor.SaveTransactions(outportBlockWithHeader)
+	err := di.elasticProcessor.CommitBlock(preparedBlock)
 	if err != nil {
-		return fmt.Errorf("%w when saving transactions, block hash %s, nonce %d",
-			err, hex.EncodeToString(headerHash), headerNonce)
+		return fmt.Errorf("%w when committing block, hash %s, nonce %d",
+			err, hex.EncodeToString(preparedBlock.HeaderHash), preparedBlock.Nonce)
 	}
 
+	di.indexingStatus.SetLastIndexedBlock(preparedBlock.ShardID, preparedBlock.Nonce, preparedBlock.Round, preparedBlock.Timestamp)
+
 	return nil
 }
 
-// Close will stop goroutine that index data in database
+func (di *dataIndexer) shouldBatchBlocks() bool {
+	di.mutImportDB.RLock()
+	defer di.mutImportDB.RUnlock()
+
+	return di.importDBBatch != nil && di.importDB
+}
+
+// flushImportDBBatch will send the blocks collected in import-db mode, if any
+func (di *dataIndexer) flushImportDBBatch() error {
+	if di.importDBBatch == nil {
+		return nil
+	}
+
+	return di.importDBBatch.flush()
+}
+
+// Close will close the elastic processor, waiting for the pending write operations, if any
 func (di *dataIndexer) Close() error {
-	return nil
+	if di.importDBBatch != nil {
+		err := di.importDBBatch.close()
+		if err != nil {
+			log.Error("dataIndexer.Close: cannot flush the import-db batch", "error", err)
+		}
+	}
+
+	return di.elasticProcessor.Close()
 }
 
 // RevertIndexedBlock will remove from database block and miniblocks
 func (di *dataIndexer) RevertIndexedBlock(blockData *outport.BlockData) error {
+	err := di.flushImportDBBatch()
+	if err != nil {
+		return err
+	}
+
 	header, err := di.getHeaderFromBytes(core.HeaderType(blockData.HeaderType), blockData.HeaderBytes)
 	if err != nil {
 		return err
@@ -177,12 +245,20 @@ func (di *dataIndexer) SaveValidatorsPubKeys(validatorsPubKeys *outport.Validato
 
 // SaveAccounts will save the provided accounts
 func (di *dataIndexer) SaveAccounts(accounts *outport.Accounts) error {
+	// the accounts are saved after the blocks that altered them
+	err := di.flushImportDBBatch()
+	if err != nil {
+		return err
+	}
+
+	removeNilAlteredAccounts(accounts.AlteredAccounts)
+
 	return di.elasticProcessor.SaveAccounts(accounts)
 }
 
-// FinalizedBlock returns nil
+// FinalizedBlock will send the blocks collected in import-db mode, so a finalized block is saved in the database
 func (di *dataIndexer) FinalizedBlock(_ *outport.FinalizedBlock) error {
-	return nil
+	return di.flushImportDBBatch()
 }
 
 // GetMarshaller return the marshaller
@@ -199,9 +275,28 @@ func (di *dataIndexer) RegisterHandler(_ func() error, _ string) error {
 func (di *dataIndexer) SetCurrentSettings(cfg outport.OutportConfig) error {
 	log.Debug("dataIndexer.SetCurrentSettings", "importDBMode", cfg.IsInImportDBMode)
 
+	err := di.flushImportDBBatch()
+	if err != nil {
+		return err
+	}
+
+	di.mutImportDB.Lock()
+	di.importDB = cfg.IsInImportDBMode
+	di.mutImportDB.Unlock()
+
 	return di.elasticProcessor.SetOutportConfig(cfg)
 }
 
+// SetIndexEnabled will enable or disable the indexing of the provided index at runtime
+func (di *dataIndexer) SetIndexEnabled(index string, enabled bool) error {
+	return di.elasticProcessor.SetIndexEnabled(index, enabled)
+}
+
+// GetEnabledIndexes returns the list of the enabled indices
+func (di *dataIndexer) GetEnabledIndexes() []string {
+	return di.elasticProcessor.GetEnabledIndexes()
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (di *dataIndexer) IsInterfaceNil() bool {
 	return di == nil
@@ -1,6 +1,7 @@
 package dataindexer
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/multiversx/mx-chain-core-go/core"
@@ -8,6 +9,8 @@ import (
 	coreData "github.com/multiversx/mx-chain-core-go/data"
 	dataBlock "github.com/multiversx/mx-chain-core-go/data/block"
 	"github.com/multiversx/mx-chain-core-go/data/outport"
+	indexerCore "github.com/multiversx/mx-chain-es-indexer-go/core"
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
 	"github.com/multiversx/mx-chain-es-indexer-go/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -17,6 +20,7 @@ func NewDataIndexerArguments() ArgDataIndexer {
 		ElasticProcessor: &mock.ElasticProcessorStub{},
 		HeaderMarshaller: &mock.MarshalizerMock{},
 		BlockContainer:   &mock.BlockContainerStub{},
+		IndexingStatus:   &mock.IndexingStatusStub{},
 	}
 }
 
@@ -38,6 +42,15 @@ func TestDataIndexer_NewIndexerWithNilMarshalizerShouldErr(t *testing.T) {
 	require.Equal(t, core.ErrNilMarshalizer, err)
 }
 
+func TestDataIndexer_NewIndexerWithNilIndexingStatusShouldErr(t *testing.T) {
+	arguments := NewDataIndexerArguments()
+	arguments.IndexingStatus = nil
+	ei, err := NewDataIndexer(arguments)
+
+	require.Nil(t, ei)
+	require.Equal(t, indexerCore.ErrNilIndexingStatusHandler, err)
+}
+
 func TestDataIndexer_NewIndexerWithCorrectParamsShouldWork(t *testing.T) {
 	arguments := NewDataIndexerArguments()
 
@@ -57,18 +70,27 @@ func TestDataIndexer_SaveBlock(t *testing.T) {
 		},
 	}
 
+	preparedBlock := &data.PreparedBlock{ShardID: 1, Nonce: 2, Round: 3, Timestamp: 4}
 	arguments.ElasticProcessor = &mock.ElasticProcessorStub{
-		SaveHeaderCalled: func(outportBlockWithHeader *outport.OutportBlockWithHeader) error {
+		PrepareBlockCalled: func(outportBlockWithHeader *outport.OutportBlockWithHeader) (*data.PreparedBlock, error) {
 			countMap[0]++
-			return nil
+			require.NotNil(t, outportBlockWithHeader.Header)
+			require.NotNil(t, outportBlockWithHeader.TransactionPool)
+			return preparedBlock, nil
 		},
-		SaveMiniblocksCalled: func(header coreData.HeaderHandler, miniBlocks []*dataBlock.MiniBlock) error {
+		CommitBlockCalled: func(block *data.PreparedBlock) error {
 			countMap[1]++
+			require.Equal(t, preparedBlock, block)
 			return nil
 		},
-		SaveTransactionsCalled: func(outportBlockWithHeader *outport.OutportBlockWithHeader) error {
+	}
+	arguments.IndexingStatus = &mock.IndexingStatusStub{
+		SetLastIndexedBlockCalled: func(shardID uint32, nonce uint64, round uint64, timestamp uint64) {
 			countMap[2]++
-			return nil
+			require.Equal(t, uint32(1), shardID)
+			require.Equal(t, uint64(2), nonce)
+			require.Equal(t, uint64(3), round)
+			require.Equal(t, uint64(4), timestamp)
 		},
 	}
 	ei, _ := NewDataIndexer(arguments)
@@ -87,6 +109,38 @@ func TestDataIndexer_SaveBlock(t *testing.T) {
 	require.Equal(t, 1, countMap[2])
 }
 
+func TestDataIndexer_SaveBlockCommitErrorShouldNotSetLastIndexedBlock(t *testing.T) {
+	expectedErr := errors.New("commit error")
+
+	arguments := NewDataIndexerArguments()
+	arguments.BlockContainer = &mock.BlockContainerStub{
+		GetCalled: func(headerType core.HeaderType) (dataBlock.EmptyBlockCreator, error) {
+			return dataBlock.NewEmptyHeaderV2Creator(), nil
+		},
+	}
+	arguments.ElasticProcessor = &mock.ElasticProcessorStub{
+		CommitBlockCalled: func(block *data.PreparedBlock) error {
+			return expectedErr
+		},
+	}
+	arguments.IndexingStatus = &mock.IndexingStatusStub{
+		SetLastIndexedBlockCalled: func(shardID uint32, nonce uint64, round uint64, timestamp uint64) {
+			require.Fail(t, "should have not been called")
+		},
+	}
+	ei, _ := NewDataIndexer(arguments)
+
+	args := &outport.OutportBlock{
+		BlockData: &outport.BlockData{
+			HeaderType:  string(core.ShardHeaderV2),
+			Body:        &dataBlock.Body{},
+			HeaderBytes: []byte("{}"),
+		},
+	}
+	err := ei.SaveBlock(args)
+	require.True(t, errors.Is(err, expectedErr))
+}
+
 func TestDataIndexer_SaveRoundInfo(t *testing.T) {
 	called := false
 
@@ -185,3 +239,19 @@ func TestDataIndexer_RevertIndexedBlock(t *testing.T) {
 	require.Equal(t, 1, countMap[2])
 	require.Equal(t, 1, countMap[3])
 }
+
+func TestDataIndexer_SaveBlockWithoutBlockDataShouldErr(t *testing.T) {
+	t.Parallel()
+
+	arguments := NewDataIndexerArguments()
+	arguments.ElasticProcessor = &mock.ElasticProcessorStub{
+		PrepareBlockCalled: func(obh *outport.OutportBlockWithHeader) (*data.PreparedBlock, error) {
+			require.Fail(t, "should have not been called")
+			return nil, nil
+		},
+	}
+	ei, _ := NewDataIndexer(arguments)
+
+	err := ei.SaveBlock(&outport.OutportBlock{})
+	require.Equal(t, ErrNilBlockData, err)
+}
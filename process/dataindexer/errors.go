@@ -86,5 +86,26 @@ var ErrNilBalanceConverter = errors.New("nil balance converter")
 // ErrNilOperationsHandler signals that a nil operations handler has been provided
 var ErrNilOperationsHandler = errors.New("nil operations handler")
 
+// ErrNilTokensCache signals that a nil tokens cache has been provided
+var ErrNilTokensCache = errors.New("nil tokens cache")
+
+// ErrNilEpochSummaryHandler signals that a nil epoch summary handler has been provided
+var ErrNilEpochSummaryHandler = errors.New("nil epoch summary handler")
+
+// ErrNilRollupsHandler signals that a nil rollups handler has been provided
+var ErrNilRollupsHandler = errors.New("nil rollups handler")
+
+// ErrNilActiveAddressesHandler signals that a nil active addresses handler has been provided
+var ErrNilActiveAddressesHandler = errors.New("nil active addresses handler")
+
+// ErrNilContractsHandler signals that a nil contracts handler has been provided
+var ErrNilContractsHandler = errors.New("nil contracts handler")
+
 // ErrNilBlockContainerHandler signals that a nil block container handler has been provided
 var ErrNilBlockContainerHandler = errors.New("nil bock container handler")
+
+// ErrInvalidImportDBBatchConfig signals that an invalid import-db batch configuration has been provided
+var ErrInvalidImportDBBatchConfig = errors.New("invalid import-db batch config")
+
+// ErrNilBlockData signals that a payload without the block data has been provided
+var ErrNilBlockData = errors.New("nil block data")
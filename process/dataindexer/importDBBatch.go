@@ -0,0 +1,186 @@
+package dataindexer
+
+import (
+	"sync"
+	"time"
+
+	indexerCore "github.com/multiversx/mx-chain-es-indexer-go/core"
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
+)
+
+// ImportDBBatchConfig holds the settings of the batch that collects the documents of many blocks while the node runs
+// in import-db mode
+type ImportDBBatchConfig struct {
+	Enabled            bool
+	BulkRequestMaxSize int
+	FlushSize          int
+	FlushInterval      time.Duration
+}
+
+type blockInfo struct {
+	nonce     uint64
+	round     uint64
+	timestamp uint64
+}
+
+// importDBBatch collects the serialized documents of many blocks of the same shard in large bulk requests, which are
+// flushed when the collected size reaches the flush size, when a block of another shard is added, or periodically.
+// The last indexed block of a shard is updated only after its documents were flushed. The bulk requests are sent one at
+// a time and a failed flush keeps only the ones that were not sent, so the scripted updates of the sent ones (as the
+// counters of the tags or of the validators stats) are not applied twice when the flush is retried
+type importDBBatch struct {
+	mut                sync.Mutex
+	elasticProcessor   ElasticProcessor
+	indexingStatus     indexerCore.IndexingStatusHandler
+	bulkRequestMaxSize int
+	flushSize          int
+	buffers            *data.BufferSlice
+	numSentBuffers     int
+	size               int
+	shardID            uint32
+	tokensInfo         []*data.TokenInfo
+	lastBlocks         map[uint32]blockInfo
+	closeChan          chan struct{}
+	closeOnce          sync.Once
+}
+
+func newImportDBBatch(cfg ImportDBBatchConfig, elasticProcessor ElasticProcessor, indexingStatus indexerCore.IndexingStatusHandler) *importDBBatch {
+	batch := &importDBBatch{
+		elasticProcessor:   elasticProcessor,
+		indexingStatus:     indexingStatus,
+		bulkRequestMaxSize: cfg.BulkRequestMaxSize,
+		flushSize:          cfg.FlushSize,
+		closeChan:          make(chan struct{}),
+	}
+	batch.reset()
+
+	go batch.flushPeriodically(cfg.FlushInterval)
+
+	return batch
+}
+
+// add will append the documents of the provided block to the batch. A block that changes the tokens data is flushed
+// right away, since that data is read while the next block is prepared
+func (ib *importDBBatch) add(preparedBlock *data.PreparedBlock) error {
+	ib.mut.Lock()
+	defer ib.mut.Unlock()
+
+	// the documents are committed with the shard ID of their blocks
+	if len(ib.lastBlocks) > 0 && ib.shardID != preparedBlock.ShardID {
+		err := ib.flushUnprotected()
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, buff := range preparedBlock.Buffers {
+		// every buffer holds complete bulk items, so the buffers of many blocks can be merged in larger ones
+		err := ib.buffers.PutData(buff.Bytes(), nil)
+		if err != nil {
+			return err
+		}
+		ib.size += buff.Len()
+	}
+	data.ReleaseBuffers(preparedBlock.Buffers)
+
+	ib.shardID = preparedBlock.ShardID
+	ib.tokensInfo = append(ib.tokensInfo, preparedBlock.TokensInfo...)
+	ib.lastBlocks[preparedBlock.ShardID] = blockInfo{
+		nonce:     preparedBlock.Nonce,
+		round:     preparedBlock.Round,
+		timestamp: preparedBlock.Timestamp,
+	}
+
+	if ib.size < ib.flushSize && !preparedBlock.MustBeCommittedBeforeNextBlock() {
+		return nil
+	}
+
+	return ib.flushUnprotected()
+}
+
+// flush will send the collected documents to the database
+func (ib *importDBBatch) flush() error {
+	ib.mut.Lock()
+	defer ib.mut.Unlock()
+
+	return ib.flushUnprotected()
+}
+
+func (ib *importDBBatch) flushUnprotected() error {
+	if len(ib.lastBlocks) == 0 {
+		return nil
+	}
+
+	startTime := time.Now()
+	buffers := ib.buffers.Buffers()
+	for ib.numSentBuffers < len(buffers) {
+		preparedBlock := &data.PreparedBlock{
+			ShardID: ib.shardID,
+			Buffers: buffers[ib.numSentBuffers : ib.numSentBuffers+1],
+		}
+		isLastBuffer := ib.numSentBuffers == len(buffers)-1
+		if isLastBuffer {
+			preparedBlock.TokensInfo = ib.tokensInfo
+		}
+
+		err := ib.elasticProcessor.CommitBlock(preparedBlock)
+		if err != nil {
+			// the documents that were not sent are kept, so they are sent on the next flush
+			return err
+		}
+		ib.numSentBuffers++
+	}
+
+	for shardID, lastBlock := range ib.lastBlocks {
+		ib.indexingStatus.SetLastIndexedBlock(shardID, lastBlock.nonce, lastBlock.round, lastBlock.timestamp)
+	}
+	log.Debug("importDBBatch.flush", "size", ib.size, "num bulk requests", len(buffers), "duration", time.Since(startTime))
+	ib.reset()
+
+	return nil
+}
+
+func (ib *importDBBatch) reset() {
+	ib.buffers = data.NewBufferSlice(ib.bulkRequestMaxSize)
+	ib.numSentBuffers = 0
+	ib.size = 0
+	ib.tokensInfo = nil
+	ib.lastBlocks = make(map[uint32]blockInfo)
+}
+
+func (ib *importDBBatch) flushPeriodically(flushInterval time.Duration) {
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			err := ib.flush()
+			if err != nil {
+				log.Warn("importDBBatch.flushPeriodically", "error", err)
+			}
+		case <-ib.closeChan:
+			return
+		}
+	}
+}
+
+// close will stop the periodic flush and will flush the collected documents
+func (ib *importDBBatch) close() error {
+	ib.closeOnce.Do(func() {
+		close(ib.closeChan)
+	})
+
+	return ib.flush()
+}
+
+func checkImportDBBatchConfig(cfg ImportDBBatchConfig) error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.FlushSize <= 0 || cfg.FlushInterval <= 0 || cfg.BulkRequestMaxSize < 0 {
+		return ErrInvalidImportDBBatchConfig
+	}
+
+	return nil
+}
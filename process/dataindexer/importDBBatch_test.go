@@ -0,0 +1,259 @@
+package dataindexer
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
+	"github.com/multiversx/mx-chain-es-indexer-go/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createPreparedBlock(nonce uint64, docs ...string) *data.PreparedBlock {
+	buffers := make([]*bytes.Buffer, 0, len(docs))
+	for _, doc := range docs {
+		buffers = append(buffers, bytes.NewBufferString(doc))
+	}
+
+	return &data.PreparedBlock{
+		ShardID: 1,
+		Nonce:   nonce,
+		Buffers: buffers,
+	}
+}
+
+func createImportDBDataIndexer(t *testing.T, cfg ImportDBBatchConfig, elasticProcessor ElasticProcessor, indexingStatus *mock.IndexingStatusStub) *dataIndexer {
+	arguments := NewDataIndexerArguments()
+	arguments.ElasticProcessor = elasticProcessor
+	arguments.IndexingStatus = indexingStatus
+	arguments.ImportDBBatch = cfg
+
+	di, err := NewDataIndexer(arguments)
+	require.Nil(t, err)
+	require.Nil(t, di.SetCurrentSettings(outport.OutportConfig{IsInImportDBMode: true}))
+
+	return di
+}
+
+func TestNewDataIndexer_InvalidImportDBBatchConfigShouldErr(t *testing.T) {
+	t.Parallel()
+
+	arguments := NewDataIndexerArguments()
+	arguments.ImportDBBatch = ImportDBBatchConfig{Enabled: true, FlushSize: 0, FlushInterval: time.Second}
+	di, err := NewDataIndexer(arguments)
+	require.Nil(t, di)
+	require.Equal(t, ErrInvalidImportDBBatchConfig, err)
+
+	arguments.ImportDBBatch = ImportDBBatchConfig{Enabled: true, FlushSize: 100, FlushInterval: 0}
+	di, err = NewDataIndexer(arguments)
+	require.Nil(t, di)
+	require.Equal(t, ErrInvalidImportDBBatchConfig, err)
+}
+
+func TestDataIndexer_ImportDBBatchShouldCollectBlocksUntilFlushSize(t *testing.T) {
+	t.Parallel()
+
+	committed := make([]*data.PreparedBlock, 0)
+	elasticProcessor := &mock.ElasticProcessorStub{
+		CommitBlockCalled: func(preparedBlock *data.PreparedBlock) error {
+			committed = append(committed, preparedBlock)
+			return nil
+		},
+	}
+	lastIndexedNonce := uint64(0)
+	indexingStatus := &mock.IndexingStatusStub{
+		SetLastIndexedBlockCalled: func(shardID uint32, nonce uint64, round uint64, timestamp uint64) {
+			lastIndexedNonce = nonce
+		},
+	}
+	cfg := ImportDBBatchConfig{Enabled: true, BulkRequestMaxSize: 1000, FlushSize: 30, FlushInterval: time.Hour}
+	di := createImportDBDataIndexer(t, cfg, elasticProcessor, indexingStatus)
+
+	require.Nil(t, di.CommitBlock(createPreparedBlock(1, "block-1-a\n", "block-1-b\n")))
+	require.Empty(t, committed)
+	require.Zero(t, lastIndexedNonce)
+
+	require.Nil(t, di.CommitBlock(createPreparedBlock(2, "block-2-a\n")))
+	require.Len(t, committed, 1)
+	require.Equal(t, uint64(2), lastIndexedNonce)
+
+	// the buffers of the two blocks were merged in a single bulk request
+	require.Len(t, committed[0].Buffers, 1)
+	require.Equal(t, "block-1-a\nblock-1-b\nblock-2-a\n", committed[0].Buffers[0].String())
+
+	require.Nil(t, di.Close())
+	require.Len(t, committed, 1)
+}
+
+func TestDataIndexer_ImportDBBatchShouldFlushBlockWithTokensChanges(t *testing.T) {
+	t.Parallel()
+
+	numCommits := 0
+	elasticProcessor := &mock.ElasticProcessorStub{
+		CommitBlockCalled: func(preparedBlock *data.PreparedBlock) error {
+			numCommits++
+			require.Len(t, preparedBlock.TokensInfo, 1)
+			return nil
+		},
+	}
+	cfg := ImportDBBatchConfig{Enabled: true, FlushSize: 1000, FlushInterval: time.Hour}
+	di := createImportDBDataIndexer(t, cfg, elasticProcessor, &mock.IndexingStatusStub{})
+
+	preparedBlock := createPreparedBlock(1, "issue\n")
+	preparedBlock.TokensInfo = []*data.TokenInfo{{Token: "TKN-01"}}
+	require.Nil(t, di.CommitBlock(preparedBlock))
+	require.Equal(t, 1, numCommits)
+}
+
+func TestDataIndexer_ImportDBBatchShouldFlushPeriodically(t *testing.T) {
+	t.Parallel()
+
+	mut := sync.Mutex{}
+	numCommits := 0
+	elasticProcessor := &mock.ElasticProcessorStub{
+		CommitBlockCalled: func(preparedBlock *data.PreparedBlock) error {
+			mut.Lock()
+			numCommits++
+			mut.Unlock()
+			return nil
+		},
+	}
+	cfg := ImportDBBatchConfig{Enabled: true, FlushSize: 1000, FlushInterval: 10 * time.Millisecond}
+	di := createImportDBDataIndexer(t, cfg, elasticProcessor, &mock.IndexingStatusStub{})
+	defer func() {
+		_ = di.Close()
+	}()
+
+	require.Nil(t, di.CommitBlock(createPreparedBlock(1, "doc\n")))
+	require.Eventually(t, func() bool {
+		mut.Lock()
+		defer mut.Unlock()
+
+		return numCommits == 1
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestDataIndexer_ImportDBBatchFlushErrorShouldKeepTheDocuments(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("bulk error")
+	shouldFail := true
+	sentBodies := make([]string, 0)
+	elasticProcessor := &mock.ElasticProcessorStub{
+		CommitBlockCalled: func(preparedBlock *data.PreparedBlock) error {
+			if shouldFail {
+				return expectedErr
+			}
+			sentBodies = append(sentBodies, preparedBlock.Buffers[0].String())
+			return nil
+		},
+	}
+	setLastIndexedCalled := false
+	indexingStatus := &mock.IndexingStatusStub{
+		SetLastIndexedBlockCalled: func(shardID uint32, nonce uint64, round uint64, timestamp uint64) {
+			setLastIndexedCalled = true
+		},
+	}
+	cfg := ImportDBBatchConfig{Enabled: true, FlushSize: 5, FlushInterval: time.Hour}
+	di := createImportDBDataIndexer(t, cfg, elasticProcessor, indexingStatus)
+
+	err := di.CommitBlock(createPreparedBlock(1, "block-1\n"))
+	require.True(t, errors.Is(err, expectedErr))
+	require.False(t, setLastIndexedCalled)
+
+	shouldFail = false
+	require.Nil(t, di.CommitBlock(createPreparedBlock(2, "block-2\n")))
+	require.Equal(t, []string{"block-1\nblock-2\n"}, sentBodies)
+	require.True(t, setLastIndexedCalled)
+}
+
+func TestDataIndexer_ImportDBModeEndShouldFlushTheBatch(t *testing.T) {
+	t.Parallel()
+
+	numCommits := 0
+	elasticProcessor := &mock.ElasticProcessorStub{
+		CommitBlockCalled: func(preparedBlock *data.PreparedBlock) error {
+			numCommits++
+			return nil
+		},
+	}
+	cfg := ImportDBBatchConfig{Enabled: true, FlushSize: 1000, FlushInterval: time.Hour}
+	di := createImportDBDataIndexer(t, cfg, elasticProcessor, &mock.IndexingStatusStub{})
+
+	require.Nil(t, di.CommitBlock(createPreparedBlock(1, "doc\n")))
+	require.Equal(t, 0, numCommits)
+
+	require.Nil(t, di.SetCurrentSettings(outport.OutportConfig{IsInImportDBMode: false}))
+	require.Equal(t, 1, numCommits)
+
+	// outside the import-db mode, the blocks are committed right away
+	require.Nil(t, di.CommitBlock(createPreparedBlock(2, "doc\n")))
+	require.Equal(t, 2, numCommits)
+	require.Nil(t, di.Close())
+}
+
+func TestDataIndexer_ImportDBBatchFlushErrorShouldNotSendTheSentBulkRequestsAgain(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("bulk error")
+	numFailures := 1
+	sentBodies := make([]string, 0)
+	elasticProcessor := &mock.ElasticProcessorStub{
+		CommitBlockCalled: func(preparedBlock *data.PreparedBlock) error {
+			body := preparedBlock.Buffers[0].String()
+			if body == "block-2\n" && numFailures > 0 {
+				numFailures--
+				return expectedErr
+			}
+			sentBodies = append(sentBodies, body)
+			return nil
+		},
+	}
+	cfg := ImportDBBatchConfig{Enabled: true, BulkRequestMaxSize: 10, FlushSize: 1000, FlushInterval: time.Hour}
+	di := createImportDBDataIndexer(t, cfg, elasticProcessor, &mock.IndexingStatusStub{})
+
+	require.Nil(t, di.CommitBlock(createPreparedBlock(1, "block-1\n")))
+	require.Nil(t, di.CommitBlock(createPreparedBlock(2, "block-2\n")))
+
+	err := di.FinalizedBlock(&outport.FinalizedBlock{})
+	require.True(t, errors.Is(err, expectedErr))
+	require.Equal(t, []string{"block-1\n"}, sentBodies)
+
+	require.Nil(t, di.FinalizedBlock(&outport.FinalizedBlock{}))
+	require.Equal(t, []string{"block-1\n", "block-2\n"}, sentBodies)
+}
+
+func TestDataIndexer_ImportDBBatchShouldCommitTheBlocksWithTheirShardID(t *testing.T) {
+	t.Parallel()
+
+	committedShards := make([]uint32, 0)
+	elasticProcessor := &mock.ElasticProcessorStub{
+		CommitBlockCalled: func(preparedBlock *data.PreparedBlock) error {
+			committedShards = append(committedShards, preparedBlock.ShardID)
+			return nil
+		},
+	}
+	lastIndexedNonces := make(map[uint32]uint64)
+	indexingStatus := &mock.IndexingStatusStub{
+		SetLastIndexedBlockCalled: func(shardID uint32, nonce uint64, round uint64, timestamp uint64) {
+			lastIndexedNonces[shardID] = nonce
+		},
+	}
+	cfg := ImportDBBatchConfig{Enabled: true, FlushSize: 1000, FlushInterval: time.Hour}
+	di := createImportDBDataIndexer(t, cfg, elasticProcessor, indexingStatus)
+
+	require.Nil(t, di.CommitBlock(createPreparedBlock(1, "shard-1\n")))
+	otherShardBlock := createPreparedBlock(5, "meta\n")
+	otherShardBlock.ShardID = 2
+	require.Nil(t, di.CommitBlock(otherShardBlock))
+	require.Equal(t, []uint32{1}, committedShards)
+	require.Equal(t, map[uint32]uint64{1: 1}, lastIndexedNonces)
+
+	require.Nil(t, di.SaveAccounts(&outport.Accounts{}))
+	require.Equal(t, []uint32{1, 2}, committedShards)
+	require.Equal(t, map[uint32]uint64{1: 1, 2: 5}, lastIndexedNonces)
+}
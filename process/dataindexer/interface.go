@@ -8,10 +8,13 @@ import (
 	"github.com/multiversx/mx-chain-core-go/data/block"
 	"github.com/multiversx/mx-chain-core-go/data/outport"
 	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
 )
 
 // ElasticProcessor defines the interface for the elastic search indexer
 type ElasticProcessor interface {
+	PrepareBlock(outportBlockWithHeader *outport.OutportBlockWithHeader) (*data.PreparedBlock, error)
+	CommitBlock(preparedBlock *data.PreparedBlock) error
 	SaveHeader(outportBlockWithHeader *outport.OutportBlockWithHeader) error
 	RemoveHeader(header coreData.HeaderHandler) error
 	RemoveMiniblocks(header coreData.HeaderHandler, body *block.Body) error
@@ -24,6 +27,9 @@ type ElasticProcessor interface {
 	SaveShardValidatorsPubKeys(validatorsPubKeys *outport.ValidatorsPubKeys) error
 	SaveAccounts(accounts *outport.Accounts) error
 	SetOutportConfig(cfg outport.OutportConfig) error
+	SetIndexEnabled(index string, enabled bool) error
+	GetEnabledIndexes() []string
+	Close() error
 	IsInterfaceNil() bool
 }
 
@@ -49,6 +55,8 @@ type ShardCoordinator interface {
 // This could be an elastic search index, a MySql database or any other external services.
 type Indexer interface {
 	SaveBlock(outportBlock *outport.OutportBlock) error
+	PrepareBlock(outportBlock *outport.OutportBlock) (*data.PreparedBlock, error)
+	CommitBlock(preparedBlock *data.PreparedBlock) error
 	RevertIndexedBlock(blockData *outport.BlockData) error
 	SaveRoundsInfo(roundsInfos *outport.RoundsInfo) error
 	SaveValidatorsPubKeys(validatorsPubKeys *outport.ValidatorsPubKeys) error
@@ -58,6 +66,8 @@ type Indexer interface {
 	GetMarshaller() marshal.Marshalizer
 	RegisterHandler(handler func() error, topic string) error
 	SetCurrentSettings(cfg outport.OutportConfig) error
+	SetIndexEnabled(index string, enabled bool) error
+	GetEnabledIndexes() []string
 	Close() error
 	IsInterfaceNil() bool
 }
@@ -0,0 +1,68 @@
+package dataindexer
+
+import (
+	"github.com/multiversx/mx-chain-core-go/data/alteredAccount"
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+)
+
+// removeMalformedEntries removes from the outport block the entries which have no data, like a transaction info
+// without the transaction. Such entries can be decoded from a malformed payload and cannot be indexed, so they are
+// skipped, instead of failing the processing of the whole block
+func removeMalformedEntries(outportBlock *outport.OutportBlock) {
+	removeNilAlteredAccounts(outportBlock.AlteredAccounts)
+
+	pool := outportBlock.TransactionPool
+	for hash, txInfo := range pool.Transactions {
+		if txInfo.GetTransaction() == nil {
+			logSkippedEntry("transaction", hash)
+			delete(pool.Transactions, hash)
+		}
+	}
+	for hash, txInfo := range pool.InvalidTxs {
+		if txInfo.GetTransaction() == nil {
+			logSkippedEntry("invalid transaction", hash)
+			delete(pool.InvalidTxs, hash)
+		}
+	}
+	for hash, scrInfo := range pool.SmartContractResults {
+		if scrInfo.GetSmartContractResult() == nil {
+			logSkippedEntry("smart contract result", hash)
+			delete(pool.SmartContractResults, hash)
+		}
+	}
+	for hash, rewardInfo := range pool.Rewards {
+		if rewardInfo.GetReward() == nil {
+			logSkippedEntry("reward", hash)
+			delete(pool.Rewards, hash)
+		}
+	}
+	for hash, rec := range pool.Receipts {
+		if rec == nil {
+			logSkippedEntry("receipt", hash)
+			delete(pool.Receipts, hash)
+		}
+	}
+
+	logs := make([]*outport.LogData, 0, len(pool.Logs))
+	for _, logData := range pool.Logs {
+		if logData.GetLog() == nil {
+			logSkippedEntry("log", logData.GetTxHash())
+			continue
+		}
+		logs = append(logs, logData)
+	}
+	pool.Logs = logs
+}
+
+func removeNilAlteredAccounts(alteredAccounts map[string]*alteredAccount.AlteredAccount) {
+	for address, account := range alteredAccounts {
+		if account == nil {
+			logSkippedEntry("altered account", address)
+			delete(alteredAccounts, address)
+		}
+	}
+}
+
+func logSkippedEntry(entryType string, key string) {
+	log.Warn("dataIndexer: skipped malformed entry from the outport block", "type", entryType, "key", key)
+}
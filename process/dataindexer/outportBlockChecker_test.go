@@ -0,0 +1,70 @@
+package dataindexer
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/alteredAccount"
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-core-go/data/receipt"
+	"github.com/multiversx/mx-chain-core-go/data/rewardTx"
+	"github.com/multiversx/mx-chain-core-go/data/smartContractResult"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoveMalformedEntries(t *testing.T) {
+	t.Parallel()
+
+	outportBlock := &outport.OutportBlock{
+		AlteredAccounts: map[string]*alteredAccount.AlteredAccount{
+			"a1": {Address: "a1"},
+			"a2": nil,
+		},
+		TransactionPool: &outport.TransactionPool{
+			Transactions: map[string]*outport.TxInfo{
+				"t1": {Transaction: &transaction.Transaction{}},
+				"t2": {},
+				"t3": nil,
+			},
+			InvalidTxs: map[string]*outport.TxInfo{
+				"i1": {Transaction: &transaction.Transaction{}},
+				"i2": {},
+			},
+			SmartContractResults: map[string]*outport.SCRInfo{
+				"s1": {SmartContractResult: &smartContractResult.SmartContractResult{}},
+				"s2": {},
+			},
+			Rewards: map[string]*outport.RewardInfo{
+				"r1": {Reward: &rewardTx.RewardTx{}},
+				"r2": nil,
+			},
+			Receipts: map[string]*receipt.Receipt{
+				"rc1": {},
+				"rc2": nil,
+			},
+			Logs: []*outport.LogData{
+				{TxHash: "l1", Log: &transaction.Log{}},
+				{TxHash: "l2"},
+				nil,
+			},
+		},
+	}
+
+	removeMalformedEntries(outportBlock)
+
+	pool := outportBlock.TransactionPool
+	require.Len(t, outportBlock.AlteredAccounts, 1)
+	require.NotNil(t, outportBlock.AlteredAccounts["a1"])
+	require.Len(t, pool.Transactions, 1)
+	require.NotNil(t, pool.Transactions["t1"])
+	require.Len(t, pool.InvalidTxs, 1)
+	require.NotNil(t, pool.InvalidTxs["i1"])
+	require.Len(t, pool.SmartContractResults, 1)
+	require.NotNil(t, pool.SmartContractResults["s1"])
+	require.Len(t, pool.Rewards, 1)
+	require.NotNil(t, pool.Rewards["r1"])
+	require.Len(t, pool.Receipts, 1)
+	require.NotNil(t, pool.Receipts["rc1"])
+	require.Len(t, pool.Logs, 1)
+	require.Equal(t, "l1", pool.Logs[0].TxHash)
+}
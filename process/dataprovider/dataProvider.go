@@ -0,0 +1,296 @@
+package dataprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-es-indexer-go/core"
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/dataindexer"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc"
+	logger "github.com/multiversx/mx-chain-logger-go"
+)
+
+const (
+	requestTimeout = 30 * time.Second
+	pingTimeout    = 5 * time.Second
+)
+
+// the timestamp is unique in the balance history of an address, the tokens of an address are identified by the token
+// and the nonce, and the events by the log hash, the shard and their order in the log. The keyword sub-field of the
+// token is added in the existing accountsesdt indices by the indexer, so it is used only after the mapping is checked
+var (
+	accountHistoryTiebreakers = []string(nil)
+	accountTokensTiebreakers  = []string{tokenKeywordField, "tokenNonce"}
+	eventsTiebreakers         = []string{"txHash", "shardID", "order"}
+)
+
+const tokenKeywordField = "token.keyword"
+
+var log = logger.GetOrCreate("process/dataprovider")
+
+// ArgsDataProvider holds all the components needed to create a new instance of dataProvider
+type ArgsDataProvider struct {
+	DBClient elasticproc.DatabaseClientHandler
+}
+
+type dataProvider struct {
+	dbClient             elasticproc.DatabaseClientHandler
+	mutTokenKeyword      sync.Mutex
+	isTokenKeywordMapped bool
+}
+
+// NewDataProvider will create a new instance of dataProvider, a component that is able to read the indexed data
+func NewDataProvider(args ArgsDataProvider) (*dataProvider, error) {
+	if check.IfNil(args.DBClient) {
+		return nil, ErrNilDatabaseClient
+	}
+
+	return &dataProvider{
+		dbClient: args.DBClient,
+	}, nil
+}
+
+// GetTransaction will return the transaction with the provided hash
+func (dp *dataProvider) GetTransaction(hash string) (*data.TransactionResponse, error) {
+	tx := &data.TransactionResponse{}
+	err := dp.getDocumentByID(dataindexer.TransactionsIndex, hash, &tx.Transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	tx.Hash = hash
+	return tx, nil
+}
+
+// GetAccount will return the account with the provided address
+func (dp *dataProvider) GetAccount(address string) (*data.AccountInfo, error) {
+	account := &data.AccountInfo{}
+	err := dp.getDocumentByID(dataindexer.AccountsIndex, address, account)
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// GetAccountHistory will return a page with the balance history of the provided address
+func (dp *dataProvider) GetAccountHistory(address string, pagination data.Pagination) (*data.AccountHistoryResponse, error) {
+	conditions := []objectsMap{termQuery("address", address)}
+	hits, nextSearchAfter, err := dp.doPaginatedSearch(dataindexer.AccountsHistoryIndex, conditions, accountHistoryTiebreakers, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]*data.AccountBalanceHistory, 0, len(hits))
+	for _, hit := range hits {
+		entry := &data.AccountBalanceHistory{}
+		err = json.Unmarshal(hit.Source, entry)
+		if err != nil {
+			return nil, err
+		}
+
+		history = append(history, entry)
+	}
+
+	return &data.AccountHistoryResponse{
+		History:     history,
+		SearchAfter: nextSearchAfter,
+	}, nil
+}
+
+// GetAccountTokens will return a page with the tokens owned by the provided address
+func (dp *dataProvider) GetAccountTokens(address string, pagination data.Pagination) (*data.AccountTokensResponse, error) {
+	err := dp.checkTokenKeywordMapping()
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := []objectsMap{termQuery("address", address)}
+	hits, nextSearchAfter, err := dp.doPaginatedSearch(dataindexer.AccountsESDTIndex, conditions, accountTokensTiebreakers, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make([]*data.AccountInfo, 0, len(hits))
+	for _, hit := range hits {
+		token := &data.AccountInfo{}
+		err = json.Unmarshal(hit.Source, token)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return &data.AccountTokensResponse{
+		Tokens:      tokens,
+		SearchAfter: nextSearchAfter,
+	}, nil
+}
+
+// checkTokenKeywordMapping will check that the token of the accountsesdt indices is mapped as a keyword or has a
+// keyword sub-field, since the indices created with an older template map it only as text, which cannot be sorted
+func (dp *dataProvider) checkTokenKeywordMapping() error {
+	dp.mutTokenKeyword.Lock()
+	defer dp.mutTokenKeyword.Unlock()
+
+	if dp.isTokenKeywordMapped {
+		return nil
+	}
+
+	response := make(map[string]*indexMapping)
+	err := dp.dbClient.GetMapping(dataindexer.AccountsESDTIndex, &response)
+	if err != nil {
+		return err
+	}
+
+	for indexName, mapping := range response {
+		token, found := mapping.Mappings.Properties["token"]
+		if !found {
+			continue
+		}
+
+		isKeyword := token.Type == "keyword" || token.Fields["keyword"].Type == "keyword"
+		if !isKeyword {
+			return fmt.Errorf("%w: %s in index %s", ErrMissingKeywordMapping, tokenKeywordField, indexName)
+		}
+	}
+
+	dp.isTokenKeywordMapped = true
+	return nil
+}
+
+// GetToken will return the token with the provided identifier
+func (dp *dataProvider) GetToken(identifier string) (*data.TokenInfo, error) {
+	token := &data.TokenInfo{}
+	err := dp.getDocumentByID(dataindexer.TokensIndex, identifier, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// GetBlock will return the block from the provided shard with the provided nonce
+func (dp *dataProvider) GetBlock(shardID uint32, nonce uint64) (*data.BlockResponse, error) {
+	body, err := encodeQuery(blockByShardAndNonceQuery(shardID, nonce))
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	response := &data.ResponseSearch{}
+	err = dp.dbClient.DoSearchRequest(ctx, dataindexer.BlockIndex, body, response)
+	if err != nil {
+		return nil, err
+	}
+	if len(response.Hits.Hits) == 0 {
+		return nil, fmt.Errorf("%w: block with shard %d and nonce %d", core.ErrNotFound, shardID, nonce)
+	}
+
+	hit := response.Hits.Hits[0]
+	block := &data.BlockResponse{}
+	err = json.Unmarshal(hit.Source, &block.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	block.Hash = hit.ID
+	return block, nil
+}
+
+// GetEvents will return a page with the events that match the provided filter
+func (dp *dataProvider) GetEvents(filter data.EventsFilter, pagination data.Pagination) (*data.EventsResponse, error) {
+	hits, nextSearchAfter, err := dp.doPaginatedSearch(dataindexer.EventsIndex, eventsConditions(filter), eventsTiebreakers, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*data.EventResponse, 0, len(hits))
+	for _, hit := range hits {
+		event := &data.EventResponse{}
+		err = json.Unmarshal(hit.Source, &event.LogEvent)
+		if err != nil {
+			return nil, err
+		}
+
+		event.ID = hit.ID
+		events = append(events, event)
+	}
+
+	return &data.EventsResponse{
+		Events:      events,
+		SearchAfter: nextSearchAfter,
+	}, nil
+}
+
+// IsElasticsearchReachable returns true if the Elasticsearch cluster answers to a ping request
+func (dp *dataProvider) IsElasticsearchReachable() bool {
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+
+	err := dp.dbClient.Ping(ctx)
+	if err != nil {
+		log.Debug("dataProvider.IsElasticsearchReachable", "error", err)
+		return false
+	}
+
+	return true
+}
+
+func (dp *dataProvider) getDocumentByID(index string, id string, dest interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	response := &data.ResponseMultiGet{}
+	err := dp.dbClient.DoMultiGet(ctx, []string{id}, index, true, response)
+	if err != nil {
+		return err
+	}
+
+	if len(response.Docs) == 0 || !response.Docs[0].Found {
+		return fmt.Errorf("%w: %s in index %s", core.ErrNotFound, id, index)
+	}
+
+	return json.Unmarshal(response.Docs[0].Source, dest)
+}
+
+func (dp *dataProvider) doPaginatedSearch(index string, conditions []objectsMap, tiebreakers []string, pagination data.Pagination) ([]*data.ResponseSearchHit, string, error) {
+	pageSize, err := getPageSize(pagination)
+	if err != nil {
+		return nil, "", err
+	}
+
+	searchAfter, err := decodeSearchAfter(pagination.SearchAfter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	body, err := encodeQuery(paginatedSearchQuery(conditions, tiebreakers, pageSize, searchAfter))
+	if err != nil {
+		return nil, "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	response := &data.ResponseSearch{}
+	err = dp.dbClient.DoSearchRequest(ctx, index, body, response)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return response.Hits.Hits, getNextSearchAfter(response.Hits.Hits, pageSize), nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (dp *dataProvider) IsInterfaceNil() bool {
+	return dp == nil
+}
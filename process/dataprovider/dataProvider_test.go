@@ -0,0 +1,211 @@
+package dataprovider
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-es-indexer-go/core"
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
+	"github.com/multiversx/mx-chain-es-indexer-go/mock"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/dataindexer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDataProvider(t *testing.T) {
+	t.Parallel()
+
+	dp, err := NewDataProvider(ArgsDataProvider{})
+	require.Nil(t, dp)
+	require.Equal(t, ErrNilDatabaseClient, err)
+
+	dp, err = NewDataProvider(ArgsDataProvider{DBClient: &mock.DatabaseWriterStub{}})
+	require.Nil(t, err)
+	require.False(t, dp.IsInterfaceNil())
+}
+
+func TestDataProvider_GetTransaction(t *testing.T) {
+	t.Parallel()
+
+	dp, _ := NewDataProvider(ArgsDataProvider{
+		DBClient: &mock.DatabaseWriterStub{
+			DoMultiGetCalled: func(ids []string, index string, withSource bool, response interface{}) error {
+				require.Equal(t, []string{"h1"}, ids)
+				require.Equal(t, dataindexer.TransactionsIndex, index)
+
+				return json.Unmarshal([]byte(`{"docs":[{"found":true,"_id":"h1","_source":{"nonce":5,"sender":"erd1"}}]}`), response)
+			},
+		},
+	})
+
+	tx, err := dp.GetTransaction("h1")
+	require.Nil(t, err)
+	require.Equal(t, "h1", tx.Hash)
+	require.Equal(t, uint64(5), tx.Nonce)
+	require.Equal(t, "erd1", tx.Sender)
+}
+
+func TestDataProvider_GetTokenNotFound(t *testing.T) {
+	t.Parallel()
+
+	dp, _ := NewDataProvider(ArgsDataProvider{
+		DBClient: &mock.DatabaseWriterStub{
+			DoMultiGetCalled: func(ids []string, index string, withSource bool, response interface{}) error {
+				return json.Unmarshal([]byte(`{"docs":[{"found":false,"_id":"TKN-0101"}]}`), response)
+			},
+		},
+	})
+
+	token, err := dp.GetToken("TKN-0101")
+	require.Nil(t, token)
+	require.True(t, errors.Is(err, core.ErrNotFound))
+}
+
+func TestDataProvider_GetBlock(t *testing.T) {
+	t.Parallel()
+
+	dp, _ := NewDataProvider(ArgsDataProvider{
+		DBClient: &mock.DatabaseWriterStub{
+			DoSearchRequestCalled: func(index string, body []byte, response interface{}) error {
+				require.Equal(t, dataindexer.BlockIndex, index)
+				require.Equal(t, `{"query":{"bool":{"must":[{"term":{"shardId":1}},{"term":{"nonce":10}}]}},"size":1}`, string(body))
+
+				return json.Unmarshal([]byte(`{"hits":{"hits":[{"_id":"bh","_source":{"nonce":10,"shardId":1}}]}}`), response)
+			},
+		},
+	})
+
+	block, err := dp.GetBlock(1, 10)
+	require.Nil(t, err)
+	require.Equal(t, "bh", block.Hash)
+	require.Equal(t, uint64(10), block.Nonce)
+
+	dp, _ = NewDataProvider(ArgsDataProvider{DBClient: &mock.DatabaseWriterStub{}})
+	block, err = dp.GetBlock(1, 10)
+	require.Nil(t, block)
+	require.True(t, errors.Is(err, core.ErrNotFound))
+}
+
+func TestDataProvider_GetAccountHistoryPagination(t *testing.T) {
+	t.Parallel()
+
+	var lastBody []byte
+	dp, _ := NewDataProvider(ArgsDataProvider{
+		DBClient: &mock.DatabaseWriterStub{
+			DoSearchRequestCalled: func(index string, body []byte, response interface{}) error {
+				require.Equal(t, dataindexer.AccountsHistoryIndex, index)
+				lastBody = body
+
+				return json.Unmarshal([]byte(`{"hits":{"hits":[
+					{"_id":"a-2","_source":{"address":"erd1","timestamp":2,"balance":"2"},"sort":[2000]},
+					{"_id":"a-1","_source":{"address":"erd1","timestamp":1,"balance":"1"},"sort":[1000]}
+				]}}`), response)
+			},
+		},
+	})
+
+	res, err := dp.GetAccountHistory("erd1", data.Pagination{Size: 2})
+	require.Nil(t, err)
+	require.Len(t, res.History, 2)
+	require.NotEmpty(t, res.SearchAfter)
+
+	_, err = dp.GetAccountHistory("erd1", data.Pagination{Size: 2, SearchAfter: res.SearchAfter})
+	require.Nil(t, err)
+	require.Contains(t, string(lastBody), `"search_after":[1000]`)
+	require.Contains(t, string(lastBody), `"size":2`)
+	require.Contains(t, string(lastBody), `"sort":[{"timestamp":{"order":"desc"}}]`)
+
+	res, err = dp.GetAccountHistory("erd1", data.Pagination{Size: 3})
+	require.Nil(t, err)
+	require.Empty(t, res.SearchAfter)
+}
+
+func TestDataProvider_InvalidPagination(t *testing.T) {
+	t.Parallel()
+
+	dp, _ := NewDataProvider(ArgsDataProvider{DBClient: &mock.DatabaseWriterStub{}})
+
+	_, err := dp.GetEvents(data.EventsFilter{}, data.Pagination{Size: maxPageSize + 1})
+	require.True(t, errors.Is(err, core.ErrInvalidPageSize))
+
+	_, err = dp.GetAccountTokens("erd1", data.Pagination{SearchAfter: "not a cursor"})
+	require.True(t, errors.Is(err, core.ErrInvalidSearchAfter))
+}
+
+func TestDataProvider_GetEventsFilter(t *testing.T) {
+	t.Parallel()
+
+	shardID := uint32(2)
+	dp, _ := NewDataProvider(ArgsDataProvider{
+		DBClient: &mock.DatabaseWriterStub{
+			DoSearchRequestCalled: func(index string, body []byte, response interface{}) error {
+				require.Equal(t, dataindexer.EventsIndex, index)
+				require.Contains(t, string(body), `{"term":{"identifier":"ESDTTransfer"}}`)
+				require.Contains(t, string(body), `{"term":{"shardID":2}}`)
+				require.Contains(t, string(body), `"sort":[{"timestamp":{"order":"desc"}},{"txHash":{"order":"asc"}},{"shardID":{"order":"asc"}},{"order":{"order":"asc"}}]`)
+
+				return json.Unmarshal([]byte(`{"hits":{"hits":[{"_id":"ev1","_source":{"identifier":"ESDTTransfer"},"sort":[1,"h1",2,0]}]}}`), response)
+			},
+		},
+	})
+
+	res, err := dp.GetEvents(data.EventsFilter{Identifier: "ESDTTransfer", ShardID: &shardID}, data.Pagination{})
+	require.Nil(t, err)
+	require.Len(t, res.Events, 1)
+	require.Equal(t, "ev1", res.Events[0].ID)
+	require.Empty(t, res.SearchAfter)
+}
+
+func TestDataProvider_GetAccountTokensChecksTheTokenMapping(t *testing.T) {
+	t.Parallel()
+
+	mapping := `{"accountsesdt-000001":{"mappings":{"properties":{"token":{"type":"text"}}}}}`
+	numMappingRequests := 0
+	numSearches := 0
+	dp, _ := NewDataProvider(ArgsDataProvider{
+		DBClient: &mock.DatabaseWriterStub{
+			GetMappingCalled: func(index string, response interface{}) error {
+				numMappingRequests++
+				require.Equal(t, dataindexer.AccountsESDTIndex, index)
+				return json.Unmarshal([]byte(mapping), response)
+			},
+			DoSearchRequestCalled: func(index string, body []byte, response interface{}) error {
+				numSearches++
+				require.Contains(t, string(body), `"sort":[{"timestamp":{"order":"desc"}},{"token.keyword":{"order":"asc"}},{"tokenNonce":{"order":"asc"}}]`)
+				return json.Unmarshal([]byte(`{"hits":{"hits":[{"_id":"erd1-TKN-01","_source":{"token":"TKN-01"},"sort":[1,"TKN-01",0]}]}}`), response)
+			},
+		},
+	})
+
+	// the index created with an older template maps the token only as text
+	_, err := dp.GetAccountTokens("erd1", data.Pagination{})
+	require.True(t, errors.Is(err, ErrMissingKeywordMapping))
+	require.Equal(t, 0, numSearches)
+
+	mapping = `{"accountsesdt-000001":{"mappings":{"properties":{"token":{"type":"text","fields":{"keyword":{"type":"keyword"}}}}}}}`
+	res, err := dp.GetAccountTokens("erd1", data.Pagination{})
+	require.Nil(t, err)
+	require.Len(t, res.Tokens, 1)
+
+	// the mapping is checked until the keyword sub-field is found
+	_, err = dp.GetAccountTokens("erd1", data.Pagination{})
+	require.Nil(t, err)
+	require.Equal(t, 2, numMappingRequests)
+	require.Equal(t, 2, numSearches)
+}
+
+func TestDataProvider_IsElasticsearchReachable(t *testing.T) {
+	t.Parallel()
+
+	pingErr := errors.New("connection refused")
+	dbClient := &mock.DatabaseWriterStub{}
+	dp, _ := NewDataProvider(ArgsDataProvider{
+		DBClient: dbClient,
+	})
+	require.True(t, dp.IsElasticsearchReachable())
+
+	dbClient.PingCalled = func() error {
+		return pingErr
+	}
+	require.False(t, dp.IsElasticsearchReachable())
+}
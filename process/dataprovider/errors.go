@@ -0,0 +1,9 @@
+package dataprovider
+
+import "errors"
+
+// ErrNilDatabaseClient signals that a nil database client has been provided
+var ErrNilDatabaseClient = errors.New("nil database client")
+
+// ErrMissingKeywordMapping signals that a field used to sort the results is not mapped as a keyword
+var ErrMissingKeywordMapping = errors.New("missing keyword mapping")
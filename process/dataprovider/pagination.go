@@ -0,0 +1,69 @@
+package dataprovider
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/multiversx/mx-chain-es-indexer-go/core"
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
+)
+
+const (
+	defaultPageSize = 25
+	maxPageSize     = 100
+)
+
+func getPageSize(pagination data.Pagination) (int, error) {
+	if pagination.Size == 0 {
+		return defaultPageSize, nil
+	}
+	if pagination.Size < 0 || pagination.Size > maxPageSize {
+		return 0, fmt.Errorf("%w: %d, it should be between 1 and %d", core.ErrInvalidPageSize, pagination.Size, maxPageSize)
+	}
+
+	return pagination.Size, nil
+}
+
+// encodeSearchAfter will convert the sort values of a hit in an opaque cursor that can be used to fetch the next page
+func encodeSearchAfter(sortValues []interface{}) string {
+	if len(sortValues) == 0 {
+		return ""
+	}
+
+	sortValuesBytes, err := json.Marshal(sortValues)
+	if err != nil {
+		log.Warn("dataprovider.encodeSearchAfter: cannot marshal sort values", "error", err)
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(sortValuesBytes)
+}
+
+func decodeSearchAfter(cursor string) ([]interface{}, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	sortValuesBytes, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", core.ErrInvalidSearchAfter, err.Error())
+	}
+
+	sortValues := make([]interface{}, 0)
+	err = json.Unmarshal(sortValuesBytes, &sortValues)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", core.ErrInvalidSearchAfter, err.Error())
+	}
+
+	return sortValues, nil
+}
+
+// getNextSearchAfter returns the cursor for the next page only if the current page is full
+func getNextSearchAfter(hits []*data.ResponseSearchHit, pageSize int) string {
+	if len(hits) == 0 || len(hits) < pageSize {
+		return ""
+	}
+
+	return encodeSearchAfter(hits[len(hits)-1].Sort)
+}
@@ -0,0 +1,90 @@
+package dataprovider
+
+import (
+	"encoding/json"
+
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
+)
+
+type objectsMap = map[string]interface{}
+
+type fieldMapping struct {
+	Type   string                  `json:"type"`
+	Fields map[string]fieldMapping `json:"fields"`
+}
+
+type indexMapping struct {
+	Mappings struct {
+		Properties map[string]fieldMapping `json:"properties"`
+	} `json:"mappings"`
+}
+
+func encodeQuery(query objectsMap) ([]byte, error) {
+	return json.Marshal(query)
+}
+
+func termQuery(field string, value interface{}) objectsMap {
+	return objectsMap{
+		"term": objectsMap{
+			field: value,
+		},
+	}
+}
+
+func boolMustQuery(conditions []objectsMap) objectsMap {
+	return objectsMap{
+		"bool": objectsMap{
+			"must": conditions,
+		},
+	}
+}
+
+// paginatedSearchQuery will return a search query sorted descending by timestamp, having the provided keyword fields
+// as tiebreakers, so the search_after parameter can be used to fetch the next pages
+func paginatedSearchQuery(conditions []objectsMap, tiebreakers []string, size int, searchAfter []interface{}) objectsMap {
+	sort := []interface{}{
+		objectsMap{"timestamp": objectsMap{"order": "desc"}},
+	}
+	for _, field := range tiebreakers {
+		sort = append(sort, objectsMap{field: objectsMap{"order": "asc"}})
+	}
+
+	query := objectsMap{
+		"size":  size,
+		"query": boolMustQuery(conditions),
+		"sort":  sort,
+	}
+	if len(searchAfter) > 0 {
+		query["search_after"] = searchAfter
+	}
+
+	return query
+}
+
+func blockByShardAndNonceQuery(shardID uint32, nonce uint64) objectsMap {
+	return objectsMap{
+		"size": 1,
+		"query": boolMustQuery([]objectsMap{
+			termQuery("shardId", shardID),
+			termQuery("nonce", nonce),
+		}),
+	}
+}
+
+func eventsConditions(filter data.EventsFilter) []objectsMap {
+	conditions := make([]objectsMap, 0)
+	if filter.Address != "" {
+		conditions = append(conditions, termQuery("address", filter.Address))
+	}
+	if filter.Identifier != "" {
+		conditions = append(conditions, termQuery("identifier", filter.Identifier))
+	}
+	if filter.TxHash != "" {
+		conditions = append(conditions, termQuery("txHash", filter.TxHash))
+	}
+	if filter.ShardID != nil {
+		conditions = append(conditions, termQuery("shardID", *filter.ShardID))
+	}
+
+	return conditions
+}
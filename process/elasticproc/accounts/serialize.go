@@ -1,23 +1,35 @@
 package accounts
 
 import (
-	"encoding/json"
 	"fmt"
 
 	"github.com/multiversx/mx-chain-es-indexer-go/data"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/converters"
 )
 
+const accountUpsertScript = `
+		if ('create' == ctx.op) {
+			ctx._source = params.account
+		} else {
+			if ((!ctx._source.containsKey('timestamp')) || (ctx._source.timestamp <= params.account.timestamp) ) {
+				params.account.forEach((key, value) -> {
+					ctx._source[key] = value;
+				});
+			}
+		}
+`
+
+// accountUpsertTemplate holds the formatted script, only the serialized account is written for every document
+var accountUpsertTemplate = data.NewDocumentTemplate(
+	`{"scripted_upsert": true, "script": {"source": "`+converters.FormatPainlessSource(accountUpsertScript)+`","lang": "painless","params": { "account": `,
+	` }},"upsert": {}}`,
+)
+
 // SerializeNFTCreateInfo will serialize the provided nft create information in a way that Elasticsearch expects a bulk request
 func (ap *accountsProcessor) SerializeNFTCreateInfo(tokensInfo []*data.TokenInfo, buffSlice *data.BufferSlice, index string) error {
+	meta := data.NewMetaTemplate(`{ "index" : { "_index":"%s", "_id" : "%s" } }`, index)
 	for _, tokenData := range tokensInfo {
-		meta := []byte(fmt.Sprintf(`{ "index" : { "_index":"%s", "_id" : "%s" } }%s`, index, converters.JsonEscape(tokenData.Identifier), "\n"))
-		serializedData, errMarshal := json.Marshal(tokenData)
-		if errMarshal != nil {
-			return errMarshal
-		}
-
-		err := buffSlice.PutData(meta, serializedData)
+		err := buffSlice.PutDocument(meta, tokenData.Identifier, tokenData)
 		if err != nil {
 			return err
 		}
@@ -28,13 +40,9 @@ func (ap *accountsProcessor) SerializeNFTCreateInfo(tokensInfo []*data.TokenInfo
 
 // SerializeAccounts will serialize the provided accounts in a way that Elasticsearch expects a bulk request
 func (ap *accountsProcessor) SerializeAccounts(accounts map[string]*data.AccountInfo, buffSlice *data.BufferSlice, index string) error {
+	meta := data.NewMetaTemplate(`{ "update" : {"_index": "%s", "_id" : "%s" } }`, index)
 	for _, acc := range accounts {
-		meta, serializedData, err := prepareSerializedAccount(acc, false, index)
-		if err != nil {
-			return err
-		}
-
-		err = buffSlice.PutData(meta, serializedData)
+		err := buffSlice.PutEnclosedDocument(meta, acc.Address, accountUpsertTemplate, acc)
 		if err != nil {
 			return err
 		}
@@ -50,13 +58,9 @@ func (ap *accountsProcessor) SerializeAccountsESDT(
 	buffSlice *data.BufferSlice,
 	index string,
 ) error {
+	upsertMeta := data.NewMetaTemplate(`{ "update" : {"_index": "%s", "_id" : "%s" } }`, index)
 	for _, acc := range accounts {
-		meta, serializedData, err := prepareSerializedAccount(acc, true, index)
-		if err != nil {
-			return err
-		}
-
-		err = buffSlice.PutData(meta, serializedData)
+		err := serializeAccountESDT(acc, buffSlice, upsertMeta, index)
 		if err != nil {
 			return err
 		}
@@ -70,22 +74,22 @@ func (ap *accountsProcessor) SerializeAccountsESDT(
 	return nil
 }
 
-func prepareSerializedAccount(acc *data.AccountInfo, isESDT bool, index string) ([]byte, []byte, error) {
-	if (acc.Balance == "0" || acc.Balance == "") && isESDT {
-		meta, serializedData := prepareDeleteAccountInfo(acc, isESDT, index)
-		return meta, serializedData, nil
+func serializeAccountESDT(acc *data.AccountInfo, buffSlice *data.BufferSlice, upsertMeta *data.MetaTemplate, index string) error {
+	if acc.Balance == "0" || acc.Balance == "" {
+		meta, serializedData := prepareDeleteAccountInfo(acc, index)
+		return buffSlice.PutData(meta, serializedData)
 	}
 
-	return prepareSerializedAccountInfo(acc, isESDT, index)
+	return buffSlice.PutEnclosedDocument(upsertMeta, computeESDTAccountID(acc), accountUpsertTemplate, acc)
 }
 
-func prepareDeleteAccountInfo(acct *data.AccountInfo, isESDT bool, index string) ([]byte, []byte) {
-	id := acct.Address
-	if isESDT {
-		hexEncodedNonce := converters.EncodeNonceToHex(acct.TokenNonce)
-		id += fmt.Sprintf("-%s-%s", acct.TokenName, hexEncodedNonce)
-	}
+func computeESDTAccountID(acc *data.AccountInfo) string {
+	hexEncodedNonce := converters.EncodeNonceToHex(acc.TokenNonce)
+	return acc.Address + "-" + acc.TokenName + "-" + hexEncodedNonce
+}
 
+func prepareDeleteAccountInfo(acct *data.AccountInfo, index string) ([]byte, []byte) {
+	id := computeESDTAccountID(acct)
 	meta := []byte(fmt.Sprintf(`{ "update" : {"_index":"%s", "_id" : "%s" } }%s`, index, converters.JsonEscape(id), "\n"))
 
 	codeToExecute := `
@@ -112,60 +116,15 @@ func prepareDeleteAccountInfo(acct *data.AccountInfo, isESDT bool, index string)
 	return meta, []byte(serializedDataStr)
 }
 
-func prepareSerializedAccountInfo(
-	account *data.AccountInfo,
-	isESDTAccount bool,
-	index string,
-) ([]byte, []byte, error) {
-	id := account.Address
-	if isESDTAccount {
-		hexEncodedNonce := converters.EncodeNonceToHex(account.TokenNonce)
-		id += fmt.Sprintf("-%s-%s", account.TokenName, hexEncodedNonce)
-	}
-
-	serializedAccount, err := json.Marshal(account)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	meta := []byte(fmt.Sprintf(`{ "update" : {"_index": "%s", "_id" : "%s" } }%s`, index, converters.JsonEscape(id), "\n"))
-	codeToExecute := `
-		if ('create' == ctx.op) {
-			ctx._source = params.account
-		} else {
-			if ((!ctx._source.containsKey('timestamp')) || (ctx._source.timestamp <= params.account.timestamp) ) {
-				params.account.forEach((key, value) -> {
-					ctx._source[key] = value;
-				});
-			}
-		}
-`
-	serializedDataStr := fmt.Sprintf(`{"scripted_upsert": true, "script": {`+
-		`"source": "%s",`+
-		`"lang": "painless",`+
-		`"params": { "account": %s }},`+
-		`"upsert": {}}`,
-		converters.FormatPainlessSource(codeToExecute), serializedAccount,
-	)
-
-	return meta, []byte(serializedDataStr), nil
-}
-
 // SerializeAccountsHistory will serialize accounts history in a way that Elasticsearch expects a bulk request
 func (ap *accountsProcessor) SerializeAccountsHistory(
 	accounts map[string]*data.AccountBalanceHistory,
 	buffSlice *data.BufferSlice,
 	index string,
 ) error {
-	var err error
-
+	meta := data.NewMetaTemplate(`{ "index" : { "_index":"%s", "_id" : "%s" } }`, index)
 	for _, acc := range accounts {
-		meta, serializedData, errPrepareAcc := prepareSerializedAccountBalanceHistory(acc, index)
-		if errPrepareAcc != nil {
-			return err
-		}
-
-		err = buffSlice.PutData(meta, serializedData)
+		err := buffSlice.PutDocument(meta, computeAccountBalanceHistoryID(acc), acc)
 		if err != nil {
 			return err
 		}
@@ -174,10 +133,7 @@ func (ap *accountsProcessor) SerializeAccountsHistory(
 	return nil
 }
 
-func prepareSerializedAccountBalanceHistory(
-	account *data.AccountBalanceHistory,
-	index string,
-) ([]byte, []byte, error) {
+func computeAccountBalanceHistoryID(account *data.AccountBalanceHistory) string {
 	id := account.Address
 
 	isESDT := account.Token != ""
@@ -186,15 +142,7 @@ func prepareSerializedAccountBalanceHistory(
 		id += fmt.Sprintf("-%s-%s", account.Token, hexEncodedNonce)
 	}
 
-	id += fmt.Sprintf("-%d", account.Timestamp)
-	meta := []byte(fmt.Sprintf(`{ "index" : { "_index":"%s", "_id" : "%s" } }%s`, index, converters.JsonEscape(id), "\n"))
-
-	serializedData, err := json.Marshal(account)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	return meta, serializedData, nil
+	return id + fmt.Sprintf("-%d", account.Timestamp)
 }
 
 // SerializeTypeForProvidedIDs will serialize the type for the provided ids
@@ -1,6 +1,9 @@
 package accounts
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
 	"testing"
 
 	"github.com/multiversx/mx-chain-core-go/core"
@@ -201,3 +204,63 @@ func TestSerializeAccountsHistory(t *testing.T) {
 `
 	require.Equal(t, expectedRes, buffSlice.Buffers()[0].String())
 }
+
+func loadAccountFixture(b *testing.B, path string) *data.AccountInfo {
+	fixture, err := os.ReadFile(path)
+	require.Nil(b, err)
+
+	account := &data.AccountInfo{}
+	require.Nil(b, json.Unmarshal(fixture, account))
+
+	return account
+}
+
+func BenchmarkSerializeAccounts(b *testing.B) {
+	fixture := loadAccountFixture(b, "../../../integrationtests/testdata/accountsBalanceWithLowerTimestamp/account-balance-first-update.json")
+
+	accounts := make(map[string]*data.AccountInfo, 1000)
+	for idx := 0; idx < 1000; idx++ {
+		account := *fixture
+		account.Address = fmt.Sprintf("%s-%d", fixture.Address, idx)
+		accounts[account.Address] = &account
+	}
+	accountsProc := &accountsProcessor{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buffSlice := data.NewBufferSlice(data.DefaultMaxBulkSize)
+		err := accountsProc.SerializeAccounts(accounts, buffSlice, "accounts")
+		if err != nil {
+			b.Fatal(err)
+		}
+		data.ReleaseBuffers(buffSlice.Buffers())
+	}
+}
+
+func BenchmarkSerializeAccountsESDT(b *testing.B) {
+	fixture := loadAccountFixture(b, "../../../integrationtests/testdata/accountsBalanceWithLowerTimestamp/account-balance-esdt-first-update.json")
+
+	accounts := make(map[string]*data.AccountInfo, 1000)
+	for idx := 0; idx < 1000; idx++ {
+		account := *fixture
+		account.Address = fmt.Sprintf("%s-%d", fixture.Address, idx)
+		if idx%10 == 0 {
+			// every tenth account is deleted
+			account.Balance = "0"
+		}
+		accounts[account.Address] = &account
+	}
+	accountsProc := &accountsProcessor{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buffSlice := data.NewBufferSlice(data.DefaultMaxBulkSize)
+		err := accountsProc.SerializeAccountsESDT(accounts, nil, buffSlice, "accountsesdt")
+		if err != nil {
+			b.Fatal(err)
+		}
+		data.ReleaseBuffers(buffSlice.Buffers())
+	}
+}
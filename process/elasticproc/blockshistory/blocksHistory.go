@@ -0,0 +1,86 @@
+package blockshistory
+
+import (
+	"bytes"
+	"sync"
+)
+
+// MaxRevertibleBlocks is the number of the last blocks of a shard which are kept, so they can be reverted
+const MaxRevertibleBlocks = 20
+
+type blockEntry struct {
+	headerHash []byte
+	value      interface{}
+}
+
+type blocksHistory struct {
+	mutex  sync.Mutex
+	blocks map[uint32][]*blockEntry
+}
+
+// NewBlocksHistory will create a new instance of blocksHistory. It keeps a value for each of the last added blocks of
+// each shard, e.g. the data which has to be subtracted when the block is reverted. The history is kept only in memory,
+// so the blocks added before a restart are not known
+func NewBlocksHistory() *blocksHistory {
+	return &blocksHistory{
+		blocks: make(map[uint32][]*blockEntry),
+	}
+}
+
+// Add will add the value of the provided block in the history of its shard. A block added again replaces its
+// previous value, and only the last MaxRevertibleBlocks blocks of the shard are kept
+func (bh *blocksHistory) Add(shardID uint32, headerHash []byte, value interface{}) {
+	bh.mutex.Lock()
+	defer bh.mutex.Unlock()
+
+	history := bh.blocks[shardID]
+	if len(history) > 0 && bytes.Equal(history[len(history)-1].headerHash, headerHash) {
+		history = history[:len(history)-1]
+	}
+
+	history = append(history, &blockEntry{
+		headerHash: headerHash,
+		value:      value,
+	})
+	if len(history) > MaxRevertibleBlocks {
+		history = history[len(history)-MaxRevertibleBlocks:]
+	}
+	bh.blocks[shardID] = history
+}
+
+// RemoveLast will remove the provided block from the history of its shard and returns its value, if it is the last
+// added block of the shard. Otherwise, the history is not changed and false is returned
+func (bh *blocksHistory) RemoveLast(shardID uint32, headerHash []byte) (interface{}, bool) {
+	bh.mutex.Lock()
+	defer bh.mutex.Unlock()
+
+	history := bh.blocks[shardID]
+	if len(history) == 0 || !bytes.Equal(history[len(history)-1].headerHash, headerHash) {
+		return nil, false
+	}
+
+	bh.blocks[shardID] = history[:len(history)-1]
+
+	return history[len(history)-1].value, true
+}
+
+// Values returns the values of the blocks of the provided shard, from the oldest to the last added one
+func (bh *blocksHistory) Values(shardID uint32) []interface{} {
+	bh.mutex.Lock()
+	defer bh.mutex.Unlock()
+
+	values := make([]interface{}, 0, len(bh.blocks[shardID]))
+	for _, entry := range bh.blocks[shardID] {
+		values = append(values, entry.value)
+	}
+
+	return values
+}
+
+// Clear will remove all the blocks of the provided shard
+func (bh *blocksHistory) Clear(shardID uint32) {
+	bh.mutex.Lock()
+	defer bh.mutex.Unlock()
+
+	delete(bh.blocks, shardID)
+}
@@ -0,0 +1,51 @@
+package blockshistory
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlocksHistory_AddAndRemoveLast(t *testing.T) {
+	t.Parallel()
+
+	history := NewBlocksHistory()
+	history.Add(0, []byte("h1"), 1)
+	history.Add(0, []byte("h2"), 2)
+	history.Add(1, []byte("h3"), 3)
+
+	// only the last block of the shard can be removed
+	value, found := history.RemoveLast(0, []byte("h1"))
+	require.False(t, found)
+	require.Nil(t, value)
+
+	value, found = history.RemoveLast(0, []byte("h2"))
+	require.True(t, found)
+	require.Equal(t, 2, value)
+	require.Equal(t, []interface{}{1}, history.Values(0))
+	require.Equal(t, []interface{}{3}, history.Values(1))
+
+	history.Clear(1)
+	_, found = history.RemoveLast(1, []byte("h3"))
+	require.False(t, found)
+	require.Empty(t, history.Values(1))
+}
+
+func TestBlocksHistory_AddShouldReplaceTheSameBlockAndKeepTheLastBlocks(t *testing.T) {
+	t.Parallel()
+
+	history := NewBlocksHistory()
+	history.Add(0, []byte("h"), 1)
+	history.Add(0, []byte("h"), 2)
+	require.Equal(t, []interface{}{2}, history.Values(0))
+
+	for i := 0; i < MaxRevertibleBlocks+5; i++ {
+		history.Add(0, []byte(fmt.Sprintf("h%d", i)), i)
+	}
+
+	values := history.Values(0)
+	require.Len(t, values, MaxRevertibleBlocks)
+	require.Equal(t, 5, values[0])
+	require.Equal(t, MaxRevertibleBlocks+4, values[len(values)-1])
+}
@@ -0,0 +1,187 @@
+package elasticproc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/multiversx/mx-chain-es-indexer-go/core/request"
+)
+
+const deleteAction = "delete"
+
+type bulkItemKey struct {
+	index string
+	id    string
+}
+
+type bulkActionMeta map[string]struct {
+	Index string `json:"_index"`
+	ID    string `json:"_id"`
+}
+
+// bulkDispatcher sends the bulk requests of a buffer slice with a bounded parallelism. A buffer that contains a
+// document with the same _index and _id as a previous buffer is sent only after the previous one succeeded, so the
+// order of the operations on the same document (as the scripted upserts) is kept
+type bulkDispatcher struct {
+	dbClient                  DatabaseClientHandler
+	numConcurrentBulkRequests int
+}
+
+func (bd *bulkDispatcher) doBulkRequests(index string, buffSlice []*bytes.Buffer, shardID uint32) error {
+	if bd.numConcurrentBulkRequests <= 1 || len(buffSlice) <= 1 {
+		return bd.doBulkRequestsSequentially(index, buffSlice, shardID)
+	}
+
+	dependencies, ok := computeBulkDependencies(buffSlice, index)
+	if !ok {
+		log.Debug("bulkDispatcher: cannot parse the bulk requests, they will be sent sequentially", "index", index)
+		return bd.doBulkRequestsSequentially(index, buffSlice, shardID)
+	}
+
+	return bd.doBulkRequestsConcurrently(index, buffSlice, shardID, dependencies)
+}
+
+func (bd *bulkDispatcher) doBulkRequestsSequentially(index string, buffSlice []*bytes.Buffer, shardID uint32) error {
+	for idx := range buffSlice {
+		err := bd.doBulkRequest(index, buffSlice[idx], shardID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (bd *bulkDispatcher) doBulkRequestsConcurrently(index string, buffSlice []*bytes.Buffer, shardID uint32, dependencies [][]int) error {
+	done := make([]chan struct{}, len(buffSlice))
+	for idx := range done {
+		done[idx] = make(chan struct{})
+	}
+
+	errs := make([]error, len(buffSlice))
+	skipped := make([]bool, len(buffSlice))
+	semaphore := make(chan struct{}, bd.numConcurrentBulkRequests)
+	failed := make(chan struct{})
+	failOnce := sync.Once{}
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(buffSlice))
+	for idx := range buffSlice {
+		go func(idx int) {
+			defer wg.Done()
+			defer close(done[idx])
+
+			for _, dependency := range dependencies[idx] {
+				<-done[dependency]
+				if errs[dependency] != nil || skipped[dependency] {
+					skipped[idx] = true
+					return
+				}
+			}
+
+			select {
+			case semaphore <- struct{}{}:
+			case <-failed:
+				skipped[idx] = true
+				return
+			}
+			defer func() { <-semaphore }()
+
+			errs[idx] = bd.doBulkRequest(index, buffSlice[idx], shardID)
+			if errs[idx] != nil {
+				failOnce.Do(func() { close(failed) })
+			}
+		}(idx)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (bd *bulkDispatcher) doBulkRequest(index string, buff *bytes.Buffer, shardID uint32) error {
+	ctxWithValue := context.WithValue(context.Background(), request.ContextKey, request.ExtendTopicWithShardID(request.BulkTopic, shardID))
+	return bd.dbClient.DoBulkRequest(ctxWithValue, buff, index)
+}
+
+// computeBulkDependencies returns, for every buffer, the previous buffers that have to be sent before it because they
+// contain operations on the same documents. It returns false if the bulk requests cannot be parsed
+func computeBulkDependencies(buffSlice []*bytes.Buffer, defaultIndex string) ([][]int, bool) {
+	dependencies := make([][]int, len(buffSlice))
+	lastBufferWithKey := make(map[bulkItemKey]int)
+
+	for idx, buff := range buffSlice {
+		keys, ok := extractBulkItemKeys(buff.Bytes(), defaultIndex)
+		if !ok {
+			return nil, false
+		}
+
+		// only the last buffer that touched a document is needed, since it waits in turn for the previous ones
+		bufferDependencies := make(map[int]struct{})
+		for _, key := range keys {
+			previousIdx, found := lastBufferWithKey[key]
+			if found && previousIdx != idx {
+				bufferDependencies[previousIdx] = struct{}{}
+			}
+			lastBufferWithKey[key] = idx
+		}
+
+		for previousIdx := range bufferDependencies {
+			dependencies[idx] = append(dependencies[idx], previousIdx)
+		}
+	}
+
+	return dependencies, true
+}
+
+// extractBulkItemKeys returns the _index and _id pairs of the operations from a bulk request body. Each action line,
+// except the delete ones, is followed by a source line
+func extractBulkItemKeys(body []byte, defaultIndex string) ([]bulkItemKey, bool) {
+	keys := make([]bulkItemKey, 0)
+	expectAction := true
+	for len(body) > 0 {
+		line := body
+		newLineIdx := bytes.IndexByte(body, '\n')
+		if newLineIdx >= 0 {
+			line, body = body[:newLineIdx], body[newLineIdx+1:]
+		} else {
+			body = nil
+		}
+
+		if !expectAction {
+			expectAction = true
+			continue
+		}
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		meta := bulkActionMeta{}
+		err := json.Unmarshal(line, &meta)
+		if err != nil || len(meta) != 1 {
+			return nil, false
+		}
+
+		for action, item := range meta {
+			expectAction = action == deleteAction
+			if item.ID == "" {
+				continue
+			}
+
+			itemIndex := item.Index
+			if itemIndex == "" {
+				itemIndex = defaultIndex
+			}
+			keys = append(keys, bulkItemKey{index: itemIndex, id: item.ID})
+		}
+	}
+
+	return keys, true
+}
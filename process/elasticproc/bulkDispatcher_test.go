@@ -0,0 +1,211 @@
+package elasticproc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
+	"github.com/multiversx/mx-chain-es-indexer-go/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createBulkBuffer(docs ...string) *bytes.Buffer {
+	buff := &bytes.Buffer{}
+	for _, doc := range docs {
+		buff.WriteString(doc)
+		buff.WriteString("\n")
+	}
+
+	return buff
+}
+
+func TestExtractBulkItemKeys(t *testing.T) {
+	t.Parallel()
+
+	body := createBulkBuffer(
+		`{ "update" : {"_index":"tokens", "_id" : "TKN-01" } }`,
+		`{"scripted_upsert": true, "script": {"source": "ctx._source.type = 'x'"}, "upsert": {}}`,
+		`{ "delete" : { "_index": "accountsesdt", "_id" : "addr-TKN-01" } }`,
+		`{ "index" : { "_id" : "hash" } }`,
+		`{"nonce":1}`,
+		`{ "index" : { "_index": "logs" } }`,
+		`{"address":"addr"}`,
+	)
+
+	keys, ok := extractBulkItemKeys(body.Bytes(), "transactions")
+	require.True(t, ok)
+	require.Equal(t, []bulkItemKey{
+		{index: "tokens", id: "TKN-01"},
+		{index: "accountsesdt", id: "addr-TKN-01"},
+		{index: "transactions", id: "hash"},
+	}, keys)
+
+	_, ok = extractBulkItemKeys([]byte("not a bulk body\n"), "")
+	require.False(t, ok)
+}
+
+func TestComputeBulkDependencies(t *testing.T) {
+	t.Parallel()
+
+	buffers := []*bytes.Buffer{
+		createBulkBuffer(`{"update":{"_index":"tokens","_id":"A"}}`, `{}`),
+		createBulkBuffer(`{"update":{"_index":"tokens","_id":"B"}}`, `{}`),
+		createBulkBuffer(`{"update":{"_index":"tokens","_id":"A"}}`, `{}`, `{"update":{"_index":"tokens","_id":"B"}}`, `{}`),
+		createBulkBuffer(`{"update":{"_index":"accounts","_id":"A"}}`, `{}`),
+		createBulkBuffer(`{"update":{"_index":"tokens","_id":"A"}}`, `{}`),
+	}
+
+	dependencies, ok := computeBulkDependencies(buffers, "")
+	require.True(t, ok)
+	require.Empty(t, dependencies[0])
+	require.Empty(t, dependencies[1])
+	require.ElementsMatch(t, []int{0, 1}, dependencies[2])
+	require.Empty(t, dependencies[3])
+	require.Equal(t, []int{2}, dependencies[4])
+}
+
+func TestBulkDispatcher_SameDocumentsShouldBeSentInOrder(t *testing.T) {
+	t.Parallel()
+
+	numBuffers := 20
+	buffers := make([]*bytes.Buffer, 0, numBuffers)
+	for idx := 0; idx < numBuffers; idx++ {
+		// the even buffers update the same token, the odd ones are independent
+		id := "TKN-even"
+		if idx%2 == 1 {
+			id = fmt.Sprintf("TKN-%d", idx)
+		}
+		buffers = append(buffers, createBulkBuffer(fmt.Sprintf(`{"update":{"_index":"tokens","_id":"%s"}}`, id), fmt.Sprintf(`{"order":%d}`, idx)))
+	}
+
+	mut := sync.Mutex{}
+	sentOrder := make([]string, 0)
+	inFlight, maxInFlight := int32(0), int32(0)
+	dispatcher := &bulkDispatcher{
+		numConcurrentBulkRequests: 4,
+		dbClient: &mock.DatabaseWriterStub{
+			DoBulkRequestCalled: func(buff *bytes.Buffer, index string) error {
+				current := atomic.AddInt32(&inFlight, 1)
+				defer atomic.AddInt32(&inFlight, -1)
+
+				mut.Lock()
+				if current > maxInFlight {
+					maxInFlight = current
+				}
+				if bytes.Contains(buff.Bytes(), []byte("TKN-even")) {
+					sentOrder = append(sentOrder, buff.String())
+				}
+				mut.Unlock()
+
+				time.Sleep(time.Millisecond)
+				return nil
+			},
+		},
+	}
+
+	err := dispatcher.doBulkRequests("", buffers, 0)
+	require.Nil(t, err)
+
+	require.Len(t, sentOrder, numBuffers/2)
+	for idx, body := range sentOrder {
+		require.Equal(t, buffers[2*idx].String(), body)
+	}
+	require.True(t, maxInFlight > 1)
+	require.True(t, maxInFlight <= 4)
+}
+
+func TestBulkDispatcher_ErrorShouldStopTheDependentRequests(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("bulk error")
+	buffers := []*bytes.Buffer{
+		createBulkBuffer(`{"update":{"_index":"tokens","_id":"A"}}`, `{"first":true}`),
+		createBulkBuffer(`{"update":{"_index":"tokens","_id":"A"}}`, `{"second":true}`),
+	}
+
+	numCalls := int32(0)
+	dispatcher := &bulkDispatcher{
+		numConcurrentBulkRequests: 4,
+		dbClient: &mock.DatabaseWriterStub{
+			DoBulkRequestCalled: func(buff *bytes.Buffer, index string) error {
+				atomic.AddInt32(&numCalls, 1)
+				return expectedErr
+			},
+		},
+	}
+
+	err := dispatcher.doBulkRequests("", buffers, 0)
+	require.Equal(t, expectedErr, err)
+	require.Equal(t, int32(1), atomic.LoadInt32(&numCalls))
+}
+
+func TestBulkDispatcher_UnparsableBodyShouldBeSentSequentially(t *testing.T) {
+	t.Parallel()
+
+	buffers := []*bytes.Buffer{
+		bytes.NewBufferString("first\n"),
+		bytes.NewBufferString("second\n"),
+		bytes.NewBufferString("third\n"),
+	}
+
+	sent := make([]string, 0)
+	dispatcher := &bulkDispatcher{
+		numConcurrentBulkRequests: 4,
+		dbClient: &mock.DatabaseWriterStub{
+			DoBulkRequestCalled: func(buff *bytes.Buffer, index string) error {
+				sent = append(sent, buff.String())
+				return nil
+			},
+		},
+	}
+
+	err := dispatcher.doBulkRequests("", buffers, 0)
+	require.Nil(t, err)
+	require.Equal(t, []string{"first\n", "second\n", "third\n"}, sent)
+}
+
+// BenchmarkBulkDispatcher simulates the bulk requests of a busy block, where every request takes a few milliseconds
+// on the Elasticsearch side, and some of the buffers update a document that is also updated by previous buffers
+func BenchmarkBulkDispatcher(b *testing.B) {
+	numBuffers := 32
+	requestLatency := 2 * time.Millisecond
+
+	buffSlice := data.NewBufferSlice(1024)
+	for idx := 0; idx < numBuffers*10; idx++ {
+		id := fmt.Sprintf("tx-%d", idx)
+		if idx%40 == 0 {
+			id = "TKN-shared"
+		}
+		meta := []byte(fmt.Sprintf(`{"update":{"_index":"transactions","_id":"%s"}}%s`, id, "\n"))
+		_ = buffSlice.PutData(meta, bytes.Repeat([]byte("a"), 90))
+	}
+
+	dbClient := &mock.DatabaseWriterStub{
+		DoBulkRequestCalled: func(buff *bytes.Buffer, index string) error {
+			time.Sleep(requestLatency)
+			return nil
+		},
+	}
+
+	for _, numConcurrentBulkRequests := range []int{1, 2, 4, 8} {
+		dispatcher := &bulkDispatcher{
+			dbClient:                  dbClient,
+			numConcurrentBulkRequests: numConcurrentBulkRequests,
+		}
+
+		b.Run(fmt.Sprintf("concurrent-requests-%d", numConcurrentBulkRequests), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				err := dispatcher.doBulkRequests("", buffSlice.Buffers(), 0)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(len(buffSlice.Buffers())*b.N)/b.Elapsed().Seconds(), "requests/s")
+		})
+	}
+}
@@ -39,6 +39,21 @@ func checkArguments(arguments *ArgElasticProcessor) error {
 	if check.IfNilReflect(arguments.OperationsProc) {
 		return elasticIndexer.ErrNilOperationsHandler
 	}
+	if check.IfNil(arguments.TokensCache) {
+		return elasticIndexer.ErrNilTokensCache
+	}
+	if check.IfNilReflect(arguments.EpochSummaryProc) {
+		return elasticIndexer.ErrNilEpochSummaryHandler
+	}
+	if check.IfNilReflect(arguments.RollupsProc) {
+		return elasticIndexer.ErrNilRollupsHandler
+	}
+	if check.IfNilReflect(arguments.ActiveAddressesProc) {
+		return elasticIndexer.ErrNilActiveAddressesHandler
+	}
+	if check.IfNilReflect(arguments.ContractsProc) {
+		return elasticIndexer.ErrNilContractsHandler
+	}
 
 	return nil
 }
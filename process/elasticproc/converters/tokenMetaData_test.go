@@ -1,6 +1,8 @@
 package converters
 
 import (
+	"bytes"
+	"encoding/json"
 	"testing"
 
 	"github.com/multiversx/mx-chain-core-go/data/alteredAccount"
@@ -81,3 +83,36 @@ func TestWhiteListedStorage(t *testing.T) {
 	uris = [][]byte{[]byte("https://dwb.link/ipfs/my-test-nft")}
 	require.False(t, whiteListedStorage(uris))
 }
+
+func FuzzPrepareTokenMetaData(f *testing.F) {
+	f.Add("token", "creator", uint32(100), []byte("hash"), []byte("https://ipfs.io/ipfs/something@uri"), []byte("tags:test,free,fun;metadata:metadata-test"))
+	f.Add("", "", uint32(0), []byte{}, []byte("@@"), []byte(";;::,,;tags:;metadata:"))
+	f.Add("\xff\xfe", "\xff\xfe", uint32(10000), []byte{0xff}, []byte("ipfs://\x00"), []byte("tags:\xff,T;metadata:\x00"))
+
+	f.Fuzz(func(t *testing.T, name string, creator string, royalties uint32, hash []byte, uris []byte, attributes []byte) {
+		tokenMetaData := &alteredAccount.TokenMetaData{
+			Name:       name,
+			Creator:    creator,
+			Royalties:  royalties,
+			Hash:       hash,
+			URIs:       bytes.Split(uris, []byte("@")),
+			Attributes: attributes,
+		}
+
+		res := PrepareTokenMetaData(tokenMetaData)
+		require.NotNil(t, res)
+		require.LessOrEqual(t, len(res.Name), data.MaxFieldLength)
+		require.LessOrEqual(t, len(res.MetaData), data.MaxFieldLength)
+		require.LessOrEqual(t, len(res.Attributes), data.MaxKeywordFieldLengthBeforeBase64Encoding)
+		require.Len(t, res.URIs, len(tokenMetaData.URIs))
+		for _, uri := range res.URIs {
+			require.LessOrEqual(t, len(uri), data.MaxKeywordFieldLengthBeforeBase64Encoding)
+		}
+		for _, tag := range res.Tags {
+			require.LessOrEqual(t, len(tag), data.MaxFieldLength)
+		}
+
+		_, err := json.Marshal(res)
+		require.Nil(t, err)
+	})
+}
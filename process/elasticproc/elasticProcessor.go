@@ -6,6 +6,8 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
+	"sort"
 	"sync"
 
 	"github.com/multiversx/mx-chain-core-go/core"
@@ -14,10 +16,12 @@ import (
 	"github.com/multiversx/mx-chain-core-go/data/alteredAccount"
 	"github.com/multiversx/mx-chain-core-go/data/block"
 	"github.com/multiversx/mx-chain-core-go/data/outport"
+	indexerCore "github.com/multiversx/mx-chain-es-indexer-go/core"
 	"github.com/multiversx/mx-chain-es-indexer-go/core/request"
 	"github.com/multiversx/mx-chain-es-indexer-go/data"
 	elasticIndexer "github.com/multiversx/mx-chain-es-indexer-go/process/dataindexer"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/converters"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/epochsummary"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/tags"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/tokeninfo"
 	"github.com/multiversx/mx-chain-es-indexer-go/templates"
@@ -31,48 +35,78 @@ var (
 		elasticIndexer.TransactionsIndex, elasticIndexer.BlockIndex, elasticIndexer.MiniblocksIndex, elasticIndexer.RatingIndex, elasticIndexer.RoundsIndex, elasticIndexer.ValidatorsIndex,
 		elasticIndexer.AccountsIndex, elasticIndexer.AccountsHistoryIndex, elasticIndexer.ReceiptsIndex, elasticIndexer.ScResultsIndex, elasticIndexer.AccountsESDTHistoryIndex, elasticIndexer.AccountsESDTIndex,
 		elasticIndexer.EpochInfoIndex, elasticIndexer.SCDeploysIndex, elasticIndexer.TokensIndex, elasticIndexer.TagsIndex, elasticIndexer.LogsIndex, elasticIndexer.DelegatorsIndex, elasticIndexer.OperationsIndex,
-		elasticIndexer.ESDTsIndex, elasticIndexer.ValuesIndex, elasticIndexer.EventsIndex,
+		elasticIndexer.ESDTsIndex, elasticIndexer.ValuesIndex, elasticIndexer.EventsIndex, elasticIndexer.ProcessingErrorsIndex, elasticIndexer.ValidatorStatsIndex, elasticIndexer.RatingHistoryIndex,
+		elasticIndexer.EpochSummaryIndex, elasticIndexer.StatsHourlyIndex, elasticIndexer.StatsDailyIndex,
+		elasticIndexer.ActiveAddressesIndex, elasticIndexer.ContractsIndex,
+	}
+
+	rollupsPeriods = []struct {
+		index           string
+		periodInSeconds uint64
+	}{
+		{index: elasticIndexer.StatsHourlyIndex, periodInSeconds: 3600},
+		{index: elasticIndexer.StatsDailyIndex, periodInSeconds: 86400},
 	}
 )
 
-const versionStr = "indexer-version"
+const (
+	versionStr = "indexer-version"
+
+	epochStartBlockQuery = `{"size":1,"query":{"bool":{"must":[{"term":{"epoch":%d}},{"term":{"shardId":%d}},{"term":{"epochStartBlock":true}}]}}}`
+)
 
 // ArgElasticProcessor holds all dependencies required by the elasticProcessor in order to create
 // new instances
 type ArgElasticProcessor struct {
-	BulkRequestMaxSize int
-	UseKibana          bool
-	ImportDB           bool
-	IndexTemplates     map[string]*bytes.Buffer
-	IndexPolicies      map[string]*bytes.Buffer
-	ExtraMappings      []templates.ExtraMapping
-	EnabledIndexes     map[string]struct{}
-	TransactionsProc   DBTransactionsHandler
-	AccountsProc       DBAccountHandler
-	BlockProc          DBBlockHandler
-	MiniblocksProc     DBMiniblocksHandler
-	StatisticsProc     DBStatisticsHandler
-	ValidatorsProc     DBValidatorsHandler
-	DBClient           DatabaseClientHandler
-	LogsAndEventsProc  DBLogsAndEventsHandler
-	OperationsProc     OperationsHandler
-	Version            string
+	BulkRequestMaxSize        int
+	NumConcurrentBulkRequests int
+	UseKibana                 bool
+	ImportDB                  bool
+	IndexTemplates            map[string]*bytes.Buffer
+	IndexPolicies             map[string]*bytes.Buffer
+	ExtraMappings             []templates.ExtraMapping
+	EnabledIndexes            map[string]struct{}
+	TransactionsProc          DBTransactionsHandler
+	AccountsProc              DBAccountHandler
+	BlockProc                 DBBlockHandler
+	MiniblocksProc            DBMiniblocksHandler
+	StatisticsProc            DBStatisticsHandler
+	ValidatorsProc            DBValidatorsHandler
+	DBClient                  DatabaseClientHandler
+	LogsAndEventsProc         DBLogsAndEventsHandler
+	OperationsProc            OperationsHandler
+	TokensCache               TokensCacheHandler
+	EpochSummaryProc          DBEpochSummaryHandler
+	RollupsProc               DBRollupsHandler
+	ActiveAddressesProc       DBActiveAddressesHandler
+	ContractsProc             DBContractsHandler
+	ImportDBIndexSettings     ImportDBIndexSettings
+	Version                   string
 }
 
 type elasticProcessor struct {
-	bulkRequestMaxSize int
-	importDB           bool
-	enabledIndexes     map[string]struct{}
-	mutex              sync.RWMutex
-	elasticClient      DatabaseClientHandler
-	accountsProc       DBAccountHandler
-	blockProc          DBBlockHandler
-	transactionsProc   DBTransactionsHandler
-	miniblocksProc     DBMiniblocksHandler
-	statisticsProc     DBStatisticsHandler
-	validatorsProc     DBValidatorsHandler
-	logsAndEventsProc  DBLogsAndEventsHandler
-	operationsProc     OperationsHandler
+	bulkRequestMaxSize        int
+	numConcurrentBulkRequests int
+	importDB                  bool
+	enabledIndexes            map[string]struct{}
+	mutex                     sync.RWMutex
+	elasticClient             DatabaseClientHandler
+	accountsProc              DBAccountHandler
+	blockProc                 DBBlockHandler
+	transactionsProc          DBTransactionsHandler
+	miniblocksProc            DBMiniblocksHandler
+	statisticsProc            DBStatisticsHandler
+	validatorsProc            DBValidatorsHandler
+	logsAndEventsProc         DBLogsAndEventsHandler
+	operationsProc            OperationsHandler
+	tokensCache               TokensCacheHandler
+	epochSummaryProc          DBEpochSummaryHandler
+	rollupsProc               DBRollupsHandler
+	activeAddressesProc       DBActiveAddressesHandler
+	contractsProc             DBContractsHandler
+	importDBIndexSettings     ImportDBIndexSettings
+	mutSettings               sync.Mutex
+	originalIndexSettings     map[string]indexSettings
 }
 
 // NewElasticProcessor handles Elasticsearch operations such as initialization, adding, modifying or removing data
@@ -83,17 +117,29 @@ func NewElasticProcessor(arguments *ArgElasticProcessor) (*elasticProcessor, err
 	}
 
 	ei := &elasticProcessor{
-		elasticClient:      arguments.DBClient,
-		enabledIndexes:     arguments.EnabledIndexes,
-		accountsProc:       arguments.AccountsProc,
-		blockProc:          arguments.BlockProc,
-		miniblocksProc:     arguments.MiniblocksProc,
-		transactionsProc:   arguments.TransactionsProc,
-		statisticsProc:     arguments.StatisticsProc,
-		validatorsProc:     arguments.ValidatorsProc,
-		logsAndEventsProc:  arguments.LogsAndEventsProc,
-		operationsProc:     arguments.OperationsProc,
-		bulkRequestMaxSize: arguments.BulkRequestMaxSize,
+		elasticClient:             arguments.DBClient,
+		enabledIndexes:            arguments.EnabledIndexes,
+		accountsProc:              arguments.AccountsProc,
+		blockProc:                 arguments.BlockProc,
+		miniblocksProc:            arguments.MiniblocksProc,
+		transactionsProc:          arguments.TransactionsProc,
+		statisticsProc:            arguments.StatisticsProc,
+		validatorsProc:            arguments.ValidatorsProc,
+		logsAndEventsProc:         arguments.LogsAndEventsProc,
+		operationsProc:            arguments.OperationsProc,
+		tokensCache:               arguments.TokensCache,
+		epochSummaryProc:          arguments.EpochSummaryProc,
+		rollupsProc:               arguments.RollupsProc,
+		activeAddressesProc:       arguments.ActiveAddressesProc,
+		contractsProc:             arguments.ContractsProc,
+		importDBIndexSettings:     arguments.ImportDBIndexSettings,
+		bulkRequestMaxSize:        arguments.BulkRequestMaxSize,
+		numConcurrentBulkRequests: arguments.NumConcurrentBulkRequests,
+	}
+
+	ei.originalIndexSettings, err = loadOriginalIndexSettings(arguments.ImportDBIndexSettings.OriginalSettingsFile)
+	if err != nil {
+		return nil, err
 	}
 
 	err = ei.init(arguments.UseKibana, arguments.IndexTemplates, arguments.IndexPolicies, arguments.ExtraMappings)
@@ -252,8 +298,97 @@ func getTemplateByName(templateName string, templateList map[string]*bytes.Buffe
 	return nil
 }
 
+// PrepareBlock will prepare and serialize the header, the miniblocks and the transactions of a block, without sending
+// them to the database. The returned block has to be provided to CommitBlock
+func (ei *elasticProcessor) PrepareBlock(obh *outport.OutportBlockWithHeader) (*data.PreparedBlock, error) {
+	preparedBlock := &data.PreparedBlock{
+		ShardID:    obh.Header.GetShardID(),
+		Nonce:      obh.Header.GetNonce(),
+		Round:      obh.Header.GetRound(),
+		Timestamp:  obh.Header.GetTimeStamp(),
+		HeaderHash: obh.BlockData.HeaderHash,
+	}
+
+	buffSlice := data.NewBufferSlice(ei.bulkRequestMaxSize)
+	err := ei.prepareHeader(obh, buffSlice)
+	if err != nil {
+		return nil, fmt.Errorf("%w when preparing header", err)
+	}
+	ei.validatorsProc.PutEpochStartHeader(obh.Header)
+
+	var preparedResults *data.PreparedResults
+	var logsData *data.PreparedLogsResults
+	if len(obh.BlockData.Body.MiniBlocks) > 0 {
+		miniBlocks := append(obh.BlockData.Body.MiniBlocks, obh.BlockData.IntraShardMiniBlocks...)
+		ei.prepareMiniblocks(obh.Header, miniBlocks, buffSlice)
+
+		preparedResults, logsData, err = ei.prepareTransactions(obh, buffSlice)
+		preparedBlock.TokensInfo = logsData.TokensInfo
+		if err != nil {
+			ei.removeTokensFromCache(preparedBlock.TokensInfo)
+			return nil, fmt.Errorf("%w when preparing transactions", err)
+		}
+	}
+
+	// the block is added in the epoch summary only after all its data was prepared, since an added block is not
+	// added again when it is prepared one more time
+	err = ei.prepareEpochSummary(obh, preparedResults, logsData, buffSlice)
+	if err != nil {
+		ei.removeTokensFromCache(preparedBlock.TokensInfo)
+		return nil, fmt.Errorf("%w when preparing the epoch summary", err)
+	}
+
+	err = ei.prepareActivityStats(obh, preparedResults, buffSlice)
+	if err != nil {
+		ei.removeTokensFromCache(preparedBlock.TokensInfo)
+		return nil, fmt.Errorf("%w when preparing the activity rollups", err)
+	}
+
+	err = ei.prepareActiveAddresses(obh, preparedResults, buffSlice)
+	if err != nil {
+		ei.removeTokensFromCache(preparedBlock.TokensInfo)
+		return nil, fmt.Errorf("%w when preparing the active addresses", err)
+	}
+
+	err = ei.prepareContractsStats(obh, preparedResults, logsData, buffSlice)
+	if err != nil {
+		ei.removeTokensFromCache(preparedBlock.TokensInfo)
+		return nil, fmt.Errorf("%w when preparing the contracts", err)
+	}
+
+	preparedBlock.Buffers = buffSlice.Buffers()
+
+	return preparedBlock, nil
+}
+
+// CommitBlock will send the data of a block prepared with PrepareBlock to the database
+func (ei *elasticProcessor) CommitBlock(preparedBlock *data.PreparedBlock) error {
+	// the tokens issued, or with a changed type or owner, are removed from the cache only after their new data is
+	// saved, so the cache is not populated again with the old data by a concurrent request
+	defer ei.removeTokensFromCache(preparedBlock.TokensInfo)
+
+	err := ei.doBulkRequests("", preparedBlock.Buffers, preparedBlock.ShardID)
+	if err != nil {
+		return err
+	}
+
+	// the buffers are reused only after a successful commit, since the caller can retry a failed one
+	data.ReleaseBuffers(preparedBlock.Buffers)
+	return nil
+}
+
 // SaveHeader will prepare and save information about a header in elasticsearch server
 func (ei *elasticProcessor) SaveHeader(outportBlockWithHeader *outport.OutportBlockWithHeader) error {
+	buffSlice := data.NewBufferSlice(ei.bulkRequestMaxSize)
+	err := ei.prepareHeader(outportBlockWithHeader, buffSlice)
+	if err != nil {
+		return err
+	}
+
+	return ei.doBulkRequests("", buffSlice.Buffers(), outportBlockWithHeader.ShardID)
+}
+
+func (ei *elasticProcessor) prepareHeader(outportBlockWithHeader *outport.OutportBlockWithHeader, buffSlice *data.BufferSlice) error {
 	if !ei.isIndexEnabled(elasticIndexer.BlockIndex) {
 		return nil
 	}
@@ -263,18 +398,12 @@ func (ei *elasticProcessor) SaveHeader(outportBlockWithHeader *outport.OutportBl
 		return err
 	}
 
-	buffSlice := data.NewBufferSlice(ei.bulkRequestMaxSize)
 	err = ei.blockProc.SerializeBlock(elasticBlock, buffSlice, elasticIndexer.BlockIndex)
 	if err != nil {
 		return err
 	}
 
-	err = ei.indexEpochInfoData(outportBlockWithHeader.Header, buffSlice)
-	if err != nil {
-		return err
-	}
-
-	return ei.doBulkRequests("", buffSlice.Buffers(), outportBlockWithHeader.ShardID)
+	return ei.indexEpochInfoData(outportBlockWithHeader.Header, buffSlice)
 }
 
 func (ei *elasticProcessor) indexEpochInfoData(header coreData.HeaderHandler, buffSlice *data.BufferSlice) error {
@@ -318,6 +447,9 @@ func (ei *elasticProcessor) RemoveMiniblocks(header coreData.HeaderHandler, body
 
 // RemoveTransactions will remove transaction that are in miniblock from the elasticsearch server
 func (ei *elasticProcessor) RemoveTransactions(header coreData.HeaderHandler, body *block.Body) error {
+	// the data of the tokens might have been changed by the reverted block
+	ei.tokensCache.Clear()
+
 	encodedTxsHashes, encodedScrsHashes := ei.transactionsProc.GetHexEncodedHashesForRemove(header, body)
 	shardID := header.GetShardID()
 
@@ -346,13 +478,67 @@ func (ei *elasticProcessor) RemoveTransactions(header coreData.HeaderHandler, bo
 		return err
 	}
 
+	if ei.isIndexEnabled(elasticIndexer.ProcessingErrorsIndex) {
+		err = ei.removeFromIndexByTimestampAndShardID(header.GetTimeStamp(), header.GetShardID(), elasticIndexer.ProcessingErrorsIndex)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = ei.revertEpochSummary(header)
+	if err != nil {
+		return err
+	}
+
+	err = ei.revertActivityStats(header, body)
+	if err != nil {
+		return err
+	}
+
+	err = ei.revertContractsStats(header, body)
+	if err != nil {
+		return err
+	}
+
+	err = ei.revertActiveAddresses(header, body)
+	if err != nil {
+		return err
+	}
+
 	return ei.updateDelegatorsInCaseOfRevert(header, body)
 }
 
+func (ei *elasticProcessor) revertEpochSummary(header coreData.HeaderHandler) error {
+	if !ei.isIndexEnabled(elasticIndexer.EpochSummaryIndex) {
+		return nil
+	}
+
+	headerHash, err := ei.blockProc.ComputeHeaderHash(header)
+	if err != nil {
+		return err
+	}
+
+	summary, idToRemove := ei.epochSummaryProc.RevertBlock(header, headerHash)
+	if idToRemove != "" {
+		return ei.removeIfHashesNotEmpty(elasticIndexer.EpochSummaryIndex, []string{idToRemove}, header.GetShardID())
+	}
+	if summary == nil {
+		return nil
+	}
+
+	buffSlice := data.NewBufferSlice(ei.bulkRequestMaxSize)
+	err = ei.epochSummaryProc.SerializeEpochSummary(summary, buffSlice, elasticIndexer.EpochSummaryIndex)
+	if err != nil {
+		return err
+	}
+
+	return ei.doBulkRequests(elasticIndexer.EpochSummaryIndex, buffSlice.Buffers(), header.GetShardID())
+}
+
 func (ei *elasticProcessor) updateDelegatorsInCaseOfRevert(header coreData.HeaderHandler, body *block.Body) error {
 	// delegators index should be updated in case of revert only if the observer is in Metachain and the reverted block has miniblocks
 	isMeta := header.GetShardID() == core.MetachainShardId
-	hasMiniblocks := len(body.MiniBlocks) > 0
+	hasMiniblocks := len(body.GetMiniBlocks()) > 0
 	shouldUpdate := isMeta && hasMiniblocks
 	if !shouldUpdate {
 		return nil
@@ -399,111 +585,362 @@ func (ei *elasticProcessor) removeFromIndexByTimestampAndShardID(headerTimestamp
 
 // SaveMiniblocks will prepare and save information about miniblocks in elasticsearch server
 func (ei *elasticProcessor) SaveMiniblocks(header coreData.HeaderHandler, miniBlocks []*block.MiniBlock) error {
+	buffSlice := data.NewBufferSlice(ei.bulkRequestMaxSize)
+	ei.prepareMiniblocks(header, miniBlocks, buffSlice)
+
+	return ei.doBulkRequests("", buffSlice.Buffers(), header.GetShardID())
+}
+
+func (ei *elasticProcessor) prepareMiniblocks(header coreData.HeaderHandler, miniBlocks []*block.MiniBlock, buffSlice *data.BufferSlice) {
 	if !ei.isIndexEnabled(elasticIndexer.MiniblocksIndex) {
-		return nil
+		return
 	}
 
 	mbs := ei.miniblocksProc.PrepareDBMiniblocks(header, miniBlocks)
 	if len(mbs) == 0 {
-		return nil
+		return
 	}
 
-	buffSlice := data.NewBufferSlice(ei.bulkRequestMaxSize)
 	ei.miniblocksProc.SerializeBulkMiniBlocks(mbs, buffSlice, elasticIndexer.MiniblocksIndex, header.GetShardID())
-
-	return ei.doBulkRequests("", buffSlice.Buffers(), header.GetShardID())
 }
 
 // SaveTransactions will prepare and save information about a transactions in elasticsearch server
 func (ei *elasticProcessor) SaveTransactions(obh *outport.OutportBlockWithHeader) error {
+	buffers := data.NewBufferSlice(ei.bulkRequestMaxSize)
+	_, logsData, err := ei.prepareTransactions(obh, buffers)
+	// the tokens issued, or with a changed type or owner, are removed from the cache only after their new data is
+	// saved, so the cache is not populated again with the old data by a concurrent request
+	defer ei.removeTokensFromCache(logsData.TokensInfo)
+	if err != nil {
+		return err
+	}
+
+	return ei.doBulkRequests("", buffers.Buffers(), obh.ShardID)
+}
+
+// prepareTransactions will serialize the transactions and all the data extracted from them. It returns the prepared
+// transactions and the data extracted from the logs, which holds the tokens whose data is changed by the block. The
+// logs data is returned even if an error occurred
+func (ei *elasticProcessor) prepareTransactions(
+	obh *outport.OutportBlockWithHeader,
+	buffers *data.BufferSlice,
+) (*data.PreparedResults, *data.PreparedLogsResults, error) {
 	headerTimestamp := obh.Header.GetTimeStamp()
 
 	miniBlocks := append(obh.BlockData.Body.MiniBlocks, obh.BlockData.IntraShardMiniBlocks...)
 	preparedResults := ei.transactionsProc.PrepareTransactionsForDatabase(miniBlocks, obh.Header, obh.TransactionPool, ei.isImportDB(), obh.NumberOfShards)
 	logsData := ei.logsAndEventsProc.ExtractDataFromLogs(obh.TransactionPool.Logs, preparedResults, headerTimestamp, obh.Header.GetShardID(), obh.NumberOfShards)
 
-	buffers := data.NewBufferSlice(ei.bulkRequestMaxSize)
 	err := ei.indexTransactions(preparedResults.Transactions, logsData.TxHashStatusInfo, obh.Header, buffers)
 	if err != nil {
-		return err
+		return preparedResults, logsData, err
 	}
 
 	err = ei.prepareAndIndexOperations(preparedResults.Transactions, logsData.TxHashStatusInfo, obh.Header, preparedResults.ScResults, buffers, ei.isImportDB())
 	if err != nil {
-		return err
+		return preparedResults, logsData, err
 	}
 
 	err = ei.indexTransactionsFeeData(preparedResults.TxHashFee, buffers)
 	if err != nil {
-		return err
+		return preparedResults, logsData, err
 	}
 
 	err = ei.indexNFTCreateInfo(logsData.Tokens, obh.AlteredAccounts, buffers, obh.ShardID)
 	if err != nil {
-		return err
+		return preparedResults, logsData, err
 	}
 
 	err = ei.indexLogs(logsData.DBLogs, buffers)
 	if err != nil {
-		return err
+		return preparedResults, logsData, err
 	}
 
 	err = ei.indexEvents(logsData.DBEvents, buffers)
 	if err != nil {
-		return err
+		return preparedResults, logsData, err
+	}
+
+	err = ei.indexProcessingErrors(logsData.ProcessingErrors, buffers)
+	if err != nil {
+		return preparedResults, logsData, err
 	}
 
 	err = ei.indexScResults(preparedResults.ScResults, buffers)
 	if err != nil {
-		return err
+		return preparedResults, logsData, err
 	}
 
 	err = ei.indexReceipts(preparedResults.Receipts, buffers)
 	if err != nil {
-		return err
+		return preparedResults, logsData, err
 	}
 
 	tagsCount := tags.NewTagsCount()
 	err = ei.indexAlteredAccounts(headerTimestamp, logsData.NFTsDataUpdates, obh.AlteredAccounts, buffers, tagsCount, obh.Header.GetShardID())
 	if err != nil {
-		return err
+		return preparedResults, logsData, err
 	}
 
 	err = ei.prepareAndIndexTagsCount(tagsCount, buffers)
 	if err != nil {
-		return err
+		return preparedResults, logsData, err
 	}
 
 	err = ei.indexTokens(logsData.TokensInfo, logsData.NFTsDataUpdates, buffers, obh.ShardID)
 	if err != nil {
-		return err
+		return preparedResults, logsData, err
 	}
 
 	err = ei.prepareAndIndexDelegators(logsData.Delegators, buffers)
 	if err != nil {
-		return err
+		return preparedResults, logsData, err
 	}
 
 	err = ei.indexNFTBurnInfo(logsData.TokensSupply, buffers, obh.ShardID)
 	if err != nil {
-		return err
+		return preparedResults, logsData, err
 	}
 
 	err = ei.prepareAndIndexRolesData(logsData.TokenRolesAndProperties, buffers, elasticIndexer.TokensIndex)
 	if err != nil {
-		return err
+		return preparedResults, logsData, err
 	}
 	err = ei.prepareAndIndexRolesData(logsData.TokenRolesAndProperties, buffers, elasticIndexer.ESDTsIndex)
 	if err != nil {
-		return err
+		return preparedResults, logsData, err
 	}
 
 	err = ei.indexScDeploys(logsData.ScDeploys, logsData.ChangeOwnerOperations, buffers)
+	if err != nil {
+		return preparedResults, logsData, err
+	}
+
+	return preparedResults, logsData, nil
+}
+
+func (ei *elasticProcessor) prepareEpochSummary(
+	obh *outport.OutportBlockWithHeader,
+	preparedResults *data.PreparedResults,
+	logsData *data.PreparedLogsResults,
+	buffSlice *data.BufferSlice,
+) error {
+	if !ei.isIndexEnabled(elasticIndexer.EpochSummaryIndex) {
+		return nil
+	}
+
+	err := ei.loadMissingEpochSummary(obh.Header)
 	if err != nil {
 		return err
 	}
 
-	return ei.doBulkRequests("", buffers.Buffers(), obh.ShardID)
+	newAccounts, err := ei.getNewAccounts(obh)
+	if err != nil {
+		return err
+	}
+
+	summary := ei.epochSummaryProc.AddBlock(&epochsummary.ArgsAddBlock{
+		Header:          obh.Header,
+		HeaderHash:      obh.BlockData.HeaderHash,
+		PreparedResults: preparedResults,
+		LogsData:        logsData,
+		NewAccounts:     newAccounts,
+	})
+	if summary == nil {
+		return nil
+	}
+
+	return ei.epochSummaryProc.SerializeEpochSummary(summary, buffSlice, elasticIndexer.EpochSummaryIndex)
+}
+
+func (ei *elasticProcessor) prepareActivityStats(obh *outport.OutportBlockWithHeader, preparedResults *data.PreparedResults, buffSlice *data.BufferSlice) error {
+	if preparedResults == nil || !ei.isAnyRollupEnabled() {
+		return nil
+	}
+
+	stats := ei.rollupsProc.PrepareActivityStats(obh.Header, obh.BlockData.HeaderHash, preparedResults)
+
+	return ei.serializeActivityStats(stats, buffSlice)
+}
+
+func (ei *elasticProcessor) revertActivityStats(header coreData.HeaderHandler, body *block.Body) error {
+	// the activity is added only for the blocks with miniblocks
+	if len(body.GetMiniBlocks()) == 0 || !ei.isAnyRollupEnabled() {
+		return nil
+	}
+
+	headerHash, err := ei.blockProc.ComputeHeaderHash(header)
+	if err != nil {
+		return err
+	}
+
+	stats := ei.rollupsProc.PrepareRevertedActivityStats(header, headerHash)
+	if stats == nil {
+		return nil
+	}
+
+	buffSlice := data.NewBufferSlice(ei.bulkRequestMaxSize)
+	err = ei.serializeActivityStats(stats, buffSlice)
+	if err != nil {
+		return err
+	}
+
+	return ei.doBulkRequests("", buffSlice.Buffers(), header.GetShardID())
+}
+
+func (ei *elasticProcessor) isAnyRollupEnabled() bool {
+	for _, rollup := range rollupsPeriods {
+		if ei.isIndexEnabled(rollup.index) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (ei *elasticProcessor) serializeActivityStats(stats *data.ActivityStats, buffSlice *data.BufferSlice) error {
+	for _, rollup := range rollupsPeriods {
+		if !ei.isIndexEnabled(rollup.index) {
+			continue
+		}
+
+		err := ei.rollupsProc.SerializeActivityStats(stats, rollup.periodInSeconds, buffSlice, rollup.index)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ei *elasticProcessor) prepareActiveAddresses(
+	obh *outport.OutportBlockWithHeader,
+	preparedResults *data.PreparedResults,
+	buffSlice *data.BufferSlice,
+) error {
+	if preparedResults == nil || !ei.isIndexEnabled(elasticIndexer.ActiveAddressesIndex) {
+		return nil
+	}
+
+	activeAddresses := ei.activeAddressesProc.PrepareActiveAddresses(obh.Header, obh.BlockData.HeaderHash, preparedResults)
+
+	return ei.activeAddressesProc.SerializeActiveAddresses(activeAddresses, buffSlice, elasticIndexer.ActiveAddressesIndex)
+}
+
+func (ei *elasticProcessor) prepareContractsStats(
+	obh *outport.OutportBlockWithHeader,
+	preparedResults *data.PreparedResults,
+	logsData *data.PreparedLogsResults,
+	buffSlice *data.BufferSlice,
+) error {
+	if preparedResults == nil || !ei.isIndexEnabled(elasticIndexer.ContractsIndex) {
+		return nil
+	}
+
+	contractsStats := ei.contractsProc.PrepareContractsStats(obh.Header, obh.BlockData.HeaderHash, preparedResults, logsData)
+
+	return ei.contractsProc.SerializeContractsStats(contractsStats, buffSlice, elasticIndexer.ContractsIndex)
+}
+
+func (ei *elasticProcessor) revertContractsStats(header coreData.HeaderHandler, body *block.Body) error {
+	// the interactions are added only for the blocks with miniblocks
+	if len(body.GetMiniBlocks()) == 0 || !ei.isIndexEnabled(elasticIndexer.ContractsIndex) {
+		return nil
+	}
+
+	headerHash, err := ei.blockProc.ComputeHeaderHash(header)
+	if err != nil {
+		return err
+	}
+
+	contractsStats := ei.contractsProc.PrepareRevertedContractsStats(header, headerHash)
+	if len(contractsStats) == 0 {
+		return nil
+	}
+
+	buffSlice := data.NewBufferSlice(ei.bulkRequestMaxSize)
+	err = ei.contractsProc.SerializeContractsStats(contractsStats, buffSlice, elasticIndexer.ContractsIndex)
+	if err != nil {
+		return err
+	}
+
+	return ei.doBulkRequests("", buffSlice.Buffers(), header.GetShardID())
+}
+
+func (ei *elasticProcessor) revertActiveAddresses(header coreData.HeaderHandler, body *block.Body) error {
+	// the active addresses are added only for the blocks with miniblocks
+	if len(body.GetMiniBlocks()) == 0 || !ei.isIndexEnabled(elasticIndexer.ActiveAddressesIndex) {
+		return nil
+	}
+
+	headerHash, err := ei.blockProc.ComputeHeaderHash(header)
+	if err != nil {
+		return err
+	}
+
+	activeAddresses := ei.activeAddressesProc.PrepareRevertedActiveAddresses(header, headerHash)
+	if len(activeAddresses) == 0 {
+		return nil
+	}
+
+	buffSlice := data.NewBufferSlice(ei.bulkRequestMaxSize)
+	err = ei.activeAddressesProc.SerializeActiveAddresses(activeAddresses, buffSlice, elasticIndexer.ActiveAddressesIndex)
+	if err != nil {
+		return err
+	}
+
+	return ei.doBulkRequests("", buffSlice.Buffers(), header.GetShardID())
+}
+
+func (ei *elasticProcessor) loadMissingEpochSummary(header coreData.HeaderHandler) error {
+	id, isMissing := ei.epochSummaryProc.GetMissingSummaryID(header)
+	if !isMissing {
+		return nil
+	}
+
+	response := &data.ResponseMultiGet{}
+	ctxWithValue := context.WithValue(context.Background(), request.ContextKey, request.ExtendTopicWithShardID(request.GetTopic, header.GetShardID()))
+	err := ei.elasticClient.DoMultiGet(ctxWithValue, []string{id}, elasticIndexer.EpochSummaryIndex, true, response)
+	if err != nil {
+		return err
+	}
+
+	return ei.epochSummaryProc.PutSummaryFromResponse(response)
+}
+
+// getNewAccounts returns the altered accounts of the block which are not in the accounts index. The new accounts are
+// not counted when the accounts index is disabled
+func (ei *elasticProcessor) getNewAccounts(obh *outport.OutportBlockWithHeader) ([]string, error) {
+	if !ei.isIndexEnabled(elasticIndexer.AccountsIndex) || len(obh.AlteredAccounts) == 0 {
+		return nil, nil
+	}
+
+	addresses := make([]string, 0, len(obh.AlteredAccounts))
+	for address := range obh.AlteredAccounts {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	candidates := ei.epochSummaryProc.GetNewAccountsCandidates(obh.Header.GetShardID(), addresses)
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	response := &data.ResponseMultiGet{}
+	ctxWithValue := context.WithValue(context.Background(), request.ContextKey, request.ExtendTopicWithShardID(request.GetTopic, obh.Header.GetShardID()))
+	err := ei.elasticClient.DoMultiGet(ctxWithValue, candidates, elasticIndexer.AccountsIndex, false, response)
+	if err != nil {
+		return nil, err
+	}
+
+	newAccounts := make([]string, 0)
+	for _, doc := range response.Docs {
+		if !doc.Found {
+			newAccounts = append(newAccounts, doc.ID)
+		}
+	}
+
+	return newAccounts, nil
 }
 
 func (ei *elasticProcessor) prepareAndIndexRolesData(tokenRolesAndProperties *tokeninfo.TokenRolesAndProperties, buffSlice *data.BufferSlice, index string) error {
@@ -551,6 +988,14 @@ func (ei *elasticProcessor) indexEvents(eventsDB []*data.LogEvent, buffSlice *da
 	return ei.logsAndEventsProc.SerializeEvents(eventsDB, buffSlice, elasticIndexer.EventsIndex)
 }
 
+func (ei *elasticProcessor) indexProcessingErrors(processingErrors []*data.ProcessingError, buffSlice *data.BufferSlice) error {
+	if !ei.isIndexEnabled(elasticIndexer.ProcessingErrorsIndex) {
+		return nil
+	}
+
+	return ei.logsAndEventsProc.SerializeProcessingErrors(processingErrors, buffSlice, elasticIndexer.ProcessingErrorsIndex)
+}
+
 func (ei *elasticProcessor) indexScDeploys(deployData map[string]*data.ScDeployInfo, changeOwnerOperation map[string]*data.OwnerData, buffSlice *data.BufferSlice) error {
 	if !ei.isIndexEnabled(elasticIndexer.SCDeploysIndex) {
 		return nil
@@ -596,6 +1041,16 @@ func (ei *elasticProcessor) prepareAndIndexOperations(
 
 // SaveValidatorsRating will save validators rating
 func (ei *elasticProcessor) SaveValidatorsRating(ratingData *outport.ValidatorsRating) error {
+	err := ei.indexValidatorStatsRating(ratingData)
+	if err != nil {
+		return err
+	}
+
+	err = ei.indexValidatorsRatingHistory(ratingData)
+	if err != nil {
+		return err
+	}
+
 	if !ei.isIndexEnabled(elasticIndexer.RatingIndex) {
 		return nil
 	}
@@ -608,8 +1063,80 @@ func (ei *elasticProcessor) SaveValidatorsRating(ratingData *outport.ValidatorsR
 	return ei.doBulkRequests(elasticIndexer.RatingIndex, buffSlice, ratingData.ShardID)
 }
 
+func (ei *elasticProcessor) indexValidatorsRatingHistory(ratingData *outport.ValidatorsRating) error {
+	if !ei.isIndexEnabled(elasticIndexer.RatingHistoryIndex) {
+		return nil
+	}
+
+	epochStartTimestamp, err := ei.getEpochStartTimestamp(ratingData.Epoch, ratingData.ShardID)
+	if err != nil {
+		return err
+	}
+
+	buffSlice := data.NewBufferSlice(ei.bulkRequestMaxSize)
+	err = ei.validatorsProc.SerializeValidatorsRatingHistory(ratingData, epochStartTimestamp, buffSlice, elasticIndexer.RatingHistoryIndex)
+	if err != nil {
+		return err
+	}
+
+	return ei.doBulkRequests(elasticIndexer.RatingHistoryIndex, buffSlice.Buffers(), ratingData.ShardID)
+}
+
+// getEpochStartTimestamp returns the timestamp of the metachain epoch start block of the provided epoch. The block is
+// searched in the blocks index when it was not received since the start, and 0 is returned when it is not found
+func (ei *elasticProcessor) getEpochStartTimestamp(epoch uint32, shardID uint32) (uint64, error) {
+	timestamp, found := ei.validatorsProc.GetEpochStartTimestamp(epoch)
+	if found || !ei.isIndexEnabled(elasticIndexer.BlockIndex) {
+		return timestamp, nil
+	}
+
+	query := fmt.Sprintf(epochStartBlockQuery, epoch, core.MetachainShardId)
+	response := &data.ResponseSearch{}
+	ctxWithValue := context.WithValue(context.Background(), request.ContextKey, request.ExtendTopicWithShardID(request.GetTopic, shardID))
+	err := ei.elasticClient.DoSearchRequest(ctxWithValue, elasticIndexer.BlockIndex, []byte(query), response)
+	if err != nil {
+		return 0, err
+	}
+
+	ei.validatorsProc.PutEpochStartBlockFromResponse(epoch, response)
+	timestamp, found = ei.validatorsProc.GetEpochStartTimestamp(epoch)
+	if !found {
+		log.Debug("elasticProcessor.getEpochStartTimestamp: epoch start block not found, the ratings have no timestamp", "epoch", epoch)
+	}
+
+	return timestamp, nil
+}
+
+func (ei *elasticProcessor) indexValidatorStatsRating(ratingData *outport.ValidatorsRating) error {
+	if !ei.isIndexEnabled(elasticIndexer.ValidatorStatsIndex) {
+		return nil
+	}
+
+	previousEpochStats := &data.ResponseValidatorsStatsRating{}
+	ids := ei.validatorsProc.GetPreviousEpochStatsIDs(ratingData)
+	if len(ids) > 0 {
+		ctxWithValue := context.WithValue(context.Background(), request.ContextKey, request.ExtendTopicWithShardID(request.GetTopic, ratingData.ShardID))
+		err := ei.elasticClient.DoMultiGet(ctxWithValue, ids, elasticIndexer.ValidatorStatsIndex, true, previousEpochStats)
+		if err != nil {
+			return err
+		}
+	}
+
+	buffSlice := data.NewBufferSlice(ei.bulkRequestMaxSize)
+	err := ei.validatorsProc.SerializeValidatorStatsRating(ratingData, previousEpochStats, buffSlice, elasticIndexer.ValidatorStatsIndex)
+	if err != nil {
+		return err
+	}
+
+	return ei.doBulkRequests(elasticIndexer.ValidatorStatsIndex, buffSlice.Buffers(), ratingData.ShardID)
+}
+
 // SaveShardValidatorsPubKeys will prepare and save information about a shard validators public keys in elasticsearch server
 func (ei *elasticProcessor) SaveShardValidatorsPubKeys(validatorsPubKeys *outport.ValidatorsPubKeys) error {
+	if ei.isIndexEnabled(elasticIndexer.ValidatorStatsIndex) {
+		ei.validatorsProc.PutValidatorsPubKeys(validatorsPubKeys)
+	}
+
 	if !ei.isIndexEnabled(elasticIndexer.ValidatorsIndex) {
 		return nil
 	}
@@ -624,6 +1151,11 @@ func (ei *elasticProcessor) SaveShardValidatorsPubKeys(validatorsPubKeys *outpor
 
 // SaveRoundsInfo will prepare and save information about a slice of rounds in elasticsearch server
 func (ei *elasticProcessor) SaveRoundsInfo(rounds *outport.RoundsInfo) error {
+	err := ei.indexValidatorStats(rounds)
+	if err != nil {
+		return err
+	}
+
 	if !ei.isIndexEnabled(elasticIndexer.RoundsIndex) {
 		return nil
 	}
@@ -634,6 +1166,49 @@ func (ei *elasticProcessor) SaveRoundsInfo(rounds *outport.RoundsInfo) error {
 	return ei.elasticClient.DoBulkRequest(ctxWithValue, buff, elasticIndexer.RoundsIndex)
 }
 
+func (ei *elasticProcessor) indexValidatorStats(rounds *outport.RoundsInfo) error {
+	if !ei.isIndexEnabled(elasticIndexer.ValidatorStatsIndex) {
+		return nil
+	}
+
+	err := ei.loadMissingValidatorsPubKeys(rounds)
+	if err != nil {
+		return err
+	}
+
+	buffSlice := data.NewBufferSlice(ei.bulkRequestMaxSize)
+	err = ei.validatorsProc.SerializeValidatorStats(rounds, buffSlice, elasticIndexer.ValidatorStatsIndex)
+	if err != nil {
+		return err
+	}
+
+	return ei.doBulkRequests(elasticIndexer.ValidatorStatsIndex, buffSlice.Buffers(), rounds.ShardID)
+}
+
+// loadMissingValidatorsPubKeys requests from the validators index the public keys of the validators which are not kept
+// in memory, like after a restart of the indexer
+func (ei *elasticProcessor) loadMissingValidatorsPubKeys(rounds *outport.RoundsInfo) error {
+	if !ei.isIndexEnabled(elasticIndexer.ValidatorsIndex) {
+		return nil
+	}
+
+	ids := ei.validatorsProc.GetMissingValidatorsPubKeysIDs(rounds)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	response := &data.ResponseValidatorsPublicKeys{}
+	ctxWithValue := context.WithValue(context.Background(), request.ContextKey, request.ExtendTopicWithShardID(request.GetTopic, rounds.ShardID))
+	err := ei.elasticClient.DoMultiGet(ctxWithValue, ids, elasticIndexer.ValidatorsIndex, true, response)
+	if err != nil {
+		return err
+	}
+
+	ei.validatorsProc.PutValidatorsPubKeysFromResponse(response)
+
+	return nil
+}
+
 func (ei *elasticProcessor) indexAlteredAccounts(
 	timestamp uint64,
 	updatesNFTsData []*data.NFTDataUpdate,
@@ -679,9 +1254,7 @@ func (ei *elasticProcessor) addTokenTypeAndCurrentOwnerInAccountsESDT(tokensData
 		return nil
 	}
 
-	responseTokens := &data.ResponseTokens{}
-	ctxWithValue := context.WithValue(context.Background(), request.ContextKey, request.ExtendTopicWithShardID(request.GetTopic, shardID))
-	err := ei.elasticClient.DoMultiGet(ctxWithValue, tokensData.GetAllTokens(), elasticIndexer.TokensIndex, true, responseTokens)
+	responseTokens, err := ei.getTokensTypeAndOwner(tokensData.GetAllTokens(), shardID)
 	if err != nil {
 		return err
 	}
@@ -719,9 +1292,7 @@ func (ei *elasticProcessor) indexNFTCreateInfo(tokensData data.TokensHandler, co
 		return nil
 	}
 
-	ctxWithValue := context.WithValue(context.Background(), request.ContextKey, request.ExtendTopicWithShardID(request.GetTopic, shardID))
-	responseTokens := &data.ResponseTokens{}
-	err := ei.elasticClient.DoMultiGet(ctxWithValue, tokensData.GetAllTokens(), elasticIndexer.TokensIndex, true, responseTokens)
+	responseTokens, err := ei.getTokensTypeAndOwner(tokensData.GetAllTokens(), shardID)
 	if err != nil {
 		return err
 	}
@@ -740,9 +1311,7 @@ func (ei *elasticProcessor) indexNFTBurnInfo(tokensData data.TokensHandler, buff
 		return nil
 	}
 
-	ctxWithValue := context.WithValue(context.Background(), request.ContextKey, request.ExtendTopicWithShardID(request.GetTopic, shardID))
-	responseTokens := &data.ResponseTokens{}
-	err := ei.elasticClient.DoMultiGet(ctxWithValue, tokensData.GetAllTokens(), elasticIndexer.TokensIndex, true, responseTokens)
+	responseTokens, err := ei.getTokensTypeAndOwner(tokensData.GetAllTokens(), shardID)
 	if err != nil {
 		return err
 	}
@@ -830,29 +1399,97 @@ func (ei *elasticProcessor) indexReceipts(receipts []*data.Receipt, buffSlice *d
 }
 
 func (ei *elasticProcessor) isIndexEnabled(index string) bool {
+	ei.mutex.RLock()
+	defer ei.mutex.RUnlock()
+
 	_, isEnabled := ei.enabledIndexes[index]
 	return isEnabled
 }
 
-func (ei *elasticProcessor) doBulkRequests(index string, buffSlice []*bytes.Buffer, shardID uint32) error {
-	var err error
-	for idx := range buffSlice {
-		ctxWithValue := context.WithValue(context.Background(), request.ContextKey, request.ExtendTopicWithShardID(request.BulkTopic, shardID))
-		err = ei.elasticClient.DoBulkRequest(ctxWithValue, buffSlice[idx], index)
-		if err != nil {
-			return err
-		}
+// SetIndexEnabled will enable or disable the indexing of the provided index at runtime
+func (ei *elasticProcessor) SetIndexEnabled(index string, enabled bool) error {
+	if !isKnownIndex(index) {
+		return fmt.Errorf("%w: %s", indexerCore.ErrUnknownIndex, index)
+	}
+
+	ei.mutex.Lock()
+	defer ei.mutex.Unlock()
+
+	if enabled {
+		ei.enabledIndexes[index] = struct{}{}
+	} else {
+		delete(ei.enabledIndexes, index)
 	}
 
+	log.Info("elasticProcessor.SetIndexEnabled", "index", index, "enabled", enabled)
+
 	return nil
 }
 
-// SetOutportConfig will set the outport config
+// GetEnabledIndexes returns the sorted list of the enabled indices
+func (ei *elasticProcessor) GetEnabledIndexes() []string {
+	ei.mutex.RLock()
+	defer ei.mutex.RUnlock()
+
+	enabledIndexes := make([]string, 0, len(ei.enabledIndexes))
+	for index := range ei.enabledIndexes {
+		enabledIndexes = append(enabledIndexes, index)
+	}
+	sort.Strings(enabledIndexes)
+
+	return enabledIndexes
+}
+
+// Close will restore the index settings changed for the import-db mode and will release the resources of the
+// database client, if it holds any
+func (ei *elasticProcessor) Close() error {
+	err := ei.restoreIndexSettings()
+	if err != nil {
+		log.Error("elasticProcessor.Close: cannot restore the index settings", "error", err)
+	}
+
+	closer, ok := ei.elasticClient.(io.Closer)
+	if !ok {
+		return nil
+	}
+
+	return closer.Close()
+}
+
+func isKnownIndex(index string) bool {
+	for _, knownIndex := range indexes {
+		if knownIndex == index {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (ei *elasticProcessor) doBulkRequests(index string, buffSlice []*bytes.Buffer, shardID uint32) error {
+	dispatcher := &bulkDispatcher{
+		dbClient:                  ei.elasticClient,
+		numConcurrentBulkRequests: ei.numConcurrentBulkRequests,
+	}
+
+	return dispatcher.doBulkRequests(index, buffSlice, shardID)
+}
+
+// SetOutportConfig will set the outport config. The import-db index settings are applied when the import-db mode
+// starts and the previous settings are restored when the node runs in normal mode, even if the import-db mode ended
+// while the indexer was stopped
 func (ei *elasticProcessor) SetOutportConfig(cfg outport.OutportConfig) error {
 	ei.mutex.Lock()
-	defer ei.mutex.Unlock()
-
+	wasImportDB := ei.importDB
 	ei.importDB = cfg.IsInImportDBMode
+	ei.mutex.Unlock()
+
+	if cfg.IsInImportDBMode && !wasImportDB {
+		return ei.applyImportDBIndexSettings()
+	}
+	if !cfg.IsInImportDBMode {
+		return ei.restoreIndexSettings()
+	}
 
 	return nil
 }
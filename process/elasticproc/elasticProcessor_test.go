@@ -3,6 +3,7 @@ package elasticproc
 import (
 	"bytes"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"strings"
 	"testing"
@@ -12,17 +13,21 @@ import (
 	dataBlock "github.com/multiversx/mx-chain-core-go/data/block"
 	"github.com/multiversx/mx-chain-core-go/data/outport"
 	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	indexerCore "github.com/multiversx/mx-chain-es-indexer-go/core"
 	"github.com/multiversx/mx-chain-es-indexer-go/data"
 	"github.com/multiversx/mx-chain-es-indexer-go/mock"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/dataindexer"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/accounts"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/block"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/converters"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/epochsummary"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/logsevents"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/miniblocks"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/operations"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/rollups"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/statistics"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/tags"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/tokenscache"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/transactions"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/validators"
 	"github.com/stretchr/testify/require"
@@ -39,6 +44,7 @@ func newElasticsearchProcessor(elasticsearchWriter DatabaseClientHandler, argume
 		validatorsProc:    arguments.ValidatorsProc,
 		statisticsProc:    arguments.StatisticsProc,
 		logsAndEventsProc: arguments.LogsAndEventsProc,
+		tokensCache:       arguments.TokensCache,
 	}
 }
 
@@ -73,20 +79,28 @@ func createMockElasticProcessorArgs() *ArgElasticProcessor {
 	}
 	lp, _ := logsevents.NewLogsAndEventsProcessor(args)
 	op, _ := operations.NewOperationsProcessor()
+	tc, _ := tokenscache.NewTokensCache(100)
+	aap, _ := rollups.NewActiveAddressesProcessor(mock.NewPubkeyConverterMock(32))
+	cp, _ := rollups.NewContractsProcessor(mock.NewPubkeyConverterMock(32))
 
 	return &ArgElasticProcessor{
 		DBClient: &mock.DatabaseWriterStub{},
 		EnabledIndexes: map[string]struct{}{
 			dataindexer.BlockIndex: {}, dataindexer.TransactionsIndex: {}, dataindexer.MiniblocksIndex: {}, dataindexer.ValidatorsIndex: {}, dataindexer.RoundsIndex: {}, dataindexer.AccountsIndex: {}, dataindexer.RatingIndex: {}, dataindexer.AccountsHistoryIndex: {},
 		},
-		ValidatorsProc:    vp,
-		StatisticsProc:    statistics.NewStatisticsProcessor(),
-		TransactionsProc:  &mock.DBTransactionProcessorStub{},
-		MiniblocksProc:    mp,
-		AccountsProc:      acp,
-		BlockProc:         bp,
-		LogsAndEventsProc: lp,
-		OperationsProc:    op,
+		ValidatorsProc:      vp,
+		StatisticsProc:      statistics.NewStatisticsProcessor(),
+		TransactionsProc:    &mock.DBTransactionProcessorStub{},
+		MiniblocksProc:      mp,
+		AccountsProc:        acp,
+		BlockProc:           bp,
+		LogsAndEventsProc:   lp,
+		OperationsProc:      op,
+		TokensCache:         tc,
+		EpochSummaryProc:    epochsummary.NewEpochSummaryProcessor(),
+		RollupsProc:         rollups.NewRollupsProcessor(),
+		ActiveAddressesProc: aap,
+		ContractsProc:       cp,
 	}
 }
 
@@ -200,6 +214,51 @@ func TestNewElasticProcessor(t *testing.T) {
 			},
 			exErr: dataindexer.ErrNilTransactionsHandler,
 		},
+		{
+			name: "NilTokensCache",
+			args: func() *ArgElasticProcessor {
+				arguments := createMockElasticProcessorArgs()
+				arguments.TokensCache = nil
+				return arguments
+			},
+			exErr: dataindexer.ErrNilTokensCache,
+		},
+		{
+			name: "NilEpochSummaryHandler",
+			args: func() *ArgElasticProcessor {
+				arguments := createMockElasticProcessorArgs()
+				arguments.EpochSummaryProc = nil
+				return arguments
+			},
+			exErr: dataindexer.ErrNilEpochSummaryHandler,
+		},
+		{
+			name: "NilRollupsHandler",
+			args: func() *ArgElasticProcessor {
+				arguments := createMockElasticProcessorArgs()
+				arguments.RollupsProc = nil
+				return arguments
+			},
+			exErr: dataindexer.ErrNilRollupsHandler,
+		},
+		{
+			name: "NilActiveAddressesHandler",
+			args: func() *ArgElasticProcessor {
+				arguments := createMockElasticProcessorArgs()
+				arguments.ActiveAddressesProc = nil
+				return arguments
+			},
+			exErr: dataindexer.ErrNilActiveAddressesHandler,
+		},
+		{
+			name: "NilContractsHandler",
+			args: func() *ArgElasticProcessor {
+				arguments := createMockElasticProcessorArgs()
+				arguments.ContractsProc = nil
+				return arguments
+			},
+			exErr: dataindexer.ErrNilContractsHandler,
+		},
 		{
 			name: "InitError",
 			args: func() *ArgElasticProcessor {
@@ -370,6 +429,56 @@ func TestElasticseachSaveTransactions(t *testing.T) {
 	require.Equal(t, localErr, err)
 }
 
+func TestElasticProcessor_PrepareBlockAndCommitBlock(t *testing.T) {
+	t.Parallel()
+
+	sentBodies := make([]string, 0)
+	arguments := createMockElasticProcessorArgs()
+	dbWriter := &mock.DatabaseWriterStub{
+		DoBulkRequestCalled: func(buff *bytes.Buffer, index string) error {
+			sentBodies = append(sentBodies, buff.String())
+			return nil
+		},
+	}
+
+	bc, _ := converters.NewBalanceConverter(18)
+	args := &transactions.ArgsTransactionProcessor{
+		AddressPubkeyConverter: mock.NewPubkeyConverterMock(32),
+		Hasher:                 &mock.HasherMock{},
+		Marshalizer:            &mock.MarshalizerMock{},
+		BalanceConverter:       bc,
+	}
+	arguments.TransactionsProc, _ = transactions.NewTransactionsProcessor(args)
+
+	outportBlock := createEmptyOutportBlockWithHeader()
+	outportBlock.Header = &dataBlock.Header{Nonce: 5, Round: 6, TimeStamp: 7, ShardID: 1, TxCount: 1}
+	outportBlock.BlockData.HeaderHash = []byte("hash")
+	outportBlock.BlockData.Body = newTestBlockBody()
+	outportBlock.TransactionPool.Transactions = map[string]*outport.TxInfo{
+		hex.EncodeToString([]byte("tx1")): {Transaction: &transaction.Transaction{}, FeeInfo: &outport.FeeInfo{}},
+	}
+
+	elasticProc := newElasticsearchProcessor(dbWriter, arguments)
+	preparedBlock, err := elasticProc.PrepareBlock(outportBlock)
+	require.Nil(t, err)
+	require.Empty(t, sentBodies)
+	require.Equal(t, uint32(1), preparedBlock.ShardID)
+	require.Equal(t, uint64(5), preparedBlock.Nonce)
+	require.Equal(t, uint64(6), preparedBlock.Round)
+	require.Equal(t, uint64(7), preparedBlock.Timestamp)
+	require.Equal(t, []byte("hash"), preparedBlock.HeaderHash)
+	require.NotEmpty(t, preparedBlock.Buffers)
+
+	err = elasticProc.CommitBlock(preparedBlock)
+	require.Nil(t, err)
+	require.Len(t, sentBodies, len(preparedBlock.Buffers))
+
+	allSent := strings.Join(sentBodies, "")
+	require.Contains(t, allSent, `"_index":"blocks"`)
+	require.Contains(t, allSent, `"_index":"miniblocks"`)
+	require.Contains(t, allSent, `"_index":"transactions"`)
+}
+
 func TestElasticProcessor_SaveValidatorsRating(t *testing.T) {
 	localErr := errors.New("localErr")
 
@@ -391,6 +500,79 @@ func TestElasticProcessor_SaveValidatorsRating(t *testing.T) {
 	require.Equal(t, localErr, err)
 }
 
+func TestElasticProcessor_SaveValidatorsRatingLoadsTheEpochStartBlock(t *testing.T) {
+	t.Parallel()
+
+	numSearches := 0
+	bulkBodies := make(map[string]string)
+	arguments := createMockElasticProcessorArgs()
+	arguments.EnabledIndexes[dataindexer.RatingHistoryIndex] = struct{}{}
+	arguments.DBClient = &mock.DatabaseWriterStub{
+		DoSearchRequestCalled: func(index string, body []byte, response interface{}) error {
+			numSearches++
+			require.Equal(t, dataindexer.BlockIndex, index)
+			require.Contains(t, string(body), `{"term":{"epoch":3}}`)
+			return json.Unmarshal([]byte(`{"hits":{"hits":[{"_id":"hash","_source":{"epoch":3,"timestamp":5040}}]}}`), response)
+		},
+		DoBulkRequestCalled: func(buff *bytes.Buffer, index string) error {
+			bulkBodies[index] += buff.String()
+			return nil
+		},
+	}
+	arguments.ValidatorsProc, _ = validators.NewValidatorsProcessor(mock.NewPubkeyConverterMock(32), 0)
+	elasticProc, _ := NewElasticProcessor(arguments)
+
+	ratingData := &outport.ValidatorsRating{
+		Epoch:                3,
+		ValidatorsRatingInfo: []*outport.ValidatorRatingInfo{{PublicKey: "6b30", Rating: 50}},
+	}
+	err := elasticProc.SaveValidatorsRating(ratingData)
+	require.Nil(t, err)
+	require.Contains(t, bulkBodies[dataindexer.RatingHistoryIndex], `"_id" : "6b30_3"`)
+	require.Contains(t, bulkBodies[dataindexer.RatingHistoryIndex], `"timestamp":5040`)
+
+	// the timestamp is kept in memory
+	err = elasticProc.SaveValidatorsRating(ratingData)
+	require.Nil(t, err)
+	require.Equal(t, 1, numSearches)
+}
+
+func TestElasticProcessor_SaveRoundsInfoLoadsMissingValidatorsPubKeys(t *testing.T) {
+	t.Parallel()
+
+	requestedIDs := make([]string, 0)
+	bulkBodies := make(map[string]string)
+	arguments := createMockElasticProcessorArgs()
+	arguments.EnabledIndexes[dataindexer.ValidatorStatsIndex] = struct{}{}
+	arguments.DBClient = &mock.DatabaseWriterStub{
+		DoMultiGetCalled: func(ids []string, index string, withSource bool, response interface{}) error {
+			require.Equal(t, dataindexer.ValidatorsIndex, index)
+			requestedIDs = append(requestedIDs, ids...)
+			return json.Unmarshal([]byte(`{"docs":[{"_id":"0_2","found":true,"_source":{"publicKeys":["6b30","6b31"]}}]}`), response)
+		},
+		DoBulkRequestCalled: func(buff *bytes.Buffer, index string) error {
+			bulkBodies[index] += buff.String()
+			return nil
+		},
+	}
+	elasticProc, _ := NewElasticProcessor(arguments)
+
+	rounds := &outport.RoundsInfo{
+		RoundsInfo: []*outport.RoundInfo{{Round: 10, SignersIndexes: []uint64{1, 0}, BlockWasProposed: true, Epoch: 2}},
+	}
+	err := elasticProc.SaveRoundsInfo(rounds)
+	require.Nil(t, err)
+	require.Equal(t, []string{"0_2"}, requestedIDs)
+	require.Contains(t, bulkBodies[dataindexer.ValidatorStatsIndex], `"_id" : "6b31_2"`)
+	require.Contains(t, bulkBodies[dataindexer.ValidatorStatsIndex], `"publicKey":"6b31","shardID":0,"epoch":2,"blocksProposed":1`)
+	require.Contains(t, bulkBodies[dataindexer.RoundsIndex], `"_id" : "0_10"`)
+
+	// the public keys are kept in memory
+	err = elasticProc.SaveRoundsInfo(rounds)
+	require.Nil(t, err)
+	require.Equal(t, []string{"0_2"}, requestedIDs)
+}
+
 func TestElasticProcessor_SaveMiniblocks(t *testing.T) {
 	localErr := errors.New("localErr")
 
@@ -592,3 +774,24 @@ func TestElasticProcessor_IndexAlteredAccounts(t *testing.T) {
 	require.Nil(t, err)
 	require.True(t, called)
 }
+
+func TestElasticProcessor_SetIndexEnabled(t *testing.T) {
+	t.Parallel()
+
+	arguments := createMockElasticProcessorArgs()
+	arguments.EnabledIndexes = map[string]struct{}{dataindexer.BlockIndex: {}}
+	elasticSearchProc := newElasticsearchProcessor(&mock.DatabaseWriterStub{}, arguments)
+
+	err := elasticSearchProc.SetIndexEnabled("unknown", true)
+	require.True(t, errors.Is(err, indexerCore.ErrUnknownIndex))
+
+	err = elasticSearchProc.SetIndexEnabled(dataindexer.TransactionsIndex, true)
+	require.Nil(t, err)
+	require.True(t, elasticSearchProc.isIndexEnabled(dataindexer.TransactionsIndex))
+	require.Equal(t, []string{dataindexer.BlockIndex, dataindexer.TransactionsIndex}, elasticSearchProc.GetEnabledIndexes())
+
+	err = elasticSearchProc.SetIndexEnabled(dataindexer.BlockIndex, false)
+	require.Nil(t, err)
+	require.False(t, elasticSearchProc.isIndexEnabled(dataindexer.BlockIndex))
+	require.Equal(t, []string{dataindexer.TransactionsIndex}, elasticSearchProc.GetEnabledIndexes())
+}
@@ -0,0 +1,291 @@
+package epochsummary
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	coreData "github.com/multiversx/mx-chain-core-go/data"
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/blockshistory"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/hyperloglog"
+	logger "github.com/multiversx/mx-chain-logger-go"
+)
+
+const epochSummaryIDFormat = "%d_%d"
+
+var log = logger.GetOrCreate("indexer/process/epochsummary")
+
+// ArgsAddBlock holds the data of a block which is added in the epoch summary of its shard. The prepared results and the
+// logs data are nil for the blocks without miniblocks
+type ArgsAddBlock struct {
+	Header          coreData.HeaderHandler
+	HeaderHash      []byte
+	PreparedResults *data.PreparedResults
+	LogsData        *data.PreparedLogsResults
+	NewAccounts     []string
+}
+
+type shardEpochState struct {
+	summary         data.EpochSummary
+	accumulatedFees *big.Int
+	developerFees   *big.Int
+	activeAccounts  *hyperloglog.Sketch
+}
+
+type blockSnapshot struct {
+	previous    *shardEpochState
+	newAccounts []string
+}
+
+type epochSummaryProcessor struct {
+	mutex   sync.Mutex
+	states  map[uint32]*shardEpochState
+	history blocksHistoryHandler
+}
+
+// NewEpochSummaryProcessor will create a new instance of epochSummaryProcessor. It accumulates in memory the activity
+// of the current epoch of each shard, and keeps the state before each of the last blocks, so they can be reverted
+func NewEpochSummaryProcessor() *epochSummaryProcessor {
+	return &epochSummaryProcessor{
+		states:  make(map[uint32]*shardEpochState),
+		history: blockshistory.NewBlocksHistory(),
+	}
+}
+
+// GetMissingSummaryID returns the ID of the summary document of the block epoch, if the summary is not in memory. It
+// should be loaded from the database with PutSummaryFromResponse before the block is added, e.g. after a restart
+func (esp *epochSummaryProcessor) GetMissingSummaryID(header coreData.HeaderHandler) (string, bool) {
+	esp.mutex.Lock()
+	defer esp.mutex.Unlock()
+
+	state, found := esp.states[header.GetShardID()]
+	if found && state.summary.Epoch == header.GetEpoch() {
+		return "", false
+	}
+
+	return fmt.Sprintf(epochSummaryIDFormat, header.GetEpoch(), header.GetShardID()), true
+}
+
+// PutSummaryFromResponse will load in memory the summaries found in the provided multi get response
+func (esp *epochSummaryProcessor) PutSummaryFromResponse(response *data.ResponseMultiGet) error {
+	esp.mutex.Lock()
+	defer esp.mutex.Unlock()
+
+	for _, doc := range response.Docs {
+		if !doc.Found {
+			continue
+		}
+
+		state, err := stateFromSource(doc.Source)
+		if err != nil {
+			return fmt.Errorf("%w when loading the epoch summary %s", err, doc.ID)
+		}
+
+		esp.states[state.summary.ShardID] = state
+		esp.history.Clear(state.summary.ShardID)
+	}
+
+	return nil
+}
+
+func stateFromSource(source json.RawMessage) (*shardEpochState, error) {
+	state := &shardEpochState{}
+	err := json.Unmarshal(source, &state.summary)
+	if err != nil {
+		return nil, err
+	}
+
+	state.accumulatedFees = stringToBigInt(state.summary.AccumulatedFees)
+	state.developerFees = stringToBigInt(state.summary.DeveloperFees)
+	state.activeAccounts, err = hyperloglog.DecodeSketch(state.summary.ActiveAccountsSketch)
+
+	return state, err
+}
+
+func stringToBigInt(value string) *big.Int {
+	result, ok := big.NewInt(0).SetString(value, 10)
+	if !ok {
+		return big.NewInt(0)
+	}
+
+	return result
+}
+
+// GetNewAccountsCandidates returns the provided addresses, without the ones which were already counted as new accounts
+// by the recent blocks of the shard. The recent blocks might not be saved yet in the database
+func (esp *epochSummaryProcessor) GetNewAccountsCandidates(shardID uint32, addresses []string) []string {
+	esp.mutex.Lock()
+	defer esp.mutex.Unlock()
+
+	counted := make(map[string]struct{})
+	for _, value := range esp.history.Values(shardID) {
+		for _, address := range value.(*blockSnapshot).newAccounts {
+			counted[address] = struct{}{}
+		}
+	}
+
+	candidates := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		_, alreadyCounted := counted[address]
+		if !alreadyCounted {
+			candidates = append(candidates, address)
+		}
+	}
+
+	return candidates
+}
+
+// AddBlock will add the activity of the provided block in the summary of its shard and epoch, and returns the updated
+// summary. A nil summary is returned if the block was already added
+func (esp *epochSummaryProcessor) AddBlock(args *ArgsAddBlock) *data.EpochSummary {
+	esp.mutex.Lock()
+	defer esp.mutex.Unlock()
+
+	shardID := args.Header.GetShardID()
+	previous := esp.states[shardID]
+	isSameEpoch := previous != nil && previous.summary.Epoch == args.Header.GetEpoch()
+	if isSameEpoch && args.Header.GetNonce() <= previous.summary.LastNonce {
+		log.Debug("epochSummaryProcessor.AddBlock: block already added", "shard", shardID, "nonce", args.Header.GetNonce())
+		return nil
+	}
+
+	var state *shardEpochState
+	if isSameEpoch {
+		state = previous.clone()
+	} else {
+		state = newShardEpochState(args.Header)
+	}
+	state.addBlock(args)
+
+	esp.history.Add(shardID, args.HeaderHash, &blockSnapshot{
+		previous:    previous,
+		newAccounts: args.NewAccounts,
+	})
+	esp.states[shardID] = state
+
+	return state.toSummary()
+}
+
+func newShardEpochState(header coreData.HeaderHandler) *shardEpochState {
+	return &shardEpochState{
+		summary: data.EpochSummary{
+			Epoch:          header.GetEpoch(),
+			ShardID:        header.GetShardID(),
+			FirstNonce:     header.GetNonce(),
+			StartTimestamp: time.Duration(header.GetTimeStamp()),
+		},
+		accumulatedFees: big.NewInt(0),
+		developerFees:   big.NewInt(0),
+		activeAccounts:  hyperloglog.NewSketch(),
+	}
+}
+
+func (state *shardEpochState) clone() *shardEpochState {
+	return &shardEpochState{
+		summary:         state.summary,
+		accumulatedFees: big.NewInt(0).Set(state.accumulatedFees),
+		developerFees:   big.NewInt(0).Set(state.developerFees),
+		activeAccounts:  state.activeAccounts.Clone(),
+	}
+}
+
+func (state *shardEpochState) addBlock(args *ArgsAddBlock) {
+	header := args.Header
+	summary := &state.summary
+	summary.LastNonce = header.GetNonce()
+	summary.LastTimestamp = time.Duration(header.GetTimeStamp())
+	summary.NumBlocks++
+	summary.NewAccounts += uint64(len(args.NewAccounts))
+
+	if header.GetAccumulatedFees() != nil {
+		state.accumulatedFees.Add(state.accumulatedFees, header.GetAccumulatedFees())
+	}
+	if header.GetDeveloperFees() != nil {
+		state.developerFees.Add(state.developerFees, header.GetDeveloperFees())
+	}
+
+	if args.PreparedResults != nil {
+		for _, tx := range args.PreparedResults.Transactions {
+			if tx.SenderShard != header.GetShardID() {
+				continue
+			}
+
+			summary.NumTransactions++
+			summary.GasUsed += tx.GasUsed
+			state.activeAccounts.Add(tx.Sender)
+		}
+		for _, scr := range args.PreparedResults.ScResults {
+			if scr.SenderShard == header.GetShardID() {
+				summary.NumScResults++
+			}
+		}
+	}
+
+	if args.LogsData != nil {
+		summary.NumEvents += uint64(len(args.LogsData.DBEvents))
+		for _, tokenInfo := range args.LogsData.TokensInfo {
+			if tokenInfo.NewToken {
+				summary.NewTokens++
+			}
+		}
+		if !check.IfNil(args.LogsData.Tokens) {
+			summary.NFTMints += uint64(args.LogsData.Tokens.Len())
+		}
+	}
+}
+
+func (state *shardEpochState) toSummary() *data.EpochSummary {
+	summary := state.summary
+	summary.AccumulatedFees = state.accumulatedFees.String()
+	summary.DeveloperFees = state.developerFees.String()
+	summary.ProtocolFees = big.NewInt(0).Sub(state.accumulatedFees, state.developerFees).String()
+	summary.ActiveAccounts = state.activeAccounts.Count()
+	summary.ActiveAccountsSketch = state.activeAccounts.Encode()
+
+	return &summary
+}
+
+// RevertBlock will restore the summary of the shard from before the provided block. It returns the summary which has
+// to be saved, or the ID of the summary which has to be removed, if the reverted block started the summary of its epoch.
+// If the block is not one of the last blocks of the shard, e.g. it was added before a restart, the summary is not
+// adjusted, so it keeps the reverted block and the block which replaces it, having the same nonce, is not added.
+// Otherwise, the reverted block would be counted twice
+func (esp *epochSummaryProcessor) RevertBlock(header coreData.HeaderHandler, headerHash []byte) (*data.EpochSummary, string) {
+	esp.mutex.Lock()
+	defer esp.mutex.Unlock()
+
+	shardID := header.GetShardID()
+	value, found := esp.history.RemoveLast(shardID, headerHash)
+	if !found {
+		log.Warn("epochSummaryProcessor.RevertBlock: the reverted block is not in the recent blocks, the summary will not be adjusted",
+			"shard", shardID, "epoch", header.GetEpoch(), "nonce", header.GetNonce())
+		return nil, ""
+	}
+
+	snapshot := value.(*blockSnapshot)
+
+	revertedID := fmt.Sprintf(epochSummaryIDFormat, header.GetEpoch(), shardID)
+	if snapshot.previous == nil {
+		delete(esp.states, shardID)
+		return nil, revertedID
+	}
+
+	esp.states[shardID] = snapshot.previous
+	if snapshot.previous.summary.Epoch != header.GetEpoch() {
+		return nil, revertedID
+	}
+
+	return snapshot.previous.toSummary(), ""
+}
+
+// SerializeEpochSummary will serialize the provided summary, replacing the previous document of its shard and epoch
+func (esp *epochSummaryProcessor) SerializeEpochSummary(summary *data.EpochSummary, buffSlice *data.BufferSlice, index string) error {
+	meta := data.NewMetaTemplate(`{ "index" : { "_index":"%s", "_id" : "%s" } }`, index)
+	id := fmt.Sprintf(epochSummaryIDFormat, summary.Epoch, summary.ShardID)
+
+	return buffSlice.PutDocument(meta, id, summary)
+}
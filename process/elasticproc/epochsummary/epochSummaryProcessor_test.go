@@ -0,0 +1,204 @@
+package epochsummary
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func createArgsAddBlock(epoch uint32, nonce uint64, senders ...string) *ArgsAddBlock {
+	txs := make([]*data.Transaction, 0, len(senders))
+	for _, sender := range senders {
+		txs = append(txs, &data.Transaction{
+			Sender:      sender,
+			SenderShard: 1,
+			GasUsed:     100,
+		})
+	}
+
+	return &ArgsAddBlock{
+		Header: &block.Header{
+			ShardID:         1,
+			Epoch:           epoch,
+			Nonce:           nonce,
+			TimeStamp:       1000 + nonce*6,
+			AccumulatedFees: big.NewInt(10),
+			DeveloperFees:   big.NewInt(3),
+		},
+		HeaderHash: []byte{byte(epoch), byte(nonce)},
+		PreparedResults: &data.PreparedResults{
+			Transactions: txs,
+			ScResults: []*data.ScResult{
+				{SenderShard: 1},
+				{SenderShard: 2},
+			},
+		},
+	}
+}
+
+func TestEpochSummaryProcessor_AddBlock(t *testing.T) {
+	t.Parallel()
+
+	esp := NewEpochSummaryProcessor()
+
+	crossShardTx := &data.Transaction{Sender: "erd1c", SenderShard: 0, GasUsed: 500}
+	args := createArgsAddBlock(5, 10, "erd1a", "erd1b")
+	args.PreparedResults.Transactions = append(args.PreparedResults.Transactions, crossShardTx)
+	args.NewAccounts = []string{"erd1b"}
+	args.LogsData = &data.PreparedLogsResults{
+		DBEvents:   []*data.LogEvent{{}, {}},
+		TokensInfo: []*data.TokenInfo{{Token: "TKN-01", NewToken: true}, {Token: "TKN-02", TransferOwnership: true}},
+		Tokens:     data.NewTokensInfo(),
+	}
+	args.LogsData.Tokens.Add(&data.TokenInfo{Identifier: "NFT-01-01"})
+	summary := esp.AddBlock(args)
+	require.NotEmpty(t, summary.ActiveAccountsSketch)
+	summary.ActiveAccountsSketch = ""
+	require.Equal(t, &data.EpochSummary{
+		Epoch:           5,
+		ShardID:         1,
+		FirstNonce:      10,
+		LastNonce:       10,
+		StartTimestamp:  1060,
+		LastTimestamp:   1060,
+		NumBlocks:       1,
+		NumTransactions: 2,
+		NumScResults:    1,
+		NumEvents:       2,
+		GasUsed:         200,
+		AccumulatedFees: "10",
+		DeveloperFees:   "3",
+		ProtocolFees:    "7",
+		ActiveAccounts:  2,
+		NewAccounts:     1,
+		NewTokens:       1,
+		NFTMints:        1,
+	}, summary)
+
+	// a block without miniblocks
+	summary = esp.AddBlock(&ArgsAddBlock{
+		Header: &block.Header{ShardID: 1, Epoch: 5, Nonce: 11, TimeStamp: 1066},
+	})
+	require.Equal(t, uint64(2), summary.NumBlocks)
+	require.Equal(t, uint64(11), summary.LastNonce)
+	require.Equal(t, "10", summary.AccumulatedFees)
+
+	summary = esp.AddBlock(createArgsAddBlock(5, 12, "erd1a", "erd1d"))
+	require.Equal(t, uint64(4), summary.NumTransactions)
+	require.Equal(t, uint64(3), summary.ActiveAccounts)
+	require.Equal(t, "14", summary.ProtocolFees)
+
+	require.Nil(t, esp.AddBlock(createArgsAddBlock(5, 12, "erd1a")))
+
+	summary = esp.AddBlock(createArgsAddBlock(6, 13, "erd1a"))
+	require.Equal(t, uint32(6), summary.Epoch)
+	require.Equal(t, uint64(13), summary.FirstNonce)
+	require.Equal(t, uint64(1), summary.NumBlocks)
+	require.Equal(t, uint64(1), summary.ActiveAccounts)
+}
+
+func TestEpochSummaryProcessor_LoadFromResponse(t *testing.T) {
+	t.Parallel()
+
+	esp := NewEpochSummaryProcessor()
+	header := &block.Header{ShardID: 1, Epoch: 5, Nonce: 12}
+
+	id, isMissing := esp.GetMissingSummaryID(header)
+	require.True(t, isMissing)
+	require.Equal(t, "5_1", id)
+
+	// the summary saved before a restart
+	previous := NewEpochSummaryProcessor()
+	previous.AddBlock(createArgsAddBlock(5, 10, "erd1a"))
+	savedSummary := previous.AddBlock(createArgsAddBlock(5, 11, "erd1b"))
+	source, _ := json.Marshal(savedSummary)
+
+	response := &data.ResponseMultiGet{}
+	response.Docs = append(response.Docs, struct {
+		Found  bool            `json:"found"`
+		ID     string          `json:"_id"`
+		Source json.RawMessage `json:"_source"`
+	}{Found: true, ID: id, Source: source})
+	err := esp.PutSummaryFromResponse(response)
+	require.Nil(t, err)
+
+	_, isMissing = esp.GetMissingSummaryID(header)
+	require.False(t, isMissing)
+
+	require.Nil(t, esp.AddBlock(createArgsAddBlock(5, 11, "erd1c")))
+	summary := esp.AddBlock(createArgsAddBlock(5, 12, "erd1a", "erd1c"))
+	require.Equal(t, uint64(10), summary.FirstNonce)
+	require.Equal(t, uint64(3), summary.NumBlocks)
+	require.Equal(t, uint64(4), summary.NumTransactions)
+	require.Equal(t, uint64(3), summary.ActiveAccounts)
+	require.Equal(t, "30", summary.AccumulatedFees)
+
+	response.Docs[0].Source = []byte(`{"shardID": 1, "activeAccountsSketch": "invalid"}`)
+	err = esp.PutSummaryFromResponse(response)
+	require.NotNil(t, err)
+}
+
+func TestEpochSummaryProcessor_RevertBlock(t *testing.T) {
+	t.Parallel()
+
+	esp := NewEpochSummaryProcessor()
+	first := createArgsAddBlock(5, 10, "erd1a")
+	second := createArgsAddBlock(5, 11, "erd1b")
+	second.NewAccounts = []string{"erd1b"}
+	esp.AddBlock(first)
+	esp.AddBlock(second)
+	require.Equal(t, []string{"erd1c"}, esp.GetNewAccountsCandidates(1, []string{"erd1b", "erd1c"}))
+
+	summary, idToRemove := esp.RevertBlock(second.Header, second.HeaderHash)
+	require.Empty(t, idToRemove)
+	require.Equal(t, uint64(1), summary.NumBlocks)
+	require.Equal(t, uint64(10), summary.LastNonce)
+	require.Equal(t, uint64(1), summary.ActiveAccounts)
+	require.Equal(t, []string{"erd1b"}, esp.GetNewAccountsCandidates(1, []string{"erd1b"}))
+
+	summary = esp.AddBlock(createArgsAddBlock(5, 11, "erd1c"))
+	require.Equal(t, uint64(2), summary.NumBlocks)
+
+	// the first block of the epoch starts the summary, so its revert removes it
+	newEpoch := createArgsAddBlock(6, 12, "erd1a")
+	esp.AddBlock(newEpoch)
+	summary, idToRemove = esp.RevertBlock(newEpoch.Header, newEpoch.HeaderHash)
+	require.Nil(t, summary)
+	require.Equal(t, "6_1", idToRemove)
+	_, isMissing := esp.GetMissingSummaryID(first.Header)
+	require.False(t, isMissing)
+}
+
+func TestEpochSummaryProcessor_RevertUnknownBlock(t *testing.T) {
+	t.Parallel()
+
+	esp := NewEpochSummaryProcessor()
+	args := createArgsAddBlock(5, 10, "erd1a")
+	esp.AddBlock(args)
+
+	summary, idToRemove := esp.RevertBlock(args.Header, []byte("other hash"))
+	require.Nil(t, summary)
+	require.Empty(t, idToRemove)
+
+	// the summary keeps the reverted block, so the block which replaces it is not counted twice
+	summary = esp.AddBlock(createArgsAddBlock(5, 10, "erd1b"))
+	require.Nil(t, summary)
+}
+
+func TestEpochSummaryProcessor_SerializeEpochSummary(t *testing.T) {
+	t.Parallel()
+
+	esp := NewEpochSummaryProcessor()
+	buffSlice := data.NewBufferSlice(0)
+	err := esp.SerializeEpochSummary(&data.EpochSummary{Epoch: 5, ShardID: 1, NumBlocks: 2}, buffSlice, "epochsummary")
+	require.Nil(t, err)
+
+	expected := `{ "index" : { "_index":"epochsummary", "_id" : "5_1" } }
+{"epoch":5,"shardID":1,"firstNonce":0,"lastNonce":0,"startTimestamp":0,"lastTimestamp":0,"numBlocks":2,"numTransactions":0,"numScResults":0,"numEvents":0,"gasUsed":0,"accumulatedFees":"","developerFees":"","protocolFees":"","activeAccounts":0,"newAccounts":0,"newTokens":0,"nftMints":0,"activeAccountsSketch":""}
+`
+	require.Equal(t, expected, buffSlice.Buffers()[0].String())
+}
@@ -0,0 +1,8 @@
+package epochsummary
+
+type blocksHistoryHandler interface {
+	Add(shardID uint32, headerHash []byte, value interface{})
+	RemoveLast(shardID uint32, headerHash []byte) (interface{}, bool)
+	Values(shardID uint32) []interface{}
+	Clear(shardID uint32)
+}
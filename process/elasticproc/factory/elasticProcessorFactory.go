@@ -4,33 +4,41 @@ import (
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/hashing"
 	"github.com/multiversx/mx-chain-core-go/marshal"
+	indexerCore "github.com/multiversx/mx-chain-es-indexer-go/core"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/dataindexer"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/accounts"
 	blockProc "github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/block"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/converters"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/epochsummary"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/logsevents"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/miniblocks"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/operations"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/rollups"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/statistics"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/templatesAndPolicies"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/tokenscache"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/transactions"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/validators"
 )
 
 // ArgElasticProcessorFactory is struct that is used to store all components that are needed to create an elastic processor factory
 type ArgElasticProcessorFactory struct {
-	Marshalizer              marshal.Marshalizer
-	Hasher                   hashing.Hasher
-	AddressPubkeyConverter   core.PubkeyConverter
-	ValidatorPubkeyConverter core.PubkeyConverter
-	DBClient                 elasticproc.DatabaseClientHandler
-	EnabledIndexes           []string
-	Version                  string
-	Denomination             int
-	BulkRequestMaxSize       int
-	UseKibana                bool
-	ImportDB                 bool
+	Marshalizer               marshal.Marshalizer
+	Hasher                    hashing.Hasher
+	AddressPubkeyConverter    core.PubkeyConverter
+	ValidatorPubkeyConverter  core.PubkeyConverter
+	DBClient                  elasticproc.DatabaseClientHandler
+	EnabledIndexes            []string
+	Version                   string
+	Denomination              int
+	BulkRequestMaxSize        int
+	NumConcurrentBulkRequests int
+	TokensCacheCapacity       int
+	UseKibana                 bool
+	ImportDB                  bool
+	ImportDBIndexSettings     elasticproc.ImportDBIndexSettings
+	StatusMetrics             indexerCore.StatusMetricsHandler
 }
 
 // CreateElasticProcessor will create a new instance of ElasticProcessor
@@ -98,6 +106,7 @@ func CreateElasticProcessor(arguments ArgElasticProcessorFactory) (dataindexer.E
 		Marshalizer:      arguments.Marshalizer,
 		BalanceConverter: balanceConverter,
 		Hasher:           arguments.Hasher,
+		StatusMetrics:    arguments.StatusMetrics,
 	}
 	logsAndEventsProc, err := logsevents.NewLogsAndEventsProcessor(argsLogsAndEventsProc)
 	if err != nil {
@@ -109,24 +118,46 @@ func CreateElasticProcessor(arguments ArgElasticProcessorFactory) (dataindexer.E
 		return nil, err
 	}
 
+	activeAddressesProc, err := rollups.NewActiveAddressesProcessor(arguments.AddressPubkeyConverter)
+	if err != nil {
+		return nil, err
+	}
+
+	contractsProc, err := rollups.NewContractsProcessor(arguments.AddressPubkeyConverter)
+	if err != nil {
+		return nil, err
+	}
+
+	tokensCache, err := tokenscache.NewTokensCache(arguments.TokensCacheCapacity)
+	if err != nil {
+		return nil, err
+	}
+
 	args := &elasticproc.ArgElasticProcessor{
-		BulkRequestMaxSize: arguments.BulkRequestMaxSize,
-		TransactionsProc:   txsProc,
-		AccountsProc:       accountsProc,
-		BlockProc:          blockProcHandler,
-		MiniblocksProc:     miniblocksProc,
-		ValidatorsProc:     validatorsProc,
-		StatisticsProc:     generalInfoProc,
-		LogsAndEventsProc:  logsAndEventsProc,
-		DBClient:           arguments.DBClient,
-		EnabledIndexes:     enabledIndexesMap,
-		UseKibana:          arguments.UseKibana,
-		IndexTemplates:     indexTemplates,
-		IndexPolicies:      indexPolicies,
-		ExtraMappings:      extraMappings,
-		OperationsProc:     operationsProc,
-		ImportDB:           arguments.ImportDB,
-		Version:            arguments.Version,
+		BulkRequestMaxSize:        arguments.BulkRequestMaxSize,
+		NumConcurrentBulkRequests: arguments.NumConcurrentBulkRequests,
+		TransactionsProc:          txsProc,
+		AccountsProc:              accountsProc,
+		BlockProc:                 blockProcHandler,
+		MiniblocksProc:            miniblocksProc,
+		ValidatorsProc:            validatorsProc,
+		StatisticsProc:            generalInfoProc,
+		LogsAndEventsProc:         logsAndEventsProc,
+		DBClient:                  arguments.DBClient,
+		EnabledIndexes:            enabledIndexesMap,
+		UseKibana:                 arguments.UseKibana,
+		IndexTemplates:            indexTemplates,
+		IndexPolicies:             indexPolicies,
+		ExtraMappings:             extraMappings,
+		OperationsProc:            operationsProc,
+		TokensCache:               tokensCache,
+		EpochSummaryProc:          epochsummary.NewEpochSummaryProcessor(),
+		RollupsProc:               rollups.NewRollupsProcessor(),
+		ActiveAddressesProc:       activeAddressesProc,
+		ContractsProc:             contractsProc,
+		ImportDB:                  arguments.ImportDB,
+		ImportDBIndexSettings:     arguments.ImportDBIndexSettings,
+		Version:                   arguments.Version,
 	}
 
 	return elasticproc.NewElasticProcessor(args)
@@ -0,0 +1,140 @@
+package hyperloglog
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/bits"
+)
+
+const (
+	precision = 12
+
+	// NumRegisters is the number of registers of a sketch
+	NumRegisters = 1 << precision
+	// Alpha is the bias correction constant for the number of registers of a sketch
+	Alpha = 0.7213 / (1 + 1.079/NumRegisters)
+)
+
+// ErrInvalidSketch signals that an encoded sketch cannot be decoded
+var ErrInvalidSketch = errors.New("invalid hyperloglog sketch")
+
+// Sketch estimates the number of distinct values added in it, using a fixed amount of memory. With 4096 registers,
+// the standard error of the estimation is about 1.6%, while the small cardinalities are counted almost exactly
+type Sketch struct {
+	registers []uint8
+}
+
+// NewSketch will create an empty sketch
+func NewSketch() *Sketch {
+	return &Sketch{
+		registers: make([]uint8, NumRegisters),
+	}
+}
+
+// DecodeSketch will create a sketch from the base64 encoding returned by Encode. An empty string is an empty sketch
+func DecodeSketch(encoded string) (*Sketch, error) {
+	if encoded == "" {
+		return NewSketch(), nil
+	}
+
+	registers, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSketch, err.Error())
+	}
+	if len(registers) != NumRegisters {
+		return nil, fmt.Errorf("%w: expected %d registers, got %d", ErrInvalidSketch, NumRegisters, len(registers))
+	}
+
+	return &Sketch{
+		registers: registers,
+	}, nil
+}
+
+// Add will add the provided value in the sketch and returns true if the estimation might have changed
+func (s *Sketch) Add(value string) bool {
+	hash := hashValue(value)
+	index := hash >> (64 - precision)
+	rank := uint8(bits.LeadingZeros64(hash<<precision|1<<(precision-1)) + 1)
+	if rank <= s.registers[index] {
+		return false
+	}
+
+	s.registers[index] = rank
+	return true
+}
+
+// Merge will add in the sketch all the values of the provided sketch
+func (s *Sketch) Merge(other *Sketch) {
+	for idx, rank := range other.registers {
+		if rank > s.registers[idx] {
+			s.registers[idx] = rank
+		}
+	}
+}
+
+// Count returns the estimated number of distinct values added in the sketch
+func (s *Sketch) Count() uint64 {
+	sum := 0.0
+	numEmptyRegisters := 0
+	for _, rank := range s.registers {
+		sum += 1.0 / float64(uint64(1)<<rank)
+		if rank == 0 {
+			numEmptyRegisters++
+		}
+	}
+
+	m := float64(NumRegisters)
+	estimate := Alpha * m * m / sum
+	if estimate <= 2.5*m && numEmptyRegisters > 0 {
+		// linear counting is more accurate for the small cardinalities
+		estimate = m * math.Log(m/float64(numEmptyRegisters))
+	}
+
+	return uint64(math.Round(estimate))
+}
+
+// NonEmptyRegisters returns the values of the registers which are not empty, by their index. It is used to merge the
+// sketch in the sketches saved in the database, by keeping the highest value of each register
+func (s *Sketch) NonEmptyRegisters() map[uint32]uint8 {
+	registers := make(map[uint32]uint8)
+	for idx, rank := range s.registers {
+		if rank > 0 {
+			registers[uint32(idx)] = rank
+		}
+	}
+
+	return registers
+}
+
+// Clone returns a copy of the sketch
+func (s *Sketch) Clone() *Sketch {
+	registers := make([]uint8, NumRegisters)
+	copy(registers, s.registers)
+
+	return &Sketch{
+		registers: registers,
+	}
+}
+
+// Encode returns the base64 encoding of the sketch registers
+func (s *Sketch) Encode() string {
+	return base64.StdEncoding.EncodeToString(s.registers)
+}
+
+func hashValue(value string) uint64 {
+	hasher := fnv.New64a()
+	_, _ = hasher.Write([]byte(value))
+
+	// the bits of the fnv hash are mixed, since the registers are selected by its most significant bits
+	hash := hasher.Sum64()
+	hash ^= hash >> 33
+	hash *= 0xff51afd7ed558ccd
+	hash ^= hash >> 33
+	hash *= 0xc4ceb9fe1a85ec53
+	hash ^= hash >> 33
+
+	return hash
+}
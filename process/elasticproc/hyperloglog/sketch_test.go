@@ -0,0 +1,75 @@
+package hyperloglog
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSketch_CountSmallCardinalitiesAlmostExactly(t *testing.T) {
+	t.Parallel()
+
+	sketch := NewSketch()
+	require.Equal(t, uint64(0), sketch.Count())
+
+	for i := 0; i < 100; i++ {
+		sketch.Add(fmt.Sprintf("erd1address%d", i))
+		sketch.Add(fmt.Sprintf("erd1address%d", i))
+	}
+	require.InDelta(t, 100, sketch.Count(), 2)
+}
+
+func TestSketch_CountLargeCardinalities(t *testing.T) {
+	t.Parallel()
+
+	sketch := NewSketch()
+	numValues := 200000
+	for i := 0; i < numValues; i++ {
+		sketch.Add(fmt.Sprintf("erd1address%d", i))
+	}
+
+	relativeError := math.Abs(float64(sketch.Count())-float64(numValues)) / float64(numValues)
+	require.Less(t, relativeError, 0.05)
+}
+
+func TestSketch_MergeAndClone(t *testing.T) {
+	t.Parallel()
+
+	first := NewSketch()
+	second := NewSketch()
+	for i := 0; i < 50; i++ {
+		first.Add(fmt.Sprintf("a%d", i))
+		second.Add(fmt.Sprintf("b%d", i))
+	}
+
+	require.Len(t, second.NonEmptyRegisters(), 50)
+
+	clone := first.Clone()
+	first.Merge(second)
+	require.InDelta(t, 100, first.Count(), 2)
+	require.InDelta(t, 50, clone.Count(), 1)
+}
+
+func TestSketch_EncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	sketch := NewSketch()
+	require.True(t, sketch.Add("erd1a"))
+	require.False(t, sketch.Add("erd1a"))
+
+	decoded, err := DecodeSketch(sketch.Encode())
+	require.Nil(t, err)
+	require.Equal(t, sketch, decoded)
+
+	empty, err := DecodeSketch("")
+	require.Nil(t, err)
+	require.Equal(t, uint64(0), empty.Count())
+
+	_, err = DecodeSketch("AAAA")
+	require.ErrorIs(t, err, ErrInvalidSketch)
+
+	_, err = DecodeSketch("not base64")
+	require.ErrorIs(t, err, ErrInvalidSketch)
+}
@@ -0,0 +1,185 @@
+package elasticproc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+)
+
+// ImportDBIndexSettings holds the settings that are applied to the indices while the node runs in import-db mode. The
+// previous settings are saved in the original settings file, so they are restored even if the indexer is restarted
+// before the import ends
+type ImportDBIndexSettings struct {
+	Enabled              bool
+	RefreshInterval      string
+	NumberOfReplicas     int
+	OriginalSettingsFile string
+}
+
+type settingsResponse map[string]struct {
+	Settings struct {
+		Index indexSettings `json:"index"`
+	} `json:"settings"`
+}
+
+// indexSettings holds the dynamic settings that are changed while importing. A nil value is serialized as null,
+// which resets the setting to its default value
+type indexSettings struct {
+	RefreshInterval  interface{} `json:"refresh_interval"`
+	NumberOfReplicas interface{} `json:"number_of_replicas"`
+}
+
+type indexSettingsBody struct {
+	Index indexSettings `json:"index"`
+}
+
+// applyImportDBIndexSettings will save the current settings of the enabled indices and will apply the import-db ones
+func (ei *elasticProcessor) applyImportDBIndexSettings() error {
+	if !ei.importDBIndexSettings.Enabled {
+		return nil
+	}
+
+	ei.mutSettings.Lock()
+	defer ei.mutSettings.Unlock()
+
+	importSettings, err := serializeIndexSettings(indexSettings{
+		RefreshInterval:  ei.importDBIndexSettings.RefreshInterval,
+		NumberOfReplicas: ei.importDBIndexSettings.NumberOfReplicas,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, index := range ei.GetEnabledIndexes() {
+		_, alreadyApplied := ei.originalIndexSettings[index]
+		if alreadyApplied {
+			continue
+		}
+
+		response := make(settingsResponse)
+		err = ei.elasticClient.GetSettings(index, &response)
+		if err != nil {
+			return err
+		}
+
+		// the previous settings are saved before they are changed, so they are not lost if the indexer stops
+		original := getSettingsOfFirstIndex(response)
+		ei.originalIndexSettings[index] = original
+		err = saveOriginalIndexSettings(ei.importDBIndexSettings.OriginalSettingsFile, ei.originalIndexSettings)
+		if err != nil {
+			return err
+		}
+
+		err = ei.elasticClient.PutSettings(index, bytes.NewBuffer(importSettings))
+		if err != nil {
+			return err
+		}
+
+		log.Info("applied the import-db index settings", "index", index,
+			"previous refresh interval", original.RefreshInterval,
+			"previous number of replicas", original.NumberOfReplicas,
+		)
+	}
+
+	return nil
+}
+
+// restoreIndexSettings will restore the settings of the indices changed by applyImportDBIndexSettings, including the
+// ones changed before a restart
+func (ei *elasticProcessor) restoreIndexSettings() error {
+	ei.mutSettings.Lock()
+	defer ei.mutSettings.Unlock()
+
+	for index, original := range ei.originalIndexSettings {
+		settings, err := serializeIndexSettings(original)
+		if err != nil {
+			return err
+		}
+
+		err = ei.elasticClient.PutSettings(index, bytes.NewBuffer(settings))
+		if err != nil {
+			return err
+		}
+
+		delete(ei.originalIndexSettings, index)
+		err = saveOriginalIndexSettings(ei.importDBIndexSettings.OriginalSettingsFile, ei.originalIndexSettings)
+		if err != nil {
+			return err
+		}
+		log.Info("restored the index settings", "index", index)
+	}
+
+	return nil
+}
+
+// loadOriginalIndexSettings returns the settings saved before the import-db ones were applied, if the indexer was
+// stopped before they were restored
+func loadOriginalIndexSettings(filePath string) (map[string]indexSettings, error) {
+	originalSettings := make(map[string]indexSettings)
+	if filePath == "" {
+		return originalSettings, nil
+	}
+
+	content, err := os.ReadFile(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return originalSettings, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(content, &originalSettings)
+	if err != nil {
+		return nil, err
+	}
+	if len(originalSettings) > 0 {
+		log.Info("loaded the index settings to be restored after the import-db mode", "num indices", len(originalSettings))
+	}
+
+	return originalSettings, nil
+}
+
+// saveOriginalIndexSettings will write the settings to be restored in the provided file, or will remove the file when
+// all of them were restored
+func saveOriginalIndexSettings(filePath string, originalSettings map[string]indexSettings) error {
+	if filePath == "" {
+		return nil
+	}
+
+	if len(originalSettings) == 0 {
+		err := os.Remove(filePath)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		return err
+	}
+
+	content, err := json.Marshal(originalSettings)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filePath, content, 0644)
+}
+
+// getSettingsOfFirstIndex returns the settings of the first index behind an alias, the indices created by the indexer
+// behind the same alias have the same settings
+func getSettingsOfFirstIndex(response settingsResponse) indexSettings {
+	names := make([]string, 0, len(response))
+	for name := range response {
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return indexSettings{}
+	}
+	sort.Strings(names)
+
+	return response[names[0]].Settings.Index
+}
+
+func serializeIndexSettings(settings indexSettings) ([]byte, error) {
+	return json.Marshal(indexSettingsBody{Index: settings})
+}
@@ -0,0 +1,119 @@
+package elasticproc
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-es-indexer-go/mock"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/dataindexer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElasticProcessor_SetOutportConfigShouldApplyAndRestoreImportDBIndexSettings(t *testing.T) {
+	t.Parallel()
+
+	putSettings := make(map[string][]string)
+	dbWriter := &mock.DatabaseWriterStub{
+		GetSettingsCalled: func(index string, response interface{}) error {
+			// the number of replicas is set on the index, the refresh interval has the default value
+			body := `{"` + index + `-000001":{"settings":{"index":{"number_of_replicas":"1"}}}}`
+			return json.Unmarshal([]byte(body), response)
+		},
+		PutSettingsCalled: func(index string, settings *bytes.Buffer) error {
+			putSettings[index] = append(putSettings[index], settings.String())
+			return nil
+		},
+	}
+	arguments := createMockElasticProcessorArgs()
+	arguments.EnabledIndexes = map[string]struct{}{dataindexer.BlockIndex: {}}
+	elasticSearchProc := newElasticsearchProcessor(dbWriter, arguments)
+	elasticSearchProc.importDBIndexSettings = ImportDBIndexSettings{
+		Enabled:          true,
+		RefreshInterval:  "-1",
+		NumberOfReplicas: 0,
+	}
+	elasticSearchProc.originalIndexSettings = make(map[string]indexSettings)
+
+	err := elasticSearchProc.SetOutportConfig(outport.OutportConfig{IsInImportDBMode: true})
+	require.Nil(t, err)
+	require.Equal(t, []string{`{"index":{"refresh_interval":"-1","number_of_replicas":0}}`}, putSettings[dataindexer.BlockIndex])
+
+	// the settings are applied only once
+	err = elasticSearchProc.SetOutportConfig(outport.OutportConfig{IsInImportDBMode: true})
+	require.Nil(t, err)
+	require.Len(t, putSettings[dataindexer.BlockIndex], 1)
+
+	err = elasticSearchProc.SetOutportConfig(outport.OutportConfig{IsInImportDBMode: false})
+	require.Nil(t, err)
+	require.Equal(t, `{"index":{"refresh_interval":null,"number_of_replicas":"1"}}`, putSettings[dataindexer.BlockIndex][1])
+	require.Empty(t, elasticSearchProc.originalIndexSettings)
+}
+
+func TestElasticProcessor_SetOutportConfigWithDisabledImportDBIndexSettingsShouldNotChangeSettings(t *testing.T) {
+	t.Parallel()
+
+	dbWriter := &mock.DatabaseWriterStub{
+		PutSettingsCalled: func(index string, settings *bytes.Buffer) error {
+			require.Fail(t, "should have not been called")
+			return nil
+		},
+	}
+	arguments := createMockElasticProcessorArgs()
+	elasticSearchProc := newElasticsearchProcessor(dbWriter, arguments)
+	elasticSearchProc.originalIndexSettings = make(map[string]indexSettings)
+
+	require.Nil(t, elasticSearchProc.SetOutportConfig(outport.OutportConfig{IsInImportDBMode: true}))
+	require.Nil(t, elasticSearchProc.SetOutportConfig(outport.OutportConfig{IsInImportDBMode: false}))
+}
+
+func TestElasticProcessor_SetOutportConfigShouldRestoreTheSettingsSavedBeforeARestart(t *testing.T) {
+	t.Parallel()
+
+	settingsFile := filepath.Join(t.TempDir(), "original-settings.json")
+	putSettings := make(map[string][]string)
+	dbWriter := &mock.DatabaseWriterStub{
+		GetSettingsCalled: func(index string, response interface{}) error {
+			body := `{"` + index + `-000001":{"settings":{"index":{"refresh_interval":"5s","number_of_replicas":"1"}}}}`
+			return json.Unmarshal([]byte(body), response)
+		},
+		PutSettingsCalled: func(index string, settings *bytes.Buffer) error {
+			putSettings[index] = append(putSettings[index], settings.String())
+			return nil
+		},
+	}
+	arguments := createMockElasticProcessorArgs()
+	arguments.EnabledIndexes = map[string]struct{}{dataindexer.BlockIndex: {}}
+	importDBIndexSettings := ImportDBIndexSettings{
+		Enabled:              true,
+		RefreshInterval:      "-1",
+		NumberOfReplicas:     0,
+		OriginalSettingsFile: settingsFile,
+	}
+
+	elasticSearchProc := newElasticsearchProcessor(dbWriter, arguments)
+	elasticSearchProc.importDBIndexSettings = importDBIndexSettings
+	elasticSearchProc.originalIndexSettings = make(map[string]indexSettings)
+	require.Nil(t, elasticSearchProc.SetOutportConfig(outport.OutportConfig{IsInImportDBMode: true}))
+	require.FileExists(t, settingsFile)
+
+	// the indexer is restarted after the import ended, so the settings of the import are read back
+	originalSettings, err := loadOriginalIndexSettings(settingsFile)
+	require.Nil(t, err)
+	restartedProc := newElasticsearchProcessor(dbWriter, arguments)
+	restartedProc.importDBIndexSettings = importDBIndexSettings
+	restartedProc.originalIndexSettings = originalSettings
+
+	require.Nil(t, restartedProc.SetOutportConfig(outport.OutportConfig{IsInImportDBMode: false}))
+	require.Equal(t, []string{
+		`{"index":{"refresh_interval":"-1","number_of_replicas":0}}`,
+		`{"index":{"refresh_interval":"5s","number_of_replicas":"1"}}`,
+	}, putSettings[dataindexer.BlockIndex])
+	require.Empty(t, restartedProc.originalIndexSettings)
+
+	_, err = os.Stat(settingsFile)
+	require.True(t, os.IsNotExist(err))
+}
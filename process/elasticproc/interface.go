@@ -9,6 +9,7 @@ import (
 	"github.com/multiversx/mx-chain-core-go/data/block"
 	"github.com/multiversx/mx-chain-core-go/data/outport"
 	"github.com/multiversx/mx-chain-es-indexer-go/data"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/epochsummary"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/tokeninfo"
 )
 
@@ -19,13 +20,18 @@ type DatabaseClientHandler interface {
 	DoMultiGet(ctx context.Context, ids []string, index string, withSource bool, res interface{}) error
 	DoScrollRequest(ctx context.Context, index string, body []byte, withSource bool, handlerFunc func(responseBytes []byte) error) error
 	DoCountRequest(ctx context.Context, index string, body []byte) (uint64, error)
+	DoSearchRequest(ctx context.Context, index string, body []byte, res interface{}) error
 	UpdateByQuery(ctx context.Context, index string, buff *bytes.Buffer) error
 
 	PutMappings(indexName string, mappings *bytes.Buffer) error
+	GetMapping(index string, res interface{}) error
+	GetSettings(index string, res interface{}) error
+	PutSettings(index string, settings *bytes.Buffer) error
 	CheckAndCreateIndex(index string) error
 	CheckAndCreateAlias(alias string, index string) error
 	CheckAndCreateTemplate(templateName string, template *bytes.Buffer) error
 	CheckAndCreatePolicy(policyName string, policy *bytes.Buffer) error
+	Ping(ctx context.Context) error
 
 	IsInterfaceNil() bool
 }
@@ -84,10 +90,57 @@ type DBStatisticsHandler interface {
 	SerializeRoundsInfo(rounds *outport.RoundsInfo) *bytes.Buffer
 }
 
+// DBEpochSummaryHandler defines the actions that an epoch summary handler should do
+type DBEpochSummaryHandler interface {
+	GetMissingSummaryID(header coreData.HeaderHandler) (string, bool)
+	PutSummaryFromResponse(response *data.ResponseMultiGet) error
+	GetNewAccountsCandidates(shardID uint32, addresses []string) []string
+	AddBlock(args *epochsummary.ArgsAddBlock) *data.EpochSummary
+	RevertBlock(header coreData.HeaderHandler, headerHash []byte) (*data.EpochSummary, string)
+	SerializeEpochSummary(summary *data.EpochSummary, buffSlice *data.BufferSlice, index string) error
+}
+
+// DBRollupsHandler defines the actions that an activity rollups handler should do
+type DBRollupsHandler interface {
+	PrepareActivityStats(header coreData.HeaderHandler, headerHash []byte, preparedResults *data.PreparedResults) *data.ActivityStats
+	PrepareRevertedActivityStats(header coreData.HeaderHandler, headerHash []byte) *data.ActivityStats
+	SerializeActivityStats(stats *data.ActivityStats, periodInSeconds uint64, buffSlice *data.BufferSlice, index string) error
+}
+
+// DBActiveAddressesHandler defines the actions that an active addresses handler should do
+type DBActiveAddressesHandler interface {
+	PrepareActiveAddresses(header coreData.HeaderHandler, headerHash []byte, preparedResults *data.PreparedResults) []*data.ActiveAddresses
+	PrepareRevertedActiveAddresses(header coreData.HeaderHandler, headerHash []byte) []*data.ActiveAddresses
+	SerializeActiveAddresses(activeAddresses []*data.ActiveAddresses, buffSlice *data.BufferSlice, index string) error
+}
+
+// DBContractsHandler defines the actions that a smart contracts interactions handler should do
+type DBContractsHandler interface {
+	PrepareContractsStats(header coreData.HeaderHandler, headerHash []byte, preparedResults *data.PreparedResults, logsData *data.PreparedLogsResults) []*data.ContractStats
+	PrepareRevertedContractsStats(header coreData.HeaderHandler, headerHash []byte) []*data.ContractStats
+	SerializeContractsStats(contractsStats []*data.ContractStats, buffSlice *data.BufferSlice, index string) error
+}
+
 // DBValidatorsHandler defines the actions that a validators handler should do
 type DBValidatorsHandler interface {
 	PrepareAnSerializeValidatorsPubKeys(validatorsPubKeys *outport.ValidatorsPubKeys) ([]*bytes.Buffer, error)
 	SerializeValidatorsRating(ratingData *outport.ValidatorsRating) ([]*bytes.Buffer, error)
+	SerializeValidatorsRatingHistory(ratingData *outport.ValidatorsRating, epochStartTimestamp uint64, buffSlice *data.BufferSlice, index string) error
+	PutEpochStartHeader(header coreData.HeaderHandler)
+	GetEpochStartTimestamp(epoch uint32) (uint64, bool)
+	PutEpochStartBlockFromResponse(epoch uint32, response *data.ResponseSearch)
+
+	PutValidatorsPubKeys(validatorsPubKeys *outport.ValidatorsPubKeys)
+	GetMissingValidatorsPubKeysIDs(rounds *outport.RoundsInfo) []string
+	PutValidatorsPubKeysFromResponse(response *data.ResponseValidatorsPublicKeys)
+	SerializeValidatorStats(rounds *outport.RoundsInfo, buffSlice *data.BufferSlice, index string) error
+	GetPreviousEpochStatsIDs(ratingData *outport.ValidatorsRating) []string
+	SerializeValidatorStatsRating(
+		ratingData *outport.ValidatorsRating,
+		previousEpochStats *data.ResponseValidatorsStatsRating,
+		buffSlice *data.BufferSlice,
+		index string,
+	) error
 }
 
 // DBLogsAndEventsHandler defines the actions that a logs and events handler should do
@@ -101,6 +154,7 @@ type DBLogsAndEventsHandler interface {
 	) *data.PreparedLogsResults
 
 	SerializeEvents(events []*data.LogEvent, buffSlice *data.BufferSlice, index string) error
+	SerializeProcessingErrors(processingErrors []*data.ProcessingError, buffSlice *data.BufferSlice, index string) error
 	SerializeLogs(logs []*data.Logs, buffSlice *data.BufferSlice, index string) error
 	SerializeSCDeploys(deploysInfo map[string]*data.ScDeployInfo, buffSlice *data.BufferSlice, index string) error
 	SerializeChangeOwnerOperations(changeOwnerOperations map[string]*data.OwnerData, buffSlice *data.BufferSlice, index string) error
@@ -120,3 +174,12 @@ type OperationsHandler interface {
 	ProcessTransactionsAndSCRs(txs []*data.Transaction, scrs []*data.ScResult, isImportDB bool, shardID uint32) ([]*data.Transaction, []*data.ScResult)
 	SerializeSCRs(scrs []*data.ScResult, buffSlice *data.BufferSlice, index string, shardID uint32) error
 }
+
+// TokensCacheHandler defines the actions that a cache of the tokens type and current owner should do
+type TokensCacheHandler interface {
+	Get(tokens []string) (map[string]data.SourceToken, []string, uint64)
+	Put(version uint64, tokens map[string]data.SourceToken)
+	Remove(tokens []string)
+	Clear()
+	IsInterfaceNil() bool
+}
@@ -0,0 +1,5 @@
+package logsevents
+
+import "errors"
+
+var errEventProcessingPanic = errors.New("panic while processing the event")
@@ -26,6 +26,7 @@ const (
 type esdtIssueProcessor struct {
 	pubkeyConverter            core.PubkeyConverter
 	issueOperationsIdentifiers map[string]struct{}
+	newTokenIdentifiers        map[string]struct{}
 }
 
 func newESDTIssueProcessor(pubkeyConverter core.PubkeyConverter) *esdtIssueProcessor {
@@ -43,6 +44,15 @@ func newESDTIssueProcessor(pubkeyConverter core.PubkeyConverter) *esdtIssueProce
 			registerAndSetRolesDynamicFunc: {},
 			changeToDynamicESDTFunc:        {},
 		},
+		newTokenIdentifiers: map[string]struct{}{
+			issueFungibleESDTFunc:          {},
+			issueSemiFungibleESDTFunc:      {},
+			issueNonFungibleESDTFunc:       {},
+			registerMetaESDTFunc:           {},
+			registerAndSetRolesFunc:        {},
+			registerDynamicFunc:            {},
+			registerAndSetRolesDynamicFunc: {},
+		},
 	}
 }
 
@@ -101,6 +111,7 @@ func (eip *esdtIssueProcessor) processEvent(args *argsProcessEvent) argOutputPro
 		Properties: &data.TokenProperties{},
 	}
 
+	_, tokenInfo.NewToken = eip.newTokenIdentifiers[identifierStr]
 	if identifierStr == changeToDynamicESDTFunc {
 		tokenInfo.ChangeToDynamic = true
 	}
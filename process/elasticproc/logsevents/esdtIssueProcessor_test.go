@@ -43,6 +43,7 @@ func TestIssueESDTProcessor(t *testing.T) {
 				Timestamp: time.Duration(1234),
 			},
 		},
+		NewToken:   true,
 		Properties: &data.TokenProperties{},
 	}, res.tokenInfo)
 }
@@ -103,7 +103,7 @@ func (epp *esdtPropertiesProc) extractTokenProperties(args *argsProcessEvent) ar
 	topics := args.event.GetTopics()
 	properties := topics[esdtPropertiesStartIndex:]
 	propertiesMap := make(map[string]bool)
-	for i := 0; i < len(properties); i += propertyPairStep {
+	for i := 0; i+1 < len(properties); i += propertyPairStep {
 		property := string(properties[i])
 		val := bytesToBool(properties[i+1])
 		propertiesMap[property] = val
@@ -12,24 +12,30 @@ import (
 	"github.com/multiversx/mx-chain-core-go/data/transaction"
 	"github.com/multiversx/mx-chain-core-go/hashing"
 	"github.com/multiversx/mx-chain-core-go/marshal"
+	indexerCore "github.com/multiversx/mx-chain-es-indexer-go/core"
+	"github.com/multiversx/mx-chain-es-indexer-go/core/request"
 	"github.com/multiversx/mx-chain-es-indexer-go/data"
+	"github.com/multiversx/mx-chain-es-indexer-go/metrics"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/dataindexer"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/converters"
 )
 
 const eventIDFormat = "%s-%d-%d"
 
-// ArgsLogsAndEventsProcessor  holds all dependencies required to create new instances of logsAndEventsProcessor
+// ArgsLogsAndEventsProcessor  holds all dependencies required to create new instances of logsAndEventsProcessor. The
+// status metrics handler is optional, when it is set the events which could not be processed are counted
 type ArgsLogsAndEventsProcessor struct {
 	PubKeyConverter  core.PubkeyConverter
 	Marshalizer      marshal.Marshalizer
 	BalanceConverter dataindexer.BalanceConverter
 	Hasher           hashing.Hasher
+	StatusMetrics    indexerCore.StatusMetricsHandler
 }
 
 type logsAndEventsProcessor struct {
 	hasher           hashing.Hasher
 	pubKeyConverter  core.PubkeyConverter
+	statusMetrics    indexerCore.StatusMetricsHandler
 	eventsProcessors []eventsProcessor
 }
 
@@ -46,6 +52,7 @@ func NewLogsAndEventsProcessor(args ArgsLogsAndEventsProcessor) (*logsAndEventsP
 		pubKeyConverter:  args.PubKeyConverter,
 		eventsProcessors: eventsProcessors,
 		hasher:           args.Hasher,
+		statusMetrics:    args.StatusMetrics,
 	}, nil
 }
 
@@ -131,22 +138,26 @@ func (lep *logsAndEventsProcessor) ExtractDataFromLogs(
 		ChangeOwnerOperations:   lgData.changeOwnerOperations,
 		DBLogs:                  dbLogs,
 		DBEvents:                dbEvents,
+		ProcessingErrors:        lgData.processingErrors,
 	}
 }
 
 func (lep *logsAndEventsProcessor) processEvents(lgData *logsData, logHashHexEncoded string, logAddress []byte, events []*transaction.Event, shardID uint32, numOfShards uint32) {
-	for _, event := range events {
+	for order, event := range events {
 		if check.IfNil(event) {
 			continue
 		}
 
-		lep.processEvent(lgData, logHashHexEncoded, logAddress, event, shardID, numOfShards)
+		err := lep.processEvent(lgData, logHashHexEncoded, logAddress, event, shardID, numOfShards)
+		if err != nil {
+			lep.addProcessingError(lgData, logHashHexEncoded, order, event, shardID, err)
+		}
 	}
 }
 
-func (lep *logsAndEventsProcessor) processEvent(lgData *logsData, logHashHexEncoded string, logAddress []byte, event coreData.EventHandler, shardID uint32, numOfShards uint32) {
+func (lep *logsAndEventsProcessor) processEvent(lgData *logsData, logHashHexEncoded string, logAddress []byte, event coreData.EventHandler, shardID uint32, numOfShards uint32) error {
 	for _, proc := range lep.eventsProcessors {
-		res := proc.processEvent(&argsProcessEvent{
+		res, err := safeProcessEvent(proc, &argsProcessEvent{
 			event:                   event,
 			txHashHexEncoded:        logHashHexEncoded,
 			logAddress:              logAddress,
@@ -162,6 +173,9 @@ func (lep *logsAndEventsProcessor) processEvent(lgData *logsData, logHashHexEnco
 			selfShardID:             shardID,
 			numOfShards:             numOfShards,
 		})
+		if err != nil {
+			return err
+		}
 		if res.tokenInfo != nil {
 			lgData.tokensInfo = append(lgData.tokensInfo, res.tokenInfo)
 		}
@@ -184,8 +198,52 @@ func (lep *logsAndEventsProcessor) processEvent(lgData *logsData, logHashHexEnco
 		}
 
 		if res.processed {
-			return
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// safeProcessEvent calls the events processor and recovers from a panic caused by a malformed event. In this case, an
+// error is returned and the event is skipped, so the processing of the other events from the block can continue
+func safeProcessEvent(proc eventsProcessor, args *argsProcessEvent) (res argOutputProcessEvent, err error) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			res, err = argOutputProcessEvent{}, fmt.Errorf("%w: %v", errEventProcessingPanic, r)
 		}
+	}()
+
+	return proc.processEvent(args), nil
+}
+
+// addProcessingError records the event which could not be processed, so it can be indexed and investigated later
+func (lep *logsAndEventsProcessor) addProcessingError(lgData *logsData, logHashHex string, order int, event coreData.EventHandler, shardID uint32, err error) {
+	log.Warn("logsAndEventsProcessor: cannot process event, the event will be skipped",
+		"tx hash", logHashHex,
+		"order", order,
+		"identifier", string(event.GetIdentifier()),
+		"error", err.Error(),
+	)
+
+	lgData.processingErrors = append(lgData.processingErrors, &data.ProcessingError{
+		UUID:       converters.GenerateBase64UUID(),
+		ID:         fmt.Sprintf(eventIDFormat, logHashHex, shardID, order),
+		TxHash:     logHashHex,
+		Order:      order,
+		Identifier: string(event.GetIdentifier()),
+		Address:    lep.pubKeyConverter.SilentEncode(event.GetAddress(), log),
+		Error:      err.Error(),
+		ShardID:    shardID,
+		Timestamp:  time.Duration(lgData.timestamp),
+	})
+
+	if !check.IfNil(lep.statusMetrics) {
+		lep.statusMetrics.AddIndexingData(metrics.ArgsAddIndexingData{
+			GotError: true,
+			Topic:    request.ExtendTopicWithShardID(request.EventProcessingTopic, shardID),
+		})
 	}
 }
 
@@ -1,6 +1,7 @@
 package logsevents
 
 import (
+	"bytes"
 	"encoding/hex"
 	"math/big"
 	"testing"
@@ -10,6 +11,7 @@ import (
 	"github.com/multiversx/mx-chain-core-go/data/outport"
 	"github.com/multiversx/mx-chain-core-go/data/transaction"
 	"github.com/multiversx/mx-chain-es-indexer-go/data"
+	"github.com/multiversx/mx-chain-es-indexer-go/metrics"
 	"github.com/multiversx/mx-chain-es-indexer-go/mock"
 	elasticIndexer "github.com/multiversx/mx-chain-es-indexer-go/process/dataindexer"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/converters"
@@ -186,6 +188,7 @@ func TestLogsAndEventsProcessor_ExtractDataFromLogsAndPutInAltered(t *testing.T)
 				Timestamp: 1000,
 			},
 		},
+		NewToken:   true,
 		Properties: &data.TokenProperties{},
 	}, resLogs.TokensInfo[0])
 
@@ -380,3 +383,119 @@ func TestHexEncodeSlice(t *testing.T) {
 	require.Equal(t, []string{""}, hexEncodeSlice([][]byte{big.NewInt(0).Bytes()}))
 	require.Equal(t, []string{"61", "62"}, hexEncodeSlice([][]byte{[]byte("a"), []byte("b")}))
 }
+
+type eventsProcessorStub struct {
+	processEventCalled func(args *argsProcessEvent) argOutputProcessEvent
+}
+
+func (eps *eventsProcessorStub) processEvent(args *argsProcessEvent) argOutputProcessEvent {
+	return eps.processEventCalled(args)
+}
+
+func TestLogsAndEventsProcessor_ExtractDataFromLogsShouldSkipEventWhichPanics(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgs()
+	statusMetrics := metrics.NewStatusMetrics()
+	args.StatusMetrics = statusMetrics
+	proc, _ := NewLogsAndEventsProcessor(args)
+
+	processedIdentifiers := make([]string, 0)
+	proc.eventsProcessors = []eventsProcessor{
+		&eventsProcessorStub{
+			processEventCalled: func(args *argsProcessEvent) argOutputProcessEvent {
+				if string(args.event.GetIdentifier()) == "bad" {
+					panic("malformed event")
+				}
+				return argOutputProcessEvent{}
+			},
+		},
+		&eventsProcessorStub{
+			processEventCalled: func(args *argsProcessEvent) argOutputProcessEvent {
+				processedIdentifiers = append(processedIdentifiers, string(args.event.GetIdentifier()))
+				return argOutputProcessEvent{processed: true}
+			},
+		},
+	}
+
+	logsAndEvents := []*outport.LogData{
+		{
+			TxHash: "747848617368",
+			Log: &transaction.Log{
+				Address: []byte("address"),
+				Events: []*transaction.Event{
+					{Identifier: []byte("bad")},
+					{Identifier: []byte("good")},
+				},
+			},
+		},
+	}
+
+	results := proc.ExtractDataFromLogs(logsAndEvents, &data.PreparedResults{}, 1234, 0, 3)
+	require.Equal(t, []string{"good"}, processedIdentifiers)
+	require.Len(t, results.DBEvents, 2)
+
+	require.Len(t, results.ProcessingErrors, 1)
+	processingError := results.ProcessingErrors[0]
+	require.Equal(t, "747848617368-0-0", processingError.ID)
+	require.Equal(t, "747848617368", processingError.TxHash)
+	require.Equal(t, 0, processingError.Order)
+	require.Equal(t, "bad", processingError.Identifier)
+	require.Equal(t, "panic while processing the event: malformed event", processingError.Error)
+	require.Equal(t, time.Duration(1234), processingError.Timestamp)
+
+	eventProcessingMetrics := statusMetrics.GetMetrics()["event_processing_0"]
+	require.NotNil(t, eventProcessingMetrics)
+	require.Equal(t, uint64(1), eventProcessingMetrics.TotalErrorsCount)
+}
+
+func FuzzEventsProcessors_ProcessEvent(f *testing.F) {
+	identifiers := []string{
+		core.BuiltInFunctionESDTNFTCreate, core.BuiltInFunctionESDTNFTBurn, core.BuiltInFunctionESDTWipe,
+		core.BuiltInFunctionESDTNFTTransfer, core.BuiltInFunctionMultiESDTNFTTransfer, core.BuiltInFunctionESDTTransfer,
+		core.BuiltInFunctionESDTNFTAddURI, core.BuiltInFunctionESDTNFTUpdateAttributes, core.BuiltInFunctionESDTFreeze,
+		core.BuiltInFunctionESDTPause, core.ESDTMetaDataRecreate, core.ESDTMetaDataUpdate, core.ESDTSetNewURIs,
+		core.ESDTModifyCreator, core.ESDTModifyRoyalties, core.BuiltInFunctionSetESDTRole,
+		core.BuiltInFunctionESDTNFTCreateRoleTransfer, upgradePropertiesEvent, core.SCDeployIdentifier,
+		core.SCUpgradeIdentifier, core.BuiltInFunctionChangeOwnerAddress, core.WriteLogIdentifier,
+		core.SignalErrorOperation, core.CompletedTxEventIdentifier, core.InternalVMErrorsOperation,
+		issueFungibleESDTFunc, registerAndSetRolesDynamicFunc, transferOwnershipFunc, changeToDynamicESDTFunc,
+		delegateFunc, unDelegateFunc, withdrawFunc, reDelegateRewardsFunc, claimRewardsFunc,
+	}
+	for _, identifier := range identifiers {
+		f.Add(identifier, []byte("addr"), []byte("TKN-abcdef@\x01@\x0a@receiver"), []byte("data"), uint32(0), uint32(3))
+	}
+	f.Add(core.BuiltInFunctionESDTNFTCreate, []byte{}, []byte("TKN@\xff\xff\xff\xff\xff\xff\xff\xff\xff@@\x00"), []byte{0xff}, uint32(5), uint32(0))
+
+	args := createMockArgs()
+	processors := createEventsProcessors(args)
+
+	f.Fuzz(func(t *testing.T, identifier string, address []byte, topics []byte, eventData []byte, selfShardID uint32, numOfShards uint32) {
+		event := &transaction.Event{
+			Address:    address,
+			Identifier: []byte(identifier),
+			Topics:     bytes.Split(topics, []byte("@")),
+			Data:       eventData,
+		}
+
+		for _, proc := range processors {
+			lgData := newLogsData(1234, []*data.Transaction{{Hash: "747848617368"}}, nil)
+			_ = proc.processEvent(&argsProcessEvent{
+				event:                   event,
+				txHashHexEncoded:        "747848617368",
+				logAddress:              address,
+				tokens:                  lgData.tokens,
+				tokensSupply:            lgData.tokensSupply,
+				timestamp:               lgData.timestamp,
+				scDeploys:               lgData.scDeploys,
+				txs:                     lgData.txsMap,
+				scrs:                    lgData.scrsMap,
+				tokenRolesAndProperties: lgData.tokenRolesAndProperties,
+				txHashStatusInfoProc:    lgData.txHashStatusInfoProc,
+				changeOwnerOperations:   lgData.changeOwnerOperations,
+				selfShardID:             selfShardID,
+				numOfShards:             numOfShards,
+			})
+		}
+	})
+}
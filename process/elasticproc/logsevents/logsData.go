@@ -18,6 +18,7 @@ type logsData struct {
 	delegators              map[string]*data.Delegator
 	tokensInfo              []*data.TokenInfo
 	nftsDataUpdates         []*data.NFTDataUpdate
+	processingErrors        []*data.ProcessingError
 	tokenRolesAndProperties *tokeninfo.TokenRolesAndProperties
 }
 
@@ -38,6 +39,7 @@ func newLogsData(
 	ld.delegators = make(map[string]*data.Delegator)
 	ld.changeOwnerOperations = make(map[string]*data.OwnerData)
 	ld.nftsDataUpdates = make([]*data.NFTDataUpdate, 0)
+	ld.processingErrors = make([]*data.ProcessingError, 0)
 	ld.tokenRolesAndProperties = tokeninfo.NewTokenRolesAndProperties()
 	ld.txHashStatusInfoProc = newTxHashStatusInfoProcessor()
 
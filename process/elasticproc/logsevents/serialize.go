@@ -10,16 +10,8 @@ import (
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/tokeninfo"
 )
 
-// SerializeEvents will serialize the provided events in a way that Elasticsearch expects a bulk request
-func (*logsAndEventsProcessor) SerializeEvents(events []*data.LogEvent, buffSlice *data.BufferSlice, index string) error {
-	for _, event := range events {
-		meta := []byte(fmt.Sprintf(`{ "update" : { "_index":"%s", "_id" : "%s" } }%s`, index, converters.JsonEscape(event.ID), "\n"))
-		serializedData, errMarshal := json.Marshal(event)
-		if errMarshal != nil {
-			return errMarshal
-		}
-
-		codeToExecute := `
+const (
+	eventUpsertScript = `
 		if ('create' == ctx.op) {
 			ctx._source = params.event
 		} else {
@@ -32,33 +24,7 @@ func (*logsAndEventsProcessor) SerializeEvents(events []*data.LogEvent, buffSlic
 			}
 		}
 `
-		serializedDataStr := fmt.Sprintf(`{"scripted_upsert": true, "script": {`+
-			`"source": "%s",`+
-			`"lang": "painless",`+
-			`"params": { "event": %s }},`+
-			`"upsert": {}}`,
-			converters.FormatPainlessSource(codeToExecute), serializedData,
-		)
-
-		err := buffSlice.PutData(meta, []byte(serializedDataStr))
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-// SerializeLogs will serialize the provided logs in a way that Elasticsearch expects a bulk request
-func (*logsAndEventsProcessor) SerializeLogs(logs []*data.Logs, buffSlice *data.BufferSlice, index string) error {
-	for _, lg := range logs {
-		meta := []byte(fmt.Sprintf(`{ "update" : { "_index":"%s", "_id" : "%s" } }%s`, index, converters.JsonEscape(lg.ID), "\n"))
-		serializedData, errMarshal := json.Marshal(lg)
-		if errMarshal != nil {
-			return errMarshal
-		}
-
-		codeToExecute := `
+	logUpsertScript = `
 		if ('create' == ctx.op) {
 			ctx._source = params.log
 		} else {
@@ -71,15 +37,52 @@ func (*logsAndEventsProcessor) SerializeLogs(logs []*data.Logs, buffSlice *data.
 			}
 		}
 `
-		serializedDataStr := fmt.Sprintf(`{"scripted_upsert": true, "script": {`+
-			`"source": "%s",`+
-			`"lang": "painless",`+
-			`"params": { "log": %s }},`+
-			`"upsert": {}}`,
-			converters.FormatPainlessSource(codeToExecute), serializedData,
-		)
+)
+
+// the scripts are formatted once, only the serialized event or log is written for every document
+var (
+	eventUpsertTemplate = newUpsertTemplate(eventUpsertScript, "event")
+	logUpsertTemplate   = newUpsertTemplate(logUpsertScript, "log")
+)
+
+func newUpsertTemplate(script string, paramName string) *data.DocumentTemplate {
+	return data.NewDocumentTemplate(
+		`{"scripted_upsert": true, "script": {"source": "`+converters.FormatPainlessSource(script)+`","lang": "painless","params": { "`+paramName+`": `,
+		` }},"upsert": {}}`,
+	)
+}
+
+// SerializeEvents will serialize the provided events in a way that Elasticsearch expects a bulk request
+func (*logsAndEventsProcessor) SerializeEvents(events []*data.LogEvent, buffSlice *data.BufferSlice, index string) error {
+	meta := data.NewMetaTemplate(`{ "update" : { "_index":"%s", "_id" : "%s" } }`, index)
+	for _, event := range events {
+		err := buffSlice.PutEnclosedDocument(meta, event.ID, eventUpsertTemplate, event)
+		if err != nil {
+			return err
+		}
+	}
 
-		err := buffSlice.PutData(meta, []byte(serializedDataStr))
+	return nil
+}
+
+// SerializeProcessingErrors will serialize the provided processing errors in a way that Elasticsearch expects a bulk request
+func (*logsAndEventsProcessor) SerializeProcessingErrors(processingErrors []*data.ProcessingError, buffSlice *data.BufferSlice, index string) error {
+	meta := data.NewMetaTemplate(`{ "index" : { "_index":"%s", "_id" : "%s" } }`, index)
+	for _, processingError := range processingErrors {
+		err := buffSlice.PutDocument(meta, processingError.ID, processingError)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SerializeLogs will serialize the provided logs in a way that Elasticsearch expects a bulk request
+func (*logsAndEventsProcessor) SerializeLogs(logs []*data.Logs, buffSlice *data.BufferSlice, index string) error {
+	meta := data.NewMetaTemplate(`{ "update" : { "_index":"%s", "_id" : "%s" } }`, index)
+	for _, lg := range logs {
+		err := buffSlice.PutEnclosedDocument(meta, lg.ID, logUpsertTemplate, lg)
 		if err != nil {
 			return err
 		}
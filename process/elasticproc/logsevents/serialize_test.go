@@ -1,7 +1,10 @@
 package logsevents
 
 import (
+	"encoding/json"
+	"fmt"
 	"math/big"
+	"os"
 	"testing"
 	"time"
 
@@ -41,6 +44,56 @@ func TestLogsAndEventsProcessor_SerializeLogs(t *testing.T) {
 	require.Equal(t, expectedRes, buffSlice.Buffers()[0].String())
 }
 
+func TestLogsAndEventsProcessor_SerializeEvents(t *testing.T) {
+	t.Parallel()
+
+	events := []*data.LogEvent{
+		{
+			ID:         "747848617368-1-0",
+			TxHash:     "747848617368",
+			Address:    "61646472",
+			Identifier: "do-something",
+			Topics:     []string{"746f70696331"},
+			ShardID:    1,
+			Timestamp:  time.Duration(1234),
+		},
+	}
+
+	buffSlice := data.NewBufferSlice(data.DefaultMaxBulkSize)
+	err := (&logsAndEventsProcessor{}).SerializeEvents(events, buffSlice, "events")
+	require.Nil(t, err)
+
+	serializedEvent, _ := json.Marshal(events[0])
+	expectedRes := `{ "update" : { "_index":"events", "_id" : "747848617368-1-0" } }
+{"scripted_upsert": true, "script": {"source": "if ('create' == ctx.op) {ctx._source = params.event} else {if (ctx._source.containsKey('timestamp')) {if (ctx._source.timestamp <= params.event.timestamp) {ctx._source = params.event}} else {ctx._source = params.event}}","lang": "painless","params": { "event": ` + string(serializedEvent) + ` }},"upsert": {}}
+`
+	require.Equal(t, expectedRes, buffSlice.Buffers()[0].String())
+}
+
+func TestLogsAndEventsProcessor_SerializeProcessingErrors(t *testing.T) {
+	t.Parallel()
+
+	processingErrors := []*data.ProcessingError{
+		{
+			ID:         "747848617368-1-0",
+			TxHash:     "747848617368",
+			Identifier: "do-something",
+			Error:      "panic while processing the event: index out of range",
+			ShardID:    1,
+			Timestamp:  time.Duration(1234),
+		},
+	}
+
+	buffSlice := data.NewBufferSlice(data.DefaultMaxBulkSize)
+	err := (&logsAndEventsProcessor{}).SerializeProcessingErrors(processingErrors, buffSlice, "processingerrors")
+	require.Nil(t, err)
+
+	expectedRes := `{ "index" : { "_index":"processingerrors", "_id" : "747848617368-1-0" } }
+{"uuid":"","txHash":"747848617368","order":0,"identifier":"do-something","address":"","error":"panic while processing the event: index out of range","shardID":1,"timestamp":1234}
+`
+	require.Equal(t, expectedRes, buffSlice.Buffers()[0].String())
+}
+
 func TestLogsAndEventsProcessor_SerializeSCDeploys(t *testing.T) {
 	t.Parallel()
 
@@ -164,3 +217,30 @@ func TestLogsAndEventsProcessor_SerializeDelegatorsDelete(t *testing.T) {
 `
 	require.Equal(t, expectedRes, buffSlice.Buffers()[0].String())
 }
+
+func BenchmarkSerializeEvents(b *testing.B) {
+	fixture, err := os.ReadFile("../../../integrationtests/testdata/logsCrossShard/event-do-something.json")
+	require.Nil(b, err)
+
+	event := &data.LogEvent{}
+	require.Nil(b, json.Unmarshal(fixture, event))
+
+	events := make([]*data.LogEvent, 0, 1000)
+	for idx := 0; idx < 1000; idx++ {
+		newEvent := *event
+		newEvent.ID = fmt.Sprintf("%s-%d-%d", event.TxHash, event.ShardID, idx)
+		events = append(events, &newEvent)
+	}
+	logsAndEventsProc := &logsAndEventsProcessor{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buffSlice := data.NewBufferSlice(data.DefaultMaxBulkSize)
+		err = logsAndEventsProc.SerializeEvents(events, buffSlice, "events")
+		if err != nil {
+			b.Fatal(err)
+		}
+		data.ReleaseBuffers(buffSlice.Buffers())
+	}
+}
@@ -0,0 +1,333 @@
+package rollups
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	coreData "github.com/multiversx/mx-chain-core-go/data"
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/dataindexer"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/blockshistory"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/converters"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/hyperloglog"
+)
+
+const (
+	// maxExactAddresses is the number of addresses of a period which are kept in an exact set, above it only the
+	// hyperloglog sketch of the addresses is kept
+	maxExactAddresses = 1000
+
+	dayPeriod    = "day"
+	epochPeriod  = "epoch"
+	secondsInDay = 86400
+)
+
+// the addresses are added in the exact set while it is not above the limit, then the set is removed and the number of
+// active addresses is estimated from the registers, which are merged for all the added addresses. A document is updated
+// by the blocks of all the shards, so the nonce of the last added block of each shard is kept, and a block which is not
+// newer is not added. While the set is exact, the addresses added by each of the last blocks of a shard are kept too, so
+// a reverted block removes them from the set if it is the last added block of its shard. An address is not removed if
+// it was active in another block, and the registers are not changed by a reverted block
+var activeAddressesScript = `
+		String shardID = '' + params.activity.shardID;
+		if (!ctx._source.containsKey('registers')) {
+			ctx._source.period = params.activity.period;
+			ctx._source.timestamp = params.activity.timestamp;
+			ctx._source.epoch = params.activity.epoch;
+			if (params.activity.containsKey('contract')) {
+				ctx._source.contract = params.activity.contract;
+			}
+			ctx._source.activeAddresses = 0;
+			ctx._source.addresses = new ArrayList();
+			ctx._source.registers = new HashMap();
+		}
+		if (!ctx._source.containsKey('lastNonces')) {
+			ctx._source.lastNonces = new HashMap();
+			ctx._source.revertibleBlocks = new HashMap();
+		}
+
+		if (params.activity.revert) {
+			List blocks = ctx._source.revertibleBlocks.getOrDefault(shardID, new ArrayList());
+			if (blocks.isEmpty() || blocks.get(blocks.size() - 1).headerHash != params.activity.headerHash) {
+				ctx.op = 'noop';
+				return;
+			}
+			Map block = blocks.remove(blocks.size() - 1);
+			if (blocks.isEmpty()) {
+				ctx._source.lastNonces.remove(shardID);
+				ctx._source.revertibleBlocks.remove(shardID);
+			} else {
+				ctx._source.lastNonces.put(shardID, blocks.get(blocks.size() - 1).nonce);
+			}
+			for (def address : block.added) {
+				int position = ctx._source.addresses.indexOf(address);
+				if (position >= 0) {
+					ctx._source.addresses.remove(position);
+				}
+			}
+			ctx._source.activeAddresses = ctx._source.addresses.size();
+			return;
+		}
+
+		if (ctx._source.lastNonces.containsKey(shardID) && params.activity.nonce <= ctx._source.lastNonces.get(shardID)) {
+			ctx.op = 'noop';
+			return;
+		}
+		ctx._source.lastNonces.put(shardID, params.activity.nonce);
+		List added = new ArrayList();
+		if (ctx._source.containsKey('addresses')) {
+			for (def address : params.activity.addresses) {
+				if (!ctx._source.addresses.contains(address)) {
+					added.add(address);
+					continue;
+				}
+				for (def shardBlocks : ctx._source.revertibleBlocks.values()) {
+					for (def previousBlock : shardBlocks) {
+						int position = previousBlock.added.indexOf(address);
+						if (position >= 0) {
+							previousBlock.added.remove(position);
+						}
+					}
+				}
+			}
+		}
+` + mergeAddressesScript("params.activity.addresses", "params.activity.registers", "addresses", "registers", "activeAddresses") + fmt.Sprintf(`
+		if (!ctx._source.containsKey('addresses')) {
+			ctx._source.revertibleBlocks = new HashMap();
+			return;
+		}
+		if (!ctx._source.revertibleBlocks.containsKey(shardID)) {
+			ctx._source.revertibleBlocks.put(shardID, new ArrayList());
+		}
+		List blocks = ctx._source.revertibleBlocks.get(shardID);
+		Map block = new HashMap();
+		block.put('nonce', params.activity.nonce);
+		block.put('headerHash', params.activity.headerHash);
+		block.put('added', added);
+		blocks.add(block);
+		if (blocks.size() > %d) {
+			blocks.remove(0);
+		}
+`, blockshistory.MaxRevertibleBlocks)
+
+// mergeAddressesScript returns the painless source which adds the provided addresses in the exact set of the document
+// while the set is not above the limit. Above it, the set is removed and the number of addresses is estimated from the
+// hyperloglog registers, which are merged for all the addresses
+func mergeAddressesScript(addressesParam string, registersParam string, addressesField string, registersField string, countField string) string {
+	return mergeRegistersScript(registersParam, registersField, countField) + fmt.Sprintf(`
+		if (ctx._source.containsKey('%[2]s')) {
+			for (def address : %[1]s) {
+				if (!ctx._source.%[2]s.contains(address)) {
+					ctx._source.%[2]s.add(address);
+				}
+			}
+			if (ctx._source.%[2]s.size() <= %[4]d) {
+				ctx._source.%[3]s = ctx._source.%[2]s.size();
+			} else {
+				ctx._source.remove('%[2]s');
+			}
+		}
+`, addressesParam, addressesField, countField, maxExactAddresses)
+}
+
+var activeAddressesTemplate = data.NewDocumentTemplate(
+	`{"scripted_upsert": true, "script": {"source": "`+converters.FormatPainlessSource(activeAddressesScript)+`","lang": "painless","params": { "activity": `,
+	` }},"upsert": {}}`,
+)
+
+type addressesSet struct {
+	addresses []string
+	added     map[string]struct{}
+}
+
+func newAddressesSet() *addressesSet {
+	return &addressesSet{
+		added: make(map[string]struct{}),
+	}
+}
+
+func (as *addressesSet) add(address string) {
+	if address == "" {
+		return
+	}
+	if _, found := as.added[address]; found {
+		return
+	}
+
+	as.added[address] = struct{}{}
+	as.addresses = append(as.addresses, address)
+}
+
+type activeAddressesProcessor struct {
+	pubKeyConverter core.PubkeyConverter
+	history         blocksHistoryHandler
+}
+
+// NewActiveAddressesProcessor will create a new instance of activeAddressesProcessor
+func NewActiveAddressesProcessor(pubKeyConverter core.PubkeyConverter) (*activeAddressesProcessor, error) {
+	if check.IfNil(pubKeyConverter) {
+		return nil, dataindexer.ErrNilPubkeyConverter
+	}
+
+	return &activeAddressesProcessor{
+		pubKeyConverter: pubKeyConverter,
+		history:         blockshistory.NewBlocksHistory(),
+	}, nil
+}
+
+// PrepareActiveAddresses will return the active addresses of the provided block, for the day and for the epoch of the
+// block, both for the whole chain and for each smart contract which was called in the block
+func (ap *activeAddressesProcessor) PrepareActiveAddresses(
+	header coreData.HeaderHandler,
+	headerHash []byte,
+	preparedResults *data.PreparedResults,
+) []*data.ActiveAddresses {
+	chainAddresses := newAddressesSet()
+	contractsCallers := make(map[string]*addressesSet)
+	addCall := func(caller string, contract string) {
+		chainAddresses.add(contract)
+		if contractsCallers[contract] == nil {
+			contractsCallers[contract] = newAddressesSet()
+		}
+		contractsCallers[contract].add(caller)
+	}
+
+	for _, tx := range preparedResults.Transactions {
+		chainAddresses.add(tx.Sender)
+		if tx.IsScCall {
+			addCall(tx.Sender, tx.Receiver)
+		}
+	}
+	for _, scr := range preparedResults.ScResults {
+		if ap.isScCall(scr) {
+			addCall(scr.Sender, scr.Receiver)
+		}
+	}
+
+	if len(chainAddresses.addresses) == 0 {
+		ap.history.Add(header.GetShardID(), headerHash, []*data.ActiveAddresses{})
+		return nil
+	}
+
+	contracts := make([]string, 0, len(contractsCallers))
+	for contract := range contractsCallers {
+		contracts = append(contracts, contract)
+	}
+	sort.Strings(contracts)
+
+	timestamp := header.GetTimeStamp()
+	dayStart := time.Duration(timestamp - timestamp%secondsInDay)
+	activeAddresses := make([]*data.ActiveAddresses, 0, 2*(len(contracts)+1))
+	block := &data.ActiveAddresses{
+		ShardID:    header.GetShardID(),
+		Nonce:      header.GetNonce(),
+		HeaderHash: hex.EncodeToString(headerHash),
+	}
+	for _, period := range []*data.ActiveAddresses{
+		{Period: dayPeriod, Timestamp: dayStart, Epoch: header.GetEpoch()},
+		{Period: epochPeriod, Timestamp: time.Duration(timestamp), Epoch: header.GetEpoch()},
+	} {
+		activeAddresses = append(activeAddresses, newActiveAddresses(period, block, "", chainAddresses))
+		for _, contract := range contracts {
+			activeAddresses = append(activeAddresses, newActiveAddresses(period, block, contract, contractsCallers[contract]))
+		}
+	}
+
+	ap.history.Add(header.GetShardID(), headerHash, activeAddresses)
+
+	return activeAddresses
+}
+
+// PrepareRevertedActiveAddresses returns the updates which remove the provided reverted block from the documents of
+// the periods it was added in. If the block is not known, nothing is returned, so the reverted block is kept and the
+// block which replaces it is not added, since it has the same nonce
+func (ap *activeAddressesProcessor) PrepareRevertedActiveAddresses(header coreData.HeaderHandler, headerHash []byte) []*data.ActiveAddresses {
+	value, found := ap.history.RemoveLast(header.GetShardID(), headerHash)
+	if !found {
+		log.Warn("activeAddressesProcessor.PrepareRevertedActiveAddresses: the reverted block is not in the recent blocks, the active addresses will not be adjusted",
+			"shard", header.GetShardID(), "nonce", header.GetNonce())
+		return nil
+	}
+
+	blockAddresses := value.([]*data.ActiveAddresses)
+	reverted := make([]*data.ActiveAddresses, 0, len(blockAddresses))
+	for _, activity := range blockAddresses {
+		reverted = append(reverted, &data.ActiveAddresses{
+			Period:     activity.Period,
+			Timestamp:  activity.Timestamp,
+			Epoch:      activity.Epoch,
+			Contract:   activity.Contract,
+			ShardID:    activity.ShardID,
+			Nonce:      activity.Nonce,
+			HeaderHash: activity.HeaderHash,
+			Revert:     true,
+			Addresses:  make([]string, 0),
+			Registers:  make(map[uint32]uint8),
+		})
+	}
+
+	return reverted
+}
+
+func (ap *activeAddressesProcessor) isScCall(scr *data.ScResult) bool {
+	if scr.Function == "" {
+		return false
+	}
+
+	receiver, err := ap.pubKeyConverter.Decode(scr.Receiver)
+	if err != nil {
+		return false
+	}
+
+	return core.IsSmartContractAddress(receiver)
+}
+
+func newActiveAddresses(period *data.ActiveAddresses, block *data.ActiveAddresses, contract string, set *addressesSet) *data.ActiveAddresses {
+	sketch := hyperloglog.NewSketch()
+	for _, address := range set.addresses {
+		sketch.Add(address)
+	}
+
+	return &data.ActiveAddresses{
+		Period:     period.Period,
+		Timestamp:  period.Timestamp,
+		Epoch:      period.Epoch,
+		Contract:   contract,
+		ShardID:    block.ShardID,
+		Nonce:      block.Nonce,
+		HeaderHash: block.HeaderHash,
+		Addresses:  set.addresses,
+		Registers:  sketch.NonEmptyRegisters(),
+	}
+}
+
+// SerializeActiveAddresses will serialize the provided active addresses as updates of the documents of their periods.
+// The documents of a day are identified by the timestamp of the start of the day, the documents of an epoch by the
+// epoch, followed by the contract address for the documents of the smart contracts
+func (ap *activeAddressesProcessor) SerializeActiveAddresses(activeAddresses []*data.ActiveAddresses, buffSlice *data.BufferSlice, index string) error {
+	meta := data.NewMetaTemplate(`{ "update" : { "_index":"%s", "_id" : "%s", "retry_on_conflict": 5 } }`, index)
+	for _, activity := range activeAddresses {
+		err := buffSlice.PutEnclosedDocument(meta, activeAddressesID(activity), activeAddressesTemplate, activity)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func activeAddressesID(activity *data.ActiveAddresses) string {
+	id := fmt.Sprintf("%s_%d", dayPeriod, activity.Timestamp)
+	if activity.Period == epochPeriod {
+		id = fmt.Sprintf("%s_%d", epochPeriod, activity.Epoch)
+	}
+	if activity.Contract != "" {
+		id += "_" + activity.Contract
+	}
+
+	return id
+}
@@ -0,0 +1,210 @@
+package rollups
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-es-indexer-go/client/memory"
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
+	"github.com/multiversx/mx-chain-es-indexer-go/mock"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/dataindexer"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	activeAddressesIndex = "activeaddresses"
+	contractAddress      = "0000000000000000050000000000000000000000000000000000000000000001"
+	userAddress          = "1000000000000000000000000000000000000000000000000000000000000002"
+)
+
+func createActiveAddressesProcessor(t *testing.T) *activeAddressesProcessor {
+	ap, err := NewActiveAddressesProcessor(mock.NewPubkeyConverterMock(32))
+	require.Nil(t, err)
+
+	return ap
+}
+
+func TestNewActiveAddressesProcessor(t *testing.T) {
+	t.Parallel()
+
+	ap, err := NewActiveAddressesProcessor(nil)
+	require.Nil(t, ap)
+	require.Equal(t, dataindexer.ErrNilPubkeyConverter, err)
+}
+
+func TestActiveAddressesProcessor_PrepareActiveAddresses(t *testing.T) {
+	t.Parallel()
+
+	ap := createActiveAddressesProcessor(t)
+	header := &block.Header{Epoch: 3, TimeStamp: 2*secondsInDay + 60}
+
+	require.Nil(t, ap.PrepareActiveAddresses(header, []byte("h0"), &data.PreparedResults{}))
+
+	header.Nonce = 5
+	header.ShardID = 1
+	activeAddresses := ap.PrepareActiveAddresses(header, []byte("h1"), &data.PreparedResults{
+		Transactions: []*data.Transaction{
+			{Sender: "erd1a", Receiver: userAddress},
+			{Sender: "erd1b", Receiver: contractAddress, IsScCall: true},
+			{Sender: "erd1a", Receiver: contractAddress, IsScCall: true},
+		},
+		ScResults: []*data.ScResult{
+			{Sender: contractAddress, Receiver: userAddress, Function: "transfer"},
+			{Sender: "erd1c", Receiver: contractAddress},
+			{Sender: "erd1d", Receiver: contractAddress, Function: "claim"},
+		},
+	})
+	require.Len(t, activeAddresses, 4)
+	for _, activity := range activeAddresses {
+		require.Len(t, activity.Registers, len(activity.Addresses))
+		activity.Registers = nil
+	}
+
+	chainAddresses := []string{"erd1a", "erd1b", contractAddress}
+	callers := []string{"erd1b", "erd1a", "erd1d"}
+	hash := hex.EncodeToString([]byte("h1"))
+	require.Equal(t, []*data.ActiveAddresses{
+		{Period: dayPeriod, Timestamp: 2 * secondsInDay, Epoch: 3, ShardID: 1, Nonce: 5, HeaderHash: hash, Addresses: chainAddresses},
+		{Period: dayPeriod, Timestamp: 2 * secondsInDay, Epoch: 3, Contract: contractAddress, ShardID: 1, Nonce: 5, HeaderHash: hash, Addresses: callers},
+		{Period: epochPeriod, Timestamp: 2*secondsInDay + 60, Epoch: 3, ShardID: 1, Nonce: 5, HeaderHash: hash, Addresses: chainAddresses},
+		{Period: epochPeriod, Timestamp: 2*secondsInDay + 60, Epoch: 3, Contract: contractAddress, ShardID: 1, Nonce: 5, HeaderHash: hash, Addresses: callers},
+	}, activeAddresses)
+
+	reverted := ap.PrepareRevertedActiveAddresses(header, []byte("h1"))
+	require.Len(t, reverted, 4)
+	for idx, activity := range reverted {
+		require.True(t, activity.Revert)
+		require.Empty(t, activity.Addresses)
+		require.Equal(t, activeAddressesID(activeAddresses[idx]), activeAddressesID(activity))
+		require.Equal(t, hash, activity.HeaderHash)
+	}
+
+	// the block is already reverted
+	require.Nil(t, ap.PrepareRevertedActiveAddresses(header, []byte("h1")))
+}
+
+// createActiveAddressesIndexer returns a function which applies the provided active addresses on an in-memory database
+// and returns the document of the epoch 3
+func createActiveAddressesIndexer(t *testing.T) func(ap *activeAddressesProcessor, activeAddresses []*data.ActiveAddresses) map[string]interface{} {
+	dbClient := memory.NewDatabaseClient()
+
+	return func(ap *activeAddressesProcessor, activeAddresses []*data.ActiveAddresses) map[string]interface{} {
+		buffSlice := data.NewBufferSlice(0)
+		require.Nil(t, ap.SerializeActiveAddresses(activeAddresses, buffSlice, activeAddressesIndex))
+		for _, buff := range buffSlice.Buffers() {
+			require.Nil(t, dbClient.DoBulkRequest(context.Background(), buff, activeAddressesIndex))
+		}
+
+		response := &data.ResponseMultiGet{}
+		require.Nil(t, dbClient.DoMultiGet(context.Background(), []string{"epoch_3"}, activeAddressesIndex, true, response))
+		document := make(map[string]interface{})
+		require.Nil(t, json.Unmarshal(response.Docs[0].Source, &document))
+		return document
+	}
+}
+
+func transactionsFrom(senders ...string) *data.PreparedResults {
+	txs := make([]*data.Transaction, 0, len(senders))
+	for _, sender := range senders {
+		txs = append(txs, &data.Transaction{Sender: sender})
+	}
+
+	return &data.PreparedResults{Transactions: txs}
+}
+
+func TestActiveAddressesProcessor_SerializeActiveAddresses(t *testing.T) {
+	t.Parallel()
+
+	ap := createActiveAddressesProcessor(t)
+	buffSlice := data.NewBufferSlice(0)
+	err := ap.SerializeActiveAddresses([]*data.ActiveAddresses{
+		{Period: dayPeriod, Timestamp: secondsInDay, Epoch: 3, Addresses: []string{"erd1a"}},
+		{Period: epochPeriod, Timestamp: secondsInDay + 60, Epoch: 3, Contract: contractAddress, Addresses: []string{"erd1a"}},
+	}, buffSlice, activeAddressesIndex)
+	require.Nil(t, err)
+
+	serialized := buffSlice.Buffers()[0].String()
+	require.Contains(t, serialized, `{ "update" : { "_index":"activeaddresses", "_id" : "day_86400", "retry_on_conflict": 5 } }`)
+	require.Contains(t, serialized, `{ "update" : { "_index":"activeaddresses", "_id" : "epoch_3_`+contractAddress+`", "retry_on_conflict": 5 } }`)
+}
+
+func TestActiveAddressesProcessor_SwitchToSketchAboveTheLimit(t *testing.T) {
+	t.Parallel()
+
+	indexActiveAddresses := createActiveAddressesIndexer(t)
+	ap := createActiveAddressesProcessor(t)
+	nonce := uint64(0)
+	index := func(senders ...string) map[string]interface{} {
+		nonce++
+		header := &block.Header{Nonce: nonce, Epoch: 3, TimeStamp: secondsInDay}
+		headerHash := []byte(fmt.Sprintf("h%d", nonce))
+		return indexActiveAddresses(ap, ap.PrepareActiveAddresses(header, headerHash, transactionsFrom(senders...)))
+	}
+
+	document := index("erd1a", "erd1b")
+	require.Equal(t, float64(2), document["activeAddresses"])
+	document = index("erd1a", "erd1c")
+	require.Equal(t, float64(3), document["activeAddresses"])
+	require.Equal(t, []interface{}{"erd1a", "erd1b", "erd1c"}, document["addresses"])
+	require.Equal(t, "epoch", document["period"])
+
+	senders := make([]string, 0, maxExactAddresses)
+	for i := 0; i < maxExactAddresses; i++ {
+		senders = append(senders, fmt.Sprintf("erd1sender%d", i))
+	}
+	document = index(senders...)
+	require.NotContains(t, document, "addresses")
+	require.InDelta(t, maxExactAddresses+3, document["activeAddresses"], 30)
+
+	// once above the limit, only the sketch is updated
+	document = index("erd1a", "erd1new")
+	require.NotContains(t, document, "addresses")
+	require.InDelta(t, maxExactAddresses+4, document["activeAddresses"], 30)
+
+	// the registers are not changed by a reverted block
+	header := &block.Header{Nonce: nonce, Epoch: 3, TimeStamp: secondsInDay}
+	document = indexActiveAddresses(ap, ap.PrepareRevertedActiveAddresses(header, []byte(fmt.Sprintf("h%d", nonce))))
+	require.InDelta(t, maxExactAddresses+4, document["activeAddresses"], 30)
+}
+
+func TestActiveAddressesProcessor_RevertConsecutiveBlocks(t *testing.T) {
+	t.Parallel()
+
+	indexActiveAddresses := createActiveAddressesIndexer(t)
+	ap := createActiveAddressesProcessor(t)
+	header := func(shardID uint32, nonce uint64) *block.Header {
+		return &block.Header{ShardID: shardID, Nonce: nonce, Epoch: 3, TimeStamp: secondsInDay}
+	}
+
+	indexActiveAddresses(ap, ap.PrepareActiveAddresses(header(0, 1), []byte("h1"), transactionsFrom("erd1a")))
+	indexActiveAddresses(ap, ap.PrepareActiveAddresses(header(0, 2), []byte("h2"), transactionsFrom("erd1a", "erd1b")))
+	indexActiveAddresses(ap, ap.PrepareActiveAddresses(header(0, 3), []byte("h3"), transactionsFrom("erd1c", "erd1d")))
+	// erd1d is active in another shard, so it is kept when the block of shard 0 is reverted
+	document := indexActiveAddresses(ap, ap.PrepareActiveAddresses(header(1, 7), []byte("s1h7"), transactionsFrom("erd1d", "erd1e")))
+	require.Equal(t, []interface{}{"erd1a", "erd1b", "erd1c", "erd1d", "erd1e"}, document["addresses"])
+
+	document = indexActiveAddresses(ap, ap.PrepareRevertedActiveAddresses(header(0, 3), []byte("h3")))
+	require.Equal(t, []interface{}{"erd1a", "erd1b", "erd1d", "erd1e"}, document["addresses"])
+	require.Equal(t, float64(4), document["activeAddresses"])
+
+	// erd1a was already active in the block with nonce 1
+	document = indexActiveAddresses(ap, ap.PrepareRevertedActiveAddresses(header(0, 2), []byte("h2")))
+	require.Equal(t, []interface{}{"erd1a", "erd1d", "erd1e"}, document["addresses"])
+	require.Equal(t, float64(3), document["activeAddresses"])
+
+	// the blocks which replace the reverted ones are added
+	indexActiveAddresses(ap, ap.PrepareActiveAddresses(header(0, 2), []byte("h2b"), transactionsFrom("erd1f")))
+	document = indexActiveAddresses(ap, ap.PrepareActiveAddresses(header(0, 3), []byte("h3b"), transactionsFrom("erd1b")))
+	require.Equal(t, []interface{}{"erd1a", "erd1d", "erd1e", "erd1f", "erd1b"}, document["addresses"])
+	require.Equal(t, float64(5), document["activeAddresses"])
+
+	// after a restart, the reverted block is not known, so it is kept and its replacement is not added
+	restartedAp := createActiveAddressesProcessor(t)
+	require.Nil(t, restartedAp.PrepareRevertedActiveAddresses(header(0, 3), []byte("h3b")))
+	document = indexActiveAddresses(restartedAp, restartedAp.PrepareActiveAddresses(header(0, 3), []byte("h3c"), transactionsFrom("erd1g")))
+	require.Equal(t, []interface{}{"erd1a", "erd1d", "erd1e", "erd1f", "erd1b"}, document["addresses"])
+}
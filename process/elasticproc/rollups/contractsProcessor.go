@@ -0,0 +1,408 @@
+package rollups
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	coreData "github.com/multiversx/mx-chain-core-go/data"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/dataindexer"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/blockshistory"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/converters"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/hyperloglog"
+)
+
+const scDeployOperation = "scDeploy"
+
+// the document of a smart contract is updated only by the blocks of its shard, so the nonce and the hash of the last
+// added block are kept in the document, together with the nonces and the hashes of the previous blocks which updated
+// it, at most the number of revertible blocks. A block which is not newer than the last added block is not added, while
+// a reverted block is subtracted only if it is the last added block, and then the previous block becomes the last
+// added one, so consecutive blocks can be reverted and the next block with the same nonce can be added. The callers,
+// the interactions timestamps and the deploy information are not changed by a reverted block, so a deploy, an upgrade
+// or an owner change whose transaction is already recorded is not added again
+var contractStatsScript = fmt.Sprintf(`
+		if (params.stats.revert) {
+			if (!ctx._source.containsKey('lastHeaderHash') || ctx._source.lastHeaderHash != params.stats.headerHash) {
+				ctx.op = 'noop';
+				return;
+			}
+			if (!ctx._source.containsKey('previousBlocks')) {
+				ctx._source.lastNonce = params.stats.nonce - 1;
+				ctx._source.lastHeaderHash = '';
+			} else if (ctx._source.previousBlocks.isEmpty()) {
+				ctx._source.lastNonce = 0;
+				ctx._source.lastHeaderHash = '';
+			} else {
+				Map previousBlock = ctx._source.previousBlocks.remove(ctx._source.previousBlocks.size() - 1);
+				ctx._source.lastNonce = previousBlock.nonce;
+				ctx._source.lastHeaderHash = previousBlock.headerHash;
+			}
+		} else {
+			if (!ctx._source.containsKey('lastNonce')) {
+				ctx._source.address = params.stats.address;
+				ctx._source.numCalls = 0;
+				ctx._source.numFailedCalls = 0;
+				ctx._source.calls = new HashMap();
+				ctx._source.failedCalls = new HashMap();
+				ctx._source.gasUsed = 0;
+				ctx._source.egldReceived = '0';
+				ctx._source.esdtReceived = new HashMap();
+				ctx._source.uniqueCallers = 0;
+				ctx._source.callers = new ArrayList();
+				ctx._source.callersRegisters = new HashMap();
+				ctx._source.firstInteraction = params.stats.timestamp;
+				ctx._source.lastInteraction = params.stats.timestamp;
+			} else if (params.stats.nonce <= ctx._source.lastNonce) {
+				ctx.op = 'noop';
+				return;
+			}
+			if (!ctx._source.containsKey('previousBlocks')) {
+				ctx._source.previousBlocks = new ArrayList();
+			}
+			if (ctx._source.containsKey('lastHeaderHash') && ctx._source.lastHeaderHash != '') {
+				Map previousBlock = new HashMap();
+				previousBlock.put('nonce', ctx._source.lastNonce);
+				previousBlock.put('headerHash', ctx._source.lastHeaderHash);
+				ctx._source.previousBlocks.add(previousBlock);
+				if (ctx._source.previousBlocks.size() > %d) {
+					ctx._source.previousBlocks.remove(0);
+				}
+			}
+			ctx._source.lastNonce = params.stats.nonce;
+			ctx._source.lastHeaderHash = params.stats.headerHash;
+			if (params.stats.timestamp < ctx._source.firstInteraction) {
+				ctx._source.firstInteraction = params.stats.timestamp;
+			}
+			if (params.stats.timestamp > ctx._source.lastInteraction) {
+				ctx._source.lastInteraction = params.stats.timestamp;
+			}
+
+			if (params.stats.containsKey('deploy')) {
+				def deploy = params.stats.deploy;
+				if (!ctx._source.containsKey('deployer')) {
+					ctx._source.deployer = deploy.deployer;
+					ctx._source.currentOwner = deploy.currentOwner;
+					ctx._source.deployTxHash = deploy.deployTxHash;
+					ctx._source.deployTimestamp = deploy.timestamp;
+					ctx._source.initialCodeHash = deploy.initialCodeHash;
+					ctx._source.upgrades = new ArrayList();
+				} else if (ctx._source.deployTxHash != deploy.deployTxHash) {
+					if (!ctx._source.containsKey('upgrades')) {
+						ctx._source.upgrades = new ArrayList();
+					}
+					boolean isRecorded = false;
+					for (def upgrade : ctx._source.upgrades) {
+						if (upgrade.upgradeTxHash == deploy.deployTxHash) {
+							isRecorded = true;
+						}
+					}
+					if (!isRecorded) {
+						HashMap upgrade = new HashMap();
+						upgrade.put('upgradeTxHash', deploy.deployTxHash);
+						upgrade.put('upgrader', deploy.deployer);
+						upgrade.put('timestamp', deploy.timestamp);
+						upgrade.put('codeHash', deploy.initialCodeHash);
+						ctx._source.upgrades.add(upgrade);
+					}
+				}
+			}
+			if (params.stats.containsKey('ownerChange')) {
+				if (!ctx._source.containsKey('owners')) {
+					ctx._source.owners = new ArrayList();
+				}
+				boolean isRecorded = false;
+				for (def owner : ctx._source.owners) {
+					if (owner.txHash == params.stats.ownerChange.txHash) {
+						isRecorded = true;
+					}
+				}
+				if (!isRecorded) {
+					ctx._source.currentOwner = params.stats.ownerChange.address;
+					ctx._source.owners.add(params.stats.ownerChange);
+				}
+			}
+		}
+
+		ctx._source.numCalls += params.stats.numCalls;
+		ctx._source.numFailedCalls += params.stats.numFailedCalls;
+		for (def function : params.stats.calls.keySet()) {
+			ctx._source.calls.put(function, ctx._source.calls.getOrDefault(function, 0) + params.stats.calls.get(function));
+		}
+		for (def function : params.stats.failedCalls.keySet()) {
+			ctx._source.failedCalls.put(function, ctx._source.failedCalls.getOrDefault(function, 0) + params.stats.failedCalls.get(function));
+		}
+		ctx._source.gasUsed += params.stats.gasUsed;
+		ctx._source.egldReceived = new BigInteger(ctx._source.egldReceived).add(new BigInteger(params.stats.egldReceived)).toString();
+		for (def token : params.stats.esdtReceived.keySet()) {
+			BigInteger received = new BigInteger(ctx._source.esdtReceived.getOrDefault(token, '0'));
+			ctx._source.esdtReceived.put(token, received.add(new BigInteger(params.stats.esdtReceived.get(token))).toString());
+		}
+
+		if (params.stats.revert) {
+			return;
+		}
+`, blockshistory.MaxRevertibleBlocks) + mergeAddressesScript("params.stats.callers", "params.stats.callersRegisters", "callers", "callersRegisters", "uniqueCallers")
+
+var contractStatsTemplate = data.NewDocumentTemplate(
+	`{"scripted_upsert": true, "script": {"source": "`+converters.FormatPainlessSource(contractStatsScript)+`","lang": "painless","params": { "stats": `,
+	` }},"upsert": {}}`,
+)
+
+type contractActivity struct {
+	stats        *data.ContractStats
+	callers      *addressesSet
+	egldReceived *big.Int
+	esdtReceived map[string]*big.Int
+}
+
+func newContractActivity(address string, header coreData.HeaderHandler, headerHash []byte) *contractActivity {
+	return &contractActivity{
+		stats: &data.ContractStats{
+			Address:     address,
+			HeaderHash:  hex.EncodeToString(headerHash),
+			Nonce:       header.GetNonce(),
+			Timestamp:   time.Duration(header.GetTimeStamp()),
+			Calls:       make(map[string]int64),
+			FailedCalls: make(map[string]int64),
+		},
+		callers:      newAddressesSet(),
+		egldReceived: big.NewInt(0),
+		esdtReceived: make(map[string]*big.Int),
+	}
+}
+
+func (ca *contractActivity) addCall(caller string, function string, isFailed bool) {
+	if function == "" {
+		return
+	}
+
+	ca.stats.NumCalls++
+	ca.stats.Calls[function]++
+	ca.callers.add(caller)
+	if isFailed {
+		ca.stats.NumFailedCalls++
+		ca.stats.FailedCalls[function]++
+	}
+}
+
+func (ca *contractActivity) addReceived(value string, tokens []string, esdtValues []string) {
+	addValue(ca.egldReceived, value)
+	for idx, token := range tokens {
+		if idx >= len(esdtValues) {
+			break
+		}
+		if ca.esdtReceived[token] == nil {
+			ca.esdtReceived[token] = big.NewInt(0)
+		}
+		addValue(ca.esdtReceived[token], esdtValues[idx])
+	}
+}
+
+func (ca *contractActivity) prepareStats() *data.ContractStats {
+	sketch := hyperloglog.NewSketch()
+	for _, caller := range ca.callers.addresses {
+		sketch.Add(caller)
+	}
+
+	ca.stats.Callers = ca.callers.addresses
+	if ca.stats.Callers == nil {
+		ca.stats.Callers = make([]string, 0)
+	}
+	ca.stats.CallersRegisters = sketch.NonEmptyRegisters()
+	ca.stats.EGLDReceived = ca.egldReceived.String()
+	ca.stats.ESDTReceived = make(map[string]string, len(ca.esdtReceived))
+	for token, received := range ca.esdtReceived {
+		ca.stats.ESDTReceived[token] = received.String()
+	}
+
+	return ca.stats
+}
+
+type contractsProcessor struct {
+	pubKeyConverter core.PubkeyConverter
+	history         blocksHistoryHandler
+}
+
+// NewContractsProcessor will create a new instance of contractsProcessor. It keeps the contracts interactions of the
+// last blocks of each shard, so they can be subtracted when the blocks are reverted
+func NewContractsProcessor(pubKeyConverter core.PubkeyConverter) (*contractsProcessor, error) {
+	if check.IfNil(pubKeyConverter) {
+		return nil, dataindexer.ErrNilPubkeyConverter
+	}
+
+	return &contractsProcessor{
+		pubKeyConverter: pubKeyConverter,
+		history:         blockshistory.NewBlocksHistory(),
+	}, nil
+}
+
+// PrepareContractsStats will compute the interactions of the provided block with each smart contract of its shard
+func (cp *contractsProcessor) PrepareContractsStats(
+	header coreData.HeaderHandler,
+	headerHash []byte,
+	preparedResults *data.PreparedResults,
+	logsData *data.PreparedLogsResults,
+) []*data.ContractStats {
+	shardID := header.GetShardID()
+	contracts := make(map[string]*contractActivity)
+	getContract := func(address string) *contractActivity {
+		if contracts[address] == nil {
+			contracts[address] = newContractActivity(address, header, headerHash)
+		}
+		return contracts[address]
+	}
+
+	for _, tx := range preparedResults.Transactions {
+		if tx.ReceiverShard != shardID || !tx.IsScCall || tx.Operation == scDeployOperation {
+			continue
+		}
+
+		isFailed := tx.Status == transaction.TxStatusFail.String() || tx.Status == transaction.TxStatusInvalid.String()
+		contract := getContract(tx.Receiver)
+		contract.addCall(tx.Sender, tx.Function, isFailed)
+		contract.stats.GasUsed += int64(tx.GasUsed)
+		if !isFailed {
+			contract.addReceived(tx.Value, tx.Tokens, tx.ESDTValues)
+		}
+	}
+
+	failedScrs := getFailedHashes(logsData)
+	for _, scr := range preparedResults.ScResults {
+		if scr.ReceiverShard != shardID || !cp.isSmartContract(scr.Receiver) {
+			continue
+		}
+
+		_, isFailed := failedScrs[scr.Hash]
+		contract := getContract(scr.Receiver)
+		contract.addCall(scr.Sender, scr.Function, isFailed)
+		if !isFailed {
+			contract.addReceived(scr.Value, scr.Tokens, scr.ESDTValues)
+		}
+	}
+
+	if logsData != nil {
+		for address, deployInfo := range logsData.ScDeploys {
+			getContract(address).stats.Deploy = &data.ScDeployInfo{
+				TxHash:       deployInfo.TxHash,
+				Creator:      deployInfo.Creator,
+				CurrentOwner: deployInfo.CurrentOwner,
+				CodeHash:     deployInfo.CodeHash,
+				Timestamp:    deployInfo.Timestamp,
+			}
+		}
+		for address, ownerData := range logsData.ChangeOwnerOperations {
+			ownerChange := *ownerData
+			getContract(address).stats.OwnerChange = &ownerChange
+		}
+	}
+
+	addresses := make([]string, 0, len(contracts))
+	for address := range contracts {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	contractsStats := make([]*data.ContractStats, 0, len(addresses))
+	for _, address := range addresses {
+		contractsStats = append(contractsStats, contracts[address].prepareStats())
+	}
+
+	cp.history.Add(shardID, headerHash, contractsStats)
+
+	return contractsStats
+}
+
+func getFailedHashes(logsData *data.PreparedLogsResults) map[string]struct{} {
+	failedHashes := make(map[string]struct{})
+	if logsData == nil {
+		return failedHashes
+	}
+
+	for _, event := range logsData.DBEvents {
+		if event.Identifier == core.SignalErrorOperation || event.Identifier == core.InternalVMErrorsOperation {
+			failedHashes[event.TxHash] = struct{}{}
+		}
+	}
+
+	return failedHashes
+}
+
+func (cp *contractsProcessor) isSmartContract(address string) bool {
+	addressBytes, err := cp.pubKeyConverter.Decode(address)
+	if err != nil {
+		return false
+	}
+
+	return core.IsSmartContractAddress(addressBytes)
+}
+
+// PrepareRevertedContractsStats returns the interactions which have to be added in the documents of the smart
+// contracts to subtract the provided reverted block. If the interactions of the block are not known, nothing is returned
+// and the block which replaces the reverted one is added over the reverted block
+func (cp *contractsProcessor) PrepareRevertedContractsStats(header coreData.HeaderHandler, headerHash []byte) []*data.ContractStats {
+	value, found := cp.history.RemoveLast(header.GetShardID(), headerHash)
+	if !found {
+		log.Warn("contractsProcessor.PrepareRevertedContractsStats: the reverted block is not in the recent blocks, the contracts will not be adjusted",
+			"shard", header.GetShardID(), "nonce", header.GetNonce())
+		return nil
+	}
+
+	blockStats := value.([]*data.ContractStats)
+	reverted := make([]*data.ContractStats, 0, len(blockStats))
+	for _, stats := range blockStats {
+		reverted = append(reverted, negateContractStats(stats))
+	}
+
+	return reverted
+}
+
+func negateContractStats(stats *data.ContractStats) *data.ContractStats {
+	reverted := &data.ContractStats{
+		Address:          stats.Address,
+		HeaderHash:       stats.HeaderHash,
+		Nonce:            stats.Nonce,
+		Timestamp:        stats.Timestamp,
+		Revert:           true,
+		NumCalls:         -stats.NumCalls,
+		NumFailedCalls:   -stats.NumFailedCalls,
+		Calls:            make(map[string]int64, len(stats.Calls)),
+		FailedCalls:      make(map[string]int64, len(stats.FailedCalls)),
+		GasUsed:          -stats.GasUsed,
+		EGLDReceived:     negate(stats.EGLDReceived),
+		ESDTReceived:     make(map[string]string, len(stats.ESDTReceived)),
+		Callers:          make([]string, 0),
+		CallersRegisters: make(map[uint32]uint8),
+	}
+	for function, numCalls := range stats.Calls {
+		reverted.Calls[function] = -numCalls
+	}
+	for function, numCalls := range stats.FailedCalls {
+		reverted.FailedCalls[function] = -numCalls
+	}
+	for token, received := range stats.ESDTReceived {
+		reverted.ESDTReceived[token] = negate(received)
+	}
+
+	return reverted
+}
+
+// SerializeContractsStats will serialize the provided interactions as updates of the documents of the smart contracts,
+// which are identified by the smart contract address
+func (cp *contractsProcessor) SerializeContractsStats(contractsStats []*data.ContractStats, buffSlice *data.BufferSlice, index string) error {
+	meta := data.NewMetaTemplate(`{ "update" : { "_index":"%s", "_id" : "%s", "retry_on_conflict": 5 } }`, index)
+	for _, stats := range contractsStats {
+		err := buffSlice.PutEnclosedDocument(meta, stats.Address, contractStatsTemplate, stats)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,246 @@
+package rollups
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-es-indexer-go/client/memory"
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
+	"github.com/multiversx/mx-chain-es-indexer-go/mock"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/dataindexer"
+	"github.com/stretchr/testify/require"
+)
+
+const contractsIndex = "contracts"
+
+func createContractsProcessor(t *testing.T) *contractsProcessor {
+	cp, err := NewContractsProcessor(mock.NewPubkeyConverterMock(32))
+	require.Nil(t, err)
+
+	return cp
+}
+
+func createContractsResults() (*data.PreparedResults, *data.PreparedLogsResults) {
+	preparedResults := &data.PreparedResults{
+		Transactions: []*data.Transaction{
+			{Sender: "erd1a", Receiver: contractAddress, ReceiverShard: 1, IsScCall: true, Function: "stake", GasUsed: 100, Value: "10"},
+			{Sender: "erd1b", Receiver: contractAddress, ReceiverShard: 1, IsScCall: true, Function: "stake", GasUsed: 200, Value: "20", Status: transaction.TxStatusFail.String()},
+			{Sender: "erd1c", Receiver: contractAddress, ReceiverShard: 1, IsScCall: true, Tokens: []string{"TKN-01"}, ESDTValues: []string{"5"}},
+			{Sender: "erd1d", Receiver: contractAddress, ReceiverShard: 0, IsScCall: true, Function: "stake", GasUsed: 400},
+			{Sender: "erd1e", Receiver: userAddress, ReceiverShard: 1, Value: "1000"},
+		},
+		ScResults: []*data.ScResult{
+			{Hash: "01", Sender: "erd1a", Receiver: contractAddress, ReceiverShard: 1, Function: "claim", Value: "3"},
+			{Hash: "02", Sender: "erd1f", Receiver: contractAddress, ReceiverShard: 1, Function: "claim", Value: "4"},
+			{Hash: "03", Sender: contractAddress, Receiver: userAddress, ReceiverShard: 1, Value: "7"},
+		},
+	}
+	logsData := &data.PreparedLogsResults{
+		DBEvents: []*data.LogEvent{
+			{TxHash: "02", Identifier: core.SignalErrorOperation},
+		},
+		ScDeploys: map[string]*data.ScDeployInfo{
+			contractAddress: {TxHash: "deploy", Creator: "erd1owner", CurrentOwner: "erd1owner", CodeHash: []byte("code"), Timestamp: 5000},
+		},
+	}
+
+	return preparedResults, logsData
+}
+
+func TestNewContractsProcessor(t *testing.T) {
+	t.Parallel()
+
+	cp, err := NewContractsProcessor(nil)
+	require.Nil(t, cp)
+	require.Equal(t, dataindexer.ErrNilPubkeyConverter, err)
+}
+
+func TestContractsProcessor_PrepareContractsStats(t *testing.T) {
+	t.Parallel()
+
+	cp := createContractsProcessor(t)
+	preparedResults, logsData := createContractsResults()
+	contractsStats := cp.PrepareContractsStats(&block.Header{ShardID: 1, Nonce: 10, TimeStamp: 5000}, []byte("h10"), preparedResults, logsData)
+	require.Len(t, contractsStats, 1)
+	require.Len(t, contractsStats[0].CallersRegisters, 3)
+	contractsStats[0].CallersRegisters = nil
+
+	require.Equal(t, &data.ContractStats{
+		Address:        contractAddress,
+		HeaderHash:     "683130",
+		Nonce:          10,
+		Timestamp:      5000,
+		NumCalls:       4,
+		NumFailedCalls: 2,
+		Calls:          map[string]int64{"stake": 2, "claim": 2},
+		FailedCalls:    map[string]int64{"stake": 1, "claim": 1},
+		GasUsed:        300,
+		EGLDReceived:   "13",
+		ESDTReceived:   map[string]string{"TKN-01": "5"},
+		Callers:        []string{"erd1a", "erd1b", "erd1f"},
+		Deploy: &data.ScDeployInfo{
+			TxHash:       "deploy",
+			Creator:      "erd1owner",
+			CurrentOwner: "erd1owner",
+			CodeHash:     []byte("code"),
+			Timestamp:    5000,
+		},
+	}, contractsStats[0])
+
+	reverted := cp.PrepareRevertedContractsStats(&block.Header{ShardID: 1, Nonce: 10}, []byte("h10"))
+	require.Len(t, reverted, 1)
+	require.True(t, reverted[0].Revert)
+	require.Equal(t, int64(-4), reverted[0].NumCalls)
+	require.Equal(t, map[string]int64{"stake": -2, "claim": -2}, reverted[0].Calls)
+	require.Equal(t, "-13", reverted[0].EGLDReceived)
+	require.Empty(t, reverted[0].Callers)
+	require.Nil(t, reverted[0].Deploy)
+
+	require.Nil(t, cp.PrepareRevertedContractsStats(&block.Header{ShardID: 1, Nonce: 10}, []byte("h10")))
+}
+
+// createContractsIndexer returns a function which applies the provided interactions on an in-memory database and
+// returns the document of the smart contract, without its callers registers
+func createContractsIndexer(t *testing.T, cp *contractsProcessor) func(contractsStats []*data.ContractStats) map[string]interface{} {
+	dbClient := memory.NewDatabaseClient()
+
+	return func(contractsStats []*data.ContractStats) map[string]interface{} {
+		buffSlice := data.NewBufferSlice(0)
+		require.Nil(t, cp.SerializeContractsStats(contractsStats, buffSlice, contractsIndex))
+		for _, buff := range buffSlice.Buffers() {
+			require.Nil(t, dbClient.DoBulkRequest(context.Background(), buff, contractsIndex))
+		}
+
+		response := &data.ResponseMultiGet{}
+		require.Nil(t, dbClient.DoMultiGet(context.Background(), []string{contractAddress}, contractsIndex, true, response))
+		document := make(map[string]interface{})
+		require.Nil(t, json.Unmarshal(response.Docs[0].Source, &document))
+		delete(document, "callersRegisters")
+		return document
+	}
+}
+
+func TestContractsProcessor_UpdateContractsWithScripts(t *testing.T) {
+	t.Parallel()
+
+	cp := createContractsProcessor(t)
+	index := createContractsIndexer(t, cp)
+
+	preparedResults, logsData := createContractsResults()
+	blockStats := cp.PrepareContractsStats(&block.Header{ShardID: 1, Nonce: 10, TimeStamp: 5000}, []byte("h10"), preparedResults, logsData)
+	index(blockStats)
+	// the same block indexed again is not added twice
+	document := index(blockStats)
+	require.Equal(t, map[string]interface{}{
+		"address":          contractAddress,
+		"numCalls":         float64(4),
+		"numFailedCalls":   float64(2),
+		"calls":            map[string]interface{}{"stake": float64(2), "claim": float64(2)},
+		"failedCalls":      map[string]interface{}{"stake": float64(1), "claim": float64(1)},
+		"gasUsed":          float64(300),
+		"egldReceived":     "13",
+		"esdtReceived":     map[string]interface{}{"TKN-01": "5"},
+		"uniqueCallers":    float64(3),
+		"callers":          []interface{}{"erd1a", "erd1b", "erd1f"},
+		"firstInteraction": float64(5000),
+		"lastInteraction":  float64(5000),
+		"deployer":         "erd1owner",
+		"currentOwner":     "erd1owner",
+		"deployTxHash":     "deploy",
+		"deployTimestamp":  float64(5000),
+		"initialCodeHash":  "Y29kZQ==",
+		"upgrades":         []interface{}{},
+		"lastNonce":        float64(10),
+		"lastHeaderHash":   "683130",
+		"previousBlocks":   []interface{}{},
+	}, document)
+
+	// a block with an upgrade and an owner change, which is reverted
+	nextResults := &data.PreparedResults{
+		Transactions: []*data.Transaction{
+			{Sender: "erd1g", Receiver: contractAddress, ReceiverShard: 1, IsScCall: true, Function: "stake", GasUsed: 50, Value: "1"},
+		},
+	}
+	nextLogs := &data.PreparedLogsResults{
+		ScDeploys: map[string]*data.ScDeployInfo{
+			contractAddress: {TxHash: "upgrade", Creator: "erd1owner", CodeHash: []byte("code2"), Timestamp: 5006},
+		},
+		ChangeOwnerOperations: map[string]*data.OwnerData{
+			contractAddress: {TxHash: "change", Address: "erd1newowner", Timestamp: 5006},
+		},
+	}
+	document = index(cp.PrepareContractsStats(&block.Header{ShardID: 1, Nonce: 11, TimeStamp: 5006}, []byte("h11"), nextResults, nextLogs))
+	require.Equal(t, float64(5), document["numCalls"])
+	require.Equal(t, float64(4), document["uniqueCallers"])
+	require.Equal(t, float64(5006), document["lastInteraction"])
+	require.Equal(t, "erd1newowner", document["currentOwner"])
+	require.Equal(t, []interface{}{map[string]interface{}{"upgradeTxHash": "upgrade", "upgrader": "erd1owner", "timestamp": float64(5006), "codeHash": "Y29kZTI="}}, document["upgrades"])
+	require.Equal(t, []interface{}{map[string]interface{}{"txHash": "change", "address": "erd1newowner", "timestamp": float64(5006)}}, document["owners"])
+
+	document = index(cp.PrepareRevertedContractsStats(&block.Header{ShardID: 1, Nonce: 11}, []byte("h11")))
+	require.Equal(t, float64(4), document["numCalls"])
+	require.Equal(t, map[string]interface{}{"stake": float64(2), "claim": float64(2)}, document["calls"])
+	require.Equal(t, float64(300), document["gasUsed"])
+	require.Equal(t, "13", document["egldReceived"])
+	require.Equal(t, float64(10), document["lastNonce"])
+
+	// the block which replaces the reverted one is added, without recording again its upgrade and owner change
+	document = index(cp.PrepareContractsStats(&block.Header{ShardID: 1, Nonce: 11, TimeStamp: 5006}, []byte("h11b"), nextResults, nextLogs))
+	require.Equal(t, float64(5), document["numCalls"])
+	require.Equal(t, float64(11), document["lastNonce"])
+	require.Len(t, document["upgrades"], 1)
+	require.Len(t, document["owners"], 1)
+
+	// a block with the nonce of the last added block is not added, unless the last added block was reverted
+	document = index(cp.PrepareContractsStats(&block.Header{ShardID: 1, Nonce: 11, TimeStamp: 5006}, []byte("h11c"), nextResults, nil))
+	require.Equal(t, float64(5), document["numCalls"])
+	require.Equal(t, "68313162", document["lastHeaderHash"])
+}
+
+func TestContractsProcessor_RevertConsecutiveBlocks(t *testing.T) {
+	t.Parallel()
+
+	cp := createContractsProcessor(t)
+	index := createContractsIndexer(t, cp)
+	call := func(nonce uint64, headerHash string) []*data.ContractStats {
+		preparedResults := &data.PreparedResults{
+			Transactions: []*data.Transaction{
+				{Sender: "erd1a", Receiver: contractAddress, ReceiverShard: 1, IsScCall: true, Function: "stake", GasUsed: 10, Value: "1"},
+			},
+		}
+		return cp.PrepareContractsStats(&block.Header{ShardID: 1, Nonce: nonce, TimeStamp: 5000}, []byte(headerHash), preparedResults, nil)
+	}
+
+	// the contract is not called in the blocks with the nonces 11 and 12
+	index(call(10, "h10"))
+	index(call(13, "h13"))
+	document := index(call(14, "h14"))
+	require.Equal(t, float64(3), document["numCalls"])
+	require.Equal(t, float64(14), document["lastNonce"])
+
+	document = index(cp.PrepareRevertedContractsStats(&block.Header{ShardID: 1, Nonce: 14}, []byte("h14")))
+	require.Equal(t, float64(2), document["numCalls"])
+	require.Equal(t, float64(13), document["lastNonce"])
+	require.Equal(t, hex.EncodeToString([]byte("h13")), document["lastHeaderHash"])
+
+	document = index(cp.PrepareRevertedContractsStats(&block.Header{ShardID: 1, Nonce: 13}, []byte("h13")))
+	require.Equal(t, float64(1), document["numCalls"])
+	require.Equal(t, float64(10), document["gasUsed"])
+	require.Equal(t, float64(10), document["lastNonce"])
+	require.Equal(t, hex.EncodeToString([]byte("h10")), document["lastHeaderHash"])
+
+	// the blocks which replace the reverted ones are added
+	index(call(13, "h13b"))
+	document = index(call(14, "h14b"))
+	require.Equal(t, float64(3), document["numCalls"])
+	require.Equal(t, float64(14), document["lastNonce"])
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"nonce": float64(10), "headerHash": hex.EncodeToString([]byte("h10"))},
+		map[string]interface{}{"nonce": float64(13), "headerHash": hex.EncodeToString([]byte("h13b"))},
+	}, document["previousBlocks"])
+}
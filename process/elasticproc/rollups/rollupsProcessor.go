@@ -0,0 +1,242 @@
+package rollups
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	coreData "github.com/multiversx/mx-chain-core-go/data"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/blockshistory"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/converters"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/hyperloglog"
+	logger "github.com/multiversx/mx-chain-logger-go"
+)
+
+var log = logger.GetOrCreate("indexer/process/rollups")
+
+// the rollup documents are updated by the blocks of all the shards, so the last added nonce is kept for each shard,
+// together with the nonces added before it, up to the number of blocks which can be reverted. A block which is not newer
+// than the last added block of its shard was already added. A reverted block is subtracted only if it is the last added
+// block of its shard, and the nonce added before it becomes the last one again, so consecutive blocks can be reverted.
+// The documents saved before the previous nonces were kept fall back to the nonce before the reverted one. The unique
+// senders are estimated from the registers of a hyperloglog sketch, which keeps the highest value of each register, so
+// the senders of a reverted block cannot be removed: its activity has no registers and the unique senders are not
+// corrected
+var activityStatsScript = fmt.Sprintf(`
+		String shardID = '' + params.stats.shardID;
+		if (params.stats.revert) {
+			if (!ctx._source.containsKey('lastNonces') || ctx._source.lastNonces.get(shardID) != params.stats.nonce) {
+				ctx.op = 'noop';
+				return;
+			}
+			if (!ctx._source.containsKey('previousNonces')) {
+				ctx._source.lastNonces.put(shardID, params.stats.nonce - 1);
+			} else {
+				List previousNonces = ctx._source.previousNonces.getOrDefault(shardID, new ArrayList());
+				if (previousNonces.isEmpty()) {
+					ctx._source.lastNonces.remove(shardID);
+				} else {
+					ctx._source.lastNonces.put(shardID, previousNonces.remove(previousNonces.size() - 1));
+				}
+			}
+		} else {
+			if (!ctx._source.containsKey('lastNonces')) {
+				ctx._source.timestamp = params.stats.timestamp;
+				ctx._source.numTransactions = 0;
+				ctx._source.egldVolume = '0';
+				ctx._source.tokensVolume = new HashMap();
+				ctx._source.fees = '0';
+				ctx._source.gasUsed = 0;
+				ctx._source.uniqueSenders = 0;
+				ctx._source.sendersRegisters = new HashMap();
+				ctx._source.lastNonces = new HashMap();
+			}
+			if (!ctx._source.containsKey('previousNonces')) {
+				ctx._source.previousNonces = new HashMap();
+			}
+			if (ctx._source.lastNonces.containsKey(shardID)) {
+				if (params.stats.nonce <= ctx._source.lastNonces.get(shardID)) {
+					ctx.op = 'noop';
+					return;
+				}
+				if (!ctx._source.previousNonces.containsKey(shardID)) {
+					ctx._source.previousNonces.put(shardID, new ArrayList());
+				}
+				List previousNonces = ctx._source.previousNonces.get(shardID);
+				previousNonces.add(ctx._source.lastNonces.get(shardID));
+				if (previousNonces.size() > %d) {
+					previousNonces.remove(0);
+				}
+			}
+			ctx._source.lastNonces.put(shardID, params.stats.nonce);
+		}
+
+		ctx._source.numTransactions += params.stats.numTransactions;
+		ctx._source.gasUsed += params.stats.gasUsed;
+		ctx._source.egldVolume = new BigInteger(ctx._source.egldVolume).add(new BigInteger(params.stats.egldVolume)).toString();
+		ctx._source.fees = new BigInteger(ctx._source.fees).add(new BigInteger(params.stats.fees)).toString();
+		for (def token : params.stats.tokensVolume.keySet()) {
+			BigInteger volume = new BigInteger(ctx._source.tokensVolume.getOrDefault(token, '0'));
+			ctx._source.tokensVolume.put(token, volume.add(new BigInteger(params.stats.tokensVolume.get(token))).toString());
+		}
+
+		if (params.stats.sendersRegisters.isEmpty()) {
+			return;
+		}
+`, blockshistory.MaxRevertibleBlocks) + mergeRegistersScript("params.stats.sendersRegisters", "sendersRegisters", "uniqueSenders")
+
+// mergeRegistersScript returns the painless source which merges the provided hyperloglog registers in the registers of
+// the document, by keeping the highest value of each register, and sets the count field with the estimated number of
+// distinct values
+func mergeRegistersScript(registersParam string, registersField string, countField string) string {
+	return fmt.Sprintf(`
+		for (def index : %[3]s.keySet()) {
+			def rank = %[3]s.get(index);
+			if (rank > ctx._source.%[4]s.getOrDefault(index, 0)) {
+				ctx._source.%[4]s.put(index, rank);
+			}
+		}
+		long emptyRegisters = %[1]d - ctx._source.%[4]s.size();
+		double sum = emptyRegisters;
+		for (def rank : ctx._source.%[4]s.values()) {
+			sum += Math.pow(2, -rank);
+		}
+		double estimate = %[2]v * %[1]d * %[1]d / sum;
+		if (estimate <= 2.5 * %[1]d && emptyRegisters > 0) {
+			estimate = %[1]d * Math.log(1.0 * %[1]d / emptyRegisters);
+		}
+		ctx._source.%[5]s = Math.round(estimate);
+`, hyperloglog.NumRegisters, hyperloglog.Alpha, registersParam, registersField, countField)
+}
+
+var activityStatsTemplate = data.NewDocumentTemplate(
+	`{"scripted_upsert": true, "script": {"source": "`+converters.FormatPainlessSource(activityStatsScript)+`","lang": "painless","params": { "stats": `,
+	` }},"upsert": {}}`,
+)
+
+type rollupsProcessor struct {
+	history blocksHistoryHandler
+}
+
+// NewRollupsProcessor will create a new instance of rollupsProcessor. It keeps the activity of the last blocks of each
+// shard, so it can be subtracted from the rollups when the blocks are reverted
+func NewRollupsProcessor() *rollupsProcessor {
+	return &rollupsProcessor{
+		history: blockshistory.NewBlocksHistory(),
+	}
+}
+
+// PrepareActivityStats will compute the activity of the provided block from its transactions
+func (rp *rollupsProcessor) PrepareActivityStats(header coreData.HeaderHandler, headerHash []byte, preparedResults *data.PreparedResults) *data.ActivityStats {
+	egldVolume := big.NewInt(0)
+	fees := big.NewInt(0)
+	tokensVolume := make(map[string]*big.Int)
+	senders := hyperloglog.NewSketch()
+	stats := &data.ActivityStats{
+		Timestamp: time.Duration(header.GetTimeStamp()),
+		ShardID:   header.GetShardID(),
+		Nonce:     header.GetNonce(),
+	}
+
+	for _, tx := range preparedResults.Transactions {
+		if tx.SenderShard != header.GetShardID() {
+			continue
+		}
+
+		stats.NumTransactions++
+		stats.GasUsed += int64(tx.GasUsed)
+		addValue(fees, tx.Fee)
+		senders.Add(tx.Sender)
+
+		isFailed := tx.Status == transaction.TxStatusFail.String() || tx.Status == transaction.TxStatusInvalid.String()
+		if isFailed {
+			continue
+		}
+
+		addValue(egldVolume, tx.Value)
+		for idx, token := range tx.Tokens {
+			if idx >= len(tx.ESDTValues) {
+				break
+			}
+			if tokensVolume[token] == nil {
+				tokensVolume[token] = big.NewInt(0)
+			}
+			addValue(tokensVolume[token], tx.ESDTValues[idx])
+		}
+	}
+
+	stats.EGLDVolume = egldVolume.String()
+	stats.Fees = fees.String()
+	stats.TokensVolume = make(map[string]string, len(tokensVolume))
+	for token, volume := range tokensVolume {
+		stats.TokensVolume[token] = volume.String()
+	}
+	stats.SendersRegisters = senders.NonEmptyRegisters()
+
+	rp.history.Add(stats.ShardID, headerHash, stats)
+
+	return stats
+}
+
+func addValue(sum *big.Int, value string) {
+	bigValue, ok := big.NewInt(0).SetString(value, 10)
+	if ok {
+		sum.Add(sum, bigValue)
+	}
+}
+
+// PrepareRevertedActivityStats returns the activity which has to be added in the rollups to subtract the provided
+// reverted block. If the activity of the block is not known, e.g. the block was added before a restart, nil is returned,
+// so the rollups keep the reverted block and the block which replaces it, having the same nonce, is not added. Otherwise,
+// the reverted block would be counted twice
+func (rp *rollupsProcessor) PrepareRevertedActivityStats(header coreData.HeaderHandler, headerHash []byte) *data.ActivityStats {
+	value, found := rp.history.RemoveLast(header.GetShardID(), headerHash)
+	if !found {
+		log.Warn("rollupsProcessor.PrepareRevertedActivityStats: the reverted block is not in the recent blocks, the rollups will not be adjusted",
+			"shard", header.GetShardID(), "nonce", header.GetNonce())
+		return nil
+	}
+
+	stats := value.(*data.ActivityStats)
+	reverted := &data.ActivityStats{
+		Timestamp:        time.Duration(header.GetTimeStamp()),
+		ShardID:          header.GetShardID(),
+		Nonce:            header.GetNonce(),
+		Revert:           true,
+		NumTransactions:  -stats.NumTransactions,
+		GasUsed:          -stats.GasUsed,
+		EGLDVolume:       negate(stats.EGLDVolume),
+		TokensVolume:     make(map[string]string, len(stats.TokensVolume)),
+		Fees:             negate(stats.Fees),
+		SendersRegisters: make(map[uint32]uint8),
+	}
+	for token, volume := range stats.TokensVolume {
+		reverted.TokensVolume[token] = negate(volume)
+	}
+
+	return reverted
+}
+
+func negate(value string) string {
+	bigValue, ok := big.NewInt(0).SetString(value, 10)
+	if !ok {
+		return "0"
+	}
+
+	return bigValue.Neg(bigValue).String()
+}
+
+// SerializeActivityStats will serialize the provided activity as an update of the rollup document of the period which
+// holds the block. The documents are identified by the timestamp of the start of their period
+func (rp *rollupsProcessor) SerializeActivityStats(stats *data.ActivityStats, periodInSeconds uint64, buffSlice *data.BufferSlice, index string) error {
+	blockTimestamp := uint64(stats.Timestamp)
+	periodStats := *stats
+	periodStats.Timestamp = time.Duration(blockTimestamp - blockTimestamp%periodInSeconds)
+
+	meta := data.NewMetaTemplate(`{ "update" : { "_index":"%s", "_id" : "%s", "retry_on_conflict": 5 } }`, index)
+	id := fmt.Sprintf("%d", periodStats.Timestamp)
+
+	return buffSlice.PutEnclosedDocument(meta, id, activityStatsTemplate, periodStats)
+}
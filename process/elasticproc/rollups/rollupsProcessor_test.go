@@ -0,0 +1,175 @@
+package rollups
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-es-indexer-go/client/memory"
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+const hourlyIndex = "stats-hourly"
+
+func createPreparedResults() *data.PreparedResults {
+	return &data.PreparedResults{
+		Transactions: []*data.Transaction{
+			{Sender: "erd1a", SenderShard: 1, GasUsed: 100, Fee: "10", Value: "1000"},
+			{Sender: "erd1a", SenderShard: 1, GasUsed: 200, Fee: "20", Value: "0", Tokens: []string{"TKN-01"}, ESDTValues: []string{"5"}},
+			{Sender: "erd1b", SenderShard: 1, GasUsed: 300, Fee: "30", Value: "500", Status: transaction.TxStatusFail.String()},
+			{Sender: "erd1c", SenderShard: 0, GasUsed: 400, Fee: "40", Value: "700"},
+		},
+	}
+}
+
+func createHeader(shardID uint32, nonce uint64) *block.Header {
+	return &block.Header{
+		ShardID:   shardID,
+		Nonce:     nonce,
+		TimeStamp: 7200 + nonce*6,
+	}
+}
+
+func TestRollupsProcessor_PrepareActivityStats(t *testing.T) {
+	t.Parallel()
+
+	rp := NewRollupsProcessor()
+	stats := rp.PrepareActivityStats(createHeader(1, 10), []byte("h10"), createPreparedResults())
+	require.Len(t, stats.SendersRegisters, 2)
+	stats.SendersRegisters = nil
+	require.Equal(t, &data.ActivityStats{
+		Timestamp:       7260,
+		ShardID:         1,
+		Nonce:           10,
+		NumTransactions: 3,
+		EGLDVolume:      "1000",
+		TokensVolume:    map[string]string{"TKN-01": "5"},
+		Fees:            "60",
+		GasUsed:         600,
+	}, stats)
+}
+
+func TestRollupsProcessor_PrepareRevertedActivityStats(t *testing.T) {
+	t.Parallel()
+
+	rp := NewRollupsProcessor()
+	_ = rp.PrepareActivityStats(createHeader(1, 10), []byte("h10"), createPreparedResults())
+
+	reverted := rp.PrepareRevertedActivityStats(createHeader(1, 10), []byte("h10"))
+	require.Equal(t, &data.ActivityStats{
+		Timestamp:        7260,
+		ShardID:          1,
+		Nonce:            10,
+		Revert:           true,
+		NumTransactions:  -3,
+		EGLDVolume:       "-1000",
+		TokensVolume:     map[string]string{"TKN-01": "-5"},
+		Fees:             "-60",
+		GasUsed:          -600,
+		SendersRegisters: map[uint32]uint8{},
+	}, reverted)
+
+	// the block was removed from the history, so it is not reverted again
+	reverted = rp.PrepareRevertedActivityStats(createHeader(1, 10), []byte("h10"))
+	require.Nil(t, reverted)
+}
+
+func TestRollupsProcessor_SerializeActivityStats(t *testing.T) {
+	t.Parallel()
+
+	rp := NewRollupsProcessor()
+	stats := rp.PrepareActivityStats(createHeader(1, 10), []byte("h10"), createPreparedResults())
+
+	buffSlice := data.NewBufferSlice(0)
+	err := rp.SerializeActivityStats(stats, 3600, buffSlice, hourlyIndex)
+	require.Nil(t, err)
+	require.Len(t, buffSlice.Buffers(), 1)
+	require.Contains(t, buffSlice.Buffers()[0].String(), `{ "update" : { "_index":"stats-hourly", "_id" : "7200", "retry_on_conflict": 5 } }`)
+	require.Contains(t, buffSlice.Buffers()[0].String(), `"timestamp":7200`)
+	require.Equal(t, uint64(10), stats.Nonce)
+	require.Equal(t, 7260, int(stats.Timestamp))
+}
+
+func TestRollupsProcessor_UpdateRollupsWithScripts(t *testing.T) {
+	t.Parallel()
+
+	dbClient := memory.NewDatabaseClient()
+	rp := NewRollupsProcessor()
+	index := func(stats *data.ActivityStats) {
+		buffSlice := data.NewBufferSlice(0)
+		require.Nil(t, rp.SerializeActivityStats(stats, 3600, buffSlice, hourlyIndex))
+		for _, buff := range buffSlice.Buffers() {
+			require.Nil(t, dbClient.DoBulkRequest(context.Background(), buff, hourlyIndex))
+		}
+	}
+	getRollup := func() map[string]interface{} {
+		response := &data.ResponseMultiGet{}
+		require.Nil(t, dbClient.DoMultiGet(context.Background(), []string{"7200"}, hourlyIndex, true, response))
+		require.True(t, response.Docs[0].Found)
+
+		rollup := make(map[string]interface{})
+		require.Nil(t, json.Unmarshal(response.Docs[0].Source, &rollup))
+		delete(rollup, "sendersRegisters")
+		return rollup
+	}
+
+	// a block which was never added is not reverted
+	require.Nil(t, rp.PrepareRevertedActivityStats(createHeader(0, 1), []byte("unknown")))
+
+	shard1Stats := rp.PrepareActivityStats(createHeader(1, 10), []byte("h10"), createPreparedResults())
+	index(shard1Stats)
+	// the same block indexed twice is added only once
+	index(shard1Stats)
+	index(rp.PrepareActivityStats(createHeader(0, 20), []byte("h20"), createPreparedResults()))
+
+	require.Equal(t, map[string]interface{}{
+		"timestamp":       float64(7200),
+		"numTransactions": float64(4),
+		"egldVolume":      "1700",
+		"tokensVolume":    map[string]interface{}{"TKN-01": "5"},
+		"fees":            "100",
+		"gasUsed":         float64(1000),
+		"uniqueSenders":   float64(3),
+		"lastNonces":      map[string]interface{}{"0": float64(20), "1": float64(10)},
+		"previousNonces":  map[string]interface{}{},
+	}, getRollup())
+
+	index(rp.PrepareActivityStats(createHeader(1, 11), []byte("h11"), createPreparedResults()))
+	require.Equal(t, float64(7), getRollup()["numTransactions"])
+	require.Equal(t, map[string]interface{}{"0": float64(20), "1": float64(11)}, getRollup()["lastNonces"])
+	require.Equal(t, map[string]interface{}{"1": []interface{}{float64(10)}}, getRollup()["previousNonces"])
+
+	// two consecutive blocks are reverted, the nonce added before each of them becomes the last one again
+	index(rp.PrepareRevertedActivityStats(createHeader(1, 11), []byte("h11")))
+	require.Equal(t, float64(4), getRollup()["numTransactions"])
+	require.Equal(t, map[string]interface{}{"0": float64(20), "1": float64(10)}, getRollup()["lastNonces"])
+
+	index(rp.PrepareRevertedActivityStats(createHeader(1, 10), []byte("h10")))
+	require.Equal(t, map[string]interface{}{
+		"timestamp":       float64(7200),
+		"numTransactions": float64(1),
+		"egldVolume":      "700",
+		"tokensVolume":    map[string]interface{}{"TKN-01": "0"},
+		"fees":            "40",
+		"gasUsed":         float64(400),
+		"uniqueSenders":   float64(3),
+		"lastNonces":      map[string]interface{}{"0": float64(20)},
+		"previousNonces":  map[string]interface{}{"1": []interface{}{}},
+	}, getRollup())
+
+	// the block which replaces the reverted one is added
+	index(rp.PrepareActivityStats(createHeader(1, 10), []byte("h10b"), createPreparedResults()))
+	require.Equal(t, float64(4), getRollup()["numTransactions"])
+	require.Equal(t, map[string]interface{}{"0": float64(20), "1": float64(10)}, getRollup()["lastNonces"])
+
+	// after a restart, the activity of the block is not known, so the rollup keeps the reverted block and the block
+	// which replaces it is not added, instead of counting both of them
+	rp = NewRollupsProcessor()
+	require.Nil(t, rp.PrepareRevertedActivityStats(createHeader(0, 20), []byte("h20")))
+	index(rp.PrepareActivityStats(createHeader(0, 20), []byte("h20b"), createPreparedResults()))
+	require.Equal(t, float64(4), getRollup()["numTransactions"])
+	require.Equal(t, map[string]interface{}{"0": float64(20), "1": float64(10)}, getRollup()["lastNonces"])
+}
@@ -43,11 +43,25 @@ func (tr *templatesAndPolicyReaderNoKibana) GetElasticTemplatesAndPolicies() (ma
 	indexTemplates[indexer.ESDTsIndex] = noKibana.ESDTs.ToBuffer()
 	indexTemplates[indexer.ValuesIndex] = noKibana.Values.ToBuffer()
 	indexTemplates[indexer.EventsIndex] = noKibana.Events.ToBuffer()
+	indexTemplates[indexer.ProcessingErrorsIndex] = noKibana.ProcessingErrors.ToBuffer()
+	indexTemplates[indexer.ValidatorStatsIndex] = noKibana.ValidatorStats.ToBuffer()
+	indexTemplates[indexer.RatingHistoryIndex] = noKibana.RatingHistory.ToBuffer()
+	indexTemplates[indexer.EpochSummaryIndex] = noKibana.EpochSummary.ToBuffer()
+	indexTemplates[indexer.StatsHourlyIndex] = noKibana.StatsHourly.ToBuffer()
+	indexTemplates[indexer.StatsDailyIndex] = noKibana.StatsDaily.ToBuffer()
+	indexTemplates[indexer.ActiveAddressesIndex] = noKibana.ActiveAddresses.ToBuffer()
+	indexTemplates[indexer.ContractsIndex] = noKibana.Contracts.ToBuffer()
 
 	return indexTemplates, indexPolicies, nil
 }
 
-// GetExtraMappings will return an array of indices extra mappings
+// GetExtraMappings will return an array of indices extra mappings, which update the existing indices created with an
+// older template
 func (tr *templatesAndPolicyReaderNoKibana) GetExtraMappings() ([]templates.ExtraMapping, error) {
-	return []templates.ExtraMapping{}, nil
+	return []templates.ExtraMapping{
+		{
+			Index:    indexer.AccountsESDTIndex,
+			Mappings: noKibana.AccountsESDTTokenKeyword.ToBuffer(),
+		},
+	}, nil
 }
@@ -14,5 +14,17 @@ func TestTemplatesAndPolicyReaderNoKibana_GetElasticTemplatesAndPolicies(t *test
 	templates, policies, err := reader.GetElasticTemplatesAndPolicies()
 	require.Nil(t, err)
 	require.Len(t, policies, 0)
-	require.Len(t, templates, 23)
+	require.Len(t, templates, 31)
+}
+
+func TestTemplatesAndPolicyReaderNoKibana_GetExtraMappings(t *testing.T) {
+	t.Parallel()
+
+	reader := NewTemplatesAndPolicyReaderNoKibana()
+
+	extraMappings, err := reader.GetExtraMappings()
+	require.Nil(t, err)
+	require.Len(t, extraMappings, 1)
+	require.Equal(t, "accountsesdt", extraMappings[0].Index)
+	require.Contains(t, extraMappings[0].Mappings.String(), `"keyword":{"type":"keyword"}`)
 }
@@ -23,9 +23,15 @@ func (tr *templatesAndPolicyReaderWithKibana) GetElasticTemplatesAndPolicies() (
 	return indexTemplates, indexPolicies, nil
 }
 
-// GetExtraMappings will return an array of indices extra mappings
+// GetExtraMappings will return an array of indices extra mappings, which update the existing indices created with an
+// older template
 func (tr *templatesAndPolicyReaderWithKibana) GetExtraMappings() ([]templates.ExtraMapping, error) {
-	return []templates.ExtraMapping{}, nil
+	return []templates.ExtraMapping{
+		{
+			Index:    indexer.AccountsESDTIndex,
+			Mappings: withKibana.AccountsESDTTokenKeyword.ToBuffer(),
+		},
+	}, nil
 }
 
 func getTemplatesKibana() map[string]*bytes.Buffer {
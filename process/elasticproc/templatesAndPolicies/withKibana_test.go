@@ -16,3 +16,15 @@ func TestTemplatesAndPolicyReaderWithKibana_GetElasticTemplatesAndPolicies(t *te
 	require.Len(t, policies, 12)
 	require.Len(t, templates, 21)
 }
+
+func TestTemplatesAndPolicyReaderWithKibana_GetExtraMappings(t *testing.T) {
+	t.Parallel()
+
+	reader := NewTemplatesAndPolicyReaderWithKibana()
+
+	extraMappings, err := reader.GetExtraMappings()
+	require.Nil(t, err)
+	require.Len(t, extraMappings, 1)
+	require.Equal(t, "accountsesdt", extraMappings[0].Index)
+	require.Contains(t, extraMappings[0].Mappings.String(), `"keyword":{"type":"keyword"}`)
+}
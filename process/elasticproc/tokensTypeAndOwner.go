@@ -0,0 +1,55 @@
+package elasticproc
+
+import (
+	"context"
+
+	"github.com/multiversx/mx-chain-es-indexer-go/core/request"
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
+	elasticIndexer "github.com/multiversx/mx-chain-es-indexer-go/process/dataindexer"
+)
+
+// getTokensTypeAndOwner returns the type and the current owner of the provided tokens. Only the tokens that are not
+// in the tokens cache are requested from the tokens index, and the found ones are added in the cache
+func (ei *elasticProcessor) getTokensTypeAndOwner(tokens []string, shardID uint32) (*data.ResponseTokens, error) {
+	cachedTokens, missingTokens, cacheVersion := ei.tokensCache.Get(tokens)
+
+	responseTokens := &data.ResponseTokens{}
+	if len(missingTokens) > 0 {
+		ctxWithValue := context.WithValue(context.Background(), request.ContextKey, request.ExtendTopicWithShardID(request.GetTopic, shardID))
+		err := ei.elasticClient.DoMultiGet(ctxWithValue, missingTokens, elasticIndexer.TokensIndex, true, responseTokens)
+		if err != nil {
+			return nil, err
+		}
+
+		foundTokens := make(map[string]data.SourceToken)
+		for _, tokenDoc := range responseTokens.Docs {
+			if tokenDoc.Found {
+				foundTokens[tokenDoc.ID] = tokenDoc.Source
+			}
+		}
+		ei.tokensCache.Put(cacheVersion, foundTokens)
+	}
+
+	for token, source := range cachedTokens {
+		responseTokens.Docs = append(responseTokens.Docs, data.ResponseTokenDB{
+			Found:  true,
+			ID:     token,
+			Source: source,
+		})
+	}
+
+	return responseTokens, nil
+}
+
+func (ei *elasticProcessor) removeTokensFromCache(tokensInfo []*data.TokenInfo) {
+	if len(tokensInfo) == 0 {
+		return
+	}
+
+	tokens := make([]string, 0, len(tokensInfo))
+	for _, tokenInfo := range tokensInfo {
+		tokens = append(tokens, tokenInfo.Token)
+	}
+
+	ei.tokensCache.Remove(tokens)
+}
@@ -0,0 +1,87 @@
+package elasticproc
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	dataBlock "github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
+	"github.com/multiversx/mx-chain-es-indexer-go/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElasticProcessor_GetTokensTypeAndOwnerShouldUseTheCache(t *testing.T) {
+	t.Parallel()
+
+	requestedTokens := make([][]string, 0)
+	args := createMockElasticProcessorArgs()
+	args.DBClient = &mock.DatabaseWriterStub{
+		DoMultiGetCalled: func(ids []string, index string, withSource bool, response interface{}) error {
+			requestedTokens = append(requestedTokens, ids)
+
+			docs := make([]data.ResponseTokenDB, 0, len(ids))
+			for _, id := range ids {
+				docs = append(docs, data.ResponseTokenDB{
+					Found:  id != "NOT-FOUND",
+					ID:     id,
+					Source: data.SourceToken{Type: core.NonFungibleESDT, CurrentOwner: "owner-" + id},
+				})
+			}
+			resBytes, _ := json.Marshal(data.ResponseTokens{Docs: docs})
+			return json.Unmarshal(resBytes, response)
+		},
+	}
+	ei := newElasticsearchProcessor(args.DBClient, args)
+
+	responseTokens, err := ei.getTokensTypeAndOwner([]string{"NFT-01", "NOT-FOUND"}, 0)
+	require.Nil(t, err)
+	require.Len(t, responseTokens.Docs, 2)
+
+	responseTokens, err = ei.getTokensTypeAndOwner([]string{"NFT-01", "NFT-02", "NOT-FOUND"}, 0)
+	require.Nil(t, err)
+	require.Len(t, responseTokens.Docs, 3)
+	require.Equal(t, [][]string{{"NFT-01", "NOT-FOUND"}, {"NFT-02", "NOT-FOUND"}}, requestedTokens)
+
+	tokensInfo := data.NewTokensInfo()
+	tokensInfo.Add(&data.TokenInfo{Token: "NFT-01", Identifier: "NFT-01-01"})
+	tokensInfo.AddTypeAndOwnerFromResponse(responseTokens)
+	require.Equal(t, core.NonFungibleESDT, tokensInfo.GetAll()[0].Type)
+	require.Equal(t, "owner-NFT-01", tokensInfo.GetAll()[0].CurrentOwner)
+
+	// a change of owner removes the token from the cache
+	ei.removeTokensFromCache([]*data.TokenInfo{{Token: "NFT-01", TransferOwnership: true}})
+	_, err = ei.getTokensTypeAndOwner([]string{"NFT-01", "NFT-02"}, 0)
+	require.Nil(t, err)
+	require.Equal(t, []string{"NFT-01"}, requestedTokens[2])
+}
+
+func TestElasticProcessor_RemoveTransactionsShouldFlushTheTokensCache(t *testing.T) {
+	t.Parallel()
+
+	numMultiGets := 0
+	args := createMockElasticProcessorArgs()
+	args.DBClient = &mock.DatabaseWriterStub{
+		DoMultiGetCalled: func(ids []string, index string, withSource bool, response interface{}) error {
+			numMultiGets++
+			resBytes, _ := json.Marshal(data.ResponseTokens{Docs: []data.ResponseTokenDB{{Found: true, ID: ids[0]}}})
+			return json.Unmarshal(resBytes, response)
+		},
+		DoQueryRemoveCalled: func(index string, body *bytes.Buffer) error {
+			return nil
+		},
+	}
+	args.TransactionsProc = &mock.DBTransactionProcessorStub{}
+	ei := newElasticsearchProcessor(args.DBClient, args)
+
+	_, _ = ei.getTokensTypeAndOwner([]string{"SFT-01"}, 0)
+	_, _ = ei.getTokensTypeAndOwner([]string{"SFT-01"}, 0)
+	require.Equal(t, 1, numMultiGets)
+
+	err := ei.RemoveTransactions(&dataBlock.Header{}, &dataBlock.Body{})
+	require.Nil(t, err)
+
+	_, _ = ei.getTokensTypeAndOwner([]string{"SFT-01"}, 0)
+	require.Equal(t, 2, numMultiGets)
+}
@@ -0,0 +1,140 @@
+package tokenscache
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
+)
+
+// ErrInvalidCapacity signals that an invalid capacity has been provided
+var ErrInvalidCapacity = errors.New("invalid tokens cache capacity")
+
+type cacheEntry struct {
+	token  string
+	source data.SourceToken
+}
+
+// tokensCache is a bounded LRU cache that holds the type and the current owner of the tokens, as they are found in
+// the tokens index. Every invalidation increments the version of the cache, so the responses of the requests
+// started before an invalidation are not added in the cache, since they might contain stale data
+type tokensCache struct {
+	mutex    sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	lruList  *list.List
+	version  uint64
+}
+
+// NewTokensCache will create a new instance of tokensCache. A capacity of 0 disables the cache
+func NewTokensCache(capacity int) (*tokensCache, error) {
+	if capacity < 0 {
+		return nil, ErrInvalidCapacity
+	}
+
+	return &tokensCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		lruList:  list.New(),
+	}, nil
+}
+
+// Get will return the cached data of the provided tokens, the tokens that are not in the cache and the current
+// version of the cache, which has to be provided when the missing tokens are added
+func (tc *tokensCache) Get(tokens []string) (map[string]data.SourceToken, []string, uint64) {
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+
+	cached := make(map[string]data.SourceToken)
+	missing := make([]string, 0)
+	for _, token := range tokens {
+		element, found := tc.entries[token]
+		if !found {
+			missing = append(missing, token)
+			continue
+		}
+
+		tc.lruList.MoveToFront(element)
+		cached[token] = element.Value.(*cacheEntry).source
+	}
+
+	return cached, missing, tc.version
+}
+
+// Put will add the provided tokens in the cache, if the cache was not invalidated since the provided version
+func (tc *tokensCache) Put(version uint64, tokens map[string]data.SourceToken) {
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+
+	if version != tc.version || tc.capacity == 0 {
+		return
+	}
+
+	for token, source := range tokens {
+		element, found := tc.entries[token]
+		if found {
+			element.Value.(*cacheEntry).source = source
+			tc.lruList.MoveToFront(element)
+			continue
+		}
+
+		tc.entries[token] = tc.lruList.PushFront(&cacheEntry{
+			token:  token,
+			source: source,
+		})
+		if tc.lruList.Len() > tc.capacity {
+			tc.evictOldest()
+		}
+	}
+}
+
+func (tc *tokensCache) evictOldest() {
+	oldest := tc.lruList.Back()
+	tc.lruList.Remove(oldest)
+	delete(tc.entries, oldest.Value.(*cacheEntry).token)
+}
+
+// Remove will remove the provided tokens from the cache
+func (tc *tokensCache) Remove(tokens []string) {
+	if len(tokens) == 0 {
+		return
+	}
+
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+
+	tc.version++
+	for _, token := range tokens {
+		element, found := tc.entries[token]
+		if !found {
+			continue
+		}
+
+		tc.lruList.Remove(element)
+		delete(tc.entries, token)
+	}
+}
+
+// Clear will remove all the tokens from the cache
+func (tc *tokensCache) Clear() {
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+
+	tc.version++
+	tc.entries = make(map[string]*list.Element)
+	tc.lruList.Init()
+}
+
+// Len returns the number of cached tokens
+func (tc *tokensCache) Len() int {
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+
+	return tc.lruList.Len()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (tc *tokensCache) IsInterfaceNil() bool {
+	return tc == nil
+}
@@ -0,0 +1,100 @@
+package tokenscache
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTokensCache(t *testing.T) {
+	t.Parallel()
+
+	tc, err := NewTokensCache(-1)
+	require.Nil(t, tc)
+	require.Equal(t, ErrInvalidCapacity, err)
+
+	tc, err = NewTokensCache(10)
+	require.Nil(t, err)
+	require.False(t, tc.IsInterfaceNil())
+}
+
+func TestTokensCache_GetAndPut(t *testing.T) {
+	t.Parallel()
+
+	tc, _ := NewTokensCache(10)
+
+	cached, missing, version := tc.Get([]string{"TKN-01", "TKN-02"})
+	require.Empty(t, cached)
+	require.Equal(t, []string{"TKN-01", "TKN-02"}, missing)
+
+	tc.Put(version, map[string]data.SourceToken{
+		"TKN-01": {Type: "NonFungibleESDT", CurrentOwner: "owner"},
+	})
+
+	cached, missing, _ = tc.Get([]string{"TKN-01", "TKN-02"})
+	require.Equal(t, map[string]data.SourceToken{"TKN-01": {Type: "NonFungibleESDT", CurrentOwner: "owner"}}, cached)
+	require.Equal(t, []string{"TKN-02"}, missing)
+}
+
+func TestTokensCache_ShouldEvictTheLeastRecentlyUsedTokens(t *testing.T) {
+	t.Parallel()
+
+	tc, _ := NewTokensCache(2)
+
+	_, _, version := tc.Get(nil)
+	tc.Put(version, map[string]data.SourceToken{"TKN-01": {}})
+	tc.Put(version, map[string]data.SourceToken{"TKN-02": {}})
+
+	// TKN-01 becomes the most recently used one
+	_, _, _ = tc.Get([]string{"TKN-01"})
+	tc.Put(version, map[string]data.SourceToken{"TKN-03": {}})
+
+	require.Equal(t, 2, tc.Len())
+	_, missing, _ := tc.Get([]string{"TKN-01", "TKN-02", "TKN-03"})
+	require.Equal(t, []string{"TKN-02"}, missing)
+}
+
+func TestTokensCache_PutAfterInvalidationShouldBeIgnored(t *testing.T) {
+	t.Parallel()
+
+	tc, _ := NewTokensCache(10)
+
+	_, _, version := tc.Get([]string{"TKN-01"})
+	tc.Remove([]string{"TKN-01"})
+	tc.Put(version, map[string]data.SourceToken{"TKN-01": {Type: "SemiFungibleESDT"}})
+	require.Equal(t, 0, tc.Len())
+
+	_, _, version = tc.Get([]string{"TKN-01"})
+	tc.Put(version, map[string]data.SourceToken{"TKN-01": {Type: "MetaESDT"}})
+	require.Equal(t, 1, tc.Len())
+
+	tc.Remove([]string{"TKN-01"})
+	require.Equal(t, 0, tc.Len())
+}
+
+func TestTokensCache_Clear(t *testing.T) {
+	t.Parallel()
+
+	tc, _ := NewTokensCache(10)
+
+	_, _, version := tc.Get(nil)
+	tc.Put(version, map[string]data.SourceToken{"TKN-01": {}, "TKN-02": {}})
+	require.Equal(t, 2, tc.Len())
+
+	tc.Clear()
+	require.Equal(t, 0, tc.Len())
+
+	tc.Put(version, map[string]data.SourceToken{"TKN-01": {}})
+	require.Equal(t, 0, tc.Len())
+}
+
+func TestTokensCache_ZeroCapacityShouldDisableTheCache(t *testing.T) {
+	t.Parallel()
+
+	tc, _ := NewTokensCache(0)
+
+	_, _, version := tc.Get(nil)
+	tc.Put(version, map[string]data.SourceToken{"TKN-01": {}})
+	require.Equal(t, 0, tc.Len())
+}
@@ -11,16 +11,71 @@ import (
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/converters"
 )
 
+const (
+	esdtTransferOnSourceScript = `
+				if ('create' == ctx.op) {
+					ctx._source = params.tx;
+				} else {
+					ctx._source.gasUsed = params.tx.gasUsed;
+					ctx._source.fee = params.tx.fee;
+					ctx._source.feeNum = params.tx.feeNum;
+				}
+			`
+	esdtTransferOnDestinationScript = `
+		if ('create' == ctx.op) {
+			ctx._source = params.tx;
+		} else {
+			def gasUsed = ctx._source.gasUsed;
+			def fee = ctx._source.fee;
+			def feeNum = ctx._source.feeNum;
+			ctx._source = params.tx;
+			ctx._source.gasUsed = gasUsed;
+			ctx._source.fee = fee;
+			ctx._source.feeNum = feeNum;
+		}
+`
+	nftTransferOrMultiTransferScript = `
+		if ('create' == ctx.op) {
+			ctx._source = params.tx;
+		} else {
+			def status = ctx._source.status;
+			def errorEvent = ctx._source.errorEvent;
+			def completedEvent = ctx._source.completedEvent;
+
+			ctx._source = params.tx;
+			if (!status.isEmpty()) {
+				ctx._source.status = status;
+			}
+			if (errorEvent != null) {
+				ctx._source.errorEvent = errorEvent;
+			}
+			if (completedEvent != null) {
+				ctx._source.completedEvent = completedEvent;
+			}
+		}
+`
+)
+
+// the scripts are formatted once, only the serialized transaction is written for every document
+var (
+	esdtTransferOnSourceTemplate       = newTxScriptTemplate(esdtTransferOnSourceScript)
+	esdtTransferOnDestinationTemplate  = newTxScriptTemplate(esdtTransferOnDestinationScript)
+	nftTransferOrMultiTransferTemplate = newTxScriptTemplate(nftTransferOrMultiTransferScript)
+	crossShardOnSourceTemplate         = data.NewDocumentTemplate(`{"script":{"source":"return"},"upsert":`, `}`)
+)
+
+func newTxScriptTemplate(script string) *data.DocumentTemplate {
+	return data.NewDocumentTemplate(
+		`{"scripted_upsert": true, "script":{"source":"`+converters.FormatPainlessSource(script)+`","lang": "painless","params":{"tx": `,
+		`}},"upsert":{}}`,
+	)
+}
+
 // SerializeScResults will serialize the provided smart contract results in a way that ElasticSearch expects a bulk request
 func (tdp *txsDatabaseProcessor) SerializeScResults(scResults []*data.ScResult, buffSlice *data.BufferSlice, index string) error {
+	meta := data.NewMetaTemplate(`{ "index" : { "_index": "%s", "_id" : "%s" } }`, index)
 	for _, sc := range scResults {
-		meta := []byte(fmt.Sprintf(`{ "index" : { "_index": "%s", "_id" : "%s" } }%s`, index, converters.JsonEscape(sc.Hash), "\n"))
-		serializedData, errPrepareSc := json.Marshal(sc)
-		if errPrepareSc != nil {
-			return errPrepareSc
-		}
-
-		err := buffSlice.PutData(meta, serializedData)
+		err := buffSlice.PutDocument(meta, sc.Hash, sc)
 		if err != nil {
 			return err
 		}
@@ -31,14 +86,9 @@ func (tdp *txsDatabaseProcessor) SerializeScResults(scResults []*data.ScResult,
 
 // SerializeReceipts will serialize the receipts in a way that ElasticSearch expects a bulk request
 func (tdp *txsDatabaseProcessor) SerializeReceipts(receipts []*data.Receipt, buffSlice *data.BufferSlice, index string) error {
+	meta := data.NewMetaTemplate(`{ "index" : { "_index": "%s", "_id" : "%s" } }`, index)
 	for _, rec := range receipts {
-		meta := []byte(fmt.Sprintf(`{ "index" : { "_index": "%s", "_id" : "%s" } }%s`, index, converters.JsonEscape(rec.Hash), "\n"))
-		serializedData, errPrepareReceipt := json.Marshal(rec)
-		if errPrepareReceipt != nil {
-			return errPrepareReceipt
-		}
-
-		err := buffSlice.PutData(meta, serializedData)
+		err := buffSlice.PutDocument(meta, rec.Hash, rec)
 		if err != nil {
 			return err
 		}
@@ -120,13 +170,11 @@ func (tdp *txsDatabaseProcessor) SerializeTransactions(
 	buffSlice *data.BufferSlice,
 	index string,
 ) error {
+	updateMeta := data.NewMetaTemplate(`{"update":{ "_index":"%s", "_id":"%s"}}`, index)
+	indexMeta := data.NewMetaTemplate(`{ "index" : { "_index":"%s", "_id" : "%s" } }`, index)
 	for _, tx := range transactions {
-		meta, serializedData, err := prepareSerializedDataForATransaction(tx, selfShardID, index)
-		if err != nil {
-			return err
-		}
-
-		err = buffSlice.PutData(meta, serializedData)
+		meta, docTemplate := getTransactionTemplates(tx, selfShardID, updateMeta, indexMeta)
+		err := buffSlice.PutEnclosedDocument(meta, tx.Hash, docTemplate, tx)
 		if err != nil {
 			return err
 		}
@@ -181,101 +229,31 @@ func serializeTxHashStatus(buffSlice *data.BufferSlice, txHashStatusInfo map[str
 	return nil
 }
 
-func prepareSerializedDataForATransaction(
+// getTransactionTemplates returns the action line and the document templates of a transaction. The intra-shard, the
+// invalid and the cross-shard on destination transactions are indexed as they are, without a script
+func getTransactionTemplates(
 	tx *data.Transaction,
 	selfShardID uint32,
-	index string,
-) ([]byte, []byte, error) {
-	metaData := []byte(fmt.Sprintf(`{"update":{ "_index":"%s", "_id":"%s"}}%s`, index, converters.JsonEscape(tx.Hash), "\n"))
-	marshaledTx, err := json.Marshal(tx)
-	if err != nil {
-		return nil, nil, err
-	}
-
+	updateMeta *data.MetaTemplate,
+	indexMeta *data.MetaTemplate,
+) (*data.MetaTemplate, *data.DocumentTemplate) {
 	if isCrossShardOnSourceShard(tx, selfShardID) {
 		if isSimpleESDTTransfer(tx) {
-			codeToExecute := `
-				if ('create' == ctx.op) {
-					ctx._source = params.tx;
-				} else {
-					ctx._source.gasUsed = params.tx.gasUsed;
-					ctx._source.fee = params.tx.fee;
-					ctx._source.feeNum = params.tx.feeNum;
-				}
-			`
-			serializedData := []byte(fmt.Sprintf(`{"scripted_upsert": true, "script":{"source":"%s","lang": "painless","params":{"tx": %s}},"upsert":{}}`,
-				converters.FormatPainlessSource(codeToExecute), string(marshaledTx)))
-
-			return metaData, serializedData, nil
+			return updateMeta, esdtTransferOnSourceTemplate
 		}
 
-		serializedData := []byte(fmt.Sprintf(`{"script":{"source":"return"},"upsert":%s}`, string(marshaledTx)))
-
-		return metaData, serializedData, nil
+		return updateMeta, crossShardOnSourceTemplate
 	}
 
 	if isNFTTransferOrMultiTransfer(tx) {
-		serializedData, errPrep := prepareNFTESDTTransferOrMultiESDTTransfer(marshaledTx)
-		if errPrep != nil {
-			return nil, nil, err
-		}
-
-		return metaData, serializedData, nil
+		return updateMeta, nftTransferOrMultiTransferTemplate
 	}
 
 	if isSimpleESDTTransferCrossShardOnDestination(tx, selfShardID) {
-		return metaData, prepareSerializedDataForESDTTransferOnDestination(marshaledTx), nil
+		return updateMeta, esdtTransferOnDestinationTemplate
 	}
 
-	// transaction is intra-shard, invalid or cross-shard destination me
-	meta := []byte(fmt.Sprintf(`{ "index" : { "_index":"%s", "_id" : "%s" } }%s`, index, converters.JsonEscape(tx.Hash), "\n"))
-
-	return meta, marshaledTx, nil
-}
-
-func prepareSerializedDataForESDTTransferOnDestination(marshaledTx []byte) []byte {
-	codeToExecute := `
-		if ('create' == ctx.op) {
-			ctx._source = params.tx;
-		} else {
-			def gasUsed = ctx._source.gasUsed;
-			def fee = ctx._source.fee;
-			def feeNum = ctx._source.feeNum;
-			ctx._source = params.tx;
-			ctx._source.gasUsed = gasUsed;
-			ctx._source.fee = fee;
-			ctx._source.feeNum = feeNum;
-		}
-`
-	return []byte(fmt.Sprintf(`{"scripted_upsert": true, "script":{"source":"%s","lang": "painless","params":{"tx": %s}},"upsert":{}}`,
-		converters.FormatPainlessSource(codeToExecute), string(marshaledTx)))
-}
-
-func prepareNFTESDTTransferOrMultiESDTTransfer(marshaledTx []byte) ([]byte, error) {
-	codeToExecute := `
-		if ('create' == ctx.op) {
-			ctx._source = params.tx;
-		} else {
-			def status = ctx._source.status;
-			def errorEvent = ctx._source.errorEvent;
-			def completedEvent = ctx._source.completedEvent;
-
-			ctx._source = params.tx;
-			if (!status.isEmpty()) {
-				ctx._source.status = status;
-			}
-			if (errorEvent != null) {
-				ctx._source.errorEvent = errorEvent;
-			}
-			if (completedEvent != null) {
-				ctx._source.completedEvent = completedEvent;
-			}
-		}
-`
-	serializedData := []byte(fmt.Sprintf(`{"scripted_upsert": true, "script":{"source":"%s","lang": "painless","params":{"tx": %s}},"upsert":{}}`,
-		converters.FormatPainlessSource(codeToExecute), string(marshaledTx)))
-
-	return serializedData, nil
+	return indexMeta, nil
 }
 
 func isNFTTransferOrMultiTransfer(tx *data.Transaction) bool {
@@ -1,6 +1,9 @@
 package transactions
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
 	"testing"
 
 	"github.com/multiversx/mx-chain-core-go/data/outport"
@@ -150,3 +153,53 @@ func TestTxsDatabaseProcessor_SerializeTransactionWithRefund(t *testing.T) {
 `
 	require.Equal(t, expectedBuff, buffSlice.Buffers()[0].String())
 }
+
+func loadTransactionFixture(b *testing.B) *data.Transaction {
+	fixture, err := os.ReadFile("../../../integrationtests/testdata/transactions/move-balance.json")
+	require.Nil(b, err)
+
+	tx := &data.Transaction{}
+	require.Nil(b, json.Unmarshal(fixture, tx))
+
+	return tx
+}
+
+// createBenchmarkTransactions returns a mix of intra-shard transactions, cross-shard transactions on source and on
+// destination and NFT transfers, all of them built from the move balance fixture
+func createBenchmarkTransactions(b *testing.B, numTxs int) []*data.Transaction {
+	fixture := loadTransactionFixture(b)
+
+	txs := make([]*data.Transaction, 0, numTxs)
+	for idx := 0; idx < numTxs; idx++ {
+		tx := *fixture
+		tx.Hash = fmt.Sprintf("%064x", idx)
+		switch idx % 4 {
+		case 1:
+			tx.ReceiverShard = 1
+		case 2:
+			tx.SenderShard = 1
+			tx.Operation = "ESDTTransfer"
+		case 3:
+			tx.Data = []byte("ESDTNFTTransfer@4e46542d616263646566@01@01@726563656976657220")
+		}
+		txs = append(txs, &tx)
+	}
+
+	return txs
+}
+
+func BenchmarkSerializeTransactions(b *testing.B) {
+	txs := createBenchmarkTransactions(b, 1000)
+	txsDBProc := &txsDatabaseProcessor{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buffSlice := data.NewBufferSlice(data.DefaultMaxBulkSize)
+		err := txsDBProc.SerializeTransactions(txs, nil, 0, buffSlice, "transactions")
+		if err != nil {
+			b.Fatal(err)
+		}
+		data.ReleaseBuffers(buffSlice.Buffers())
+	}
+}
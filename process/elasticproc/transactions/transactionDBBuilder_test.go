@@ -2,6 +2,7 @@ package transactions
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"testing"
@@ -282,3 +283,43 @@ func TestGetMoveBalanceTransactionInvalid(t *testing.T) {
 	dbTx.UUID = ""
 	require.Equal(t, expectedTx, dbTx)
 }
+
+func FuzzDBTransactionBuilder_PrepareTransactionDataField(f *testing.F) {
+	sender := []byte("erd1sender00000000000000000000000")
+	contract := make([]byte, 32)
+	f.Add([]byte("ESDTTransfer@544f4b454e2d616263646566@0a"), sender, sender, uint32(3), uint8(block.TxBlock))
+	f.Add([]byte("ESDTNFTTransfer@4e46542d616263646566@01@01@"+hex.EncodeToString(contract)+"@636c61696d"), sender, sender, uint32(3), uint8(block.TxBlock))
+	f.Add([]byte("MultiESDTNFTTransfer@"+hex.EncodeToString(contract)+"@02@544f4b@@0a@4e4654@01@01"), sender, sender, uint32(3), uint8(block.TxBlock))
+	f.Add([]byte("relayedTx@7b226e6f6e6365223a317d"), sender, contract, uint32(1), uint8(block.InvalidBlock))
+	f.Add([]byte("relayedTxV2@"+hex.EncodeToString(contract)+"@01@636c61696d@"), sender, contract, uint32(0), uint8(block.InvalidBlock))
+	f.Add([]byte("@@@@"), []byte{}, []byte{}, uint32(0), uint8(0))
+
+	cp := createCommonProcessor()
+	header := &block.Header{Nonce: 2}
+
+	f.Fuzz(func(t *testing.T, dataField []byte, senderAddr []byte, receiverAddr []byte, numOfShards uint32, mbType uint8) {
+		txInfo := &outport.TxInfo{
+			Transaction: &transaction.Transaction{
+				Value:   big.NewInt(10),
+				SndAddr: senderAddr,
+				RcvAddr: receiverAddr,
+				Data:    dataField,
+			},
+			FeeInfo: &outport.FeeInfo{
+				Fee:            big.NewInt(1),
+				InitialPaidFee: big.NewInt(1),
+			},
+		}
+		mb := &block.MiniBlock{Type: block.Type(mbType)}
+
+		dbTx := cp.prepareTransaction(txInfo, []byte("txHash"), []byte("mbHash"), mb, header, "success", numOfShards)
+		require.NotNil(t, dbTx)
+		require.LessOrEqual(t, len(dbTx.Function), data.MaxFieldLength)
+		for _, token := range dbTx.Tokens {
+			require.LessOrEqual(t, len(token), data.MaxFieldLength)
+		}
+
+		_, err := json.Marshal(dbTx)
+		require.Nil(t, err)
+	})
+}
@@ -0,0 +1,74 @@
+package validators
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	coreData "github.com/multiversx/mx-chain-core-go/data"
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
+)
+
+// epochStartTimestamps keeps the timestamps of the metachain epoch start blocks of the last epochs, since the ratings
+// of an epoch are received after its epoch start block
+type epochStartTimestamps struct {
+	mutex      sync.RWMutex
+	timestamps map[uint32]uint64
+}
+
+func newEpochStartTimestamps() *epochStartTimestamps {
+	return &epochStartTimestamps{
+		timestamps: make(map[uint32]uint64),
+	}
+}
+
+func (est *epochStartTimestamps) put(epoch uint32, timestamp uint64) {
+	est.mutex.Lock()
+	defer est.mutex.Unlock()
+
+	est.timestamps[epoch] = timestamp
+	for cachedEpoch := range est.timestamps {
+		if cachedEpoch+numEpochsToKeep <= epoch {
+			delete(est.timestamps, cachedEpoch)
+		}
+	}
+}
+
+func (est *epochStartTimestamps) get(epoch uint32) (uint64, bool) {
+	est.mutex.RLock()
+	defer est.mutex.RUnlock()
+
+	timestamp, found := est.timestamps[epoch]
+
+	return timestamp, found
+}
+
+// PutEpochStartHeader keeps the timestamp of the provided header, if it is a metachain epoch start block
+func (vp *validatorsProcessor) PutEpochStartHeader(header coreData.HeaderHandler) {
+	if header.GetShardID() != core.MetachainShardId || !header.IsStartOfEpochBlock() {
+		return
+	}
+
+	vp.epochStartTimestamps.put(header.GetEpoch(), header.GetTimeStamp())
+}
+
+// GetEpochStartTimestamp returns the timestamp of the metachain epoch start block of the provided epoch, if it is known.
+// After a restart, it should be loaded from the blocks index with PutEpochStartBlockFromResponse
+func (vp *validatorsProcessor) GetEpochStartTimestamp(epoch uint32) (uint64, bool) {
+	return vp.epochStartTimestamps.get(epoch)
+}
+
+// PutEpochStartBlockFromResponse keeps the timestamp of the epoch start block found by the provided search response
+func (vp *validatorsProcessor) PutEpochStartBlockFromResponse(epoch uint32, response *data.ResponseSearch) {
+	for _, hit := range response.Hits.Hits {
+		block := &data.Block{}
+		err := json.Unmarshal(hit.Source, block)
+		if err != nil {
+			log.Debug("validatorsProcessor.PutEpochStartBlockFromResponse: cannot decode block", "id", hit.ID, "error", err)
+			continue
+		}
+
+		vp.epochStartTimestamps.put(epoch, uint64(block.Timestamp))
+		return
+	}
+}
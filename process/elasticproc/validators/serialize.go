@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/multiversx/mx-chain-core-go/data/outport"
 	"github.com/multiversx/mx-chain-es-indexer-go/data"
@@ -34,3 +35,29 @@ func (vp *validatorsProcessor) SerializeValidatorsRating(ratingData *outport.Val
 
 	return buffSlice.Buffers(), nil
 }
+
+// SerializeValidatorsRatingHistory will serialize validators rating as a document for each validator and epoch, so the
+// ratings of the previous epochs are kept and a rating received again overwrites the same document. Each document holds
+// the timestamp of the epoch start block, which is omitted when the block is not known
+func (vp *validatorsProcessor) SerializeValidatorsRatingHistory(ratingData *outport.ValidatorsRating, epochStartTimestamp uint64, buffSlice *data.BufferSlice, index string) error {
+	meta := data.NewMetaTemplate(`{ "index" : { "_index":"%s", "_id" : "%s" } }`, index)
+	for _, ratingInfo := range ratingData.ValidatorsRatingInfo {
+		if ratingInfo == nil {
+			continue
+		}
+
+		ratingHistory := &data.ValidatorRatingHistory{
+			PublicKey: ratingInfo.PublicKey,
+			Epoch:     ratingData.Epoch,
+			Rating:    ratingInfo.Rating,
+			Timestamp: time.Duration(epochStartTimestamp),
+		}
+		id := fmt.Sprintf("%s_%d", ratingInfo.PublicKey, ratingData.Epoch)
+		err := buffSlice.PutDocument(meta, id, ratingHistory)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -1,9 +1,13 @@
 package validators
 
 import (
+	"encoding/json"
 	"testing"
 
+	dataBlock "github.com/multiversx/mx-chain-core-go/data/block"
 	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
+	"github.com/multiversx/mx-chain-es-indexer-go/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -33,3 +37,60 @@ func TestValidatorsProcessor_SerializeValidatorsRating(t *testing.T) {
 `
 	require.Equal(t, expected, buff[0].String())
 }
+
+func TestValidatorsProcessor_SerializeValidatorsRatingHistory(t *testing.T) {
+	t.Parallel()
+
+	vp, _ := NewValidatorsProcessor(&mock.PubkeyConverterMock{}, 0)
+	ratingInfo := &outport.ValidatorsRating{
+		Epoch: 7,
+		ValidatorsRatingInfo: []*outport.ValidatorRatingInfo{
+			{PublicKey: "bls1", Rating: 50.1},
+			nil,
+			{PublicKey: "bls2", Rating: 49},
+		},
+	}
+	buffSlice := data.NewBufferSlice(0)
+	err := vp.SerializeValidatorsRatingHistory(ratingInfo, 1700000000, buffSlice, "ratinghistory")
+	require.Nil(t, err)
+
+	expected := `{ "index" : { "_index":"ratinghistory", "_id" : "bls1_7" } }
+{"publicKey":"bls1","epoch":7,"rating":50.1,"timestamp":1700000000}
+{ "index" : { "_index":"ratinghistory", "_id" : "bls2_7" } }
+{"publicKey":"bls2","epoch":7,"rating":49,"timestamp":1700000000}
+`
+	require.Equal(t, expected, buffSlice.Buffers()[0].String())
+
+	// without the epoch start block, the timestamp is omitted
+	buffSlice = data.NewBufferSlice(0)
+	err = vp.SerializeValidatorsRatingHistory(ratingInfo, 0, buffSlice, "ratinghistory")
+	require.Nil(t, err)
+	require.Contains(t, buffSlice.Buffers()[0].String(), `{"publicKey":"bls1","epoch":7,"rating":50.1}`)
+}
+
+func TestValidatorsProcessor_EpochStartTimestamp(t *testing.T) {
+	t.Parallel()
+
+	vp, _ := NewValidatorsProcessor(&mock.PubkeyConverterMock{}, 0)
+
+	// only the metachain epoch start blocks are kept
+	vp.PutEpochStartHeader(&dataBlock.Header{Epoch: 7, TimeStamp: 100, EpochStartMetaHash: []byte("meta")})
+	vp.PutEpochStartHeader(&dataBlock.MetaBlock{Epoch: 7, TimeStamp: 200})
+	_, found := vp.GetEpochStartTimestamp(7)
+	require.False(t, found)
+
+	vp.PutEpochStartHeader(&dataBlock.MetaBlock{Epoch: 7, TimeStamp: 300, EpochStart: dataBlock.EpochStart{
+		LastFinalizedHeaders: []dataBlock.EpochStartShardData{{}},
+	}})
+	timestamp, found := vp.GetEpochStartTimestamp(7)
+	require.True(t, found)
+	require.Equal(t, uint64(300), timestamp)
+
+	response := &data.ResponseSearch{}
+	err := json.Unmarshal([]byte(`{"hits":{"hits":[{"_id":"hash","_source":{"epoch":8,"timestamp":400}}]}}`), response)
+	require.Nil(t, err)
+	vp.PutEpochStartBlockFromResponse(8, response)
+	timestamp, found = vp.GetEpochStartTimestamp(8)
+	require.True(t, found)
+	require.Equal(t, uint64(400), timestamp)
+}
@@ -0,0 +1,224 @@
+package validators
+
+import (
+	"fmt"
+
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/converters"
+)
+
+const (
+	validatorStatsIDFormat = "%s_%d"
+
+	// the counters of the rounds are added to the existing ones. The rounds are received in order, so the rounds which
+	// are not newer than the last counted round of the document were already counted and are skipped. A document
+	// created only from the rating does not have counters yet
+	validatorStatsRoundsScript = `
+		if (ctx._source.containsKey('lastRound')) {
+			if (params.stats.lastRound <= ctx._source.lastRound) {
+				ctx.op = 'noop';
+				return;
+			}
+			ctx._source.blocksProposed += params.stats.blocksProposed;
+			ctx._source.blocksMissed += params.stats.blocksMissed;
+			ctx._source.roundsSigned += params.stats.roundsSigned;
+			ctx._source.roundsEligible += params.stats.roundsEligible;
+			ctx._source.shardID = params.stats.shardID;
+			ctx._source.lastRound = params.stats.lastRound;
+			ctx._source.timestamp = params.stats.timestamp;
+		} else {
+			params.stats.forEach((key, value) -> ctx._source.put(key, value));
+		}
+
+		long leaderRounds = ctx._source.blocksProposed + ctx._source.blocksMissed;
+		if (leaderRounds > 0) {
+			ctx._source.leaderSuccessRate = 1.0 * ctx._source.blocksProposed / leaderRounds;
+		}
+		if (ctx._source.roundsEligible > 0) {
+			ctx._source.roundsSignedRate = 1.0 * ctx._source.roundsSigned / ctx._source.roundsEligible;
+		}
+`
+	validatorStatsRatingScript = `
+		params.rating.forEach((key, value) -> ctx._source.put(key, value));
+`
+)
+
+var (
+	validatorStatsRoundsTemplate = data.NewDocumentTemplate(
+		`{"scripted_upsert": true, "script": {"source": "`+converters.FormatPainlessSource(validatorStatsRoundsScript)+`","lang": "painless","params": { "stats": `,
+		` }},"upsert": {}}`,
+	)
+	validatorStatsRatingTemplate = data.NewDocumentTemplate(
+		`{"scripted_upsert": true, "script": {"source": "`+converters.FormatPainlessSource(validatorStatsRatingScript)+`","lang": "painless","params": { "rating": `,
+		` }},"upsert": {}}`,
+	)
+)
+
+// SerializeValidatorStats will compute, for each validator, the blocks proposed and missed and the signed and the
+// eligible rounds from the provided rounds, and will serialize them as updates of the epoch documents. The consensus
+// group of a round is made of all the validators of its shard from the same epoch, so every one of them is eligible for
+// the round, while only the validators from the signers indexes signed it. The leader of a round is its first signer.
+// The rounds whose public keys are not known are skipped
+func (vp *validatorsProcessor) SerializeValidatorStats(rounds *outport.RoundsInfo, buffSlice *data.BufferSlice, index string) error {
+	statsByID := make(map[string]*data.ValidatorStats)
+	ids := make([]string, 0)
+	getStats := func(publicKey string, roundInfo *outport.RoundInfo) *data.ValidatorStats {
+		id := fmt.Sprintf(validatorStatsIDFormat, publicKey, roundInfo.Epoch)
+		stats, found := statsByID[id]
+		if !found {
+			stats = &data.ValidatorStats{
+				PublicKey: publicKey,
+				ShardID:   roundInfo.ShardId,
+				Epoch:     roundInfo.Epoch,
+			}
+			statsByID[id] = stats
+			ids = append(ids, id)
+		}
+
+		return stats
+	}
+
+	for _, roundInfo := range rounds.RoundsInfo {
+		if roundInfo == nil {
+			continue
+		}
+
+		publicKeys, found := vp.keysCache.get(roundInfo.ShardId, roundInfo.Epoch)
+		if !found {
+			log.Debug("validatorsProcessor.SerializeValidatorStats: missing validators public keys, round skipped",
+				"shard", roundInfo.ShardId, "epoch", roundInfo.Epoch, "round", roundInfo.Round)
+			continue
+		}
+
+		for _, publicKey := range publicKeys {
+			stats := getStats(publicKey, roundInfo)
+			stats.RoundsEligible++
+			if roundInfo.Round > stats.LastRound {
+				stats.LastRound = roundInfo.Round
+				stats.Timestamp = roundInfo.Timestamp
+			}
+		}
+
+		signers := resolveSigners(roundInfo.SignersIndexes, publicKeys)
+		if len(signers) == 0 {
+			continue
+		}
+
+		leaderStats := getStats(signers[0], roundInfo)
+		if !roundInfo.BlockWasProposed {
+			leaderStats.BlocksMissed++
+			continue
+		}
+
+		leaderStats.BlocksProposed++
+		for _, signer := range signers {
+			getStats(signer, roundInfo).RoundsSigned++
+		}
+	}
+
+	meta := data.NewMetaTemplate(`{ "update" : { "_index":"%s", "_id" : "%s", "retry_on_conflict": 5 } }`, index)
+	for _, id := range ids {
+		stats := statsByID[id]
+		computeRates(stats)
+
+		err := buffSlice.PutEnclosedDocument(meta, id, validatorStatsRoundsTemplate, stats)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resolveSigners(signersIndexes []uint64, publicKeys []string) []string {
+	signers := make([]string, 0, len(signersIndexes))
+	seen := make(map[uint64]struct{}, len(signersIndexes))
+	for _, signerIndex := range signersIndexes {
+		_, alreadyAdded := seen[signerIndex]
+		if alreadyAdded {
+			continue
+		}
+		if signerIndex >= uint64(len(publicKeys)) {
+			log.Debug("validatorsProcessor: signer index out of range", "index", signerIndex, "num validators", len(publicKeys))
+			continue
+		}
+
+		seen[signerIndex] = struct{}{}
+		signers = append(signers, publicKeys[signerIndex])
+	}
+
+	return signers
+}
+
+func computeRates(stats *data.ValidatorStats) {
+	leaderRounds := stats.BlocksProposed + stats.BlocksMissed
+	if leaderRounds > 0 {
+		stats.LeaderSuccessRate = float64(stats.BlocksProposed) / float64(leaderRounds)
+	}
+	if stats.RoundsEligible > 0 {
+		stats.RoundsSignedRate = float64(stats.RoundsSigned) / float64(stats.RoundsEligible)
+	}
+}
+
+// GetPreviousEpochStatsIDs returns the IDs of the validator stats documents from the epoch before the rating
+func (vp *validatorsProcessor) GetPreviousEpochStatsIDs(ratingData *outport.ValidatorsRating) []string {
+	ids := make([]string, 0, len(ratingData.ValidatorsRatingInfo))
+	if ratingData.Epoch == 0 {
+		return ids
+	}
+
+	for _, ratingInfo := range ratingData.ValidatorsRatingInfo {
+		if ratingInfo == nil {
+			continue
+		}
+		ids = append(ids, fmt.Sprintf(validatorStatsIDFormat, ratingInfo.PublicKey, ratingData.Epoch-1))
+	}
+
+	return ids
+}
+
+// SerializeValidatorStatsRating will serialize the rating of each validator as an update of its epoch document, together
+// with the change from the rating of the previous epoch, when the previous epoch document has a rating
+func (vp *validatorsProcessor) SerializeValidatorStatsRating(
+	ratingData *outport.ValidatorsRating,
+	previousEpochStats *data.ResponseValidatorsStatsRating,
+	buffSlice *data.BufferSlice,
+	index string,
+) error {
+	previousRatings := make(map[string]float32)
+	for _, doc := range previousEpochStats.Docs {
+		if doc.Found && doc.Source.Rating != nil {
+			previousRatings[doc.ID] = *doc.Source.Rating
+		}
+	}
+
+	meta := data.NewMetaTemplate(`{ "update" : { "_index":"%s", "_id" : "%s" } }`, index)
+	for _, ratingInfo := range ratingData.ValidatorsRatingInfo {
+		if ratingInfo == nil {
+			continue
+		}
+
+		rating := &data.ValidatorStatsRating{
+			PublicKey: ratingInfo.PublicKey,
+			Epoch:     ratingData.Epoch,
+			Rating:    ratingInfo.Rating,
+		}
+		if ratingData.Epoch > 0 {
+			previousRating, found := previousRatings[fmt.Sprintf(validatorStatsIDFormat, ratingInfo.PublicKey, ratingData.Epoch-1)]
+			if found {
+				ratingChange := ratingInfo.Rating - previousRating
+				rating.PreviousEpochRating = &previousRating
+				rating.RatingChange = &ratingChange
+			}
+		}
+
+		id := fmt.Sprintf(validatorStatsIDFormat, ratingInfo.PublicKey, ratingData.Epoch)
+		err := buffSlice.PutEnclosedDocument(meta, id, validatorStatsRatingTemplate, rating)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package validators
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-es-indexer-go/data"
+	"github.com/multiversx/mx-chain-es-indexer-go/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createValidatorsProcessorWithKeys(t *testing.T) *validatorsProcessor {
+	vp, err := NewValidatorsProcessor(&mock.PubkeyConverterMock{}, 0)
+	require.Nil(t, err)
+
+	vp.PutValidatorsPubKeys(&outport.ValidatorsPubKeys{
+		Epoch: 3,
+		ShardValidatorsPubKeys: map[uint32]*outport.PubKeys{
+			0: {Keys: [][]byte{[]byte("k0"), []byte("k1"), []byte("k2"), []byte("k3")}},
+		},
+	})
+
+	return vp
+}
+
+func TestValidatorsProcessor_SerializeValidatorStats(t *testing.T) {
+	t.Parallel()
+
+	vp := createValidatorsProcessorWithKeys(t)
+	rounds := &outport.RoundsInfo{
+		RoundsInfo: []*outport.RoundInfo{
+			{Round: 100, SignersIndexes: []uint64{0, 1, 2}, BlockWasProposed: true, Epoch: 3, Timestamp: 600},
+			{Round: 101, SignersIndexes: []uint64{1, 0}, BlockWasProposed: false, Epoch: 3, Timestamp: 606},
+			// the signer index out of range and the duplicated index are ignored
+			{Round: 102, SignersIndexes: []uint64{2, 7, 2}, BlockWasProposed: true, Epoch: 3, Timestamp: 612},
+			// the public keys of the epoch are not known
+			{Round: 103, SignersIndexes: []uint64{0}, BlockWasProposed: true, Epoch: 4, Timestamp: 618},
+			nil,
+		},
+	}
+
+	buffSlice := data.NewBufferSlice(0)
+	err := vp.SerializeValidatorStats(rounds, buffSlice, "validatorstats")
+	require.Nil(t, err)
+	require.Len(t, buffSlice.Buffers(), 1)
+
+	expectedMeta := `{ "update" : { "_index":"validatorstats", "_id" : "6b30_3", "retry_on_conflict": 5 } }`
+	expectedStats := `{"publicKey":"6b30","shardID":0,"epoch":3,"blocksProposed":1,"blocksMissed":0,"roundsSigned":1,` +
+		`"roundsEligible":3,"leaderSuccessRate":1,"roundsSignedRate":0.3333333333333333,"lastRound":102,"timestamp":612}`
+	// all the validators of the shard are in the consensus group of each round, while only the signers signed it
+	lines := splitLines(buffSlice.Buffers()[0].String())
+	require.Len(t, lines, 8)
+	require.Equal(t, expectedMeta, lines[0])
+	require.Contains(t, lines[1], `"params": { "stats": `+expectedStats+` }}`)
+
+	require.Contains(t, lines[2], `"_id" : "6b31_3"`)
+	require.Contains(t, lines[3], `"blocksProposed":0,"blocksMissed":1,"roundsSigned":1,"roundsEligible":3,"leaderSuccessRate":0`)
+	require.Contains(t, lines[4], `"_id" : "6b32_3"`)
+	require.Contains(t, lines[5], `"blocksProposed":1,"blocksMissed":0,"roundsSigned":2,"roundsEligible":3,"leaderSuccessRate":1`)
+	require.Contains(t, lines[6], `"_id" : "6b33_3"`)
+	require.Contains(t, lines[7], `"blocksProposed":0,"blocksMissed":0,"roundsSigned":0,"roundsEligible":3,"leaderSuccessRate":0,"roundsSignedRate":0`)
+}
+
+func TestValidatorsProcessor_GetMissingValidatorsPubKeysIDs(t *testing.T) {
+	t.Parallel()
+
+	vp := createValidatorsProcessorWithKeys(t)
+	rounds := &outport.RoundsInfo{
+		RoundsInfo: []*outport.RoundInfo{
+			{Round: 1, Epoch: 3, ShardId: 0},
+			{Round: 2, Epoch: 4, ShardId: 0},
+			{Round: 3, Epoch: 4, ShardId: 0},
+			{Round: 4, Epoch: 3, ShardId: 1},
+		},
+	}
+	require.Equal(t, []string{"0_4", "1_3"}, vp.GetMissingValidatorsPubKeysIDs(rounds))
+
+	response := &data.ResponseValidatorsPublicKeys{}
+	err := json.Unmarshal([]byte(`{"docs":[`+
+		`{"_id":"0_4","found":true,"_source":{"publicKeys":["6b30"]}},`+
+		`{"_id":"1_3","found":false},`+
+		`{"_id":"invalid","found":true,"_source":{"publicKeys":["6b30"]}}]}`), response)
+	require.Nil(t, err)
+	vp.PutValidatorsPubKeysFromResponse(response)
+
+	require.Empty(t, vp.GetMissingValidatorsPubKeysIDs(rounds))
+	keys, found := vp.keysCache.get(0, 4)
+	require.True(t, found)
+	require.Equal(t, []string{"6b30"}, keys)
+	keys, found = vp.keysCache.get(1, 3)
+	require.True(t, found)
+	require.Empty(t, keys)
+}
+
+func TestValidatorsProcessor_SerializeValidatorStatsRating(t *testing.T) {
+	t.Parallel()
+
+	vp := createValidatorsProcessorWithKeys(t)
+	ratingData := &outport.ValidatorsRating{
+		Epoch: 4,
+		ValidatorsRatingInfo: []*outport.ValidatorRatingInfo{
+			{PublicKey: "6b30", Rating: 52},
+			{PublicKey: "6b31", Rating: 40},
+			nil,
+		},
+	}
+	require.Equal(t, []string{"6b30_3", "6b31_3"}, vp.GetPreviousEpochStatsIDs(ratingData))
+
+	previousEpochStats := &data.ResponseValidatorsStatsRating{}
+	err := json.Unmarshal([]byte(`{"docs":[`+
+		`{"_id":"6b30_3","found":true,"_source":{"rating":50}},`+
+		`{"_id":"6b31_3","found":true,"_source":{"blocksProposed":1}}]}`), previousEpochStats)
+	require.Nil(t, err)
+
+	buffSlice := data.NewBufferSlice(0)
+	err = vp.SerializeValidatorStatsRating(ratingData, previousEpochStats, buffSlice, "validatorstats")
+	require.Nil(t, err)
+
+	lines := splitLines(buffSlice.Buffers()[0].String())
+	require.Len(t, lines, 4)
+	require.Equal(t, `{ "update" : { "_index":"validatorstats", "_id" : "6b30_4" } }`, lines[0])
+	require.Contains(t, lines[1], `"params": { "rating": {"publicKey":"6b30","epoch":4,"rating":52,"previousEpochRating":50,"ratingChange":2} }}`)
+	require.Equal(t, `{ "update" : { "_index":"validatorstats", "_id" : "6b31_4" } }`, lines[2])
+	require.Contains(t, lines[3], `"params": { "rating": {"publicKey":"6b31","epoch":4,"rating":40} }}`)
+}
+
+func TestValidatorsProcessor_GetPreviousEpochStatsIDsFirstEpoch(t *testing.T) {
+	t.Parallel()
+
+	vp := createValidatorsProcessorWithKeys(t)
+	ids := vp.GetPreviousEpochStatsIDs(&outport.ValidatorsRating{
+		ValidatorsRatingInfo: []*outport.ValidatorRatingInfo{{PublicKey: "6b30", Rating: 50}},
+	})
+	require.Empty(t, ids)
+}
+
+func TestValidatorsKeysCache_KeepsTheLastEpochs(t *testing.T) {
+	t.Parallel()
+
+	cache := newValidatorsKeysCache()
+	cache.put(0, 1, []string{"a"})
+	cache.put(1, 1, []string{"b"})
+	cache.put(0, 2, []string{"c"})
+	cache.put(0, 3, []string{"d"})
+
+	_, found := cache.get(0, 1)
+	require.False(t, found)
+	keys, found := cache.get(0, 2)
+	require.True(t, found)
+	require.Equal(t, []string{"c"}, keys)
+	_, found = cache.get(0, 3)
+	require.True(t, found)
+	_, found = cache.get(1, 1)
+	require.True(t, found)
+}
+
+func splitLines(buff string) []string {
+	lines := make([]string, 0)
+	for _, line := range strings.Split(buff, "\n") {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
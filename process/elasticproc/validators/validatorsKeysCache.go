@@ -0,0 +1,46 @@
+package validators
+
+import "sync"
+
+// numEpochsToKeep is the number of epochs, for each shard, whose validators public keys are kept in the cache. The
+// rounds from the start of an epoch can still arrive after the public keys of the next epoch
+const numEpochsToKeep = 2
+
+type shardEpoch struct {
+	shardID uint32
+	epoch   uint32
+}
+
+// validatorsKeysCache keeps the encoded public keys of the validators of each shard, for the last epochs, so the
+// signers indexes of the rounds can be resolved without requesting the validators index for every round
+type validatorsKeysCache struct {
+	mutex sync.RWMutex
+	keys  map[shardEpoch][]string
+}
+
+func newValidatorsKeysCache() *validatorsKeysCache {
+	return &validatorsKeysCache{
+		keys: make(map[shardEpoch][]string),
+	}
+}
+
+func (vkc *validatorsKeysCache) put(shardID uint32, epoch uint32, publicKeys []string) {
+	vkc.mutex.Lock()
+	defer vkc.mutex.Unlock()
+
+	vkc.keys[shardEpoch{shardID: shardID, epoch: epoch}] = publicKeys
+	for key := range vkc.keys {
+		if key.shardID == shardID && key.epoch+numEpochsToKeep <= epoch {
+			delete(vkc.keys, key)
+		}
+	}
+}
+
+func (vkc *validatorsKeysCache) get(shardID uint32, epoch uint32) ([]string, bool) {
+	vkc.mutex.RLock()
+	defer vkc.mutex.RUnlock()
+
+	publicKeys, found := vkc.keys[shardEpoch{shardID: shardID, epoch: epoch}]
+
+	return publicKeys, found
+}
@@ -10,11 +10,18 @@ import (
 	"github.com/multiversx/mx-chain-core-go/data/outport"
 	"github.com/multiversx/mx-chain-es-indexer-go/data"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/dataindexer"
+	logger "github.com/multiversx/mx-chain-logger-go"
 )
 
+const validatorsKeysIDFormat = "%d_%d"
+
+var log = logger.GetOrCreate("indexer/process/validators")
+
 type validatorsProcessor struct {
 	bulkSizeMaxSize          int
 	validatorPubkeyConverter core.PubkeyConverter
+	keysCache                *validatorsKeysCache
+	epochStartTimestamps     *epochStartTimestamps
 }
 
 // NewValidatorsProcessor will create a new instance of validatorsProcessor
@@ -26,6 +33,8 @@ func NewValidatorsProcessor(validatorPubkeyConverter core.PubkeyConverter, bulkS
 	return &validatorsProcessor{
 		bulkSizeMaxSize:          bulkSizeMaxSize,
 		validatorPubkeyConverter: validatorPubkeyConverter,
+		keysCache:                newValidatorsKeysCache(),
+		epochStartTimestamps:     newEpochStartTimestamps(),
 	}, nil
 }
 
@@ -34,7 +43,7 @@ func (vp *validatorsProcessor) PrepareAnSerializeValidatorsPubKeys(validatorsPub
 	buffSlice := data.NewBufferSlice(vp.bulkSizeMaxSize)
 
 	for shardID, validatorPk := range validatorsPubKeys.ShardValidatorsPubKeys {
-		err := vp.prepareAndSerializeValidatorsKeysForShard(shardID, validatorsPubKeys.Epoch, validatorPk.Keys, buffSlice)
+		err := vp.prepareAndSerializeValidatorsKeysForShard(shardID, validatorsPubKeys.Epoch, validatorPk.GetKeys(), buffSlice)
 		if err != nil {
 			return nil, err
 		}
@@ -45,16 +54,10 @@ func (vp *validatorsProcessor) PrepareAnSerializeValidatorsPubKeys(validatorsPub
 
 func (vp *validatorsProcessor) prepareAndSerializeValidatorsKeysForShard(shardID uint32, epoch uint32, keys [][]byte, buffSlice *data.BufferSlice) error {
 	preparedValidatorsPubKeys := &data.ValidatorsPublicKeys{
-		PublicKeys: make([]string, 0),
+		PublicKeys: vp.encodePublicKeys(keys),
 	}
 
-	for _, key := range keys {
-		// it will never throw an error here
-		strValidatorPk, _ := vp.validatorPubkeyConverter.Encode(key)
-		preparedValidatorsPubKeys.PublicKeys = append(preparedValidatorsPubKeys.PublicKeys, strValidatorPk)
-	}
-
-	id := fmt.Sprintf("%d_%d", shardID, epoch)
+	id := formatValidatorsKeysID(shardID, epoch)
 	meta := []byte(fmt.Sprintf(`{ "index" : { "_id" : "%s" } }%s`, id, "\n"))
 
 	serializedData, err := json.Marshal(preparedValidatorsPubKeys)
@@ -69,3 +72,71 @@ func (vp *validatorsProcessor) prepareAndSerializeValidatorsKeysForShard(shardID
 
 	return nil
 }
+
+// PutValidatorsPubKeys keeps the encoded public keys of the validators in memory, in order to resolve the signers
+// indexes of the rounds from the same epoch
+func (vp *validatorsProcessor) PutValidatorsPubKeys(validatorsPubKeys *outport.ValidatorsPubKeys) {
+	for shardID, validatorPk := range validatorsPubKeys.ShardValidatorsPubKeys {
+		vp.keysCache.put(shardID, validatorsPubKeys.Epoch, vp.encodePublicKeys(validatorPk.GetKeys()))
+	}
+}
+
+// GetMissingValidatorsPubKeysIDs returns the IDs from the validators index of the public keys needed to resolve the
+// signers of the provided rounds, which are not kept in memory
+func (vp *validatorsProcessor) GetMissingValidatorsPubKeysIDs(rounds *outport.RoundsInfo) []string {
+	ids := make([]string, 0)
+	seen := make(map[string]struct{})
+	for _, roundInfo := range rounds.RoundsInfo {
+		if roundInfo == nil {
+			continue
+		}
+		_, found := vp.keysCache.get(roundInfo.ShardId, roundInfo.Epoch)
+		if found {
+			continue
+		}
+
+		id := formatValidatorsKeysID(roundInfo.ShardId, roundInfo.Epoch)
+		_, alreadyAdded := seen[id]
+		if !alreadyAdded {
+			seen[id] = struct{}{}
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// PutValidatorsPubKeysFromResponse keeps in memory the public keys loaded from the validators index. The missing
+// documents are kept as empty lists, so they are not requested again for every round of the same epoch
+func (vp *validatorsProcessor) PutValidatorsPubKeysFromResponse(response *data.ResponseValidatorsPublicKeys) {
+	for _, doc := range response.Docs {
+		var shardID, epoch uint32
+		_, err := fmt.Sscanf(doc.ID, validatorsKeysIDFormat, &shardID, &epoch)
+		if err != nil {
+			log.Warn("validatorsProcessor.PutValidatorsPubKeysFromResponse: invalid document id", "id", doc.ID, "error", err)
+			continue
+		}
+
+		publicKeys := doc.Source.PublicKeys
+		if !doc.Found {
+			log.Debug("validatorsProcessor: validators public keys not found", "shard", shardID, "epoch", epoch)
+			publicKeys = make([]string, 0)
+		}
+		vp.keysCache.put(shardID, epoch, publicKeys)
+	}
+}
+
+func (vp *validatorsProcessor) encodePublicKeys(keys [][]byte) []string {
+	encodedKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		// it will never throw an error here
+		strValidatorPk, _ := vp.validatorPubkeyConverter.Encode(key)
+		encodedKeys = append(encodedKeys, strValidatorPk)
+	}
+
+	return encodedKeys
+}
+
+func formatValidatorsKeysID(shardID uint32, epoch uint32) string {
+	return fmt.Sprintf(validatorsKeysIDFormat, shardID, epoch)
+}
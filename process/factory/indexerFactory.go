@@ -13,6 +13,7 @@ import (
 	"github.com/multiversx/mx-chain-core-go/hashing"
 	"github.com/multiversx/mx-chain-core-go/marshal"
 	"github.com/multiversx/mx-chain-es-indexer-go/client"
+	"github.com/multiversx/mx-chain-es-indexer-go/client/dualwrite"
 	"github.com/multiversx/mx-chain-es-indexer-go/client/logging"
 	"github.com/multiversx/mx-chain-es-indexer-go/client/transport"
 	indexerCore "github.com/multiversx/mx-chain-es-indexer-go/core"
@@ -22,28 +23,55 @@ import (
 	logger "github.com/multiversx/mx-chain-logger-go"
 )
 
-var log = logger.GetOrCreate("indexer/factory")
+var (
+	log = logger.GetOrCreate("indexer/factory")
+
+	// the 502, 503 and 504 responses are retried on the next node only for the idempotent requests, by the
+	// idempotent retry transport, so a bulk which may have been partially applied is not sent again
+	retryOnStatus = []int{http.StatusConflict}
+)
 
 // ArgsIndexerFactory holds all dependencies required by the data indexer factory in order to create
 // new instances
 type ArgsIndexerFactory struct {
-	Enabled                  bool
-	UseKibana                bool
-	ImportDB                 bool
-	Denomination             int
-	BulkRequestMaxSize       int
-	Url                      string
-	UserName                 string
-	Password                 string
-	TemplatesPath            string
-	Version                  string
-	EnabledIndexes           []string
-	HeaderMarshaller         marshal.Marshalizer
-	Marshalizer              marshal.Marshalizer
-	Hasher                   hashing.Hasher
-	AddressPubkeyConverter   core.PubkeyConverter
-	ValidatorPubkeyConverter core.PubkeyConverter
-	StatusMetrics            indexerCore.StatusMetricsHandler
+	Enabled                   bool
+	UseKibana                 bool
+	ImportDB                  bool
+	Denomination              int
+	BulkRequestMaxSize        int
+	NumConcurrentBulkRequests int
+	TokensCacheCapacity       int
+	Urls                      []string
+	DiscoverNodesInterval     time.Duration
+	UserName                  string
+	Password                  string
+	APIKey                    string
+	CloudID                   string
+	TLS                       client.TLSConfig
+	CompressBulkRequests      bool
+	TemplatesPath             string
+	Version                   string
+	EnabledIndexes            []string
+	HeaderMarshaller          marshal.Marshalizer
+	Marshalizer               marshal.Marshalizer
+	Hasher                    hashing.Hasher
+	AddressPubkeyConverter    core.PubkeyConverter
+	ValidatorPubkeyConverter  core.PubkeyConverter
+	StatusMetrics             indexerCore.StatusMetricsHandler
+	IndexingStatus            indexerCore.IndexingStatusHandler
+	DBClient                  elasticproc.DatabaseClientHandler
+	DualWrite                 ArgsDualWrite
+	ImportDBBatch             dataindexer.ImportDBBatchConfig
+	ImportDBIndexSettings     elasticproc.ImportDBIndexSettings
+}
+
+// ArgsDualWrite holds the settings for writing the indexed data to a secondary cluster as well
+type ArgsDualWrite struct {
+	Enabled       bool
+	Secondary     ArgsElasticClientFactory
+	QueueSize     int
+	MaxRetries    int
+	RetryInterval time.Duration
 }
 
 // NewIndexer will create a new instance of Indexer
@@ -57,6 +85,7 @@ func NewIndexer(args ArgsIndexerFactory) (dataindexer.Indexer, error) {
 	if err != nil {
 		return nil, err
 	}
+	args.IndexingStatus.SetTemplatesChecked()
 
 	blockContainer, err := createBlockCreatorsContainer()
 	if err != nil {
@@ -67,6 +96,8 @@ func NewIndexer(args ArgsIndexerFactory) (dataindexer.Indexer, error) {
 		HeaderMarshaller: args.HeaderMarshaller,
 		ElasticProcessor: elasticProcessor,
 		BlockContainer:   blockContainer,
+		IndexingStatus:   args.IndexingStatus,
+		ImportDBBatch:    args.ImportDBBatch,
 	}
 
 	return dataindexer.NewDataIndexer(arguments)
@@ -80,47 +111,137 @@ func retryBackOff(attempt int) time.Duration {
 }
 
 func createElasticProcessor(args ArgsIndexerFactory) (dataindexer.ElasticProcessor, error) {
-	databaseClient, err := createElasticClient(args)
+	databaseClient, err := createDatabaseClient(args)
 	if err != nil {
 		return nil, err
 	}
 
 	argsElasticProcFac := factory.ArgElasticProcessorFactory{
-		Marshalizer:              args.Marshalizer,
-		Hasher:                   args.Hasher,
-		AddressPubkeyConverter:   args.AddressPubkeyConverter,
-		ValidatorPubkeyConverter: args.ValidatorPubkeyConverter,
-		UseKibana:                args.UseKibana,
-		DBClient:                 databaseClient,
-		Denomination:             args.Denomination,
-		EnabledIndexes:           args.EnabledIndexes,
-		BulkRequestMaxSize:       args.BulkRequestMaxSize,
-		ImportDB:                 args.ImportDB,
-		Version:                  args.Version,
+		Marshalizer:               args.Marshalizer,
+		Hasher:                    args.Hasher,
+		AddressPubkeyConverter:    args.AddressPubkeyConverter,
+		ValidatorPubkeyConverter:  args.ValidatorPubkeyConverter,
+		UseKibana:                 args.UseKibana,
+		DBClient:                  databaseClient,
+		Denomination:              args.Denomination,
+		EnabledIndexes:            args.EnabledIndexes,
+		BulkRequestMaxSize:        args.BulkRequestMaxSize,
+		NumConcurrentBulkRequests: args.NumConcurrentBulkRequests,
+		TokensCacheCapacity:       args.TokensCacheCapacity,
+		ImportDB:                  args.ImportDB,
+		ImportDBIndexSettings:     args.ImportDBIndexSettings,
+		Version:                   args.Version,
+		StatusMetrics:             args.StatusMetrics,
 	}
 
 	return factory.CreateElasticProcessor(argsElasticProcFac)
 }
 
-func createElasticClient(args ArgsIndexerFactory) (elasticproc.DatabaseClientHandler, error) {
+// createDatabaseClient returns the provided database client, if any, or creates a client for the configured cluster
+func createDatabaseClient(args ArgsIndexerFactory) (elasticproc.DatabaseClientHandler, error) {
+	if !check.IfNil(args.DBClient) {
+		return args.DBClient, nil
+	}
+
+	databaseClient, err := CreateElasticClient(ArgsElasticClientFactory{
+		Urls:                  args.Urls,
+		DiscoverNodesInterval: args.DiscoverNodesInterval,
+		UserName:              args.UserName,
+		Password:              args.Password,
+		APIKey:                args.APIKey,
+		CloudID:               args.CloudID,
+		TLS:                   args.TLS,
+		CompressBulkRequests:  args.CompressBulkRequests,
+		StatusMetrics:         args.StatusMetrics,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if args.DualWrite.Enabled {
+		databaseClient, err = createDualWriteClient(args, databaseClient)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return databaseClient, nil
+}
+
+func createDualWriteClient(args ArgsIndexerFactory, primary elasticproc.DatabaseClientHandler) (elasticproc.DatabaseClientHandler, error) {
+	// the requests of the secondary cluster are not accounted in the requests metrics, the dual-write client
+	// records the lag of each cluster instead
+	secondary, err := CreateElasticClient(args.DualWrite.Secondary)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("dual-write is enabled", "secondary cluster", args.DualWrite.Secondary.Urls)
+
+	return dualwrite.NewDualWriteClient(dualwrite.ArgsDualWriteClient{
+		Primary:       primary,
+		Secondary:     secondary,
+		StatusMetrics: args.StatusMetrics,
+		QueueSize:     args.DualWrite.QueueSize,
+		NumWorkers:    args.NumConcurrentBulkRequests,
+		MaxRetries:    args.DualWrite.MaxRetries,
+		RetryInterval: args.DualWrite.RetryInterval,
+	})
+}
+
+// ArgsElasticClientFactory holds all dependencies required for creating a new Elasticsearch client
+type ArgsElasticClientFactory struct {
+	Urls                  []string
+	DiscoverNodesInterval time.Duration
+	UserName              string
+	Password              string
+	APIKey                string
+	CloudID               string
+	TLS                   client.TLSConfig
+	CompressBulkRequests  bool
+	StatusMetrics         indexerCore.StatusMetricsHandler
+}
+
+// CreateElasticClient will create a new instance of an Elasticsearch client
+func CreateElasticClient(args ArgsElasticClientFactory) (elasticproc.DatabaseClientHandler, error) {
+	httpTransport, err := client.NewHTTPTransport(args.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	// the requests are spread in a round-robin manner across the provided nodes. A node that fails is taken
+	// out of the pool and resurrected after a timeout, while the failed request is retried on the next node
 	argsEsClient := elasticsearch.Config{
-		Addresses:     []string{args.Url},
-		Username:      args.UserName,
-		Password:      args.Password,
-		Logger:        &logging.CustomLogger{},
-		RetryOnStatus: []int{http.StatusConflict},
-		RetryBackoff:  retryBackOff,
+		Addresses:             args.Urls,
+		Username:              args.UserName,
+		Password:              args.Password,
+		APIKey:                args.APIKey,
+		CloudID:               args.CloudID,
+		Logger:                &logging.CustomLogger{},
+		RetryOnStatus:         retryOnStatus,
+		RetryBackoff:          retryBackOff,
+		Transport:             httpTransport,
+		DiscoverNodesInterval: args.DiscoverNodesInterval,
 	}
 
-	if check.IfNil(args.StatusMetrics) {
-		return client.NewElasticClient(argsEsClient)
+	if args.CompressBulkRequests {
+		argsEsClient.Transport, err = transport.NewGzipTransport(argsEsClient.Transport)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	transportMetrics, err := transport.NewMetricsTransport(args.StatusMetrics)
+	if !check.IfNil(args.StatusMetrics) {
+		argsEsClient.Transport, err = transport.NewMetricsTransport(args.StatusMetrics, argsEsClient.Transport)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	argsEsClient.Transport, err = transport.NewIdempotentRetryTransport(argsEsClient.Transport)
 	if err != nil {
 		return nil, err
 	}
-	argsEsClient.Transport = transportMetrics
 
 	return client.NewElasticClient(argsEsClient)
 }
@@ -132,7 +253,7 @@ func checkDataIndexerParams(arguments ArgsIndexerFactory) error {
 	if check.IfNil(arguments.ValidatorPubkeyConverter) {
 		return fmt.Errorf("%w when setting ValidatorPubkeyConverter in indexer", dataindexer.ErrNilPubkeyConverter)
 	}
-	if arguments.Url == "" {
+	if len(arguments.Urls) == 0 && arguments.CloudID == "" && check.IfNil(arguments.DBClient) {
 		return dataindexer.ErrNilUrl
 	}
 	if check.IfNil(arguments.Marshalizer) {
@@ -144,6 +265,9 @@ func checkDataIndexerParams(arguments ArgsIndexerFactory) error {
 	if check.IfNil(arguments.HeaderMarshaller) {
 		return fmt.Errorf("%w: header marshaller", dataindexer.ErrNilMarshalizer)
 	}
+	if check.IfNil(arguments.IndexingStatus) {
+		return indexerCore.ErrNilIndexingStatusHandler
+	}
 
 	return nil
 }
@@ -1,11 +1,17 @@
 package factory
 
 import (
+	"bytes"
+	"context"
 	errorsGo "errors"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 
+	"github.com/multiversx/mx-chain-es-indexer-go/client/dualwrite"
+	indexerCore "github.com/multiversx/mx-chain-es-indexer-go/core"
+	"github.com/multiversx/mx-chain-es-indexer-go/metrics"
 	"github.com/multiversx/mx-chain-es-indexer-go/mock"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/dataindexer"
 	"github.com/stretchr/testify/require"
@@ -16,7 +22,7 @@ func createMockIndexerFactoryArgs() ArgsIndexerFactory {
 
 	return ArgsIndexerFactory{
 		Enabled:                  true,
-		Url:                      ts.URL,
+		Urls:                     []string{ts.URL},
 		UserName:                 "",
 		Password:                 "",
 		Marshalizer:              &mock.MarshalizerMock{},
@@ -24,6 +30,7 @@ func createMockIndexerFactoryArgs() ArgsIndexerFactory {
 		Hasher:                   &mock.HasherMock{},
 		AddressPubkeyConverter:   mock.NewPubkeyConverterMock(32),
 		ValidatorPubkeyConverter: &mock.PubkeyConverterMock{},
+		IndexingStatus:           &mock.IndexingStatusStub{},
 		TemplatesPath:            "../testdata",
 		EnabledIndexes:           []string{"blocks", "transactions", "miniblocks", "validators", "round", "accounts", "rating"},
 	}
@@ -80,15 +87,37 @@ func TestNewIndexerFactory(t *testing.T) {
 			},
 			exError: dataindexer.ErrNilMarshalizer,
 		},
+		{
+			name: "NilIndexingStatus",
+			argsFunc: func() ArgsIndexerFactory {
+				args := createMockIndexerFactoryArgs()
+				args.IndexingStatus = nil
+				return args
+			},
+			exError: indexerCore.ErrNilIndexingStatusHandler,
+		},
 		{
 			name: "EmptyUrl",
 			argsFunc: func() ArgsIndexerFactory {
 				args := createMockIndexerFactoryArgs()
-				args.Url = ""
+				args.Urls = nil
 				return args
 			},
 			exError: dataindexer.ErrNilUrl,
 		},
+		{
+			name: "DualWriteInvalidQueueSize",
+			argsFunc: func() ArgsIndexerFactory {
+				args := createMockIndexerFactoryArgs()
+				args.StatusMetrics = metrics.NewStatusMetrics()
+				args.DualWrite = ArgsDualWrite{
+					Enabled:   true,
+					Secondary: ArgsElasticClientFactory{Urls: args.Urls},
+				}
+				return args
+			},
+			exError: dualwrite.ErrInvalidQueueSize,
+		},
 		{
 			name: "All arguments ok",
 			argsFunc: func() ArgsIndexerFactory {
@@ -109,7 +138,7 @@ func TestNewIndexerFactory(t *testing.T) {
 func TestIndexerFactoryCreate_ElasticIndexer(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
 	args := createMockIndexerFactoryArgs()
-	args.Url = ts.URL
+	args.Urls = []string{ts.URL}
 
 	elasticIndexer, err := NewIndexer(args)
 	require.NoError(t, err)
@@ -117,3 +146,65 @@ func TestIndexerFactoryCreate_ElasticIndexer(t *testing.T) {
 	err = elasticIndexer.Close()
 	require.NoError(t, err)
 }
+
+func TestIndexerFactoryCreate_ElasticIndexerWithDualWrite(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	args := createMockIndexerFactoryArgs()
+	args.Urls = []string{primary.URL}
+	args.StatusMetrics = metrics.NewStatusMetrics()
+	args.DualWrite = ArgsDualWrite{
+		Enabled:   true,
+		Secondary: ArgsElasticClientFactory{Urls: []string{secondary.URL}},
+		QueueSize: 10,
+	}
+
+	elasticIndexer, err := NewIndexer(args)
+	require.NoError(t, err)
+
+	err = elasticIndexer.Close()
+	require.NoError(t, err)
+}
+
+func TestIndexerFactoryCreate_ElasticIndexerWithProvidedDBClient(t *testing.T) {
+	createdIndices := make([]string, 0)
+	args := createMockIndexerFactoryArgs()
+	args.Urls = nil
+	args.DBClient = &mock.DatabaseWriterStub{
+		CheckAndCreateIndexCalled: func(index string) error {
+			createdIndices = append(createdIndices, index)
+			return nil
+		},
+	}
+
+	elasticIndexer, err := NewIndexer(args)
+	require.NoError(t, err)
+	require.NotEmpty(t, createdIndices)
+
+	err = elasticIndexer.Close()
+	require.NoError(t, err)
+}
+
+func TestCreateElasticClient_ShouldNotSendAgainABulkAnsweredWithAnUnavailableStatus(t *testing.T) {
+	t.Parallel()
+
+	numBulkRequests := 0
+	mut := sync.Mutex{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mut.Lock()
+		numBulkRequests++
+		mut.Unlock()
+
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer ts.Close()
+
+	esClient, err := CreateElasticClient(ArgsElasticClientFactory{
+		Urls: []string{ts.URL},
+	})
+	require.Nil(t, err)
+
+	err = esClient.DoBulkRequest(context.Background(), bytes.NewBufferString(`{"update":{"_id":"a"}}`+"\n"+`{}`+"\n"), "")
+	require.NotNil(t, err)
+	require.Equal(t, 1, numBulkRequests)
+}
@@ -21,16 +21,20 @@ var (
 
 // ArgsIndexer holds all the components needed to create a new instance of indexer
 type ArgsIndexer struct {
-	Marshaller    marshal.Marshalizer
-	DataIndexer   DataIndexer
-	StatusMetrics core.StatusMetricsHandler
+	Marshaller     marshal.Marshalizer
+	DataIndexer    DataIndexer
+	StatusMetrics  core.StatusMetricsHandler
+	IndexingStatus core.IndexingStatusHandler
+	Controller     core.IndexingControllerHandler
 }
 
 type indexer struct {
-	marshaller    marshal.Marshalizer
-	di            DataIndexer
-	statusMetrics core.StatusMetricsHandler
-	actions       map[string]func(marshalledData []byte) error
+	marshaller     marshal.Marshalizer
+	di             DataIndexer
+	statusMetrics  core.StatusMetricsHandler
+	indexingStatus core.IndexingStatusHandler
+	controller     core.IndexingControllerHandler
+	actions        map[string]func(marshalledData []byte) error
 }
 
 // NewIndexer will create a new instance of *indexer
@@ -44,11 +48,19 @@ func NewIndexer(args ArgsIndexer) (*indexer, error) {
 	if check.IfNil(args.StatusMetrics) {
 		return nil, core.ErrNilMetricsHandler
 	}
+	if check.IfNil(args.IndexingStatus) {
+		return nil, core.ErrNilIndexingStatusHandler
+	}
+	if check.IfNil(args.Controller) {
+		return nil, core.ErrNilIndexingController
+	}
 
 	payloadIndexer := &indexer{
-		marshaller:    args.Marshaller,
-		di:            args.DataIndexer,
-		statusMetrics: args.StatusMetrics,
+		marshaller:     args.Marshaller,
+		di:             args.DataIndexer,
+		statusMetrics:  args.StatusMetrics,
+		indexingStatus: args.IndexingStatus,
+		controller:     args.Controller,
 	}
 	payloadIndexer.initActionsMap()
 
@@ -75,6 +87,14 @@ func (i *indexer) ProcessPayload(payload []byte, topic string, version uint32) e
 		log.Warn("received a payload with a different version", "version", version)
 	}
 
+	i.indexingStatus.SetPayloadReceived()
+
+	err := i.controller.StartProcessing()
+	if err != nil {
+		return err
+	}
+	defer i.controller.DoneProcessing()
+
 	payloadTypeAction, ok := i.actions[topic]
 	if !ok {
 		log.Warn("invalid payload type", "topic", topic)
@@ -161,8 +181,14 @@ func (i *indexer) saveAccounts(marshalledData []byte) error {
 	return i.di.SaveAccounts(accounts)
 }
 
-func (i *indexer) finalizedBlock(_ []byte) error {
-	return nil
+func (i *indexer) finalizedBlock(marshalledData []byte) error {
+	finalizedBlock := &outport.FinalizedBlock{}
+	err := i.marshaller.Unmarshal(finalizedBlock, marshalledData)
+	if err != nil {
+		return err
+	}
+
+	return i.di.FinalizedBlock(finalizedBlock)
 }
 
 func (i *indexer) setSettings(marshalledData []byte) error {
@@ -0,0 +1,242 @@
+package wsindexer
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
+	"github.com/multiversx/mx-chain-core-go/data/alteredAccount"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/multiversx/mx-chain-es-indexer-go/client/memory"
+	"github.com/multiversx/mx-chain-es-indexer-go/metrics"
+	"github.com/multiversx/mx-chain-es-indexer-go/mock"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/dataindexer"
+	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/factory"
+	"github.com/stretchr/testify/require"
+)
+
+var testMarshaller = &marshal.JsonMarshalizer{}
+
+func createMockIndexerArgs(dataIndexer DataIndexer) ArgsIndexer {
+	return ArgsIndexer{
+		Marshaller:     testMarshaller,
+		DataIndexer:    dataIndexer,
+		StatusMetrics:  metrics.NewStatusMetrics(),
+		IndexingStatus: &mock.IndexingStatusStub{},
+		Controller:     NewIndexingController(),
+	}
+}
+
+func createBlockPayload(t *testing.T, shardID uint32, hash string) []byte {
+	payload, err := testMarshaller.Marshal(&outport.OutportBlock{
+		ShardID:   shardID,
+		BlockData: &outport.BlockData{HeaderHash: []byte(hash)},
+	})
+	require.Nil(t, err)
+
+	return payload
+}
+
+func TestIndexer_ProcessPayloadShouldSaveBlockBeforeReturning(t *testing.T) {
+	t.Parallel()
+
+	saved := false
+	dataIndexer := &mock.DataIndexerStub{
+		SaveBlockCalled: func(outportBlock *outport.OutportBlock) error {
+			saved = true
+			return nil
+		},
+	}
+	wsIndexer, _ := NewIndexer(createMockIndexerArgs(dataIndexer))
+
+	err := wsIndexer.ProcessPayload(createBlockPayload(t, 0, "h1"), outport.TopicSaveBlock, 1)
+	require.Nil(t, err)
+	require.True(t, saved)
+}
+
+func TestIndexer_ProcessPayloadShouldForwardTheFinalizedBlock(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("flush error")
+	var receivedHash []byte
+	dataIndexer := &mock.DataIndexerStub{
+		FinalizedBlockCalled: func(finalizedBlock *outport.FinalizedBlock) error {
+			receivedHash = finalizedBlock.HeaderHash
+			return expectedErr
+		},
+	}
+	wsIndexer, _ := NewIndexer(createMockIndexerArgs(dataIndexer))
+
+	payload, _ := testMarshaller.Marshal(&outport.FinalizedBlock{ShardID: 1, HeaderHash: []byte("h1")})
+
+	// the error is returned, so the payload is not acknowledged while the collected blocks were not saved
+	err := wsIndexer.ProcessPayload(payload, outport.TopicFinalizedBlock, 1)
+	require.Equal(t, expectedErr, err)
+	require.Equal(t, []byte("h1"), receivedHash)
+}
+
+var fuzzedTopics = []string{
+	outport.TopicSaveBlock,
+	outport.TopicRevertIndexedBlock,
+	outport.TopicSaveRoundsInfo,
+	outport.TopicSaveValidatorsRating,
+	outport.TopicSaveValidatorsPubKeys,
+	outport.TopicSaveAccounts,
+	outport.TopicFinalizedBlock,
+	outport.TopicSettings,
+	"unknownTopic",
+}
+
+func createFuzzIndexer(tb testing.TB, marshaller marshal.Marshalizer) *indexer {
+	addressConverter, _ := pubkeyConverter.NewBech32PubkeyConverter(32, "erd")
+	elasticProcessor, err := factory.CreateElasticProcessor(factory.ArgElasticProcessorFactory{
+		Marshalizer:              &mock.MarshalizerMock{},
+		Hasher:                   &mock.HasherMock{},
+		AddressPubkeyConverter:   addressConverter,
+		ValidatorPubkeyConverter: mock.NewPubkeyConverterMock(96),
+		DBClient:                 memory.NewDatabaseClient(),
+		Denomination:             18,
+		EnabledIndexes: []string{
+			dataindexer.BlockIndex, dataindexer.MiniblocksIndex, dataindexer.TransactionsIndex, dataindexer.ValidatorsIndex,
+			dataindexer.RoundsIndex, dataindexer.RatingIndex, dataindexer.AccountsIndex, dataindexer.AccountsHistoryIndex,
+			dataindexer.ReceiptsIndex, dataindexer.ScResultsIndex, dataindexer.AccountsESDTIndex,
+			dataindexer.AccountsESDTHistoryIndex, dataindexer.EpochInfoIndex, dataindexer.SCDeploysIndex,
+			dataindexer.TokensIndex, dataindexer.TagsIndex, dataindexer.LogsIndex, dataindexer.DelegatorsIndex,
+			dataindexer.OperationsIndex, dataindexer.ESDTsIndex, dataindexer.ValuesIndex, dataindexer.EventsIndex,
+		},
+	})
+	require.Nil(tb, err)
+
+	blockContainer := block.NewEmptyBlockCreatorsContainer()
+	_ = blockContainer.Add(core.ShardHeaderV1, block.NewEmptyHeaderCreator())
+	_ = blockContainer.Add(core.ShardHeaderV2, block.NewEmptyHeaderV2Creator())
+	_ = blockContainer.Add(core.MetaHeader, block.NewEmptyMetaBlockCreator())
+
+	dataIndexer, err := dataindexer.NewDataIndexer(dataindexer.ArgDataIndexer{
+		HeaderMarshaller: marshaller,
+		ElasticProcessor: elasticProcessor,
+		BlockContainer:   blockContainer,
+		IndexingStatus:   &mock.IndexingStatusStub{},
+	})
+	require.Nil(tb, err)
+
+	args := createMockIndexerArgs(dataIndexer)
+	args.Marshaller = marshaller
+	wsIndexer, err := NewIndexer(args)
+	require.Nil(tb, err)
+
+	return wsIndexer
+}
+
+func createFuzzSeeds(tb testing.TB, marshaller marshal.Marshalizer) map[string][]byte {
+	sender, receiver := make([]byte, 32), make([]byte, 32)
+	sender[31], receiver[31] = 1, 2
+	txHash := hex.EncodeToString([]byte("txHash"))
+
+	headerBytes, err := marshaller.Marshal(&block.Header{Nonce: 10, Round: 11, Epoch: 1, TimeStamp: 1000})
+	require.Nil(tb, err)
+	blockData := &outport.BlockData{
+		ShardID:     0,
+		HeaderBytes: headerBytes,
+		HeaderType:  string(core.ShardHeaderV1),
+		HeaderHash:  []byte("headerHash"),
+		Body: &block.Body{MiniBlocks: []*block.MiniBlock{
+			{TxHashes: [][]byte{[]byte("txHash")}, Type: block.TxBlock, ReceiverShardID: 1},
+		}},
+	}
+
+	payloads := map[string]interface{}{
+		outport.TopicSaveBlock: &outport.OutportBlock{
+			ShardID:        0,
+			BlockData:      blockData,
+			NumberOfShards: 3,
+			TransactionPool: &outport.TransactionPool{
+				Transactions: map[string]*outport.TxInfo{
+					txHash: {
+						Transaction: &transaction.Transaction{
+							Nonce:   1,
+							Value:   big.NewInt(100),
+							SndAddr: sender,
+							RcvAddr: receiver,
+							Data:    []byte("ESDTNFTTransfer@544b4e2d616263646566@01@01@" + hex.EncodeToString(receiver)),
+						},
+						FeeInfo: &outport.FeeInfo{Fee: big.NewInt(1), InitialPaidFee: big.NewInt(1)},
+					},
+				},
+				Logs: []*outport.LogData{
+					{
+						TxHash: txHash,
+						Log: &transaction.Log{
+							Address: sender,
+							Events: []*transaction.Event{
+								{Address: sender, Identifier: []byte(core.BuiltInFunctionESDTNFTCreate), Topics: [][]byte{[]byte("TKN-abcdef"), {1}, {1}}},
+								{Address: sender, Identifier: []byte("upgradeProperties"), Topics: [][]byte{[]byte("TKN-abcdef"), {}, []byte("canPause"), []byte("true")}},
+							},
+						},
+					},
+				},
+			},
+			AlteredAccounts: map[string]*alteredAccount.AlteredAccount{
+				hex.EncodeToString(sender): {
+					Address:        hex.EncodeToString(sender),
+					Balance:        "1000",
+					Tokens:         []*alteredAccount.AccountTokenData{{Identifier: "TKN-abcdef", Nonce: 1, Balance: "1"}},
+					AdditionalData: &alteredAccount.AdditionalAccountData{IsSender: true, BalanceChanged: true},
+				},
+			},
+			HeaderGasConsumption: &outport.HeaderGasConsumption{},
+		},
+		outport.TopicRevertIndexedBlock: blockData,
+		outport.TopicSaveRoundsInfo: &outport.RoundsInfo{
+			RoundsInfo: []*outport.RoundInfo{{Round: 11, SignersIndexes: []uint64{0, 1}, BlockWasProposed: true, Timestamp: 1000}},
+		},
+		outport.TopicSaveValidatorsRating: &outport.ValidatorsRating{
+			ShardID: 0, Epoch: 1, ValidatorsRatingInfo: []*outport.ValidatorRatingInfo{{PublicKey: "pk", Rating: 50}},
+		},
+		outport.TopicSaveValidatorsPubKeys: &outport.ValidatorsPubKeys{
+			Epoch: 1, ShardValidatorsPubKeys: map[uint32]*outport.PubKeys{0: {Keys: [][]byte{[]byte("pk")}}},
+		},
+		outport.TopicSaveAccounts: &outport.Accounts{
+			ShardID: 0, BlockTimestamp: 1000,
+			AlteredAccounts: map[string]*alteredAccount.AlteredAccount{hex.EncodeToString(sender): {Address: hex.EncodeToString(sender), Balance: "10"}},
+		},
+		outport.TopicFinalizedBlock: &outport.FinalizedBlock{ShardID: 0, HeaderHash: []byte("headerHash")},
+		outport.TopicSettings:       &outport.OutportConfig{IsInImportDBMode: false},
+	}
+
+	seeds := make(map[string][]byte)
+	for topic, payload := range payloads {
+		seeds[topic], err = marshaller.Marshal(payload)
+		require.Nil(tb, err)
+	}
+
+	return seeds
+}
+
+// FuzzIndexer_ProcessPayload sends malformed payloads on all the topics, encoded with both the marshallers which can be
+// configured for the web socket connection
+func FuzzIndexer_ProcessPayload(f *testing.F) {
+	marshallers := []marshal.Marshalizer{&marshal.GogoProtoMarshalizer{}, &marshal.JsonMarshalizer{}}
+	wsIndexers := make([]*indexer, 0, len(marshallers))
+	for marshallerIndex, marshaller := range marshallers {
+		seeds := createFuzzSeeds(f, marshaller)
+		for topicIndex, topic := range fuzzedTopics {
+			f.Add(seeds[topic], uint8(topicIndex), uint8(marshallerIndex))
+			f.Add([]byte{}, uint8(topicIndex), uint8(marshallerIndex))
+		}
+
+		wsIndexers = append(wsIndexers, createFuzzIndexer(f, marshaller))
+	}
+
+	f.Fuzz(func(t *testing.T, payload []byte, topicIndex uint8, marshallerIndex uint8) {
+		topic := fuzzedTopics[int(topicIndex)%len(fuzzedTopics)]
+		wsIndexer := wsIndexers[int(marshallerIndex)%len(wsIndexers)]
+		_ = wsIndexer.ProcessPayload(payload, topic, 1)
+	})
+}
@@ -0,0 +1,187 @@
+package wsindexer
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-es-indexer-go/core"
+)
+
+const (
+	// StateRunning is the state of the indexing controller when payloads are processed
+	StateRunning = "running"
+	// StatePaused is the state of the indexing controller when new payloads are held back
+	StatePaused = "paused"
+	// StateDraining is the state of the indexing controller while the in-flight payloads are finished
+	StateDraining = "draining"
+	// StateDrained is the state of the indexing controller after all the in-flight payloads were finished
+	StateDrained = "drained"
+)
+
+type indexingController struct {
+	mut            sync.Mutex
+	cond           *sync.Cond
+	state          string
+	inFlight       int
+	drained        chan struct{}
+	indicesHandler core.IndicesHandler
+}
+
+// NewIndexingController will create a new instance of indexingController, a component that is able to pause,
+// resume or drain the processing of the received payloads
+func NewIndexingController() *indexingController {
+	ic := &indexingController{
+		state:   StateRunning,
+		drained: make(chan struct{}),
+	}
+	ic.cond = sync.NewCond(&ic.mut)
+
+	return ic
+}
+
+// StartProcessing must be called before processing a payload. It will block while the indexing is paused, so the
+// payload is not acknowledged, and it will return an error if the indexing is drained
+func (ic *indexingController) StartProcessing() error {
+	ic.mut.Lock()
+	defer ic.mut.Unlock()
+
+	for ic.state == StatePaused {
+		ic.cond.Wait()
+	}
+
+	if ic.state == StateDraining || ic.state == StateDrained {
+		return core.ErrIndexingDrained
+	}
+
+	ic.inFlight++
+	return nil
+}
+
+// DoneProcessing must be called after a payload started with StartProcessing was processed
+func (ic *indexingController) DoneProcessing() {
+	ic.mut.Lock()
+	defer ic.mut.Unlock()
+
+	ic.inFlight--
+	if ic.inFlight == 0 && ic.state == StateDraining {
+		ic.markDrained()
+	}
+}
+
+// Pause will hold back the processing of the new payloads
+func (ic *indexingController) Pause() error {
+	ic.mut.Lock()
+	defer ic.mut.Unlock()
+
+	if ic.state == StateDraining || ic.state == StateDrained {
+		return core.ErrIndexingDrained
+	}
+
+	ic.state = StatePaused
+	log.Info("indexing was paused")
+
+	return nil
+}
+
+// Resume will restart the processing of the payloads
+func (ic *indexingController) Resume() error {
+	ic.mut.Lock()
+	defer ic.mut.Unlock()
+
+	if ic.state == StateDraining || ic.state == StateDrained {
+		return core.ErrIndexingDrained
+	}
+
+	ic.state = StateRunning
+	ic.cond.Broadcast()
+	log.Info("indexing was resumed")
+
+	return nil
+}
+
+// Drain will reject all the new payloads and will close the drained channel after the in-flight payloads are processed
+func (ic *indexingController) Drain() {
+	ic.mut.Lock()
+	defer ic.mut.Unlock()
+
+	if ic.state == StateDraining || ic.state == StateDrained {
+		return
+	}
+
+	ic.state = StateDraining
+	ic.cond.Broadcast()
+	log.Info("draining indexing", "in-flight payloads", ic.inFlight)
+
+	if ic.inFlight == 0 {
+		ic.markDrained()
+	}
+}
+
+func (ic *indexingController) markDrained() {
+	ic.state = StateDrained
+	close(ic.drained)
+	log.Info("indexing was drained")
+}
+
+// DrainedChan returns the channel that is closed after the indexing was drained
+func (ic *indexingController) DrainedChan() <-chan struct{} {
+	return ic.drained
+}
+
+// GetState returns the current state of the indexing
+func (ic *indexingController) GetState() string {
+	ic.mut.Lock()
+	defer ic.mut.Unlock()
+
+	return ic.state
+}
+
+// SetIndicesHandler will set the component that is able to enable or disable indices at runtime
+func (ic *indexingController) SetIndicesHandler(handler core.IndicesHandler) error {
+	if check.IfNil(handler) {
+		return core.ErrNilIndicesHandler
+	}
+
+	ic.mut.Lock()
+	ic.indicesHandler = handler
+	ic.mut.Unlock()
+
+	return nil
+}
+
+// SetIndexEnabled will enable or disable the indexing of the provided index
+func (ic *indexingController) SetIndexEnabled(index string, enabled bool) error {
+	handler, err := ic.getIndicesHandler()
+	if err != nil {
+		return err
+	}
+
+	return handler.SetIndexEnabled(index, enabled)
+}
+
+// GetEnabledIndexes returns the list of the enabled indices
+func (ic *indexingController) GetEnabledIndexes() ([]string, error) {
+	handler, err := ic.getIndicesHandler()
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.GetEnabledIndexes(), nil
+}
+
+func (ic *indexingController) getIndicesHandler() (core.IndicesHandler, error) {
+	ic.mut.Lock()
+	defer ic.mut.Unlock()
+
+	if check.IfNil(ic.indicesHandler) {
+		return nil, fmt.Errorf("%w: the indexer is still starting", core.ErrIndicesHandlerNotSet)
+	}
+
+	return ic.indicesHandler, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ic *indexingController) IsInterfaceNil() bool {
+	return ic == nil
+}
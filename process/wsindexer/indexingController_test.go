@@ -0,0 +1,120 @@
+package wsindexer
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-es-indexer-go/core"
+	"github.com/multiversx/mx-chain-es-indexer-go/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexingController_PauseShouldHoldBackPayloadsUntilResume(t *testing.T) {
+	t.Parallel()
+
+	ic := NewIndexingController()
+	require.Nil(t, ic.Pause())
+	require.Equal(t, StatePaused, ic.GetState())
+
+	processed := uint32(0)
+	go func() {
+		_ = ic.StartProcessing()
+		atomic.StoreUint32(&processed, 1)
+		ic.DoneProcessing()
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, uint32(0), atomic.LoadUint32(&processed))
+
+	require.Nil(t, ic.Resume())
+	require.Eventually(t, func() bool {
+		return atomic.LoadUint32(&processed) == 1
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, StateRunning, ic.GetState())
+}
+
+func TestIndexingController_DrainShouldWaitForInFlightPayloads(t *testing.T) {
+	t.Parallel()
+
+	ic := NewIndexingController()
+	require.Nil(t, ic.StartProcessing())
+
+	ic.Drain()
+	require.Equal(t, StateDraining, ic.GetState())
+	require.Equal(t, core.ErrIndexingDrained, ic.StartProcessing())
+	require.Equal(t, core.ErrIndexingDrained, ic.Pause())
+	require.Equal(t, core.ErrIndexingDrained, ic.Resume())
+
+	select {
+	case <-ic.DrainedChan():
+		require.Fail(t, "should not be drained while a payload is in-flight")
+	default:
+	}
+
+	ic.DoneProcessing()
+	select {
+	case <-ic.DrainedChan():
+	case <-time.After(time.Second):
+		require.Fail(t, "should have been drained")
+	}
+	require.Equal(t, StateDrained, ic.GetState())
+
+	// drain is idempotent
+	ic.Drain()
+}
+
+func TestIndexingController_DrainShouldReleasePausedPayloads(t *testing.T) {
+	t.Parallel()
+
+	ic := NewIndexingController()
+	require.Nil(t, ic.Pause())
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- ic.StartProcessing()
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	ic.Drain()
+
+	select {
+	case err := <-errChan:
+		require.Equal(t, core.ErrIndexingDrained, err)
+	case <-time.After(time.Second):
+		require.Fail(t, "paused payload should have been released")
+	}
+	require.Equal(t, StateDrained, ic.GetState())
+}
+
+func TestIndexingController_Indices(t *testing.T) {
+	t.Parallel()
+
+	ic := NewIndexingController()
+
+	_, err := ic.GetEnabledIndexes()
+	require.ErrorIs(t, err, core.ErrIndicesHandlerNotSet)
+	require.ErrorIs(t, ic.SetIndexEnabled("blocks", false), core.ErrIndicesHandlerNotSet)
+	require.Equal(t, core.ErrNilIndicesHandler, ic.SetIndicesHandler(nil))
+
+	disabledIndex := ""
+	err = ic.SetIndicesHandler(&mock.ElasticProcessorStub{
+		SetIndexEnabledCalled: func(index string, enabled bool) error {
+			if !enabled {
+				disabledIndex = index
+			}
+			return nil
+		},
+		GetEnabledIndexesCalled: func() []string {
+			return []string{"transactions"}
+		},
+	})
+	require.Nil(t, err)
+
+	require.Nil(t, ic.SetIndexEnabled("blocks", false))
+	require.Equal(t, "blocks", disabledIndex)
+
+	indices, err := ic.GetEnabledIndexes()
+	require.Nil(t, err)
+	require.Equal(t, []string{"transactions"}, indices)
+}
@@ -20,6 +20,8 @@ type DataIndexer interface {
 	SaveAccounts(accountsData *outport.Accounts) error
 	FinalizedBlock(finalizedBlock *outport.FinalizedBlock) error
 	SetCurrentSettings(settings outport.OutportConfig) error
+	SetIndexEnabled(index string, enabled bool) error
+	GetEnabledIndexes() []string
 	Close() error
 	IsInterfaceNil() bool
 }
@@ -0,0 +1,50 @@
+package noKibana
+
+// ActiveAddresses will hold the configuration for the activeaddresses index
+var ActiveAddresses = Object{
+	"index_patterns": Array{
+		"activeaddresses-*",
+	},
+	"template": Object{
+		"settings": Object{
+			"number_of_shards":   1,
+			"number_of_replicas": 0,
+		},
+		"mappings": Object{
+			"properties": Object{
+				"period": Object{
+					"type": "keyword",
+				},
+				"timestamp": Object{
+					"type":   "date",
+					"format": "epoch_second",
+				},
+				"epoch": Object{
+					"type": "long",
+				},
+				"contract": Object{
+					"type": "keyword",
+				},
+				"activeAddresses": Object{
+					"type": "long",
+				},
+				"addresses": Object{
+					"type":  "keyword",
+					"index": false,
+				},
+				"registers": Object{
+					"type":    "object",
+					"enabled": false,
+				},
+				"lastNonces": Object{
+					"type":    "object",
+					"enabled": false,
+				},
+				"revertibleBlocks": Object{
+					"type":    "object",
+					"enabled": false,
+				},
+			},
+		},
+	},
+}
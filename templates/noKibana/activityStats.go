@@ -0,0 +1,59 @@
+package noKibana
+
+// StatsHourly will hold the configuration for the stats-hourly index
+var StatsHourly = activityStats("stats-hourly-*")
+
+// StatsDaily will hold the configuration for the stats-daily index
+var StatsDaily = activityStats("stats-daily-*")
+
+func activityStats(indexPattern string) Object {
+	return Object{
+		"index_patterns": Array{
+			indexPattern,
+		},
+		"template": Object{
+			"settings": Object{
+				"number_of_shards":   1,
+				"number_of_replicas": 0,
+			},
+			"mappings": Object{
+				"properties": Object{
+					"timestamp": Object{
+						"type":   "date",
+						"format": "epoch_second",
+					},
+					"numTransactions": Object{
+						"type": "long",
+					},
+					"egldVolume": Object{
+						"type": "keyword",
+					},
+					"tokensVolume": Object{
+						"type": "flattened",
+					},
+					"fees": Object{
+						"type": "keyword",
+					},
+					"gasUsed": Object{
+						"type": "double",
+					},
+					"uniqueSenders": Object{
+						"type": "long",
+					},
+					"sendersRegisters": Object{
+						"type":    "object",
+						"enabled": false,
+					},
+					"lastNonces": Object{
+						"type":    "object",
+						"enabled": false,
+					},
+					"previousNonces": Object{
+						"type":    "object",
+						"enabled": false,
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,121 @@
+package noKibana
+
+// Contracts will hold the configuration for the contracts index
+var Contracts = Object{
+	"index_patterns": Array{
+		"contracts-*",
+	},
+	"template": Object{
+		"settings": Object{
+			"number_of_shards":   3,
+			"number_of_replicas": 0,
+		},
+		"mappings": Object{
+			"properties": Object{
+				"address": Object{
+					"type": "keyword",
+				},
+				"numCalls": Object{
+					"type": "long",
+				},
+				"numFailedCalls": Object{
+					"type": "long",
+				},
+				"calls": Object{
+					"type": "flattened",
+				},
+				"failedCalls": Object{
+					"type": "flattened",
+				},
+				"gasUsed": Object{
+					"type": "long",
+				},
+				"egldReceived": Object{
+					"type": "keyword",
+				},
+				"esdtReceived": Object{
+					"type": "flattened",
+				},
+				"uniqueCallers": Object{
+					"type": "long",
+				},
+				"callers": Object{
+					"type":  "keyword",
+					"index": false,
+				},
+				"callersRegisters": Object{
+					"type":    "object",
+					"enabled": false,
+				},
+				"firstInteraction": Object{
+					"type":   "date",
+					"format": "epoch_second",
+				},
+				"lastInteraction": Object{
+					"type":   "date",
+					"format": "epoch_second",
+				},
+				"deployTxHash": Object{
+					"type": "keyword",
+				},
+				"deployer": Object{
+					"type": "keyword",
+				},
+				"deployTimestamp": Object{
+					"type":   "date",
+					"format": "epoch_second",
+				},
+				"currentOwner": Object{
+					"type": "keyword",
+				},
+				"initialCodeHash": Object{
+					"type": "keyword",
+				},
+				"upgrades": Object{
+					"type": "nested",
+					"properties": Object{
+						"timestamp": Object{
+							"type":   "date",
+							"format": "epoch_second",
+						},
+						"upgradeTxHash": Object{
+							"type": "keyword",
+						},
+						"upgrader": Object{
+							"type": "keyword",
+						},
+						"codeHash": Object{
+							"type": "keyword",
+						},
+					},
+				},
+				"owners": Object{
+					"type": "nested",
+					"properties": Object{
+						"timestamp": Object{
+							"type":   "date",
+							"format": "epoch_second",
+						},
+						"txHash": Object{
+							"type": "keyword",
+						},
+						"address": Object{
+							"type": "keyword",
+						},
+					},
+				},
+				"lastNonce": Object{
+					"type": "long",
+				},
+				"lastHeaderHash": Object{
+					"type":  "keyword",
+					"index": false,
+				},
+				"previousBlocks": Object{
+					"type":    "object",
+					"enabled": false,
+				},
+			},
+		},
+	},
+}
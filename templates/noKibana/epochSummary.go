@@ -0,0 +1,77 @@
+package noKibana
+
+// EpochSummary will hold the configuration for the epochsummary index
+var EpochSummary = Object{
+	"index_patterns": Array{
+		"epochsummary-*",
+	},
+	"template": Object{
+		"settings": Object{
+			"number_of_shards":   1,
+			"number_of_replicas": 0,
+		},
+		"mappings": Object{
+			"properties": Object{
+				"epoch": Object{
+					"type": "long",
+				},
+				"shardID": Object{
+					"type": "long",
+				},
+				"firstNonce": Object{
+					"type": "double",
+				},
+				"lastNonce": Object{
+					"type": "double",
+				},
+				"startTimestamp": Object{
+					"type":   "date",
+					"format": "epoch_second",
+				},
+				"lastTimestamp": Object{
+					"type":   "date",
+					"format": "epoch_second",
+				},
+				"numBlocks": Object{
+					"type": "long",
+				},
+				"numTransactions": Object{
+					"type": "long",
+				},
+				"numScResults": Object{
+					"type": "long",
+				},
+				"numEvents": Object{
+					"type": "long",
+				},
+				"gasUsed": Object{
+					"type": "double",
+				},
+				"accumulatedFees": Object{
+					"type": "keyword",
+				},
+				"developerFees": Object{
+					"type": "keyword",
+				},
+				"protocolFees": Object{
+					"type": "keyword",
+				},
+				"activeAccounts": Object{
+					"type": "long",
+				},
+				"newAccounts": Object{
+					"type": "long",
+				},
+				"newTokens": Object{
+					"type": "long",
+				},
+				"nftMints": Object{
+					"type": "long",
+				},
+				"activeAccountsSketch": Object{
+					"type": "binary",
+				},
+			},
+		},
+	},
+}
@@ -0,0 +1,40 @@
+package noKibana
+
+// ProcessingErrors will hold the configuration for the processing errors index
+var ProcessingErrors = Object{
+	"index_patterns": Array{
+		"processingerrors-*",
+	},
+	"template": Object{
+		"settings": Object{
+			"number_of_shards":   1,
+			"number_of_replicas": 0,
+		},
+		"mappings": Object{
+			"properties": Object{
+				"txHash": Object{
+					"type": "keyword",
+				},
+				"order": Object{
+					"type": "long",
+				},
+				"identifier": Object{
+					"type": "keyword",
+				},
+				"address": Object{
+					"type": "keyword",
+				},
+				"error": Object{
+					"type": "text",
+				},
+				"shardID": Object{
+					"type": "long",
+				},
+				"timestamp": Object{
+					"type":   "date",
+					"format": "epoch_second",
+				},
+			},
+		},
+	},
+}
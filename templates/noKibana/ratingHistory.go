@@ -0,0 +1,31 @@
+package noKibana
+
+// RatingHistory will hold the configuration for the rating history index
+var RatingHistory = Object{
+	"index_patterns": Array{
+		"ratinghistory-*",
+	},
+	"template": Object{
+		"settings": Object{
+			"number_of_shards":   1,
+			"number_of_replicas": 0,
+		},
+		"mappings": Object{
+			"properties": Object{
+				"publicKey": Object{
+					"type": "keyword",
+				},
+				"epoch": Object{
+					"type": "long",
+				},
+				"rating": Object{
+					"type": "double",
+				},
+				"timestamp": Object{
+					"type":   "date",
+					"format": "epoch_second",
+				},
+			},
+		},
+	},
+}
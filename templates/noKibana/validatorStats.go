@@ -0,0 +1,61 @@
+package noKibana
+
+// ValidatorStats will hold the configuration for the validator stats index
+var ValidatorStats = Object{
+	"index_patterns": Array{
+		"validatorstats-*",
+	},
+	"template": Object{
+		"settings": Object{
+			"number_of_shards":   1,
+			"number_of_replicas": 0,
+		},
+		"mappings": Object{
+			"properties": Object{
+				"publicKey": Object{
+					"type": "keyword",
+				},
+				"shardID": Object{
+					"type": "long",
+				},
+				"epoch": Object{
+					"type": "long",
+				},
+				"blocksProposed": Object{
+					"type": "long",
+				},
+				"blocksMissed": Object{
+					"type": "long",
+				},
+				"roundsSigned": Object{
+					"type": "long",
+				},
+				"roundsEligible": Object{
+					"type": "long",
+				},
+				"leaderSuccessRate": Object{
+					"type": "double",
+				},
+				"roundsSignedRate": Object{
+					"type": "double",
+				},
+				"rating": Object{
+					"type": "double",
+				},
+				"previousEpochRating": Object{
+					"type": "double",
+				},
+				"ratingChange": Object{
+					"type": "double",
+				},
+				"lastRound": Object{
+					"type": "long",
+				},
+				"timestamp": Object{
+					"type":   "date",
+					"format": "epoch_second",
+				},
+			},
+		},
+	},
+}
@@ -74,6 +74,11 @@ var AccountsESDT = Object{
 			},
 			"token": Object{
 				"type": "text",
+				"fields": Object{
+					"keyword": Object{
+						"type": "keyword",
+					},
+				},
 			},
 			"tokenNonce": Object{
 				"type": "double",
@@ -84,3 +89,18 @@ var AccountsESDT = Object{
 		},
 	},
 }
+
+// AccountsESDTTokenKeyword will hold the mapping which adds the keyword sub-field of the token in the existing
+// accountsesdt indices, created before the sub-field was added in the template
+var AccountsESDTTokenKeyword = Object{
+	"properties": Object{
+		"token": Object{
+			"type": "text",
+			"fields": Object{
+				"keyword": Object{
+					"type": "keyword",
+				},
+			},
+		},
+	},
+}
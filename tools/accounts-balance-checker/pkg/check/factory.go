@@ -6,6 +6,7 @@ import (
 
 	"github.com/elastic/go-elasticsearch/v7"
 	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
+	indexerClient "github.com/multiversx/mx-chain-es-indexer-go/client"
 	"github.com/multiversx/mx-chain-es-indexer-go/client/logging"
 	"github.com/multiversx/mx-chain-es-indexer-go/process/elasticproc/converters"
 	"github.com/multiversx/mx-chain-es-indexer-go/tools/accounts-balance-checker/pkg/config"
@@ -13,12 +14,32 @@ import (
 	"github.com/multiversx/mx-chain-es-indexer-go/tools/accounts-balance-checker/pkg/rest"
 )
 
+const addressPrefix = "erd"
+
 // CreateBalanceChecker will create a new instance of balanceChecker
 func CreateBalanceChecker(cfg *config.Config, repair bool) (*balanceChecker, error) {
+	httpTransport, err := indexerClient.NewHTTPTransport(indexerClient.TLSConfig{
+		CACertFile:         cfg.Elasticsearch.CACertFile,
+		ClientCertFile:     cfg.Elasticsearch.ClientCertFile,
+		ClientKeyFile:      cfg.Elasticsearch.ClientKeyFile,
+		InsecureSkipVerify: cfg.Elasticsearch.InsecureSkipVerify,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var addresses []string
+	if cfg.Elasticsearch.URL != "" {
+		addresses = []string{cfg.Elasticsearch.URL}
+	}
+
 	esClient, err := esclient.NewElasticClient(elasticsearch.Config{
-		Addresses: []string{cfg.Elasticsearch.URL},
+		Addresses: addresses,
 		Username:  cfg.Elasticsearch.Username,
 		Password:  cfg.Elasticsearch.Password,
+		APIKey:    cfg.Elasticsearch.APIKey,
+		CloudID:   cfg.Elasticsearch.CloudID,
+		Transport: httpTransport,
 		Logger:    &logging.CustomLogger{},
 		RetryBackoff: func(i int) time.Duration {
 			// A simple exponential delay
@@ -38,7 +59,7 @@ func CreateBalanceChecker(cfg *config.Config, repair bool) (*balanceChecker, err
 		return nil, err
 	}
 
-	pubKeyConverter, err := pubkeyConverter.NewBech32PubkeyConverter(32, log)
+	pubKeyConverter, err := pubkeyConverter.NewBech32PubkeyConverter(32, addressPrefix)
 	if err != nil {
 		return nil, err
 	}
@@ -2,9 +2,15 @@ package config
 
 type Config struct {
 	Elasticsearch struct {
-		URL      string `json:"url"`
-		Username string `json:"username"`
-		Password string `json:"password"`
+		URL                string `json:"url"`
+		Username           string `json:"username"`
+		Password           string `json:"password"`
+		APIKey             string `json:"api-key"`
+		CloudID            string `json:"cloud-id"`
+		CACertFile         string `json:"ca-cert-file"`
+		ClientCertFile     string `json:"client-cert-file"`
+		ClientKeyFile      string `json:"client-key-file"`
+		InsecureSkipVerify bool   `json:"insecure-skip-verify"`
 	}
 	Proxy struct {
 		URL                         string `json:"url"`
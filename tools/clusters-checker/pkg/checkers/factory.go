@@ -8,26 +8,19 @@ import (
 	"time"
 
 	"github.com/elastic/go-elasticsearch/v7"
+	indexerClient "github.com/multiversx/mx-chain-es-indexer-go/client"
 	"github.com/multiversx/mx-chain-es-indexer-go/tools/clusters-checker/pkg/client"
 	"github.com/multiversx/mx-chain-es-indexer-go/tools/clusters-checker/pkg/config"
 )
 
 // CreateClusterChecker will create a new instance of clusterChecker structure
 func CreateClusterChecker(cfg *config.Config, interval *Interval, logPrefix string, onlyIDs bool) (*clusterChecker, error) {
-	clientSource, err := client.NewElasticClient(elasticsearch.Config{
-		Addresses: []string{cfg.SourceCluster.URL},
-		Username:  cfg.SourceCluster.User,
-		Password:  cfg.SourceCluster.Password,
-	})
+	clientSource, err := createElasticClient(cfg.SourceCluster)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create source client %s", err.Error())
 	}
 
-	clientDestination, err := client.NewElasticClient(elasticsearch.Config{
-		Addresses: []string{cfg.DestinationCluster.URL},
-		Username:  cfg.DestinationCluster.User,
-		Password:  cfg.DestinationCluster.Password,
-	})
+	clientDestination, err := createElasticClient(cfg.DestinationCluster)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create destination client %s", err.Error())
 	}
@@ -48,6 +41,31 @@ func CreateClusterChecker(cfg *config.Config, interval *Interval, logPrefix stri
 	}, nil
 }
 
+func createElasticClient(clusterCfg config.ClusterConfig) (ESClient, error) {
+	httpTransport, err := indexerClient.NewHTTPTransport(indexerClient.TLSConfig{
+		CACertFile:         clusterCfg.CACertFile,
+		ClientCertFile:     clusterCfg.ClientCertFile,
+		ClientKeyFile:      clusterCfg.ClientKeyFile,
+		InsecureSkipVerify: clusterCfg.InsecureSkipVerify,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	esConfig := elasticsearch.Config{
+		Username:  clusterCfg.User,
+		Password:  clusterCfg.Password,
+		APIKey:    clusterCfg.APIKey,
+		CloudID:   clusterCfg.CloudID,
+		Transport: httpTransport,
+	}
+	if clusterCfg.URL != "" {
+		esConfig.Addresses = []string{clusterCfg.URL}
+	}
+
+	return client.NewElasticClient(esConfig)
+}
+
 // CreateMultipleCheckers will create multiple instances of clusterChecker structure
 func CreateMultipleCheckers(cfg *config.Config, onlyIDs bool) ([]*clusterChecker, error) {
 	currentTimestampUnix := time.Now().Unix()
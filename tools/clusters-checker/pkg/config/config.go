@@ -1,17 +1,9 @@
 package config
 
 type Config struct {
-	SourceCluster struct {
-		URL      string `toml:"url"`
-		User     string `toml:"user"`
-		Password string `toml:"password"`
-	} `toml:"source-cluster"`
-	DestinationCluster struct {
-		URL      string `toml:"url"`
-		User     string `toml:"user"`
-		Password string `toml:"password"`
-	} `toml:"destination-cluster"`
-	Compare struct {
+	SourceCluster      ClusterConfig `toml:"source-cluster"`
+	DestinationCluster ClusterConfig `toml:"destination-cluster"`
+	Compare            struct {
 		BlockchainStartTime  int64    `toml:"blockchain-start-time"`
 		NumParallelReads     int      `toml:"num-parallel-reads"`
 		IndicesWithTimestamp []string `toml:"indices-with-timestamp"`
@@ -24,3 +16,16 @@ type Config struct {
 		LogsPath             string `toml:"logs-path"`
 	} `toml:"logs"`
 }
+
+// ClusterConfig holds the connection settings of an Elasticsearch cluster
+type ClusterConfig struct {
+	URL                string `toml:"url"`
+	User               string `toml:"user"`
+	Password           string `toml:"password"`
+	APIKey             string `toml:"api-key"`
+	CloudID            string `toml:"cloud-id"`
+	CACertFile         string `toml:"ca-cert-file"`
+	ClientCertFile     string `toml:"client-cert-file"`
+	ClientKeyFile      string `toml:"client-key-file"`
+	InsecureSkipVerify bool   `toml:"insecure-skip-verify"`
+}
@@ -1,19 +1,28 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 
 	"github.com/multiversx/mx-chain-es-indexer-go/tools/index-modifier/pkg/alterindex"
+	"github.com/multiversx/mx-chain-es-indexer-go/tools/index-modifier/pkg/config"
 	"github.com/multiversx/mx-chain-es-indexer-go/tools/index-modifier/pkg/modifiers"
 )
 
-const (
-	scrollClientAddress = ""
-	bulkClientAddress   = ""
-)
+var configFile = flag.String("config", "./config.toml", "the toml file with the settings of the source and destination clusters")
 
 func main() {
-	indexModifier, err := alterindex.CreateIndexModifier(scrollClientAddress, bulkClientAddress)
+	flag.Parse()
+
+	cfg, err := config.LoadConfig(*configFile)
+	if err != nil {
+		panic("cannot load config file: " + err.Error())
+	}
+
+	indexModifier, err := alterindex.CreateIndexModifier(
+		cfg.ScrollCluster.ToAlterIndexConfig(),
+		cfg.BulkCluster.ToAlterIndexConfig(),
+	)
 	if err != nil {
 		panic("cannot create index modifier: " + err.Error())
 	}
@@ -2,6 +2,7 @@ package alterindex
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"math"
 	"time"
@@ -30,21 +31,55 @@ func backOff(i int) time.Duration {
 	return d
 }
 
-// CreateIndexModifier will create a new instance of indexModifier
-func CreateIndexModifier(scrollClientAddress, bulkClientAddress string) (*indexModifier, error) {
+// ClusterConfig holds the connection settings of an Elasticsearch cluster
+type ClusterConfig struct {
+	URL      string
+	Username string
+	Password string
+	APIKey   string
+	CloudID  string
+	TLS      indexerClient.TLSConfig
+}
+
+func createElasticConfig(clusterCfg ClusterConfig) (elasticsearch.Config, error) {
+	httpTransport, err := indexerClient.NewHTTPTransport(clusterCfg.TLS)
+	if err != nil {
+		return elasticsearch.Config{}, err
+	}
+
 	cfg := elasticsearch.Config{
-		Addresses:     []string{scrollClientAddress},
+		Username:      clusterCfg.Username,
+		Password:      clusterCfg.Password,
+		APIKey:        clusterCfg.APIKey,
+		CloudID:       clusterCfg.CloudID,
+		Transport:     httpTransport,
 		MaxRetries:    0,
 		RetryBackoff:  backOff,
 		RetryOnStatus: []int{429, 502, 503, 504},
 	}
-	scrollClient, err := client.NewElasticClient(cfg)
+	if clusterCfg.URL != "" {
+		cfg.Addresses = []string{clusterCfg.URL}
+	}
+
+	return cfg, nil
+}
+
+// CreateIndexModifier will create a new instance of indexModifier
+func CreateIndexModifier(scrollClusterCfg, bulkClusterCfg ClusterConfig) (*indexModifier, error) {
+	scrollCfg, err := createElasticConfig(scrollClusterCfg)
+	if err != nil {
+		return nil, err
+	}
+	scrollClient, err := client.NewElasticClient(scrollCfg)
 	if err != nil {
 		return nil, err
 	}
 
-	cfg.Addresses = []string{bulkClientAddress}
-	bulkClient, err := indexerClient.NewElasticClient(cfg)
+	bulkCfg, err := createElasticConfig(bulkClusterCfg)
+	if err != nil {
+		return nil, err
+	}
+	bulkClient, err := indexerClient.NewElasticClient(bulkCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -66,7 +101,7 @@ func (im *indexModifier) AlterIndex(indexRead, indexWrite string, modifier func(
 		}
 
 		for i := 0; i < len(dataBuffers); i++ {
-			err = im.bulkClient.DoBulkRequest(dataBuffers[i], indexWrite)
+			err = im.bulkClient.DoBulkRequest(context.Background(), dataBuffers[i], indexWrite)
 			if err != nil {
 				return fmt.Errorf("%w while r.destinationElastic.DoBulkRequest", err)
 			}
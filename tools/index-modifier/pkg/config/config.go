@@ -0,0 +1,61 @@
+package config
+
+import (
+	"os"
+
+	indexerClient "github.com/multiversx/mx-chain-es-indexer-go/client"
+	"github.com/multiversx/mx-chain-es-indexer-go/tools/index-modifier/pkg/alterindex"
+	"github.com/pelletier/go-toml"
+)
+
+// Config holds the settings of the clusters the documents are read from and written to
+type Config struct {
+	ScrollCluster ClusterConfig `toml:"scroll-cluster"`
+	BulkCluster   ClusterConfig `toml:"bulk-cluster"`
+}
+
+// ClusterConfig holds the connection settings of an Elasticsearch cluster
+type ClusterConfig struct {
+	URL                string `toml:"url"`
+	User               string `toml:"user"`
+	Password           string `toml:"password"`
+	APIKey             string `toml:"api-key"`
+	CloudID            string `toml:"cloud-id"`
+	CACertFile         string `toml:"ca-cert-file"`
+	ClientCertFile     string `toml:"client-cert-file"`
+	ClientKeyFile      string `toml:"client-key-file"`
+	InsecureSkipVerify bool   `toml:"insecure-skip-verify"`
+}
+
+// LoadConfig will load the configuration from the provided toml file
+func LoadConfig(filePath string) (*Config, error) {
+	tomlBytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{}
+	err = toml.Unmarshal(tomlBytes, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// ToAlterIndexConfig will return the connection settings in the format used by the index modifier
+func (cc ClusterConfig) ToAlterIndexConfig() alterindex.ClusterConfig {
+	return alterindex.ClusterConfig{
+		URL:      cc.URL,
+		Username: cc.User,
+		Password: cc.Password,
+		APIKey:   cc.APIKey,
+		CloudID:  cc.CloudID,
+		TLS: indexerClient.TLSConfig{
+			CACertFile:         cc.CACertFile,
+			ClientCertFile:     cc.ClientCertFile,
+			ClientKeyFile:      cc.ClientKeyFile,
+			InsecureSkipVerify: cc.InsecureSkipVerify,
+		},
+	}
+}
@@ -14,6 +14,8 @@ import (
 	datafield "github.com/multiversx/mx-chain-vm-common-go/parsers/dataField"
 )
 
+const addressPrefix = "erd"
+
 var log = logger.GetOrCreate("index-modifier/pkg/alterindex")
 
 type responseTransactionsBulk struct {
@@ -57,7 +59,7 @@ func createOperationParser() (transactions.DataFieldParser, error) {
 }
 
 func createPubKeyConverterAndParser() (core.PubkeyConverter, transactions.DataFieldParser, error) {
-	pubKeyConverter, err := pubkeyConverter.NewBech32PubkeyConverter(32, log)
+	pubKeyConverter, err := pubkeyConverter.NewBech32PubkeyConverter(32, addressPrefix)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -20,11 +20,17 @@ const configFileName = "cluster.toml"
 
 type config struct {
 	ClusterConfig struct {
-		URL            string   `toml:"url"`
-		Username       string   `toml:"username"`
-		Password       string   `toml:"password"`
-		UseKibana      bool     `toml:"use-kibana"`
-		EnabledIndices []string `toml:"enabled-indices"`
+		URL                string   `toml:"url"`
+		Username           string   `toml:"username"`
+		Password           string   `toml:"password"`
+		APIKey             string   `toml:"api-key"`
+		CloudID            string   `toml:"cloud-id"`
+		CACertFile         string   `toml:"ca-cert-file"`
+		ClientCertFile     string   `toml:"client-cert-file"`
+		ClientKeyFile      string   `toml:"client-key-file"`
+		InsecureSkipVerify bool     `toml:"insecure-skip-verify"`
+		UseKibana          bool     `toml:"use-kibana"`
+		EnabledIndices     []string `toml:"enabled-indices"`
 	} `toml:"config"`
 }
 
@@ -111,10 +117,28 @@ func createIndexesAndMappings(ctx *cli.Context) {
 }
 
 func createTemplates(cfg *config, indexesMappings map[string]*bytes.Buffer) error {
+	httpTransport, err := client.NewHTTPTransport(client.TLSConfig{
+		CACertFile:         cfg.ClusterConfig.CACertFile,
+		ClientCertFile:     cfg.ClusterConfig.ClientCertFile,
+		ClientKeyFile:      cfg.ClusterConfig.ClientKeyFile,
+		InsecureSkipVerify: cfg.ClusterConfig.InsecureSkipVerify,
+	})
+	if err != nil {
+		return err
+	}
+
+	var addresses []string
+	if cfg.ClusterConfig.URL != "" {
+		addresses = []string{cfg.ClusterConfig.URL}
+	}
+
 	databaseClient, err := client.NewElasticClient(elasticsearch.Config{
-		Addresses: []string{cfg.ClusterConfig.URL},
+		Addresses: addresses,
 		Username:  cfg.ClusterConfig.Username,
 		Password:  cfg.ClusterConfig.Password,
+		APIKey:    cfg.ClusterConfig.APIKey,
+		CloudID:   cfg.ClusterConfig.CloudID,
+		Transport: httpTransport,
 		Logger:    &logging.CustomLogger{},
 	})
 	if err != nil {